@@ -2,24 +2,46 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"slices"
 	"sort"
 	"strings"
+	"syscall"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
+	"github.com/tekierz/dotfiles/internal/backup"
 	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/diagnostics"
+	"github.com/tekierz/dotfiles/internal/diff"
+	"github.com/tekierz/dotfiles/internal/hotkeys"
+	"github.com/tekierz/dotfiles/internal/opener"
 	"github.com/tekierz/dotfiles/internal/pkg"
+	"github.com/tekierz/dotfiles/internal/runner"
+	"github.com/tekierz/dotfiles/internal/serve"
+	"github.com/tekierz/dotfiles/internal/suggest"
 	"github.com/tekierz/dotfiles/internal/tools"
 	"github.com/tekierz/dotfiles/internal/ui"
 	"github.com/tekierz/dotfiles/internal/ui/screens"
+	"github.com/tekierz/dotfiles/internal/wallpaper"
 )
 
 var (
 	skipIntro bool
 	version   = "2.0.1"
+	// Set with -ldflags "-X main.commit=... -X main.buildDate=..." (see the
+	// Makefile); otherwise taken from the Go build info where available
+	commit    = ""
+	buildDate = ""
 )
 
 // rootCmd is the base command
@@ -32,7 +54,8 @@ It provides installation, configuration, and updates for your terminal
 tools including zsh, tmux, neovim, yazi, ghostty, and more.
 
 Quick user switch:
-  dotfiles --<Username>    Switch to user profile (e.g., dotfiles --Pratik)`,
+  dotfiles --<Username>    Switch to user profile (e.g., dotfiles --Pratik)
+                           Case-insensitive; unique prefixes work (dotfiles --pra)`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Default: launch TUI main menu
 		launchTUI(ui.ScreenMainMenu)
@@ -41,13 +64,96 @@ Quick user switch:
 
 // installCmd launches the installation wizard
 var installCmd = &cobra.Command{
-	Use:   "install",
-	Short: "Launch installation wizard",
+	Use:   "install [tool[@version]...]",
+	Short: "Launch installation wizard, or install tools directly",
+	Long: `Launch the installation wizard.
+
+Name tools to install them directly without the wizard. Add @version to
+install a specific version where the package manager supports it (brew
+installs the versioned formula, e.g. node@20; apt installs node=20, which
+must be the full Debian version string as apt-cache policy lists it, e.g.
+ripgrep@14.1.0-1). pacman and paru only install the version in their
+repositories, so a version is rejected there:
+
+  dotfiles install ripgrep node@20
+
+Use --category to scope the deep dive menu to tools in one or more
+categories (repeatable or comma-separated):
+
+  dotfiles install --category shell --category terminal
+
+Use --profile to start from a saved install profile's tool selection. Save
+one from the summary screen at the end of an install (press P), and list
+them with "dotfiles profiles":
+
+  dotfiles install --profile work
+
+Use --from to reproduce a machine captured with "dotfiles snapshot": its
+tools, helper scripts, theme and navigation style are selected, and its
+Manage settings are applied:
+
+  dotfiles install --from machine.json
+
+When an install ran, a summary line is printed on exit and the exit status
+is 1 if any tool or configuration step failed:
+
+  INSTALLED=8 FAILED=1 SKIPPED=2`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) > 0 {
+			if cmd.Flags().Changed("category") || cmd.Flags().Changed("profile") || cmd.Flags().Changed("from") {
+				fmt.Fprintln(os.Stderr, "Error: tool names cannot be combined with --category, --profile or --from")
+				os.Exit(1)
+			}
+			runInstallTools(args)
+			return
+		}
+
+		names, _ := cmd.Flags().GetStringSlice("category")
+		cats, err := parseCategories(names)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts := []ui.AppOption{ui.WithDeepDiveCategories(cats)}
+
+		from, _ := cmd.Flags().GetString("from")
+		if name, _ := cmd.Flags().GetString("profile"); name != "" && from != "" {
+			fmt.Fprintln(os.Stderr, "Error: --profile cannot be combined with --from")
+			os.Exit(1)
+		}
+		if from != "" {
+			snap, err := loadSnapshotForInstall(from)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			opts = append(opts, ui.WithSnapshot(snap))
+		}
+
+		if name, _ := cmd.Flags().GetString("profile"); name != "" {
+			profile, err := config.LoadInstallProfile(name)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if unknown := ui.NewDeepDiveConfig().ApplySelection(profile.Tools); len(unknown) > 0 {
+				fmt.Fprintf(os.Stderr, "Warning: profile %q lists tools not available here: %s\n", name, strings.Join(unknown, ", "))
+			}
+			opts = append(opts, ui.WithInstallProfile(profile))
+		}
+
+		start := ui.ScreenAnimation
 		if skipIntro {
-			launchTUI(ui.ScreenWelcome)
-		} else {
-			launchTUI(ui.ScreenAnimation)
+			start = ui.ScreenWelcome
+		}
+		app := launchTUI(start, opts...)
+
+		// Summary line and exit status let scripts detect partial failures
+		if result, ok := app.InstallResult(); ok {
+			fmt.Println(result)
+			if result.Failed > 0 {
+				os.Exit(1)
+			}
 		}
 	},
 }
@@ -76,11 +182,52 @@ var updateCmd = &cobra.Command{
 	},
 }
 
+// updatePinCmd holds a package at its current version
+var updatePinCmd = &cobra.Command{
+	Use:   "pin <package>",
+	Short: "Exclude a package from updates",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		pinPackage(args[0])
+	},
+}
+
+// updateUnpinCmd allows a pinned package to be updated again
+var updateUnpinCmd = &cobra.Command{
+	Use:   "unpin <package>",
+	Short: "Allow a pinned package to be updated again",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		unpinPackage(args[0])
+	},
+}
+
 // themeCmd handles theme operations
 var themeCmd = &cobra.Command{
-	Use:   "theme [set <name>]",
+	Use:   "theme [set <name>|list|show [name]]",
 	Short: "View or change theme",
+	Long: `View or change the theme. Without arguments, launches the TUI picker.
+
+"show" prints the palette a theme applies to the TUI: each Color* variable
+with the palette field it comes from and its hex value. It defaults to the
+current theme; add --json for a machine-readable palette.
+
+--from-wallpaper samples an image's dominant colors (PNG, JPEG or GIF),
+saves them as a custom theme in ~/.config/dotfiles/themes and selects it.
+Name it with --name (default "wallpaper"):
+
+  dotfiles theme --from-wallpaper ~/Pictures/dunes.jpg --name dunes`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if path, _ := cmd.Flags().GetString("from-wallpaper"); path != "" {
+			if len(args) > 0 {
+				fmt.Fprintln(os.Stderr, "Error: --from-wallpaper takes no arguments")
+				os.Exit(1)
+			}
+			name, _ := cmd.Flags().GetString("name")
+			themeFromWallpaper(path, name)
+			return
+		}
+
 		if len(args) == 0 {
 			// No args: launch TUI picker
 			launchTUI(ui.ScreenThemePicker)
@@ -93,17 +240,30 @@ var themeCmd = &cobra.Command{
 		} else if args[0] == "list" {
 			// List available themes
 			listThemes()
+		} else if args[0] == "show" && len(args) <= 2 {
+			asJSON, _ := cmd.Flags().GetBool("json")
+			name := ""
+			if len(args) == 2 {
+				name = args[1]
+			}
+			showThemePalette(name, asJSON)
 		} else {
-			fmt.Println("Usage: dotfiles theme [set <name>|list]")
+			fmt.Println("Usage: dotfiles theme [set <name>|list|show [name]]")
 		}
 	},
 }
 
 // configCmd handles per-tool configuration
 var configCmd = &cobra.Command{
-	Use:   "config <tool>",
+	Use:   "config <tool> [set key=value...]",
 	Short: "Configure a specific tool",
-	Long: `Configure a specific tool. Without flags, launches TUI.
+	Long: `Configure a specific tool. Without arguments, launches TUI.
+
+Use "set" to change settings without the TUI. Keys and values are the same
+as the tool's fields in the Manage screen:
+
+  dotfiles config tmux set prefix=C-a mouse=true
+  dotfiles config global set theme=nord
 
 Available tools: ghostty, tmux, zsh, neovim, git, yazi, fzf, apps, utilities`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -113,12 +273,110 @@ Available tools: ghostty, tmux, zsh, neovim, git, yazi, fzf, apps, utilities`,
 			return
 		}
 
-		// Check for flags (direct set mode)
-		// For now, launch TUI for the specific tool
+		// Direct set mode
+		if len(args) > 1 && args[1] == "set" {
+			setToolConfig(args[0], args[2:])
+			return
+		}
+
 		launchToolConfig(args[0])
 	},
 }
 
+// configListCmd reports which config files dotfiles manages
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show which config files dotfiles manages",
+	Long: `Scan the known tool config paths and report ownership:
+
+  managed    written by dotfiles and unchanged since
+  edited     written by dotfiles, then changed by hand
+  legacy     written by an older dotfiles without an ownership marker
+  unmanaged  not written by dotfiles
+
+Use --all to include config paths that don't exist.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		all, _ := cmd.Flags().GetBool("all")
+		listManagedConfigs(all)
+	},
+}
+
+// configMigrateCmd upgrades config files to the current schema
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade config files to the current schema",
+	Long: `Rewrite the global and per-tool config files in the current schema:
+fields added since a file was written get their defaults, fields that no
+longer exist are dropped, and the schema version is updated.
+
+Older files are migrated automatically when loaded; this forces it for
+every file. Changes are logged to ~/.config/dotfiles/logs/migrations.log.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		migrateConfigs()
+	},
+}
+
+// configLogCmd shows the config audit log
+var configLogCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show recent config changes",
+	Long: `Show the config audit log: every save of the global config, a tool
+config, a user profile or the hotkeys file, with who made it, when, and
+which keys changed. Newest first.
+
+The log is kept in ~/.config/dotfiles/logs/config-audit.jsonl.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		limit, _ := cmd.Flags().GetInt("limit")
+		file, _ := cmd.Flags().GetString("file")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		showConfigLog(limit, file, asJSON)
+	},
+}
+
+// configMirrorCmd commits the generated config files into the dotfiles repo
+var configMirrorCmd = &cobra.Command{
+	Use:   "mirror",
+	Short: "Commit generated config files into your dotfiles git repo",
+	Long: `Copy the config files dotfiles generated into the git repository set as
+"dotfiles_repo" in ~/.config/dotfiles/global.json, at their paths relative to
+your home directory, and commit the ones that changed. Installs do this
+automatically once dotfiles_repo is set.
+
+Only the mirrored files are committed. Files with uncommitted changes in the
+repo are never overwritten; commit or discard them first.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		mirrorConfigs()
+	},
+}
+
+// configShareCmd prints a share code for a tool's settings
+var configShareCmd = &cobra.Command{
+	Use:   "share <tool>",
+	Short: "Print a share code for a tool's settings",
+	Long: `Encode a tool's current settings (the fields in the Manage screen) as a
+short code to paste to someone else, who applies it with
+"dotfiles config import-share <code>". Personal details such as the git
+author name and email are left out.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		shareToolConfig(args[0])
+	},
+}
+
+// configImportShareCmd applies a share code
+var configImportShareCmd = &cobra.Command{
+	Use:   "import-share <code>",
+	Short: "Apply settings from a share code",
+	Long: `Decode a share code from "dotfiles config share", validate every setting
+against the tool's fields, show what would change and apply it after
+confirmation (skipped with --yes).`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		yes, _ := cmd.Flags().GetBool("yes")
+		importShareCode(args[0], yes)
+	},
+}
+
 // hotkeysCmd launches the hotkey viewer
 var hotkeysCmd = &cobra.Command{
 	Use:     "hotkeys",
@@ -134,6 +392,65 @@ var hotkeysCmd = &cobra.Command{
 	},
 }
 
+// hotkeysFavoritesCmd groups favorite hotkey management
+var hotkeysFavoritesCmd = &cobra.Command{
+	Use:     "favorites",
+	Aliases: []string{"fav"},
+	Short:   "Manage the active user's favorite hotkeys",
+	Long: `List or edit the active user's favorite hotkeys. Without a subcommand,
+opens the My Favorites screen: every favorite from every tool in one list.
+
+Examples:
+  dotfiles hotkeys favorites
+  dotfiles hotkeys favorites list
+  dotfiles hotkeys favorites remove tmux "prefix |"
+  dotfiles hotkeys favorites clear tmux`,
+	Run: func(cmd *cobra.Command, args []string) {
+		launchTUI(ui.ScreenFavorites)
+	},
+}
+
+// hotkeysFavoritesListCmd lists favorites
+var hotkeysFavoritesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List favorite hotkeys",
+	Run: func(cmd *cobra.Command, args []string) {
+		listFavorites()
+	},
+}
+
+// hotkeysFavoritesRemoveCmd removes a single favorite
+var hotkeysFavoritesRemoveCmd = &cobra.Command{
+	Use:     "remove <category> <keys>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a favorite hotkey",
+	Args:    cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		removeFavorite(args[0], strings.Join(args[1:], " "))
+	},
+}
+
+// hotkeysFavoritesClearCmd clears all favorites in a category
+var hotkeysFavoritesClearCmd = &cobra.Command{
+	Use:   "clear <category>",
+	Short: "Remove all favorites in a category",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		clearFavorites(args[0])
+	},
+}
+
+// spaceCmd reports disk usage of the config directory
+var spaceCmd = &cobra.Command{
+	Use:   "space",
+	Short: "Show disk space used by dotfiles",
+	Long: `Show the size of the dotfiles config directory, broken down by
+backups, logs, tool configs and user profiles.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		showSpace()
+	},
+}
+
 // statusCmd shows current status
 var statusCmd = &cobra.Command{
 	Use:   "status",
@@ -147,8 +464,79 @@ var statusCmd = &cobra.Command{
 var backupsCmd = &cobra.Command{
 	Use:   "backups",
 	Short: "List available backups",
+	Long: `List available backups, newest first.
+
+Use --json to emit machine-readable output for scripts and dashboards.
+Use --open to browse the backups directory in the file manager (or yazi
+on a headless machine).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if open, _ := cmd.Flags().GetBool("open"); open {
+			openBackupsDir()
+			return
+		}
+		asJSON, _ := cmd.Flags().GetBool("json")
+		listBackups(asJSON)
+	},
+}
+
+// backupCmd groups backup management subcommands
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Manage configuration backups",
+}
+
+// backupCreateCmd creates a new backup
+var backupCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a backup of current dotfiles",
+	Long: `Create a timestamped backup of the managed dotfiles.
+
+Use --exclude (repeatable) to skip specific files for this run, e.g.:
+  dotfiles backup create --exclude ~/.gitconfig --exclude .zshrc
+A path that isn't one of the backed-up files is an error.
+
+Use --name to label the backup so it is easy to find later, e.g.:
+  dotfiles backup create --name "before nvim migration"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		exclude, _ := cmd.Flags().GetStringArray("exclude")
+		label, _ := cmd.Flags().GetString("name")
+		createBackup(exclude, label)
+	},
+}
+
+// backupVerifyCmd checks a backup's integrity
+var backupVerifyCmd = &cobra.Command{
+	Use:   "verify <name>",
+	Short: "Verify a backup's files against their checksums",
+	Long: `Check every file in a backup against the SHA-256 checksums recorded in its
+manifest, without restoring anything. Exits non-zero if any file is missing
+or corrupted.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		listBackups()
+		verifyBackup(args[0])
+	},
+}
+
+// backupDiffCmd compares two backups, or a backup with the live files
+var backupDiffCmd = &cobra.Command{
+	Use:   "diff <name1> [name2]",
+	Short: "Compare two backups",
+	Long: `Report which files were added, removed or changed between two backups,
+going from <name1> to <name2>.
+
+Use --vs-current to compare a backup with the files on disk instead, and
+--patch to show the line diff of each changed file, e.g.:
+  dotfiles backup diff 2024-03-01_10-00-00 2024-03-08_10-00-00 --patch
+  dotfiles backup diff 2024-03-01_10-00-00 --vs-current`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		vsCurrent, _ := cmd.Flags().GetBool("vs-current")
+		patch, _ := cmd.Flags().GetBool("patch")
+		if vsCurrent == (len(args) == 2) {
+			fmt.Fprintln(os.Stderr, "Error: give two backups, or one backup with --vs-current")
+			os.Exit(1)
+		}
+		diffBackups(args, vsCurrent, patch)
 	},
 }
 
@@ -156,13 +544,47 @@ var backupsCmd = &cobra.Command{
 var restoreCmd = &cobra.Command{
 	Use:   "restore [backup-name]",
 	Short: "Restore from a backup",
+	Long: `Restore configuration files from a backup.
+
+Without arguments, opens the backup browser in the TUI.
+Use --latest to restore the most recent backup directly (asks for
+confirmation unless --yes is given).
+
+Files changed on disk after the backup was taken are listed before they are
+overwritten, with the choice to keep them. --keep-newer keeps them without
+asking; --yes overwrites them without asking.
+
+--preview extracts a backup to a temporary directory and reports what it
+contains and how each file differs from the one in your home directory,
+without changing anything:
+
+  dotfiles restore 2024-03-01_10-00-00 --preview`,
 	Run: func(cmd *cobra.Command, args []string) {
+		latest, _ := cmd.Flags().GetBool("latest")
+		yes, _ := cmd.Flags().GetBool("yes")
+		keepNewer, _ := cmd.Flags().GetBool("keep-newer")
+		if preview, _ := cmd.Flags().GetBool("preview"); preview {
+			if len(args) != 1 || latest {
+				fmt.Fprintln(os.Stderr, "Error: --preview needs a backup name (see 'dotfiles backups')")
+				os.Exit(1)
+			}
+			previewRestore(args[0])
+			return
+		}
+		if latest {
+			if len(args) > 0 {
+				fmt.Fprintln(os.Stderr, "Error: --latest cannot be combined with a backup name")
+				os.Exit(1)
+			}
+			restoreLatestBackup(yes, keepNewer)
+			return
+		}
 		if len(args) == 0 {
 			// TUI mode: select backup
 			launchTUI(ui.ScreenBackups)
 		} else {
 			// CLI mode: restore specific backup
-			restoreBackup(args[0])
+			restoreBackup(args[0], yes, keepNewer)
 		}
 	},
 }
@@ -171,8 +593,30 @@ var restoreCmd = &cobra.Command{
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show version information",
+	Long: `Show the dotfiles version. With --json, print the build metadata too
+(git commit, build date, Go version, OS/arch and the detected package
+manager), e.g. to paste into a bug report.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("dotfiles version %s\n", version)
+		asJSON, _ := cmd.Flags().GetBool("json")
+		showVersion(asJSON)
+	},
+}
+
+// diagnosticsCmd exports a redacted diagnostics bundle for bug reports
+var diagnosticsCmd = &cobra.Command{
+	Use:   "diagnostics",
+	Short: "Export a diagnostics bundle for bug reports",
+	Long: `Collect environment info and the dotfiles config directory (including any
+logs and history stored there) into a zip for bug reports.
+
+Anything that looks like a secret is redacted, as are email addresses unless
+--include-email is given. Backups are never included.
+
+  dotfiles diagnostics --out bundle.zip`,
+	Run: func(cmd *cobra.Command, args []string) {
+		out, _ := cmd.Flags().GetString("out")
+		includeEmail, _ := cmd.Flags().GetBool("include-email")
+		exportDiagnostics(out, includeEmail)
 	},
 }
 
@@ -236,6 +680,20 @@ var userDeleteCmd = &cobra.Command{
 	},
 }
 
+// userRepairCmd repairs a corrupted user profile
+var userRepairCmd = &cobra.Command{
+	Use:   "repair <name>",
+	Short: "Repair an unreadable user profile",
+	Long: `Rewrite a user profile, keeping valid fields and resetting
+missing or invalid ones (theme, nav style, keyboard style) to defaults.
+
+Useful after hand-editing or a partially-written profile file.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		repairUser(args[0])
+	},
+}
+
 // usersCmd lists all users
 var usersCmd = &cobra.Command{
 	Use:   "users",
@@ -245,6 +703,108 @@ var usersCmd = &cobra.Command{
 	},
 }
 
+// profilesCmd lists saved install profiles
+var profilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "List saved install profiles",
+	Run: func(cmd *cobra.Command, args []string) {
+		listInstallProfiles()
+	},
+}
+
+// snapshotCmd records the current machine setup
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Record this machine's setup to reproduce it elsewhere",
+	Long: `Record everything needed to reproduce this machine's setup: installed
+tools and helper scripts, theme, navigation and keyboard style, and the
+Manage settings. Replay it on another machine with install --from:
+
+  dotfiles snapshot --out machine.json
+  dotfiles install --from machine.json
+
+Without --out the snapshot is printed to stdout.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		out, _ := cmd.Flags().GetString("out")
+		writeSnapshot(out)
+	},
+}
+
+// serveCmd answers status queries on a unix socket
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve read-only status to status bars over a unix socket",
+	Long: `Listen on a unix socket (readable only by you) and answer read-only
+status queries, so status bars like sketchybar, polybar or tmux can poll
+without starting the CLI each time. Each request and reply is one JSON line:
+
+  echo '{"method":"status"}' | nc -U "$XDG_RUNTIME_DIR/dotfiles.sock"
+  {"result":{"theme":"nord","active_user":"ada","pending_updates":3,...}}
+
+Methods: status, theme, user, updates. The pending update count is refreshed
+in the background at most every 15 minutes.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		socket, _ := cmd.Flags().GetString("socket")
+		runServe(socket)
+	},
+}
+
+// repairCmd reinstalls tools that are installed but not working
+var repairCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "Reinstall tools that are installed but broken",
+	Long: `Verify every installed tool by running its binary (e.g. "rg --version"),
+then reinstall the ones that are installed according to the package manager
+but missing from PATH or failing to run. Verification is repeated afterwards
+so you can see what was fixed.
+
+GUI apps have no binary to check and are skipped.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		yes, _ := cmd.Flags().GetBool("yes")
+		runRepair(yes)
+	},
+}
+
+// resetCmd repairs corrupt config files
+var resetCmd = &cobra.Command{
+	Use:   "reset --config",
+	Short: "Replace corrupt config files with defaults",
+	Long: `Check every JSON file in ~/.config/dotfiles (including tools/ and users/)
+and replace the ones that can't be parsed with defaults. Each corrupt file is
+kept next to the original as <name>.corrupt so nothing is lost.
+
+Files that parse are left alone, so settings in other files survive.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfgFlag, _ := cmd.Flags().GetBool("config")
+		if !cfgFlag {
+			fmt.Fprintln(os.Stderr, "Error: nothing to reset; pass --config to repair corrupt config files")
+			os.Exit(1)
+		}
+		yes, _ := cmd.Flags().GetBool("yes")
+		runResetConfig(yes)
+	},
+}
+
+// pkgCmd forwards arguments to the package manager (unmanaged)
+var pkgCmd = &cobra.Command{
+	Use:   "pkg -- <args...>",
+	Short: "Run the package manager directly (advanced, unmanaged)",
+	Long: `Forward arguments to the detected package manager's own command (brew,
+pacman, paru or apt), for operations dotfiles doesn't model. Output is
+streamed, and sudo is requested first where the manager needs it.
+
+This is an escape hatch: dotfiles doesn't track or verify what these commands
+change. Input isn't forwarded, so pass the manager's non-interactive flag
+(-y, --noconfirm) to commands that would prompt.
+
+  dotfiles pkg -- services list
+  dotfiles pkg -- install -y --no-install-recommends build-essential`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runPkgPassthrough(args)
+	},
+}
+
 // uninstallCmd removes dotfiles and restores original config
 var uninstallCmd = &cobra.Command{
 	Use:   "uninstall",
@@ -257,31 +817,106 @@ This will:
   - Remove utility scripts (hk, caff, sshh)
   - Remove dotfiles configuration directory
 
+Before asking to confirm, it lists the binaries it found, the size of the
+configuration directory and how many backups are deleted with it.
+
 Use --keep-config to preserve the ~/.config/dotfiles directory.
 Use --keep-binaries to preserve installed binaries.
-Use --no-restore to skip restoring backups.`,
+Use --no-restore to skip restoring backups.
+Use --dry-run to list every action without changing anything.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		keepConfig, _ := cmd.Flags().GetBool("keep-config")
 		keepBinaries, _ := cmd.Flags().GetBool("keep-binaries")
 		noRestore, _ := cmd.Flags().GetBool("no-restore")
 		force, _ := cmd.Flags().GetBool("force")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
 
-		runUninstall(keepConfig, keepBinaries, noRestore, force)
+		runUninstall(keepConfig, keepBinaries, noRestore, force, dryRun)
 	},
 }
 
 func init() {
+	tools.Version = version
+
 	// Global flags
 	rootCmd.PersistentFlags().BoolVar(&skipIntro, "skip-intro", false, "Skip intro animation")
 
+	// Install flags
+	installCmd.Flags().StringSlice("category", nil, "Only show deep dive sections for this tool category (repeatable)")
+	installCmd.Flags().String("profile", "", "Start with the tools selected in a saved install profile")
+	installCmd.Flags().String("from", "", "Start from a machine snapshot written by \"dotfiles snapshot\"")
+
+	// Snapshot flags
+	snapshotCmd.Flags().StringP("out", "o", "", "Write the snapshot to this file instead of stdout")
+
+	// Serve flags
+	serveCmd.Flags().String("socket", serve.DefaultSocketPath(), "Unix socket to listen on")
+
+	// Theme flags
+	themeCmd.Flags().Bool("json", false, "With show, print the palette as JSON")
+	themeCmd.Flags().String("from-wallpaper", "", "Generate a custom theme from an image's colors and select it")
+	themeCmd.Flags().String("name", "wallpaper", "With --from-wallpaper, the custom theme's name")
+
 	// Hotkeys flags
 	hotkeysCmd.Flags().String("tool", "", "Filter hotkeys by tool (tmux, zsh, neovim, etc.)")
 
+	// Hotkeys favorites subcommands
+	hotkeysFavoritesCmd.AddCommand(hotkeysFavoritesListCmd)
+	hotkeysFavoritesCmd.AddCommand(hotkeysFavoritesRemoveCmd)
+	hotkeysFavoritesCmd.AddCommand(hotkeysFavoritesClearCmd)
+	hotkeysCmd.AddCommand(hotkeysFavoritesCmd)
+
+	// Config subcommands
+	configListCmd.Flags().Bool("all", false, "Include config paths that don't exist")
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configMigrateCmd)
+	configLogCmd.Flags().IntP("limit", "n", 20, "Show at most this many changes (0 for all)")
+	configLogCmd.Flags().String("file", "", "Only show changes to files containing this (e.g. tmux)")
+	configLogCmd.Flags().Bool("json", false, "Output changes as JSON")
+	configCmd.AddCommand(configLogCmd)
+	configCmd.AddCommand(configMirrorCmd)
+	configImportShareCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+	configCmd.AddCommand(configShareCmd)
+	configCmd.AddCommand(configImportShareCmd)
+
+	// Update subcommands
+	updateCmd.AddCommand(updatePinCmd)
+	updateCmd.AddCommand(updateUnpinCmd)
+
+	// Backups flags
+	backupsCmd.Flags().Bool("json", false, "Output backups as JSON")
+	backupsCmd.Flags().Bool("open", false, "Open the backups directory in the file manager")
+	backupCreateCmd.Flags().StringArray("exclude", nil, "Skip a file for this backup (repeatable)")
+	backupCreateCmd.Flags().String("name", "", "Label for this backup (e.g. \"before nvim migration\")")
+	backupDiffCmd.Flags().Bool("vs-current", false, "Compare the backup with the files on disk")
+	backupDiffCmd.Flags().BoolP("patch", "p", false, "Show the line diff of changed files")
+	restoreCmd.Flags().Bool("latest", false, "Restore the most recent backup")
+	restoreCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompts")
+	restoreCmd.Flags().Bool("keep-newer", false, "Keep files changed on disk after the backup was taken")
+	restoreCmd.Flags().Bool("preview", false, "Extract to a temporary directory and report what restoring would change")
+
+	// Backup subcommands
+	backupCmd.AddCommand(backupCreateCmd)
+	backupCmd.AddCommand(backupVerifyCmd)
+	backupCmd.AddCommand(backupDiffCmd)
+
+	// Diagnostics flags
+	diagnosticsCmd.Flags().StringP("out", "o", "", "Bundle path (default: dotfiles-diagnostics-<timestamp>.zip)")
+	diagnosticsCmd.Flags().Bool("include-email", false, "Keep email addresses (e.g. git user.email) unredacted")
+
+	// Repair flags
+	repairCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+
+	// Reset flags
+	resetCmd.Flags().Bool("config", false, "Replace corrupt config files with defaults")
+	resetCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+
 	// Uninstall flags
 	uninstallCmd.Flags().Bool("keep-config", false, "Keep ~/.config/dotfiles directory")
 	uninstallCmd.Flags().Bool("keep-binaries", false, "Keep installed binaries")
 	uninstallCmd.Flags().Bool("no-restore", false, "Skip restoring backups")
 	uninstallCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+	uninstallCmd.Flags().Bool("dry-run", false, "Show what would be removed and restored without doing it")
 
 	// User command flags
 	userAddCmd.Flags().String("theme", "", "Theme name (e.g., catppuccin-mocha)")
@@ -292,6 +927,7 @@ func init() {
 	// User subcommands
 	userCmd.AddCommand(userAddCmd)
 	userCmd.AddCommand(userDeleteCmd)
+	userCmd.AddCommand(userRepairCmd)
 
 	// Add subcommands
 	rootCmd.AddCommand(installCmd)
@@ -301,12 +937,22 @@ func init() {
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(hotkeysCmd)
 	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(spaceCmd)
 	rootCmd.AddCommand(backupsCmd)
+	rootCmd.AddCommand(backupCmd)
 	rootCmd.AddCommand(restoreCmd)
+	versionCmd.Flags().Bool("json", false, "Output version and build metadata as JSON")
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(diagnosticsCmd)
+	rootCmd.AddCommand(repairCmd)
+	rootCmd.AddCommand(resetCmd)
+	rootCmd.AddCommand(pkgCmd)
 	rootCmd.AddCommand(uninstallCmd)
 	rootCmd.AddCommand(userCmd)
 	rootCmd.AddCommand(usersCmd)
+	rootCmd.AddCommand(profilesCmd)
+	rootCmd.AddCommand(snapshotCmd)
+	rootCmd.AddCommand(serveCmd)
 }
 
 func main() {
@@ -318,14 +964,8 @@ func main() {
 			username := arg[2:]
 			// Skip if it's a known flag or looks like a help request
 			if username != "help" && username != "version" && username != "skip-intro" {
-				if config.ValidateUsername(username) == nil && config.UserExists(username) {
-					switchToUser(username)
-					return
-				}
-				// If username is valid but doesn't exist, show helpful message
 				if config.ValidateUsername(username) == nil {
-					fmt.Printf("User %q does not exist.\n", username)
-					fmt.Println("Create with: dotfiles user add", username)
+					quickSwitchUser(username)
 					return
 				}
 			}
@@ -344,9 +984,10 @@ func createScreenFactory() ui.ScreenFactory {
 	return factory.CreateFactory()
 }
 
-// launchTUI launches the TUI at a specific screen
-func launchTUI(screen ui.Screen) {
-	app := ui.NewApp(skipIntro, ui.WithScreenFactory(createScreenFactory()))
+// launchTUI launches the TUI at a specific screen and returns the app once
+// it exits
+func launchTUI(screen ui.Screen, opts ...ui.AppOption) *ui.App {
+	app := ui.NewApp(skipIntro, append([]ui.AppOption{ui.WithScreenFactory(createScreenFactory())}, opts...)...)
 	app.SetStartScreen(screen)
 
 	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithMouseCellMotion())
@@ -354,6 +995,7 @@ func launchTUI(screen ui.Screen) {
 		fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
 		os.Exit(1)
 	}
+	return app
 }
 
 // launchToolConfig launches TUI for a specific tool config
@@ -363,7 +1005,8 @@ func launchToolConfig(tool string) {
 	screen, ok := ui.GetToolConfigScreen(tool)
 	if !ok {
 		fmt.Fprintf(os.Stderr, "Unknown tool: %s\n", tool)
-		fmt.Println("Available: ghostty, tmux, zsh, neovim, git, yazi, fzf, apps, utilities")
+		printDidYouMean(tool, ui.ToolConfigNames())
+		fmt.Println("Available: " + strings.Join(ui.ToolConfigNames(), ", "))
 		os.Exit(1)
 	}
 
@@ -376,6 +1019,87 @@ func launchToolConfig(tool string) {
 	}
 }
 
+// printDidYouMean suggests the candidates closest to a mistyped name, if any
+func printDidYouMean(name string, candidates []string) {
+	if hint := suggest.DidYouMean(name, candidates); hint != "" {
+		fmt.Fprintln(os.Stderr, strings.ToUpper(hint[:1])+hint[1:])
+	}
+}
+
+// toolIDs returns the IDs of the tools in reg
+func toolIDs(reg *tools.Registry) []string {
+	all := reg.All()
+	ids := make([]string, 0, len(all))
+	for _, t := range all {
+		ids = append(ids, t.ID())
+	}
+	return ids
+}
+
+// setToolConfig applies key=value settings to a tool without the TUI
+func setToolConfig(tool string, assignments []string) {
+	app := ui.NewApp(true)
+	applied, err := app.SetManageFields(tool, assignments)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	for _, a := range applied {
+		fmt.Printf("✓ %s %s\n", tool, a)
+	}
+}
+
+// shareToolConfig prints a share code for a tool's settings
+func shareToolConfig(tool string) {
+	code, err := ui.NewApp(true).ShareManageFields(tool)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(code)
+}
+
+// importShareCode previews a share code's changes and applies them,
+// confirming first unless yes is set
+func importShareCode(code string, yes bool) {
+	app := ui.NewApp(true)
+	tool, changes, err := app.PreviewShareCode(code)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(changes) == 0 {
+		fmt.Printf("Your %s settings already match this share code.\n", tool)
+		return
+	}
+
+	fmt.Printf("This share code changes %d %s setting(s):\n", len(changes), tool)
+	for _, c := range changes {
+		fmt.Printf("  %s: %s → %s\n", c.Key, c.From, c.To)
+	}
+
+	if !yes {
+		fmt.Print("Apply? [y/N]: ")
+		reader := bufio.NewReader(os.Stdin)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+			os.Exit(1)
+		}
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Import cancelled.")
+			return
+		}
+	}
+
+	if _, _, err := app.ImportShareCode(code); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✓ Applied %d %s setting(s)\n", len(changes), tool)
+}
+
 // launchHotkeysFiltered launches hotkey viewer filtered to a tool
 func launchHotkeysFiltered(tool string) {
 	app := ui.NewApp(true, ui.WithScreenFactory(createScreenFactory()))
@@ -393,8 +1117,9 @@ func launchHotkeysFiltered(tool string) {
 func setTheme(theme string) {
 	if !config.IsValidTheme(theme) {
 		fmt.Fprintf(os.Stderr, "Invalid theme: %s\n", theme)
+		printDidYouMean(theme, config.ThemeNames())
 		fmt.Println("Available themes:")
-		for _, t := range config.AvailableThemes {
+		for _, t := range config.ThemeNames() {
 			fmt.Printf("  %s\n", t)
 		}
 		os.Exit(1)
@@ -416,13 +1141,36 @@ func setTheme(theme string) {
 	fmt.Println("Run 'dotfiles install' to apply the new theme to all tools.")
 }
 
+// themeFromWallpaper generates a custom theme from an image, saves it and
+// selects it
+func themeFromWallpaper(path, name string) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	scheme, err := wallpaper.FromFile(abs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	theme := &config.CustomTheme{Name: name, Light: scheme.Light, Source: abs, Colors: scheme.Colors}
+	if err := config.SaveCustomTheme(theme); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving theme: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✓ Generated theme %s from %s (%s)\n", name, filepath.Base(abs), filepath.Join(config.ThemesDir(), name+".json"))
+	setTheme(name)
+}
+
 // listThemes prints available themes
 func listThemes() {
 	cfg, _ := config.LoadGlobalConfig()
 	current := cfg.Theme
 
 	fmt.Println("Available themes:")
-	for _, t := range config.AvailableThemes {
+	for _, t := range config.ThemeNames() {
 		if t == current {
 			fmt.Printf("  * %s (current)\n", t)
 		} else {
@@ -431,14 +1179,73 @@ func listThemes() {
 	}
 }
 
-// showStatus prints current configuration status
-func showStatus() {
-	cfg, err := config.LoadGlobalConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
-		os.Exit(1)
-	}
-
+// showThemePalette prints the colors a theme applies to the TUI (the current
+// theme when name is empty)
+func showThemePalette(name string, asJSON bool) {
+	if name == "" {
+		cfg, err := config.LoadGlobalConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+		name = cfg.Theme
+	}
+	ui.LoadCustomThemes()
+	colors, ok := ui.ThemeColors(name)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown theme: %s (see dotfiles theme list)\n", name)
+		printDidYouMean(name, config.ThemeNames())
+		os.Exit(1)
+	}
+	palette, _ := ui.ThemePalette(name)
+
+	if asJSON {
+		out := struct {
+			Theme   string            `json:"theme"`
+			Palette map[string]string `json:"palette"`
+			Colors  map[string]string `json:"colors"`
+		}{name, map[string]string{}, map[string]string{}}
+		for _, c := range palette {
+			out.Palette[c.Name] = string(c.Hex)
+		}
+		for _, c := range colors {
+			out.Colors[c.Name] = string(c.Hex)
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding palette: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Printf("Theme: %s\n\n", name)
+	fmt.Println("Palette:")
+	for _, c := range palette {
+		fmt.Printf("  %s %-11s %s\n", colorSwatch(c.Hex), c.Name, c.Hex)
+	}
+	fmt.Println()
+	fmt.Println("TUI colors:")
+	for _, c := range colors {
+		fmt.Printf("  %s %-16s %-8s (%s)\n", colorSwatch(c.Hex), c.Name, c.Hex, c.From)
+	}
+}
+
+// colorSwatch renders a small block in color (plain text when stdout isn't
+// a color terminal)
+func colorSwatch(color lipgloss.Color) string {
+	return lipgloss.NewStyle().Foreground(color).Render("██")
+}
+
+// showStatus prints current configuration status
+func showStatus() {
+	cfg, err := config.LoadGlobalConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
 	fmt.Println("Dotfiles Status")
 	fmt.Println("===============")
 	if cfg.ActiveUser != "" {
@@ -447,10 +1254,42 @@ func showStatus() {
 	fmt.Printf("Theme:      %s\n", cfg.Theme)
 	fmt.Printf("Navigation: %s\n", cfg.NavStyle)
 	fmt.Printf("Config dir: %s\n", config.ConfigDir())
+	if shell := tools.LoginShell(); shell != "" {
+		fmt.Printf("Shell:      %s\n", shell)
+	}
+	rcShell := tools.ResolveShell(cfg.Shell)
+	if rcShell == tools.ShellZsh {
+		if zsh := tools.ZshNotDefault(); zsh != "" {
+			fmt.Printf("⚠ zsh is installed but is not your login shell. Run: chsh -s %s\n", zsh)
+		}
+	}
+	if brew := tools.BrewNotOnPath(rcShell); brew != "" {
+		fmt.Printf("⚠ Homebrew is installed at %s but is not on PATH. Add to %s: %s\n", brew, rcShell.DisplayPath(), tools.BrewShellenvLine(rcShell, brew))
+	}
+	registry := tools.GetRegistry()
+	for _, err := range registry.CustomErrors() {
+		fmt.Printf("⚠ tools.d: skipped %v\n", err)
+	}
+	for _, c := range registry.Conflicts() {
+		fmt.Printf("⚠ tools.d: %s\n", c)
+	}
+	for _, err := range ui.ValidateKeybindings(cfg.Keybindings) {
+		fmt.Printf("⚠ keybindings: %v\n", err)
+	}
+	switch tools.CheckLocalBin(rcShell) {
+	case tools.LocalBinMissing:
+		fmt.Printf("⚠ ~/.local/bin is not on PATH, so dotfiles and hk won't be found. Add to %s: %s\n", rcShell.DisplayPath(), tools.LocalBinPathLine(rcShell))
+	case tools.LocalBinNeedsRestart:
+		fmt.Printf("⚠ ~/.local/bin is not on PATH in this shell. Restart your shell or run: source %s\n", rcShell.DisplayPath())
+	}
+	if cfg.Shell != "" {
+		if _, err := tools.ParseShell(cfg.Shell); err != nil {
+			fmt.Printf("⚠ shell: %v; using %s\n", err, tools.ResolveShell(""))
+		}
+	}
 	fmt.Println()
 
 	// Show installed tools (filtered by platform)
-	registry := tools.GetRegistry()
 	installed := registry.Installed()
 	notInstalled := registry.NotInstalledForPlatform()
 
@@ -463,16 +1302,11 @@ func showStatus() {
 		byCategory[t.Category()] = append(byCategory[t.Category()], t)
 	}
 
-	categories := []tools.Category{
-		tools.CategoryShell, tools.CategoryTerminal, tools.CategoryEditor,
-		tools.CategoryFile, tools.CategoryGit, tools.CategoryContainer,
-		tools.CategoryUtility, tools.CategoryApp,
-	}
-
 	// Get current platform for package details
 	platform := pkg.DetectPlatform()
+	versions := installedVersions()
 
-	for _, cat := range categories {
+	for _, cat := range tools.AllCategories {
 		if catTools, ok := byCategory[cat]; ok && len(catTools) > 0 {
 			names := make([]string, 0, len(catTools))
 			for _, t := range catTools {
@@ -481,17 +1315,23 @@ func showStatus() {
 				if len(pkgs) == 0 {
 					pkgs = t.Packages()["all"]
 				}
+				name := t.Name()
+				if v := t.InstalledVersion(versions); v != "" {
+					name += " " + v
+				}
 				if len(pkgs) > 1 {
 					// Show tool name with package count
-					names = append(names, fmt.Sprintf("%s (+%d pkgs)", t.Name(), len(pkgs)-1))
+					names = append(names, fmt.Sprintf("%s (+%d pkgs)", name, len(pkgs)-1))
 				} else {
-					names = append(names, t.Name())
+					names = append(names, name)
 				}
 			}
 			fmt.Printf("  %s: %s\n", strings.Title(string(cat)), strings.Join(names, ", "))
 		}
 	}
 
+	printRecentInstalls(registry)
+
 	if len(notInstalled) > 0 {
 		fmt.Println()
 		fmt.Printf("Not Installed: %d tools\n", len(notInstalled))
@@ -501,193 +1341,1180 @@ func showStatus() {
 		}
 		fmt.Printf("  %s\n", strings.Join(names, ", "))
 	}
-}
-
-// checkUpdates prints outdated packages (CLI mode)
-func checkUpdates() {
-	fmt.Println("Checking for updates...")
+}
+
+// Status lists tools installed within recentInstallDays, up to
+// recentInstallMax of them
+const (
+	recentInstallDays = 7
+	recentInstallMax  = 10
+)
+
+// printRecentInstalls prints the "Recently Installed" section of status from
+// the install history, if anything was installed recently
+func printRecentInstalls(registry *tools.Registry) {
+	since := time.Now().AddDate(0, 0, -recentInstallDays)
+	recent, err := config.RecentInstalls(since, recentInstallMax)
+	if err != nil || len(recent) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("Recently Installed (last %d days):\n", recentInstallDays)
+	for _, r := range recent {
+		name := r.Tool
+		if t, ok := registry.Get(r.Tool); ok {
+			name = t.Name()
+		}
+		if r.Version != "" {
+			name += "@" + r.Version
+		}
+		fmt.Printf("  %-20s %s\n", name, r.Time.Local().Format("2006-01-02 15:04"))
+	}
+}
+
+// installedVersions maps installed package names to their versions, from a
+// single package manager query. It is empty if the manager can't list them.
+func installedVersions() map[string]string {
+	versions := make(map[string]string)
+	mgr := pkg.DetectManager()
+	if mgr == nil {
+		return versions
+	}
+	list, err := mgr.ListInstalled()
+	if err != nil {
+		return versions
+	}
+	for _, p := range list {
+		versions[p.Name] = p.CurrentVersion
+	}
+	return versions
+}
+
+// showSpace prints the disk space used by the config directory
+func showSpace() {
+	usage, err := backup.Usage()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading config dir: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Disk usage: %s\n", ui.FormatBytes(usage.Total))
+	fmt.Printf("Config dir: %s\n", usage.Dir)
+	if len(usage.Parts) == 0 {
+		return
+	}
+	fmt.Println("─────────────────────────")
+	for _, p := range usage.Parts {
+		fmt.Printf("  %-8s %10s  %3.0f%%\n", p.Name, ui.FormatBytes(p.Bytes), float64(p.Bytes)*100/float64(usage.Total))
+	}
+
+	if usage.BackupsDominate() {
+		fmt.Println()
+		fmt.Println("⚠ Backups use most of this space.")
+		if cfg, err := config.LoadGlobalConfig(); err == nil {
+			fmt.Printf("  Lower backup_max_count (now %d) or backup_max_age_days (now %d) in global.json;\n",
+				cfg.BackupMaxCount, cfg.BackupMaxAgeDays)
+			fmt.Println("  older backups are pruned on the next backup.")
+		}
+	}
+}
+
+// checkUpdates prints outdated packages (CLI mode)
+func checkUpdates() {
+	fmt.Println("Checking for updates...")
+
+	mgr := pkg.DetectManager()
+	if mgr == nil {
+		fmt.Println("No package manager detected.")
+		return
+	}
+
+	fmt.Printf("Using %s package manager\n\n", mgr.Name())
+
+	updates, err := tools.CheckUpdates()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error checking updates: %v\n", err)
+		return
+	}
+
+	if len(updates) == 0 {
+		fmt.Println("All packages are up to date!")
+		return
+	}
+
+	fmt.Printf("Found %d outdated package(s):\n\n", len(updates))
+	fmt.Printf("%-25s %-15s %-15s\n", "PACKAGE", "CURRENT", "LATEST")
+	fmt.Printf("%-25s %-15s %-15s\n", "-------", "-------", "------")
+	for _, p := range updates {
+		pinned := ""
+		if p.Pinned {
+			pinned = "(pinned)"
+		}
+		fmt.Printf("%-25s %-15s %-15s %s\n", p.Name, p.CurrentVersion, p.LatestVersion, pinned)
+	}
+	fmt.Println()
+	fmt.Println("Run 'dotfiles update' for interactive update selection.")
+}
+
+// listManagedConfigs prints the ownership state of each known config file
+func listManagedConfigs(all bool) {
+	home, _ := os.UserHomeDir()
+
+	fmt.Printf("%-12s %-10s %-9s %-21s %s\n", "TOOL", "STATE", "VERSION", "GENERATED", "PATH")
+	missing := 0
+	for _, f := range tools.KnownConfigFiles() {
+		info := tools.InspectManaged(f.Path)
+		if info.State == tools.StateMissing && !all {
+			missing++
+			continue
+		}
+
+		path := f.Path
+		if home != "" && strings.HasPrefix(path, home+string(os.PathSeparator)) {
+			path = "~" + strings.TrimPrefix(path, home)
+		}
+		fmt.Printf("%-12s %-10s %-9s %-21s %s\n",
+			f.ToolID, info.State, orDash(info.Marker.Version), orDash(info.Marker.Generated), path)
+	}
+
+	if missing > 0 {
+		fmt.Printf("\n%d config path(s) not present (use --all to show)\n", missing)
+	}
+}
+
+// mirrorConfigs commits the generated config files into the dotfiles repo
+func mirrorConfigs() {
+	cfg, err := config.LoadGlobalConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	if cfg.DotfilesRepo == "" {
+		fmt.Fprintf(os.Stderr, "Error: no dotfiles repo set. Add \"dotfiles_repo\": \"~/dotfiles\" to %s\n",
+			filepath.Join(config.ConfigDir(), "global.json"))
+		os.Exit(1)
+	}
+
+	result, err := tools.MirrorToRepo(context.Background(), cfg.DotfilesRepo, "dotfiles config mirror")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if result.Commit == "" {
+		fmt.Printf("%s is already up to date.\n", result.Repo)
+		return
+	}
+	fmt.Printf("✓ Committed %d file(s) to %s as %s:\n", len(result.Files), result.Repo, result.Commit)
+	for _, f := range result.Files {
+		fmt.Printf("  %s\n", f)
+	}
+}
+
+// migrateConfigs forces a schema migration of every config file
+func migrateConfigs() {
+	migrations, err := config.MigrateAllConfigs()
+	if err == nil {
+		var m *config.Migration
+		if m, err = config.MigrateToolConfig("manage", ui.NewManageConfig); m != nil && m.Changed() {
+			migrations = append(migrations, *m)
+		}
+	}
+	for _, m := range migrations {
+		fmt.Printf("  %s\n", m)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error migrating config: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(migrations) == 0 {
+		fmt.Printf("All config files are up to date (schema v%d)\n", config.SchemaVersion)
+		return
+	}
+	fmt.Printf("\nMigrated %d file(s) to schema v%d\n", len(migrations), config.SchemaVersion)
+}
+
+// showConfigLog prints the config audit log newest first, optionally only
+// the changes to matching files
+func showConfigLog(limit int, file string, asJSON bool) {
+	records, err := config.LoadAuditLog()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading config log: %v\n", err)
+		os.Exit(1)
+	}
+
+	shown := []config.AuditRecord{}
+	for i := len(records) - 1; i >= 0; i-- {
+		if file != "" && !strings.Contains(records[i].File, file) {
+			continue
+		}
+		shown = append(shown, records[i])
+		if limit > 0 && len(shown) == limit {
+			break
+		}
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(shown, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding config log: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	if len(shown) == 0 {
+		fmt.Println("No config changes recorded.")
+		fmt.Printf("Log file: %s\n", config.AuditLogPath())
+		return
+	}
+
+	for _, r := range shown {
+		who := r.User
+		if r.Profile != "" {
+			who += " (" + r.Profile + ")"
+		}
+		fmt.Printf("%s  %-22s %s\n", r.Time.Local().Format("2006-01-02 15:04:05"), r.File, who)
+		if r.Created {
+			fmt.Println("    (new file)")
+		} else {
+			fmt.Printf("    %s\n", strings.Join(r.Keys, ", "))
+		}
+	}
+}
+
+// parseCategories validates tool category names, dropping duplicates
+func parseCategories(names []string) ([]tools.Category, error) {
+	var cats []tools.Category
+	for _, name := range names {
+		c, err := tools.ParseCategory(name)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(cats, c) {
+			cats = append(cats, c)
+		}
+	}
+	return cats, nil
+}
+
+// orDash returns s, or "-" when empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// pinPackage adds a package to the pinned list
+func pinPackage(name string) {
+	added, err := config.PinPackage(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error pinning %s: %v\n", name, err)
+		os.Exit(1)
+	}
+	if !added {
+		fmt.Printf("%s is already pinned.\n", name)
+		return
+	}
+	fmt.Printf("Pinned %s; it will be skipped by updates.\n", name)
+}
+
+// unpinPackage removes a package from the pinned list
+func unpinPackage(name string) {
+	removed, err := config.UnpinPackage(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error unpinning %s: %v\n", name, err)
+		os.Exit(1)
+	}
+	if !removed {
+		fmt.Printf("%s is not pinned.\n", name)
+		return
+	}
+	fmt.Printf("Unpinned %s.\n", name)
+}
+
+// listFavorites prints the active user's favorite hotkeys by category
+func listFavorites() {
+	cfg, err := config.LoadHotkeysConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading hotkeys config: %v\n", err)
+		os.Exit(1)
+	}
+
+	username := config.ActiveUsername()
+	userHotkeys := cfg.GetUserHotkeys(username)
+	if userHotkeys.GetFavoriteCount() == 0 {
+		fmt.Printf("No favorites for user %s.\n", username)
+		fmt.Println("Mark favorites in the hotkeys viewer: dotfiles hotkeys")
+		return
+	}
+
+	// Look up descriptions for display
+	navStyle := "emacs"
+	if g, err := config.LoadGlobalConfig(); err == nil {
+		navStyle = g.NavStyle
+	}
+	descriptions := make(map[string]string)
+	for _, cat := range hotkeys.Categories(navStyle) {
+		for _, it := range cat.Items {
+			descriptions[cat.ID+"\x00"+it.Keys] = it.Description
+		}
+	}
+
+	categories := make([]string, 0, len(userHotkeys.Favorites))
+	for cat, items := range userHotkeys.Favorites {
+		if len(items) > 0 {
+			categories = append(categories, cat)
+		}
+	}
+	sort.Strings(categories)
+
+	fmt.Printf("Favorites for %s (%d):\n", username, userHotkeys.GetFavoriteCount())
+	fmt.Println("─────────────────────────")
+	for _, cat := range categories {
+		fmt.Printf("%s:\n", cat)
+		for _, keys := range userHotkeys.Favorites[cat] {
+			if desc := descriptions[cat+"\x00"+keys]; desc != "" {
+				fmt.Printf("  %-20s %s\n", keys, desc)
+			} else {
+				fmt.Printf("  %s\n", keys)
+			}
+		}
+	}
+}
+
+// removeFavorite removes one favorite hotkey for the active user
+func removeFavorite(category, keys string) {
+	cfg, err := config.LoadHotkeysConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading hotkeys config: %v\n", err)
+		os.Exit(1)
+	}
+
+	username := config.ActiveUsername()
+	userHotkeys := cfg.GetUserHotkeys(username)
+	if !userHotkeys.RemoveFavorite(category, keys) {
+		fmt.Fprintf(os.Stderr, "%q is not a favorite in %s.\n", keys, category)
+		fmt.Fprintln(os.Stderr, "Run 'dotfiles hotkeys favorites list' to see favorites.")
+		os.Exit(1)
+	}
+	cfg.SetUserHotkeys(username, userHotkeys)
+
+	if err := config.SaveHotkeysConfig(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving hotkeys config: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Removed favorite: %s %s\n", category, keys)
+}
+
+// clearFavorites removes all favorites in a category for the active user
+func clearFavorites(category string) {
+	cfg, err := config.LoadHotkeysConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading hotkeys config: %v\n", err)
+		os.Exit(1)
+	}
+
+	username := config.ActiveUsername()
+	userHotkeys := cfg.GetUserHotkeys(username)
+	removed := userHotkeys.ClearFavorites(category)
+	if removed == 0 {
+		fmt.Printf("No favorites in %s.\n", category)
+		return
+	}
+	cfg.SetUserHotkeys(username, userHotkeys)
+
+	if err := config.SaveHotkeysConfig(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving hotkeys config: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Cleared %d favorite(s) from %s.\n", removed, category)
+}
+
+// versionInfo is the build metadata printed by version --json
+type versionInfo struct {
+	Version        string `json:"version"`
+	Commit         string `json:"commit,omitempty"`
+	BuildDate      string `json:"build_date,omitempty"`
+	GoVersion      string `json:"go_version"`
+	OS             string `json:"os"`
+	Arch           string `json:"arch"`
+	PackageManager string `json:"package_manager,omitempty"`
+}
+
+// buildInfo returns the version metadata. Builds without ldflags fall back
+// to the VCS details the Go toolchain embeds (go build in a git checkout).
+func buildInfo() versionInfo {
+	info := versionInfo{
+		Version:   version,
+		Commit:    commit,
+		BuildDate: buildDate,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok && info.Commit == "" {
+		dirty := false
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				info.Commit = s.Value[:min(12, len(s.Value))]
+			case "vcs.time":
+				// The commit's time: the closest to a build date available
+				if info.BuildDate == "" {
+					info.BuildDate = s.Value
+				}
+			case "vcs.modified":
+				dirty = s.Value == "true"
+			}
+		}
+		if dirty && info.Commit != "" {
+			info.Commit += "-dirty"
+		}
+	}
+	if mgr := pkg.DetectManager(); mgr != nil {
+		info.PackageManager = mgr.Name()
+	}
+	return info
+}
+
+// showVersion prints the version, or every build detail as JSON
+func showVersion(asJSON bool) {
+	if !asJSON {
+		fmt.Printf("dotfiles version %s\n", version)
+		return
+	}
+
+	data, err := json.MarshalIndent(buildInfo(), "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding version: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+}
+
+// listBackups prints available backups
+func listBackups(asJSON bool) {
+	backups, err := backup.List()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading backups: %v\n", err)
+		os.Exit(1)
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(backups, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding backups: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	if len(backups) == 0 {
+		fmt.Println("No backups found.")
+		fmt.Printf("Backup directory: %s\n", backup.Dir())
+		return
+	}
+
+	fmt.Printf("Available backups (%d):\n", len(backups))
+	fmt.Println("─────────────────────────")
+
+	for _, b := range backups {
+		fmt.Printf("  %s  (%d files, %s)",
+			b.Name,
+			b.FileCount,
+			b.Timestamp.Format("Jan 02 15:04"))
+		if b.Label != "" {
+			fmt.Printf(" — %s", b.Label)
+		}
+		if b.Reason != "" {
+			fmt.Printf(" [%s]", b.Reason)
+		}
+		fmt.Println()
+	}
+
+	fmt.Println()
+	fmt.Println("To restore: dotfiles restore <backup-name>")
+}
+
+// openBackupsDir opens the backups directory in the OS file manager, falling
+// back to yazi and then to printing the path
+func openBackupsDir() {
+	dir := backup.Dir()
+	if _, err := os.Stat(dir); err != nil {
+		fmt.Println("No backups found.")
+		fmt.Printf("Backup directory: %s\n", dir)
+		return
+	}
+
+	cmd, err := opener.FileManager(dir)
+	if err != nil {
+		cmd, err = opener.Yazi(dir)
+	}
+	if err != nil {
+		fmt.Println("No file manager available. Backups are in:")
+		fmt.Printf("  %s\n", dir)
+		return
+	}
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening %s: %v\n", dir, err)
+		os.Exit(1)
+	}
+}
+
+// createBackup creates a new, optionally labelled backup, skipping any
+// excluded files
+func createBackup(exclude []string, label string) {
+	excluded, err := backup.Excludes(exclude)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	name, err := backup.Create(backup.CreateOptions{Exclude: exclude, Label: label})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating backup: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Created backup: %s\n", name)
+	if label != "" {
+		fmt.Printf("Label: %s\n", label)
+	}
+	if len(excluded) > 0 {
+		fmt.Printf("Excluded: %s\n", strings.Join(excluded, ", "))
+	}
+}
+
+// restoreBackup restores a specific backup. Files changed on disk since the
+// backup was taken are overwritten after asking, unless yes is set (overwrite
+// without asking) or keepNewer is set (keep them without asking).
+func restoreBackup(name string, yes, keepNewer bool) {
+	backupDir := filepath.Join(config.ConfigDir(), "backups", name)
+
+	info, err := os.Stat(backupDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "Backup '%s' not found.\n", name)
+			fmt.Println("Run 'dotfiles backups' to see available backups.")
+			return
+		}
+		fmt.Fprintf(os.Stderr, "Error accessing backup: %v\n", err)
+		return
+	}
+
+	if !info.IsDir() {
+		fmt.Fprintf(os.Stderr, "'%s' is not a valid backup directory.\n", name)
+		return
+	}
+
+	fmt.Printf("Restoring backup: %s\n", name)
+	if reason := backup.ReadReason(backupDir); reason != "" {
+		fmt.Printf("Taken automatically: %s\n", reason)
+	}
+
+	// Warn about files that no longer match their manifest checksum
+	if result, err := backup.Verify(backupDir); err == nil {
+		for _, m := range result.Mismatches {
+			fmt.Fprintf(os.Stderr, "  Warning: %s: %s\n", m.Path, m.Reason)
+		}
+	}
+
+	// Plan skips files that would land outside the home and config
+	// directories, so a malicious backup can't overwrite arbitrary files
+	files, err := backup.Plan(backupDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading backup: %v\n", err)
+		return
+	}
+
+	opts := backup.RestoreOptions{KeepNewer: keepNewer}
+	if newer := backup.NewerFiles(files); len(newer) > 0 && !keepNewer && !yes {
+		if !resolveNewerFiles(files, newer) {
+			fmt.Println("Restore cancelled.")
+			return
+		}
+	}
+
+	for _, f := range files {
+		switch {
+		case f.Status == backup.FileIdentical:
+			continue
+		case f.Kept(opts):
+			fmt.Printf("  Kept (newer): %s\n", f.RelPath)
+		default:
+			fmt.Printf("  Restoring: %s\n", f.RelPath)
+		}
+	}
+
+	restored, err := backup.Restore(files, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "  Error: %v\n", err)
+	}
+	fmt.Printf("\nRestored %d files from backup.\n", restored)
+}
+
+// resolveNewerFiles warns about files changed since the backup was taken and
+// asks whether to overwrite them, setting Keep on the files to leave alone.
+// It returns false if the restore was cancelled.
+func resolveNewerFiles(files, newer []backup.RestoreFile) bool {
+	fmt.Printf("\n%d file(s) changed after this backup was taken; restoring will overwrite them:\n", len(newer))
+	for _, f := range newer {
+		fmt.Printf("  ~/%s\n", f.RelPath)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	ask := func(prompt string) string {
+		fmt.Print(prompt)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(strings.ToLower(response))
+	}
+
+	switch ask("[O]verwrite all, [k]eep newer, [a]sk for each, [c]ancel: ") {
+	case "", "o", "overwrite":
+		return true
+	case "k", "keep":
+		for i := range files {
+			files[i].Keep = files[i].Newer
+		}
+		return true
+	case "a", "ask":
+		for i := range files {
+			if !files[i].Newer {
+				continue
+			}
+			response := ask(fmt.Sprintf("  Overwrite ~/%s? [y/N]: ", files[i].RelPath))
+			files[i].Keep = response != "y" && response != "yes"
+		}
+		return true
+	}
+	return false
+}
+
+// restoreLatestBackup restores the most recent backup, confirming first
+// unless yes is set
+func restoreLatestBackup(yes, keepNewer bool) {
+	name := latestBackupName(config.ConfigDir())
+	if name == "" {
+		fmt.Fprintln(os.Stderr, "No backups found.")
+		os.Exit(1)
+	}
+
+	if !yes {
+		fmt.Printf("Restore latest backup %q? [y/N]: ", name)
+		reader := bufio.NewReader(os.Stdin)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+			os.Exit(1)
+		}
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Restore cancelled.")
+			return
+		}
+	}
+
+	restoreBackup(name, yes, keepNewer)
+}
+
+// verifyBackup checks a backup's files against its manifest checksums
+func verifyBackup(name string) {
+	backupDir := filepath.Join(backup.Dir(), name)
+	if info, err := os.Stat(backupDir); err != nil || !info.IsDir() {
+		fmt.Fprintf(os.Stderr, "Backup '%s' not found.\n", name)
+		fmt.Println("Run 'dotfiles backups' to see available backups.")
+		os.Exit(1)
+	}
+
+	result, err := backup.Verify(backupDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error verifying backup: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, m := range result.Mismatches {
+		fmt.Printf("  ✗ %s: %s\n", m.Path, m.Reason)
+	}
+	fmt.Printf("Verified %d files in %s.\n", result.Verified, name)
+	if result.Unchecked > 0 {
+		fmt.Printf("%d files have no recorded checksum (backup predates checksums).\n", result.Unchecked)
+	}
+	if !result.OK() {
+		fmt.Fprintf(os.Stderr, "Backup '%s' failed verification: %d problem(s).\n", name, len(result.Mismatches))
+		os.Exit(1)
+	}
+}
+
+// previewRestore extracts a backup to a temporary directory and reports its
+// contents and how they differ from the live files, then cleans up
+func previewRestore(name string) {
+	backupDir := filepath.Join(backup.Dir(), name)
+	if info, err := os.Stat(backupDir); err != nil || !info.IsDir() {
+		fmt.Fprintf(os.Stderr, "Backup '%s' not found.\n", name)
+		fmt.Println("Run 'dotfiles backups' to see available backups.")
+		os.Exit(1)
+	}
+
+	files, err := backup.Plan(backupDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading backup: %v\n", err)
+		os.Exit(1)
+	}
+	tmp, err := os.MkdirTemp("", "dotfiles-restore-preview-")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer os.RemoveAll(tmp)
+	if _, err := backup.Extract(files, tmp); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.RemoveAll(tmp)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Preview of backup: %s\n", name)
+	if reason := backup.ReadReason(backupDir); reason != "" {
+		fmt.Printf("Taken automatically: %s\n", reason)
+	}
+	fmt.Println()
+
+	sort.Slice(files, func(i, j int) bool { return files[i].RelPath < files[j].RelPath })
+	var shownDirs []string
+	for _, f := range files {
+		// Print each directory once, as a tree
+		parts := strings.Split(filepath.ToSlash(f.RelPath), "/")
+		for depth := range parts[:len(parts)-1] {
+			if depth >= len(shownDirs) || shownDirs[depth] != parts[depth] {
+				shownDirs = append(shownDirs[:depth], parts[depth])
+				fmt.Printf("  %s%s/\n", strings.Repeat("  ", depth), parts[depth])
+			}
+		}
+		shownDirs = shownDirs[:len(parts)-1]
+		indent := strings.Repeat("  ", len(parts)-1)
+		width := 32 - len(indent)
+		if width < 1 {
+			width = 1
+		}
+		fmt.Printf("  %s%-*s %s\n", indent, width, parts[len(parts)-1],
+			describeRestoreFile(f, filepath.Join(tmp, f.RelPath)))
+	}
+	if len(files) == 0 {
+		fmt.Println("  (no files)")
+	}
+
+	fmt.Println()
+	fmt.Printf("Restoring would: %s.\n", backup.Summarize(files))
+	fmt.Printf("Nothing was changed. Run 'dotfiles restore %s' to restore.\n", name)
+}
+
+// describeRestoreFile summarizes how an extracted backup file compares to
+// the live file restoring it would replace
+func describeRestoreFile(f backup.RestoreFile, extracted string) string {
+	var desc string
+	switch {
+	case f.LinkTarget != "":
+		desc = fmt.Sprintf("→ %s (%s)", f.LinkTarget, f.Status)
+	case f.Status == backup.FileDiffers:
+		desc = "differs"
+		live, err1 := os.ReadFile(f.Dest)
+		stored, err2 := os.ReadFile(extracted)
+		if err1 == nil && err2 == nil {
+			added, removed := diff.Stats(diff.Lines(string(live), string(stored)))
+			desc = fmt.Sprintf("differs (+%d -%d)", added, removed)
+		}
+	default:
+		desc = f.Status.String()
+	}
+	if info, err := os.Lstat(extracted); err == nil && f.LinkTarget == "" {
+		desc = fmt.Sprintf("%-9s %s", ui.FormatBytes(info.Size()), desc)
+	}
+	if f.Newer {
+		desc += ", newer on disk"
+	}
+	if f.Corrupt {
+		desc += ", failed checksum"
+	}
+	return desc
+}
+
+// diffBackups prints what changed between two backups, or between a backup
+// and the live files
+func diffBackups(names []string, vsCurrent, patch bool) {
+	paths := make([]string, len(names))
+	for i, name := range names {
+		paths[i] = filepath.Join(backup.Dir(), name)
+		if info, err := os.Stat(paths[i]); err != nil || !info.IsDir() {
+			fmt.Fprintf(os.Stderr, "Backup '%s' not found.\n", name)
+			fmt.Println("Run 'dotfiles backups' to see available backups.")
+			os.Exit(1)
+		}
+	}
+
+	var changes []backup.FileChange
+	var err error
+	to := "current files"
+	if vsCurrent {
+		changes, err = backup.CompareCurrent(paths[0])
+	} else {
+		changes, err = backup.Compare(paths[0], paths[1])
+		to = names[1]
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error comparing backups: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s → %s\n", names[0], to)
+	counts := make(map[backup.ChangeKind]int)
+	for _, c := range changes {
+		counts[c.Kind]++
+		switch c.Kind {
+		case backup.Added:
+			fmt.Printf("  + %s\n", c.RelPath)
+		case backup.Removed:
+			fmt.Printf("  - %s\n", c.RelPath)
+		case backup.Changed:
+			lines := diff.Lines(string(c.Old), string(c.New))
+			added, removed := diff.Stats(lines)
+			fmt.Printf("  ~ %s (+%d -%d)\n", c.RelPath, added, removed)
+			if patch {
+				for i, hunk := range diff.Hunks(lines, 3) {
+					if i > 0 {
+						fmt.Println("      ...")
+					}
+					for _, l := range hunk {
+						fmt.Println("      " + l.String())
+					}
+				}
+			}
+		}
+	}
+	if counts[backup.Added]+counts[backup.Removed]+counts[backup.Changed] == 0 {
+		fmt.Println("  No differences.")
+	}
+	fmt.Printf("%d added, %d removed, %d changed, %d unchanged.\n",
+		counts[backup.Added], counts[backup.Removed], counts[backup.Changed], counts[backup.Unchanged])
+}
+
+// exportDiagnostics writes a redacted diagnostics bundle
+func exportDiagnostics(out string, includeEmail bool) {
+	if out == "" {
+		out = fmt.Sprintf("dotfiles-diagnostics-%s.zip", time.Now().Format("2006-01-02_15-04-05"))
+	}
+
+	files, err := diagnostics.Create(diagnostics.Options{
+		Out:          out,
+		Version:      version,
+		IncludeEmail: includeEmail,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating diagnostics bundle: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Wrote %s (%d files, secrets redacted)\n", out, len(files))
+	fmt.Println("Review the bundle before attaching it to a bug report.")
+}
+
+// runPkgPassthrough runs args with the package manager's own command,
+// streaming its output
+func runPkgPassthrough(args []string) {
+	mgr := pkg.DetectManager()
+	if mgr == nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", pkg.ErrNoManager)
+		os.Exit(1)
+	}
+	if _, _, err := pkg.PassthroughCommand(mgr, args...); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("⚠ Unmanaged: dotfiles doesn't track changes made this way.")
+	fmt.Printf("▶ %s\n", pkg.PassthroughCommandLine(mgr, args...))
+
+	if mgr.NeedsSudo() && !runner.CheckSudoCached() {
+		if err := runner.CacheSudoCredentials(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", pkg.ErrNeedsSudo)
+			os.Exit(1)
+		}
+	}
+
+	cmd, err := pkg.PassthroughStreaming(context.Background(), mgr, args...)
+	if err == nil {
+		var output []string
+		for line := range cmd.Output {
+			fmt.Println("  " + line)
+			output = append(output, line)
+		}
+		err = pkg.Classify(cmd.Wait(), strings.Join(output, "\n"))
+	}
+	if err != nil {
+		fmt.Printf("✗ Failed: %v\n", err)
+		if hint := pkg.Hint(err); hint != "" {
+			fmt.Printf("  %s\n", hint)
+		}
+		os.Exit(1)
+	}
+}
+
+// runInstallTools installs the named tools (tool or tool@version) with the
+// package manager, streaming its output
+func runInstallTools(args []string) {
+	mgr := pkg.DetectManager()
+	if mgr == nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", pkg.ErrNoManager)
+		os.Exit(1)
+	}
+	reg := tools.GetRegistry()
+	platform := pkg.DetectPlatform()
+
+	// Resolve everything first so a typo or unsupported version installs nothing
+	type request struct {
+		tool    tools.Tool
+		version string
+		pkgs    []string
+	}
+	var requests []request
+	for _, arg := range args {
+		id, version, _ := strings.Cut(arg, "@")
+		t, ok := reg.Get(id)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: unknown tool %q. Run 'dotfiles status' to see available tools.\n", id)
+			printDidYouMean(id, toolIDs(reg))
+			os.Exit(1)
+		}
+		pkgs, err := tools.InstallPackages(t, mgr, platform, version)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			if hint := pkg.Hint(err); hint != "" {
+				fmt.Fprintln(os.Stderr, hint)
+			}
+			os.Exit(1)
+		}
+		if len(pkgs) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: no packages for %s on %s\n", t.Name(), platform)
+			os.Exit(1)
+		}
+		if version == "" {
+			version = t.Version()
+		}
+		requests = append(requests, request{tool: t, version: version, pkgs: pkgs})
+	}
+
+	if mgr.NeedsSudo() && !runner.CheckSudoCached() {
+		if err := runner.CacheSudoCredentials(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", pkg.ErrNeedsSudo)
+			os.Exit(1)
+		}
+	}
+
+	failed := 0
+	for _, r := range requests {
+		fmt.Printf("▶ Installing %s (%s)...\n", r.tool.Name(), strings.Join(r.pkgs, " "))
+		cmd, err := mgr.InstallStreaming(context.Background(), r.pkgs...)
+		if err == nil {
+			var output []string
+			for line := range cmd.Output {
+				fmt.Println("  " + line)
+				output = append(output, line)
+			}
+			err = pkg.Classify(cmd.Wait(), strings.Join(output, "\n"))
+		}
+		if err != nil {
+			failed++
+			fmt.Printf("  ✗ Failed: %v\n", err)
+			if hint := pkg.Hint(err); hint != "" {
+				fmt.Printf("    %s\n", hint)
+			}
+			continue
+		}
+		config.RecordInstall(r.tool.ID(), r.version)
+		fmt.Printf("  ✓ Installed %s\n", r.tool.Name())
+	}
 
-	mgr := pkg.DetectManager()
-	if mgr == nil {
-		fmt.Println("No package manager detected.")
-		return
+	reg.InvalidateCache()
+	if failed > 0 {
+		os.Exit(1)
 	}
+}
 
-	fmt.Printf("Using %s package manager\n\n", mgr.Name())
+// runRepair verifies installed tools and reinstalls the broken ones through
+// the streaming install path, reporting verification before and after
+func runRepair(yes bool) {
+	reg := tools.GetRegistry()
 
-	updates, err := pkg.CheckDotfilesUpdates()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error checking updates: %v\n", err)
+	fmt.Println("Verifying installed tools...")
+	before := reg.VerifyInstalled()
+	printHealth(before)
+
+	broken := tools.Broken(before)
+	if len(broken) == 0 {
+		fmt.Println()
+		fmt.Println("✓ All installed tools are working.")
 		return
 	}
 
-	if len(updates) == 0 {
-		fmt.Println("All packages are up to date!")
-		return
+	mgr := pkg.DetectManager()
+	if mgr == nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", pkg.ErrNoManager)
+		os.Exit(1)
 	}
 
-	fmt.Printf("Found %d outdated package(s):\n\n", len(updates))
-	fmt.Printf("%-25s %-15s %-15s\n", "PACKAGE", "CURRENT", "LATEST")
-	fmt.Printf("%-25s %-15s %-15s\n", "-------", "-------", "------")
-	for _, p := range updates {
-		fmt.Printf("%-25s %-15s %-15s\n", p.Name, p.CurrentVersion, p.LatestVersion)
+	names := make([]string, 0, len(broken))
+	for _, t := range broken {
+		names = append(names, t.Name())
 	}
 	fmt.Println()
-	fmt.Println("Run 'dotfiles update' for interactive update selection.")
-}
-
-// listBackups prints available backups
-func listBackups() {
-	backupDir := filepath.Join(config.ConfigDir(), "backups")
-
-	entries, err := os.ReadDir(backupDir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			fmt.Println("No backups found.")
-			fmt.Printf("Backup directory: %s\n", backupDir)
+	if !yes {
+		fmt.Printf("Reinstall %d broken tool(s) with %s (%s)? [y/N]: ", len(broken), mgr.Name(), strings.Join(names, ", "))
+		reader := bufio.NewReader(os.Stdin)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+			os.Exit(1)
+		}
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Repair cancelled.")
 			return
 		}
-		fmt.Fprintf(os.Stderr, "Error reading backups: %v\n", err)
-		return
 	}
 
-	// Filter for directories only (backups are stored in timestamped dirs)
-	var backups []os.DirEntry
-	for _, e := range entries {
-		if e.IsDir() {
-			backups = append(backups, e)
+	if mgr.NeedsSudo() && !runner.CheckSudoCached() {
+		if err := runner.CacheSudoCredentials(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", pkg.ErrNeedsSudo)
+			os.Exit(1)
 		}
 	}
 
-	if len(backups) == 0 {
-		fmt.Println("No backups found.")
-		return
-	}
-
-	// Sort by name (timestamps sort chronologically)
-	sort.Slice(backups, func(i, j int) bool {
-		return backups[i].Name() > backups[j].Name() // Most recent first
-	})
-
-	fmt.Printf("Available backups (%d):\n", len(backups))
-	fmt.Println("─────────────────────────")
-
-	for _, b := range backups {
-		info, err := b.Info()
-		if err != nil {
-			fmt.Printf("  %s\n", b.Name())
-			continue
+	platform := pkg.DetectPlatform()
+	for _, t := range broken {
+		pkgs := t.Packages()[platform]
+		if len(pkgs) == 0 {
+			pkgs = t.Packages()["all"]
 		}
 
-		// Count files in backup
-		files, _ := os.ReadDir(filepath.Join(backupDir, b.Name()))
-		fileCount := 0
-		for _, f := range files {
-			if !f.IsDir() {
-				fileCount++
+		fmt.Printf("\n▶ Reinstalling %s...\n", t.Name())
+		cmd, err := mgr.ReinstallStreaming(context.Background(), pkgs...)
+		if err == nil {
+			var output []string
+			for line := range cmd.Output {
+				fmt.Println("  " + line)
+				output = append(output, line)
+			}
+			err = pkg.Classify(cmd.Wait(), strings.Join(output, "\n"))
+		}
+		if err != nil {
+			fmt.Printf("  ✗ Failed: %v\n", err)
+			if hint := pkg.Hint(err); hint != "" {
+				fmt.Printf("    %s\n", hint)
 			}
 		}
-
-		fmt.Printf("  %s  (%d files, %s)\n",
-			b.Name(),
-			fileCount,
-			info.ModTime().Format("Jan 02 15:04"))
 	}
 
+	reg.InvalidateCache()
 	fmt.Println()
-	fmt.Println("To restore: dotfiles restore <backup-name>")
-}
-
-// restoreBackup restores a specific backup
-func restoreBackup(name string) {
-	backupDir := filepath.Join(config.ConfigDir(), "backups", name)
-
-	info, err := os.Stat(backupDir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			fmt.Fprintf(os.Stderr, "Backup '%s' not found.\n", name)
-			fmt.Println("Run 'dotfiles backups' to see available backups.")
-			return
+	fmt.Println("Verifying again...")
+	stillBroken := 0
+	for _, t := range broken {
+		h := tools.CheckHealth(t)
+		fmt.Printf("  %-14s broken → %s", t.Name(), h.Status)
+		if h.Status == tools.HealthBroken {
+			stillBroken++
+			fmt.Printf(" (%s)", h.Detail)
 		}
-		fmt.Fprintf(os.Stderr, "Error accessing backup: %v\n", err)
-		return
-	}
-
-	if !info.IsDir() {
-		fmt.Fprintf(os.Stderr, "'%s' is not a valid backup directory.\n", name)
-		return
+		fmt.Println()
 	}
 
-	// Read manifest if it exists
-	manifestPath := filepath.Join(backupDir, "manifest.txt")
-	manifest, _ := os.ReadFile(manifestPath)
-
-	fmt.Printf("Restoring backup: %s\n", name)
-	if len(manifest) > 0 {
-		fmt.Printf("Manifest:\n%s\n", string(manifest))
+	fmt.Println()
+	if stillBroken > 0 {
+		fmt.Printf("✗ %d of %d tool(s) still broken.\n", stillBroken, len(broken))
+		os.Exit(1)
 	}
+	fmt.Printf("✓ Repaired %d tool(s).\n", len(broken))
+}
 
-	// Walk backup directory and restore files
-	entries, err := os.ReadDir(backupDir)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading backup: %v\n", err)
+// runResetConfig moves corrupt config files aside and writes defaults in
+// their place
+func runResetConfig(yes bool) {
+	corrupt := config.FindCorruptConfigs()
+	if len(corrupt) == 0 {
+		fmt.Println("No corrupt config files found.")
 		return
 	}
 
-	home, err := os.UserHomeDir()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error getting home directory: %v\n", err)
-		return
+	fmt.Printf("Found %d corrupt config file(s):\n", len(corrupt))
+	for _, c := range corrupt {
+		fmt.Printf("  %s\n    %v\n", c.Path, c.Err)
 	}
+	fmt.Println()
 
-	restored := 0
-	for _, entry := range entries {
-		if entry.IsDir() || entry.Name() == "manifest.txt" {
-			continue
+	if !yes {
+		fmt.Print("Move them aside and reset to defaults? [y/N]: ")
+		reader := bufio.NewReader(os.Stdin)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+			os.Exit(1)
 		}
-
-		srcPath := filepath.Join(backupDir, entry.Name())
-		// Backup files are named with underscores replacing slashes
-		// e.g., ".config_dotfiles_settings.json" -> ".config/dotfiles/settings.json"
-		relPath := strings.ReplaceAll(entry.Name(), "_", string(os.PathSeparator))
-		dstPath := filepath.Clean(filepath.Join(home, relPath))
-
-		// Security: Prevent path traversal attacks. A malicious backup file named
-		// ".._.._etc_passwd" would be converted to "../../etc/passwd", allowing
-		// arbitrary file overwrites outside the home directory. We validate that
-		// the final destination path stays within the user's home directory.
-		if !strings.HasPrefix(dstPath, home+string(os.PathSeparator)) && dstPath != home {
-			fmt.Fprintf(os.Stderr, "  Warning: Skipping %s - path traversal detected\n", entry.Name())
-			continue
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Reset cancelled.")
+			return
 		}
+	}
 
-		// Ensure destination directory exists
-		if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
-			fmt.Fprintf(os.Stderr, "  Error creating directory for %s: %v\n", relPath, err)
-			continue
+	failed := 0
+	for _, c := range corrupt {
+		defaults := config.DefaultsFor(c.Path)
+		if c.Path == filepath.Join(config.ToolsDir(), "manage.json") {
+			defaults = ui.NewManageConfig()
 		}
-
-		// Read source and write to destination
-		data, err := os.ReadFile(srcPath)
+		moved, err := config.ResetConfigFile(c.Path, defaults)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "  Error reading %s: %v\n", entry.Name(), err)
+			fmt.Printf("  ✗ %s: %v\n", c.Path, err)
+			failed++
 			continue
 		}
-
-		if err := os.WriteFile(dstPath, data, 0644); err != nil {
-			fmt.Fprintf(os.Stderr, "  Error writing %s: %v\n", relPath, err)
-			continue
+		if defaults != nil {
+			fmt.Printf("  ✓ Reset %s to defaults (old file: %s)\n", c.Path, filepath.Base(moved))
+		} else {
+			fmt.Printf("  ✓ Moved %s aside to %s\n", c.Path, filepath.Base(moved))
 		}
+	}
 
-		fmt.Printf("  Restored: %s\n", relPath)
-		restored++
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "Error: %d file(s) could not be reset\n", failed)
+		os.Exit(1)
 	}
+}
 
-	fmt.Printf("\nRestored %d files from backup.\n", restored)
+// printHealth prints one line per verified tool
+func printHealth(results []tools.Health) {
+	for _, h := range results {
+		switch h.Status {
+		case tools.HealthOK:
+			fmt.Printf("  ✓ %-14s %s\n", h.Tool.Name(), h.Detail)
+		case tools.HealthBroken:
+			fmt.Printf("  ✗ %-14s %s\n", h.Tool.Name(), h.Detail)
+		case tools.HealthUnchecked:
+			fmt.Printf("  · %-14s no check (app)\n", h.Tool.Name())
+		}
+	}
 }
 
 // runUninstall removes dotfiles and optionally restores original configuration
-func runUninstall(keepConfig, keepBinaries, noRestore, force bool) {
+func runUninstall(keepConfig, keepBinaries, noRestore, force, dryRun bool) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error getting home directory: %v\n", err)
@@ -696,6 +2523,11 @@ func runUninstall(keepConfig, keepBinaries, noRestore, force bool) {
 
 	configDir := config.ConfigDir()
 
+	if dryRun {
+		previewUninstall(home, configDir, keepConfig, keepBinaries, noRestore)
+		return
+	}
+
 	// Show what will be done
 	fmt.Println("Dotfiles Uninstaller")
 	fmt.Println("====================")
@@ -703,14 +2535,29 @@ func runUninstall(keepConfig, keepBinaries, noRestore, force bool) {
 	fmt.Println("This will:")
 
 	if !noRestore {
-		fmt.Println("  • Restore configuration files from latest backup (if available)")
+		if latestBackup := latestBackupName(configDir); latestBackup != "" {
+			fmt.Printf("  • Restore configuration files from backup %s\n", latestBackup)
+		} else {
+			fmt.Println("  • Restore nothing (no backups found)")
+		}
 	}
 	if !keepBinaries {
-		fmt.Println("  • Remove dotfiles binaries (dotfiles, dotfiles-tui, dotfiles-setup)")
-		fmt.Println("  • Remove utility scripts (hk, caff, y)")
+		binaries := installedBinaries(home)
+		if len(binaries) == 0 {
+			fmt.Println("  • Remove no binaries (none found)")
+		} else {
+			fmt.Println("  • Remove these binaries and scripts:")
+			for _, binPath := range binaries {
+				fmt.Printf("      %s\n", binPath)
+			}
+		}
 	}
 	if !keepConfig {
-		fmt.Printf("  • Remove configuration directory (%s)\n", configDir)
+		if _, err := os.Stat(configDir); err == nil {
+			fmt.Printf("  • Remove configuration directory %s\n", describeConfigDir(configDir))
+		} else {
+			fmt.Printf("  • Remove no configuration directory (%s not found)\n", configDir)
+		}
 	}
 	fmt.Println()
 
@@ -734,22 +2581,10 @@ func runUninstall(keepConfig, keepBinaries, noRestore, force bool) {
 	// Restore from latest backup
 	if !noRestore {
 		fmt.Println("Checking for backups...")
-		backupDir := filepath.Join(configDir, "backups")
-		if entries, err := os.ReadDir(backupDir); err == nil && len(entries) > 0 {
-			// Find most recent backup (directories sorted by timestamp)
-			var latestBackup string
-			for _, e := range entries {
-				if e.IsDir() {
-					if latestBackup == "" || e.Name() > latestBackup {
-						latestBackup = e.Name()
-					}
-				}
-			}
-			if latestBackup != "" {
-				fmt.Printf("Restoring from backup: %s\n", latestBackup)
-				restoreBackup(latestBackup)
-				fmt.Println()
-			}
+		if latestBackup := latestBackupName(configDir); latestBackup != "" {
+			fmt.Printf("Restoring from backup: %s\n", latestBackup)
+			restoreBackup(latestBackup, force, false)
+			fmt.Println()
 		} else {
 			fmt.Println("No backups found to restore.")
 			fmt.Println()
@@ -760,34 +2595,13 @@ func runUninstall(keepConfig, keepBinaries, noRestore, force bool) {
 	if !keepBinaries {
 		fmt.Println("Removing binaries...")
 
-		// Binaries to remove
-		binaries := []string{
-			"dotfiles",
-			"dotfiles-tui",
-			"dotfiles-setup",
-			"hk",
-			"caff",
-			"y",
-		}
-
-		// Locations to check
-		locations := []string{
-			filepath.Join(home, ".local", "bin"),
-			"/usr/local/bin",
-		}
-
 		removed := 0
-		for _, loc := range locations {
-			for _, bin := range binaries {
-				binPath := filepath.Join(loc, bin)
-				if _, err := os.Stat(binPath); err == nil {
-					if err := os.Remove(binPath); err != nil {
-						fmt.Fprintf(os.Stderr, "  Warning: Could not remove %s: %v\n", binPath, err)
-					} else {
-						fmt.Printf("  Removed: %s\n", binPath)
-						removed++
-					}
-				}
+		for _, binPath := range installedBinaries(home) {
+			if err := os.Remove(binPath); err != nil {
+				fmt.Fprintf(os.Stderr, "  Warning: Could not remove %s: %v\n", binPath, err)
+			} else {
+				fmt.Printf("  Removed: %s\n", binPath)
+				removed++
 			}
 		}
 
@@ -825,6 +2639,121 @@ func runUninstall(keepConfig, keepBinaries, noRestore, force bool) {
 	fmt.Println("  brew uninstall tekierz/tap/dotfiles")
 }
 
+// previewUninstall prints every action runUninstall would take without
+// removing or restoring anything
+func previewUninstall(home, configDir string, keepConfig, keepBinaries, noRestore bool) {
+	fmt.Println("Dotfiles Uninstaller (dry run)")
+	fmt.Println("==============================")
+	fmt.Println()
+
+	if !noRestore {
+		if latestBackup := latestBackupName(configDir); latestBackup != "" {
+			fmt.Printf("Would restore from backup: %s\n", latestBackup)
+			files, err := backup.Plan(filepath.Join(configDir, "backups", latestBackup))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "  Warning: %v\n", err)
+			}
+			for _, f := range files {
+				if f.LinkTarget != "" {
+					fmt.Printf("  %-10s ~/%s → %s\n", f.Status, f.RelPath, f.LinkTarget)
+					continue
+				}
+				fmt.Printf("  %-10s ~/%s\n", f.Status, f.RelPath)
+			}
+		} else {
+			fmt.Println("No backups found to restore.")
+		}
+		fmt.Println()
+	}
+
+	if !keepBinaries {
+		binaries := installedBinaries(home)
+		if len(binaries) == 0 {
+			fmt.Println("No binaries found to remove.")
+		} else {
+			fmt.Println("Would remove binaries:")
+			for _, binPath := range binaries {
+				fmt.Printf("  %s\n", binPath)
+			}
+		}
+		fmt.Println()
+	}
+
+	if !keepConfig {
+		if _, err := os.Stat(configDir); err == nil {
+			fmt.Printf("Would remove configuration directory: %s\n", describeConfigDir(configDir))
+		} else {
+			fmt.Printf("Configuration directory not found: %s\n", configDir)
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("Dry run complete. Nothing was changed.")
+}
+
+// latestBackupName returns the most recent backup directory name, or "" if
+// there are no backups (names are timestamps, so they sort chronologically)
+func latestBackupName(configDir string) string {
+	entries, err := os.ReadDir(filepath.Join(configDir, "backups"))
+	if err != nil {
+		return ""
+	}
+	var latest string
+	for _, e := range entries {
+		if e.IsDir() && e.Name() > latest {
+			latest = e.Name()
+		}
+	}
+	return latest
+}
+
+// describeConfigDir names the config directory with its size and the number
+// of backups deleted along with it, e.g. "~/.config/dotfiles (1.2 MB, including 3 backups)"
+func describeConfigDir(configDir string) string {
+	backups := 0
+	if entries, err := os.ReadDir(filepath.Join(configDir, "backups")); err == nil {
+		for _, e := range entries {
+			if e.IsDir() {
+				backups++
+			}
+		}
+	}
+	noun := "backups"
+	if backups == 1 {
+		noun = "backup"
+	}
+	return fmt.Sprintf("%s (%s, including %d %s)", configDir, ui.FormatBytes(backup.DirSize(configDir)), backups, noun)
+}
+
+// installedBinaries lists the dotfiles binaries and utility scripts present
+// in the install locations
+func installedBinaries(home string) []string {
+	binaries := []string{
+		"dotfiles",
+		"dotfiles-tui",
+		"dotfiles-setup",
+		"hk",
+		"caff",
+		"y",
+	}
+
+	locations := []string{
+		filepath.Join(home, ".local", "bin"),
+		"/usr/local/bin",
+	}
+
+	var found []string
+	for _, loc := range locations {
+		for _, bin := range binaries {
+			binPath := filepath.Join(loc, bin)
+			if _, err := os.Stat(binPath); err == nil {
+				found = append(found, binPath)
+			}
+		}
+	}
+	return found
+}
+
 // showCurrentUser displays the current active user
 func showCurrentUser() {
 	profile, err := config.GetActiveUser()
@@ -847,6 +2776,31 @@ func showCurrentUser() {
 	fmt.Printf("  Keyboard: %s\n", profile.KeyboardStyle)
 }
 
+// quickSwitchUser resolves a --<Username> query (case-insensitive, prefix)
+// and switches only when exactly one profile matches
+func quickSwitchUser(query string) {
+	matches, err := config.MatchUserProfiles(query)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing users: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch len(matches) {
+	case 0:
+		fmt.Printf("User %q does not exist.\n", query)
+		fmt.Println("Create with: dotfiles user add", query)
+	case 1:
+		switchToUser(matches[0])
+	default:
+		fmt.Printf("Multiple users match %q:\n", query)
+		for _, name := range matches {
+			fmt.Printf("  %s\n", name)
+		}
+		fmt.Println()
+		fmt.Println("Be more specific, e.g.: dotfiles --" + matches[0])
+	}
+}
+
 // switchToUser switches to a user profile, prompting to create if it doesn't exist
 func switchToUser(name string) {
 	if err := config.ValidateUsername(name); err != nil {
@@ -924,8 +2878,9 @@ func addUser(name, theme, nav, keyboard string) {
 	if theme != "" {
 		if !config.IsValidTheme(theme) {
 			fmt.Fprintf(os.Stderr, "Invalid theme: %s\n", theme)
+			printDidYouMean(theme, config.ThemeNames())
 			fmt.Println("Available themes:")
-			for _, t := range config.AvailableThemes {
+			for _, t := range config.ThemeNames() {
 				fmt.Printf("  %s\n", t)
 			}
 			os.Exit(1)
@@ -1014,6 +2969,58 @@ func deleteUser(name string, force bool) {
 	fmt.Printf("Deleted user profile: %s\n", name)
 }
 
+// repairUser fixes unparseable fields in a user profile
+func repairUser(name string) {
+	profile, repaired, err := config.RepairUserProfile(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error repairing user profile: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(repaired) == 0 {
+		fmt.Printf("User profile %q needed no repairs.\n", name)
+		return
+	}
+
+	fmt.Printf("Repaired user profile: %s\n", profile.Name)
+	fmt.Printf("  Reset fields: %s\n", strings.Join(repaired, ", "))
+	fmt.Printf("  Theme:    %s\n", profile.Theme)
+	fmt.Printf("  Nav:      %s\n", profile.NavStyle)
+	fmt.Printf("  Keyboard: %s\n", profile.KeyboardStyle)
+}
+
+// listInstallProfiles displays the saved install profiles and their tools
+func listInstallProfiles() {
+	names, err := config.ListInstallProfiles()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing install profiles: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(names) == 0 {
+		fmt.Println("No install profiles found.")
+		fmt.Println()
+		fmt.Println("Save one from the summary screen at the end of:")
+		fmt.Println("  dotfiles install")
+		return
+	}
+
+	fmt.Printf("Install Profiles (%d):\n", len(names))
+	fmt.Println("─────────────────────────")
+	for _, name := range names {
+		profile, err := config.LoadInstallProfile(name)
+		if err != nil {
+			fmt.Printf("  ! %s (error loading)\n", name)
+			fmt.Printf("      %v\n", err)
+			continue
+		}
+		fmt.Printf("  %s (%d tools)\n", name, len(profile.Tools))
+		if len(profile.Tools) > 0 {
+			fmt.Printf("      %s\n", strings.Join(profile.Tools, ", "))
+		}
+	}
+}
+
 // listUsers displays all user profiles
 func listUsers() {
 	users, err := config.ListUserProfiles()
@@ -1043,7 +3050,9 @@ func listUsers() {
 	for _, name := range users {
 		profile, err := config.LoadUserProfile(name)
 		if err != nil {
-			fmt.Printf("  %s (error loading)\n", name)
+			fmt.Printf("  ! %s (error loading)\n", name)
+			fmt.Printf("      %v\n", err)
+			fmt.Printf("      Repair with: dotfiles user repair %s\n", name)
 			continue
 		}
 
@@ -1060,3 +3069,59 @@ func listUsers() {
 	fmt.Println()
 	fmt.Println("● = active user")
 }
+
+// runServe answers status queries on socket until interrupted
+func runServe(socket string) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Fprintf(os.Stderr, "Serving status on %s (Ctrl+C to stop)\n", socket)
+	if err := serve.New(tools.CheckUpdates).ListenAndServe(ctx, socket); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// writeSnapshot records the current machine to path, or stdout if empty
+func writeSnapshot(path string) {
+	snap := ui.NewApp(true).Snapshot()
+
+	if path == "" {
+		data, err := json.MarshalIndent(snap, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	if err := config.SaveSnapshot(path, snap); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Saved snapshot to %s (%d tools, %d helper scripts, theme %s)\n", path, len(snap.Tools), len(snap.Utilities), snap.Theme)
+}
+
+// loadSnapshotForInstall reads a machine snapshot for install --from,
+// reporting tools this machine can't install and rejecting invalid Manage
+// settings. Nothing is saved here: the TUI saves the snapshot's settings
+// once the install starts.
+func loadSnapshotForInstall(path string) (*config.Snapshot, error) {
+	snap, err := config.LoadSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if unknown := ui.NewDeepDiveConfig().ApplySelection(snap.Selection()); len(unknown) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: snapshot lists tools not available here: %s\n", strings.Join(unknown, ", "))
+	}
+
+	if len(snap.Manage) > 0 {
+		manage := ui.NewManageConfig()
+		if err := json.Unmarshal(snap.Manage, manage); err != nil {
+			return nil, fmt.Errorf("invalid manage settings in snapshot: %w", err)
+		}
+	}
+	return snap, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLatestBackupName(t *testing.T) {
+	configDir := t.TempDir()
+
+	if got := latestBackupName(configDir); got != "" {
+		t.Errorf("latestBackupName() with no backups = %q, want empty", got)
+	}
+
+	backups := filepath.Join(configDir, "backups")
+	for _, name := range []string{"20240101-120000", "20250301-090000", "20241231-235959"} {
+		if err := os.MkdirAll(filepath.Join(backups, name), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Stray files are not backups
+	if err := os.WriteFile(filepath.Join(backups, "zz-notes.txt"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := latestBackupName(configDir), "20250301-090000"; got != want {
+		t.Errorf("latestBackupName() = %q, want %q", got, want)
+	}
+}
+
+func TestInstalledBinaries(t *testing.T) {
+	home := t.TempDir()
+	bin := filepath.Join(home, ".local", "bin")
+	if err := os.MkdirAll(bin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"dotfiles", "hk", "unrelated"} {
+		if err := os.WriteFile(filepath.Join(bin, name), nil, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	found := map[string]bool{}
+	for _, p := range installedBinaries(home) {
+		found[p] = true
+	}
+	for _, name := range []string{"dotfiles", "hk"} {
+		if !found[filepath.Join(bin, name)] {
+			t.Errorf("installedBinaries() missing %s", name)
+		}
+	}
+	if found[filepath.Join(bin, "unrelated")] {
+		t.Error("installedBinaries() should only list dotfiles binaries")
+	}
+}
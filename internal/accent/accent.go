@@ -0,0 +1,48 @@
+// Package accent reads the operating system's accent color.
+package accent
+
+import (
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// macAccents maps AppleAccentColor values to the system accent hex colors.
+// An unset value means the default ("multicolor"), which renders as blue.
+var macAccents = map[string]string{
+	"-1": "#8C8C8C", // graphite
+	"0":  "#FF5257", // red
+	"1":  "#F7821B", // orange
+	"2":  "#FFC600", // yellow
+	"3":  "#62BA46", // green
+	"4":  "#007AFF", // blue
+	"5":  "#A550A7", // purple
+	"6":  "#F74F9E", // pink
+}
+
+// macDefaultAccent is the accent used when AppleAccentColor is unset
+const macDefaultAccent = "#007AFF"
+
+// System returns the system accent as a hex color. It reports false on
+// platforms without an accent setting or when it cannot be read.
+func System() (string, bool) {
+	if runtime.GOOS != "darwin" {
+		return "", false
+	}
+
+	out, err := exec.Command("defaults", "read", "-g", "AppleAccentColor").Output()
+	if err != nil {
+		// defaults exits non-zero when the key is unset (the default accent)
+		if _, ok := err.(*exec.ExitError); ok {
+			return macDefaultAccent, true
+		}
+		return "", false
+	}
+	return parseMacAccent(string(out))
+}
+
+// parseMacAccent maps `defaults read -g AppleAccentColor` output to a color
+func parseMacAccent(out string) (string, bool) {
+	color, ok := macAccents[strings.TrimSpace(out)]
+	return color, ok
+}
@@ -0,0 +1,25 @@
+package accent
+
+import "testing"
+
+func TestParseMacAccent(t *testing.T) {
+	tests := []struct {
+		out    string
+		want   string
+		wantOK bool
+	}{
+		{"-1\n", "#8C8C8C", true},
+		{"0\n", "#FF5257", true},
+		{"4", "#007AFF", true},
+		{"6\n", "#F74F9E", true},
+		{"42\n", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseMacAccent(tt.out)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseMacAccent(%q) = %q, %v; want %q, %v", tt.out, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+// Package backup provides shared access to dotfiles backups for the TUI and CLI.
+package backup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tekierz/dotfiles/internal/config"
+)
+
+// Entry represents a single backup directory
+type Entry struct {
+	Name      string    `json:"name"`
+	Timestamp time.Time `json:"timestamp"`
+	Label     string    `json:"label,omitempty"`
+	Reason    string    `json:"reason,omitempty"` // Why an automatic backup was taken, e.g. "auto: pre-install"
+	FileCount int       `json:"fileCount"`
+	Size      int64     `json:"size"` // bytes
+	Path      string    `json:"-"`
+}
+
+// Dir returns the directory where backups are stored
+func Dir() string {
+	return filepath.Join(config.ConfigDir(), "backups")
+}
+
+// List returns all backups sorted by timestamp (newest first).
+// A missing backup directory is not an error and yields an empty list.
+func List() ([]Entry, error) {
+	backupDir := Dir()
+	entries, err := os.ReadDir(backupDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Entry{}, nil
+		}
+		return nil, err
+	}
+
+	backups := []Entry{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		info, _ := entry.Info()
+		path := filepath.Join(backupDir, entry.Name())
+
+		timestamp := time.Time{}
+		if info != nil {
+			timestamp = info.ModTime()
+		}
+
+		backups = append(backups, Entry{
+			Name:      entry.Name(),
+			Timestamp: timestamp,
+			Label:     ReadLabel(path),
+			Reason:    ReadReason(path),
+			FileCount: CountFiles(path),
+			Size:      DirSize(path),
+			Path:      path,
+		})
+	}
+
+	// Sort by timestamp descending (newest first)
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Timestamp.After(backups[j].Timestamp)
+	})
+
+	return backups, nil
+}
+
+// CountFiles counts files in a backup directory
+func CountFiles(path string) int {
+	count := 0
+	filepath.Walk(path, func(_ string, info os.FileInfo, _ error) error {
+		if info != nil && !info.IsDir() {
+			count++
+		}
+		return nil
+	})
+	return count
+}
+
+// DirSize calculates the total size of files in a directory
+func DirSize(path string) int64 {
+	var size int64
+	filepath.Walk(path, func(_ string, info os.FileInfo, _ error) error {
+		if info != nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// ManifestName is the file inside each backup listing what it contains
+const ManifestName = "manifest.txt"
+
+// DefaultFiles are the dotfiles (relative to home) captured by every backup.
+// Paths under .config/ are read from $XDG_CONFIG_HOME when it is set.
+var DefaultFiles = []string{
+	".zshrc",
+	".bashrc",
+	".config/fish/config.fish",
+	".tmux.conf",
+	".config/nvim/init.lua",
+	".config/ghostty/config",
+	".config/yazi/yazi.toml",
+	".gitconfig",
+}
+
+// CreateOptions customizes a single backup run
+type CreateOptions struct {
+	Suffix  string   // Appended to the timestamp name (e.g. "_auto")
+	Exclude []string // Paths to skip for this run (relative, ~/ or absolute)
+	Label   string   // Optional human-readable name stored in the manifest
+	Reason  string   // Why the backup was taken automatically, e.g. "auto: pre-install"
+}
+
+// Create copies the default dotfiles into a new timestamped backup directory,
+// writes its manifest, and prunes old backups per the global settings.
+// It returns the name of the new backup.
+func Create(opts CreateOptions) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	skipped, err := excludes(home, opts.Exclude)
+	if err != nil {
+		return "", err
+	}
+
+	name := time.Now().Format("2006-01-02_15-04-05") + opts.Suffix
+	backupDir := filepath.Join(Dir(), name)
+	if err := os.MkdirAll(backupDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	backedUp := []ManifestEntry{}
+	for _, relPath := range DefaultFiles {
+		if slices.Contains(skipped, relPath) {
+			continue
+		}
+
+		srcPath := config.HomePath(home, relPath)
+		info, err := os.Lstat(srcPath)
+		if err != nil {
+			continue
+		}
+
+		// Symlinks (e.g. into a dotfiles git repo) are recorded so restore
+		// can recreate the link; their contents are stored as a fallback
+		var linkTarget string
+		if info.Mode()&os.ModeSymlink != 0 {
+			if linkTarget, err = os.Readlink(srcPath); err != nil {
+				continue
+			}
+		}
+
+		data, err := os.ReadFile(srcPath)
+		if err != nil && linkTarget == "" {
+			continue
+		}
+
+		// Replace path separators with underscores for flat storage
+		dstPath := filepath.Join(backupDir, flatName(relPath))
+
+		// The stored copy keeps the original's permissions for restore
+		perm := os.FileMode(0600)
+		if linkTarget == "" {
+			perm = info.Mode().Perm()
+		}
+		if err := os.WriteFile(dstPath, data, perm); err != nil {
+			continue
+		}
+
+		backedUp = append(backedUp, ManifestEntry{Path: relPath, Checksum: checksum(data), LinkTarget: linkTarget})
+	}
+
+	// Write manifest: one checksummed path per line, label, reason and
+	// exclusions as comments
+	manifestPath := filepath.Join(backupDir, ManifestName)
+	if err := os.WriteFile(manifestPath, []byte(formatManifest(opts.Label, opts.Reason, backedUp, skipped)), 0600); err != nil {
+		return name, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	// Run backup cleanup based on settings
+	Cleanup()
+
+	return name, nil
+}
+
+// Excludes resolves paths to skip (relative, ~/ or absolute) to the
+// DefaultFiles entries they name, in DefaultFiles order. A path that names
+// no backed-up file is an error rather than a skip that does nothing.
+func Excludes(paths []string) ([]string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return excludes(home, paths)
+}
+
+// excludes is Excludes for the given home directory
+func excludes(home string, paths []string) ([]string, error) {
+	excluded := make(map[string]bool)
+	for _, p := range paths {
+		rel := normalizePath(home, p)
+		if !slices.Contains(DefaultFiles, rel) {
+			return nil, fmt.Errorf("can't exclude %s: not a backed-up file (%s)", p, strings.Join(DefaultFiles, ", "))
+		}
+		excluded[rel] = true
+	}
+	var skipped []string
+	for _, relPath := range DefaultFiles {
+		if excluded[relPath] {
+			skipped = append(skipped, relPath)
+		}
+	}
+	return skipped, nil
+}
+
+// normalizePath converts a user-supplied path into the home-relative form
+// used by DefaultFiles (accepts ".zshrc", "~/.zshrc" or "/home/me/.zshrc").
+// Absolute paths inside $XDG_CONFIG_HOME map to their .config/ form.
+func normalizePath(home, p string) string {
+	p = strings.TrimSpace(p)
+	if strings.HasPrefix(p, "~/") {
+		p = p[2:]
+	} else if filepath.IsAbs(p) {
+		if rel, ok := relativeTo(config.ConfigHome(), p); ok {
+			p = filepath.Join(".config", rel)
+		} else if rel, ok := relativeTo(home, p); ok {
+			p = rel
+		}
+	}
+	return filepath.Clean(p)
+}
+
+// relativeTo returns p relative to base, if p lies inside base
+func relativeTo(base, p string) (string, bool) {
+	if base == "" {
+		return "", false
+	}
+	rel, err := filepath.Rel(base, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// Cleanup removes old backups based on global config settings
+func Cleanup() {
+	cfg, err := config.LoadGlobalConfig()
+	if err != nil {
+		return
+	}
+
+	backups, err := List()
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	for i, b := range backups {
+		shouldDelete := false
+
+		// Delete if exceeds max count (and max count is set)
+		if cfg.BackupMaxCount > 0 && i >= cfg.BackupMaxCount {
+			shouldDelete = true
+		}
+
+		// Delete if exceeds max age (and max age is set)
+		if cfg.BackupMaxAgeDays > 0 {
+			age := now.Sub(b.Timestamp)
+			if age > time.Duration(cfg.BackupMaxAgeDays)*24*time.Hour {
+				shouldDelete = true
+			}
+		}
+
+		if shouldDelete {
+			os.RemoveAll(b.Path)
+		}
+	}
+}
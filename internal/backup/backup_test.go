@@ -0,0 +1,665 @@
+package backup
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tekierz/dotfiles/internal/testutil"
+)
+
+func TestListEmpty(t *testing.T) {
+	testutil.TempConfigDir(t)
+
+	backups, err := List()
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	if backups == nil || len(backups) != 0 {
+		t.Errorf("List() = %v, want empty non-nil slice", backups)
+	}
+}
+
+func TestListSortedNewestFirst(t *testing.T) {
+	testutil.TempConfigDir(t)
+
+	older := filepath.Join(Dir(), "2024-01-01_10-00-00")
+	newer := filepath.Join(Dir(), "2024-02-01_10-00-00")
+	testutil.CreateTempFile(t, older, ".zshrc", "old")
+	testutil.CreateTempFile(t, newer, ".zshrc", "newer")
+	testutil.CreateTempFile(t, newer, "manifest.txt", ".zshrc")
+
+	now := time.Now()
+	os.Chtimes(older, now.Add(-time.Hour), now.Add(-time.Hour))
+	os.Chtimes(newer, now, now)
+
+	backups, err := List()
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("len(List()) = %d, want 2", len(backups))
+	}
+	if backups[0].Name != "2024-02-01_10-00-00" {
+		t.Errorf("backups[0].Name = %q, want newest first", backups[0].Name)
+	}
+	if backups[0].FileCount != 2 {
+		t.Errorf("FileCount = %d, want 2", backups[0].FileCount)
+	}
+	if backups[0].Size != int64(len("newer")+len(".zshrc")) {
+		t.Errorf("Size = %d, want %d", backups[0].Size, len("newer")+len(".zshrc"))
+	}
+}
+
+func TestEntryJSON(t *testing.T) {
+	e := Entry{Name: "b1", FileCount: 3, Size: 42, Path: "/secret/path"}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	out := string(data)
+	for _, key := range []string{`"name"`, `"timestamp"`, `"fileCount"`, `"size"`} {
+		if !strings.Contains(out, key) {
+			t.Errorf("JSON %s missing key %s", out, key)
+		}
+	}
+	if strings.Contains(out, "/secret/path") {
+		t.Errorf("JSON %s should not include path", out)
+	}
+}
+
+func TestCreateWithExclude(t *testing.T) {
+	home := filepath.Dir(filepath.Dir(testutil.TempConfigDir(t)))
+	testutil.CreateTempFile(t, home, ".zshrc", "zsh")
+	testutil.CreateTempFile(t, home, ".gitconfig", "git")
+	testutil.CreateTempFile(t, home, ".tmux.conf", "tmux")
+
+	name, err := Create(CreateOptions{Exclude: []string{"~/.gitconfig", filepath.Join(home, ".tmux.conf")}})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	backupDir := filepath.Join(Dir(), name)
+	if !testutil.FileExists(filepath.Join(backupDir, ".zshrc")) {
+		t.Error(".zshrc should be backed up")
+	}
+	if testutil.FileExists(filepath.Join(backupDir, ".gitconfig")) {
+		t.Error(".gitconfig should be excluded")
+	}
+	if testutil.FileExists(filepath.Join(backupDir, ".tmux.conf")) {
+		t.Error(".tmux.conf should be excluded")
+	}
+
+	manifest := testutil.MustReadFile(t, filepath.Join(backupDir, ManifestName))
+	testutil.RequireContains(t, manifest, "# excluded: .gitconfig", "manifest should note exclusion")
+	testutil.RequireContains(t, manifest, "# excluded: .tmux.conf", "manifest should note exclusion")
+}
+
+func TestCreateWithUnknownExclude(t *testing.T) {
+	testutil.TempConfigDir(t)
+
+	_, err := Create(CreateOptions{Exclude: []string{".zshrc", "~/.vimrc"}})
+	if err == nil {
+		t.Fatal("Create() should reject an exclude that names no backed-up file")
+	}
+	testutil.RequireContains(t, err.Error(), "~/.vimrc", "error should name the unmatched path")
+	if backups, _ := List(); len(backups) != 0 {
+		t.Errorf("no backup should be created, got %d", len(backups))
+	}
+}
+
+func TestExcludes(t *testing.T) {
+	home := filepath.Dir(filepath.Dir(testutil.TempConfigDir(t)))
+
+	got, err := Excludes([]string{filepath.Join(home, ".gitconfig"), ".zshrc", "~/.zshrc"})
+	if err != nil {
+		t.Fatalf("Excludes() failed: %v", err)
+	}
+	if want := []string{".zshrc", ".gitconfig"}; !slices.Equal(got, want) {
+		t.Errorf("Excludes() = %v, want %v", got, want)
+	}
+}
+
+func TestPlanAndRestore(t *testing.T) {
+	testutil.TempConfigDir(t)
+	home, _ := os.UserHomeDir()
+
+	dir := filepath.Join(Dir(), "2024-03-01_10-00-00")
+	testutil.CreateTempFile(t, dir, ".zshrc", "same")
+	testutil.CreateTempFile(t, dir, ".gitconfig", "backed up")
+	testutil.CreateTempFile(t, dir, ".config_ghostty_config", "theme = nord")
+	testutil.CreateTempFile(t, dir, ManifestName, ".zshrc\n.gitconfig\n.config/ghostty/config")
+
+	testutil.CreateTempFile(t, home, ".zshrc", "same")
+	testutil.CreateTempFile(t, home, ".gitconfig", "edited since")
+	// Edited before the backup was taken, so not flagged as newer
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(filepath.Join(home, ".gitconfig"), past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := Plan(dir)
+	if err != nil {
+		t.Fatalf("Plan() failed: %v", err)
+	}
+
+	want := map[string]FileStatus{
+		".zshrc":                 FileIdentical,
+		".gitconfig":             FileDiffers,
+		".config/ghostty/config": FileNew,
+	}
+	if len(files) != len(want) {
+		t.Fatalf("len(Plan()) = %d, want %d", len(files), len(want))
+	}
+	for _, f := range files {
+		if f.Status != want[f.RelPath] {
+			t.Errorf("%s status = %v, want %v", f.RelPath, f.Status, want[f.RelPath])
+		}
+	}
+
+	if got, want := Summarize(files), "1 file differs, 1 identical, 1 new"; got != want {
+		t.Errorf("Summarize() = %q, want %q", got, want)
+	}
+
+	restored, err := Restore(files, RestoreOptions{})
+	if err != nil {
+		t.Fatalf("Restore() failed: %v", err)
+	}
+	if restored != 2 {
+		t.Errorf("Restore() = %d, want 2 (identical files skipped)", restored)
+	}
+	if got := testutil.MustReadFile(t, filepath.Join(home, ".config", "ghostty", "config")); got != "theme = nord" {
+		t.Errorf("restored ghostty config = %q", got)
+	}
+}
+
+func TestRestoreKeepsFileMode(t *testing.T) {
+	home := filepath.Dir(filepath.Dir(testutil.TempConfigDir(t)))
+	modes := map[string]os.FileMode{".zshrc": 0644, ".gitconfig": 0600}
+	for name, mode := range modes {
+		path := testutil.CreateTempFile(t, home, name, name)
+		if err := os.Chmod(path, mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	name, err := Create(CreateOptions{})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	for name := range modes {
+		if err := os.Remove(filepath.Join(home, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files, err := Plan(filepath.Join(Dir(), name))
+	if err != nil {
+		t.Fatalf("Plan() failed: %v", err)
+	}
+	if _, err := Restore(files, RestoreOptions{}); err != nil {
+		t.Fatalf("Restore() failed: %v", err)
+	}
+
+	for name, mode := range modes {
+		info, err := os.Stat(filepath.Join(home, name))
+		if err != nil {
+			t.Fatalf("%s not restored: %v", name, err)
+		}
+		if got := info.Mode().Perm(); got != mode {
+			t.Errorf("%s mode = %v, want %v", name, got, mode)
+		}
+	}
+}
+
+func TestRestoreResetsModeOfExistingFile(t *testing.T) {
+	home := filepath.Dir(filepath.Dir(testutil.TempConfigDir(t)))
+	path := testutil.CreateTempFile(t, home, ".gitconfig", "secret")
+
+	name, err := Create(CreateOptions{})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	// The live file has since been loosened and changed
+	if err := os.WriteFile(path, []byte("changed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, 0644); err != nil {
+		t.Fatal(err)
+	}
+	files, err := Plan(filepath.Join(Dir(), name))
+	if err != nil {
+		t.Fatalf("Plan() failed: %v", err)
+	}
+	if _, err := Restore(files, RestoreOptions{}); err != nil {
+		t.Fatalf("Restore() failed: %v", err)
+	}
+
+	if got := testutil.MustReadFile(t, path); got != "secret" {
+		t.Errorf("content = %q, want secret", got)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info.Mode().Perm(); got != 0600 {
+		t.Errorf("mode = %v, want the backed-up 0600", got)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	testutil.TempConfigDir(t)
+	home, _ := os.UserHomeDir()
+
+	dir := filepath.Join(Dir(), "2024-03-01_10-00-00")
+	testutil.CreateTempFile(t, dir, ".gitconfig", "backed up")
+	testutil.CreateTempFile(t, dir, ".config_ghostty_config", "theme = nord")
+	testutil.CreateTempFile(t, dir, ManifestName, ".gitconfig\n.config/ghostty/config")
+	testutil.CreateTempFile(t, home, ".gitconfig", "live")
+
+	files, err := Plan(dir)
+	if err != nil {
+		t.Fatalf("Plan() failed: %v", err)
+	}
+	out := t.TempDir()
+	extracted, err := Extract(files, out)
+	if err != nil || extracted != 2 {
+		t.Fatalf("Extract() = %d, %v; want 2", extracted, err)
+	}
+	if got := testutil.MustReadFile(t, filepath.Join(out, ".config", "ghostty", "config")); got != "theme = nord" {
+		t.Errorf("extracted ghostty config = %q", got)
+	}
+	// The live files are untouched
+	if got := testutil.MustReadFile(t, filepath.Join(home, ".gitconfig")); got != "live" {
+		t.Errorf("live .gitconfig = %q, want it left alone", got)
+	}
+}
+
+func TestCreateRecordsChecksumsAndVerify(t *testing.T) {
+	home := filepath.Dir(filepath.Dir(testutil.TempConfigDir(t)))
+	testutil.CreateTempFile(t, home, ".zshrc", "zsh")
+	testutil.CreateTempFile(t, home, ".config/ghostty/config", "theme = nord")
+
+	name, err := Create(CreateOptions{})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	backupDir := filepath.Join(Dir(), name)
+
+	entries, err := ReadManifest(backupDir)
+	if err != nil {
+		t.Fatalf("ReadManifest() failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("len(ReadManifest()) = %d, want 2", len(entries))
+	}
+	for _, e := range entries {
+		if len(e.Checksum) != 64 {
+			t.Errorf("%s checksum = %q, want sha256 hex", e.Path, e.Checksum)
+		}
+	}
+
+	result, err := Verify(backupDir)
+	if err != nil {
+		t.Fatalf("Verify() failed: %v", err)
+	}
+	if !result.OK() || result.Verified != 2 {
+		t.Errorf("Verify() = %+v, want 2 verified and no mismatches", result)
+	}
+
+	// Corrupt one file and remove the other
+	testutil.CreateTempFile(t, backupDir, ".config_ghostty_config", "theme = trunc")
+	os.Remove(filepath.Join(backupDir, ".zshrc"))
+
+	result, err = Verify(backupDir)
+	if err != nil {
+		t.Fatalf("Verify() failed: %v", err)
+	}
+	reasons := map[string]string{}
+	for _, m := range result.Mismatches {
+		reasons[m.Path] = m.Reason
+	}
+	if reasons[".zshrc"] != "missing" {
+		t.Errorf(".zshrc reason = %q, want missing", reasons[".zshrc"])
+	}
+	if reasons[".config/ghostty/config"] != "checksum mismatch" {
+		t.Errorf("ghostty reason = %q, want checksum mismatch", reasons[".config/ghostty/config"])
+	}
+
+	files, err := Plan(backupDir)
+	if err != nil {
+		t.Fatalf("Plan() failed: %v", err)
+	}
+	if len(files) != 1 || !files[0].Corrupt {
+		t.Errorf("Plan() = %+v, want the corrupted file flagged", files)
+	}
+	testutil.RequireContains(t, Summarize(files), "1 failed checksum", "summary should mention corruption")
+}
+
+func TestVerifyLegacyManifest(t *testing.T) {
+	testutil.TempConfigDir(t)
+
+	dir := filepath.Join(Dir(), "2024-03-01_10-00-00")
+	testutil.CreateTempFile(t, dir, ".zshrc", "zsh")
+	testutil.CreateTempFile(t, dir, ManifestName, ".zshrc\n# excluded: .gitconfig")
+
+	result, err := Verify(dir)
+	if err != nil {
+		t.Fatalf("Verify() failed: %v", err)
+	}
+	if !result.OK() || result.Unchecked != 1 || result.Verified != 0 {
+		t.Errorf("Verify() = %+v, want 1 unchecked file", result)
+	}
+}
+
+func TestCreateWithLabel(t *testing.T) {
+	home := filepath.Dir(filepath.Dir(testutil.TempConfigDir(t)))
+	testutil.CreateTempFile(t, home, ".zshrc", "zsh")
+
+	name, err := Create(CreateOptions{Label: "  before nvim migration "})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	backups, err := List()
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	if len(backups) != 1 || backups[0].Name != name {
+		t.Fatalf("List() = %+v, want the new backup", backups)
+	}
+	if got, want := backups[0].Label, "before nvim migration"; got != want {
+		t.Errorf("Label = %q, want %q", got, want)
+	}
+
+	// The label line must not be mistaken for a backed-up file
+	entries, err := ReadManifest(filepath.Join(Dir(), name))
+	if err != nil {
+		t.Fatalf("ReadManifest() failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Path != ".zshrc" {
+		t.Errorf("ReadManifest() = %+v, want only .zshrc", entries)
+	}
+}
+
+func TestCreateWithReason(t *testing.T) {
+	home := filepath.Dir(filepath.Dir(testutil.TempConfigDir(t)))
+	testutil.CreateTempFile(t, home, ".zshrc", "zsh")
+
+	auto, err := Create(CreateOptions{Suffix: "_auto", Reason: "auto: pre-install"})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if got := ReadReason(filepath.Join(Dir(), auto)); got != "auto: pre-install" {
+		t.Errorf("ReadReason() = %q, want %q", got, "auto: pre-install")
+	}
+	if entries, _ := ReadManifest(filepath.Join(Dir(), auto)); len(entries) != 1 {
+		t.Errorf("ReadManifest() = %+v, want only .zshrc", entries)
+	}
+
+	// Manual backups have no reason
+	manual, err := Create(CreateOptions{Suffix: "_manual", Label: "mine"})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if got := ReadReason(filepath.Join(Dir(), manual)); got != "" {
+		t.Errorf("ReadReason(manual) = %q, want none", got)
+	}
+}
+
+func TestCreateAndPlanFollowXDG(t *testing.T) {
+	home := filepath.Dir(filepath.Dir(testutil.TempConfigDir(t)))
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	testutil.CreateTempFile(t, filepath.Join(xdg, "nvim"), "init.lua", "-- xdg")
+	testutil.CreateTempFile(t, filepath.Join(home, ".config", "nvim"), "init.lua", "-- stale")
+
+	name, err := Create(CreateOptions{Exclude: []string{filepath.Join(xdg, "ghostty", "config")}})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	dir := filepath.Join(Dir(), name)
+	if !strings.HasPrefix(dir, xdg) {
+		t.Errorf("backup dir = %q, want it under XDG_CONFIG_HOME %q", dir, xdg)
+	}
+	if got := testutil.MustReadFile(t, filepath.Join(dir, ".config_nvim_init.lua")); got != "-- xdg" {
+		t.Errorf("backed up init.lua = %q, want the XDG copy", got)
+	}
+	manifest := testutil.MustReadFile(t, filepath.Join(dir, ManifestName))
+	testutil.RequireContains(t, manifest, "# excluded: .config/ghostty/config", "absolute XDG path should exclude")
+
+	testutil.CreateTempFile(t, filepath.Join(xdg, "nvim"), "init.lua", "-- edited")
+	files, err := Plan(dir)
+	if err != nil {
+		t.Fatalf("Plan() failed: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("len(Plan()) = %d, want 1", len(files))
+	}
+	if want := filepath.Join(xdg, "nvim", "init.lua"); files[0].Dest != want {
+		t.Errorf("Dest = %q, want %q", files[0].Dest, want)
+	}
+	if _, err := Restore(files, RestoreOptions{}); err != nil {
+		t.Fatalf("Restore() failed: %v", err)
+	}
+	if got := testutil.MustReadFile(t, filepath.Join(xdg, "nvim", "init.lua")); got != "-- xdg" {
+		t.Errorf("restored init.lua = %q, want %q", got, "-- xdg")
+	}
+	if got := testutil.MustReadFile(t, filepath.Join(home, ".config", "nvim", "init.lua")); got != "-- stale" {
+		t.Errorf("~/.config copy was modified: %q", got)
+	}
+}
+
+func TestSymlinkedConfigRoundTrip(t *testing.T) {
+	testutil.TempConfigDir(t)
+	home, _ := os.UserHomeDir()
+
+	// ~/.tmux.conf links into a dotfiles repo
+	repo := filepath.Join(home, "src", "dotfiles")
+	testutil.CreateTempFile(t, repo, "tmux.conf", "set -g mouse on")
+	target := filepath.Join(repo, "tmux.conf")
+	link := filepath.Join(home, ".tmux.conf")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	name, err := Create(CreateOptions{})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	entries, err := ReadManifest(filepath.Join(Dir(), name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Path != ".tmux.conf" || entries[0].LinkTarget != target {
+		t.Fatalf("manifest = %+v, want .tmux.conf -> %s", entries, target)
+	}
+	if res, _ := Verify(filepath.Join(Dir(), name)); !res.OK() || res.Verified != 1 {
+		t.Errorf("Verify() = %+v, want the stored contents to verify", res)
+	}
+
+	// Replace the link with a plain file, then restore
+	os.Remove(link)
+	testutil.CreateTempFile(t, home, ".tmux.conf", "plain file")
+
+	files, err := Plan(filepath.Join(Dir(), name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Status != FileDiffers || files[0].LinkTarget != target {
+		t.Fatalf("Plan() = %+v, want the symlink to differ", files)
+	}
+	if _, err := Restore(files, RestoreOptions{}); err != nil {
+		t.Fatalf("Restore() failed: %v", err)
+	}
+	if got, err := os.Readlink(link); err != nil || got != target {
+		t.Errorf("Readlink = %q, %v, want symlink to %s", got, err, target)
+	}
+
+	// Restoring again is a no-op, and a missing target is skipped, not dangled
+	if files, _ := Plan(filepath.Join(Dir(), name)); files[0].Status != FileIdentical {
+		t.Errorf("status after restore = %v, want identical", files[0].Status)
+	}
+	os.Remove(link)
+	os.Remove(target)
+	testutil.CreateTempFile(t, home, ".tmux.conf", "keep me")
+	files, _ = Plan(filepath.Join(Dir(), name))
+	if _, err := Restore(files, RestoreOptions{}); err == nil {
+		t.Error("Restore() should report a missing symlink target")
+	}
+	if got := testutil.MustReadFile(t, link); got != "keep me" {
+		t.Errorf(".tmux.conf = %q, want it left alone", got)
+	}
+}
+
+func TestRestoreKeepNewer(t *testing.T) {
+	testutil.TempConfigDir(t)
+	home, _ := os.UserHomeDir()
+
+	dir := filepath.Join(Dir(), "2024-03-01_10-00-00")
+	testutil.CreateTempFile(t, dir, ".zshrc", "backed up")
+	testutil.CreateTempFile(t, dir, ".gitconfig", "backed up")
+	testutil.CreateTempFile(t, dir, ManifestName, ".zshrc\n.gitconfig")
+
+	// .zshrc was edited after the backup, .gitconfig before it
+	testutil.CreateTempFile(t, home, ".zshrc", "recent edit")
+	testutil.CreateTempFile(t, home, ".gitconfig", "old edit")
+	backupTime := time.Now().Add(-time.Hour)
+	for _, name := range []string{".zshrc", ".gitconfig"} {
+		if err := os.Chtimes(filepath.Join(dir, name), backupTime, backupTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	older := backupTime.Add(-time.Hour)
+	if err := os.Chtimes(filepath.Join(home, ".gitconfig"), older, older); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := Plan(dir)
+	if err != nil {
+		t.Fatalf("Plan() failed: %v", err)
+	}
+	newer := NewerFiles(files)
+	if len(newer) != 1 || newer[0].RelPath != ".zshrc" {
+		t.Fatalf("NewerFiles() = %+v, want only .zshrc", newer)
+	}
+	if got, want := Summarize(files), "2 files differ, 0 identical, 0 new, 1 newer on disk"; got != want {
+		t.Errorf("Summarize() = %q, want %q", got, want)
+	}
+
+	restored, err := Restore(files, RestoreOptions{KeepNewer: true})
+	if err != nil {
+		t.Fatalf("Restore() failed: %v", err)
+	}
+	if restored != 1 {
+		t.Errorf("Restore() = %d, want 1 (newer file kept)", restored)
+	}
+	if got := testutil.MustReadFile(t, filepath.Join(home, ".zshrc")); got != "recent edit" {
+		t.Errorf(".zshrc = %q, want the newer file kept", got)
+	}
+	if got := testutil.MustReadFile(t, filepath.Join(home, ".gitconfig")); got != "backed up" {
+		t.Errorf(".gitconfig = %q, want it restored", got)
+	}
+
+	// The default still overwrites newer files
+	if _, err := Restore(files, RestoreOptions{}); err != nil {
+		t.Fatalf("Restore() failed: %v", err)
+	}
+	if got := testutil.MustReadFile(t, filepath.Join(home, ".zshrc")); got != "backed up" {
+		t.Errorf(".zshrc = %q, want it overwritten by default", got)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	testutil.TempConfigDir(t)
+	home, _ := os.UserHomeDir()
+
+	older := filepath.Join(Dir(), "2024-03-01_10-00-00")
+	testutil.CreateTempFile(t, older, ".zshrc", "same")
+	testutil.CreateTempFile(t, older, ".gitconfig", "old name")
+	testutil.CreateTempFile(t, older, ".tmux.conf", "set -g mouse on")
+	testutil.CreateTempFile(t, older, ManifestName, ".zshrc\n.gitconfig\n.tmux.conf")
+
+	newer := filepath.Join(Dir(), "2024-03-02_10-00-00")
+	testutil.CreateTempFile(t, newer, ".zshrc", "same")
+	testutil.CreateTempFile(t, newer, ".gitconfig", "new name")
+	// No manifest entry: falls back to the flat-name convention
+	testutil.CreateTempFile(t, newer, ".config_ghostty_config", "theme = nord")
+
+	changes, err := Compare(older, newer)
+	if err != nil {
+		t.Fatalf("Compare() failed: %v", err)
+	}
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.Kind.String()+" "+c.RelPath)
+	}
+	want := "added .config/ghostty/config|changed .gitconfig|removed .tmux.conf|unchanged .zshrc"
+	if strings.Join(got, "|") != want {
+		t.Errorf("Compare() = %q, want %q", strings.Join(got, "|"), want)
+	}
+
+	// Against the live files: .gitconfig is edited and .bashrc is new on disk
+	testutil.CreateTempFile(t, home, ".zshrc", "same")
+	testutil.CreateTempFile(t, home, ".gitconfig", "edited")
+	testutil.CreateTempFile(t, home, ".bashrc", "bash")
+	changes, err = CompareCurrent(older)
+	if err != nil {
+		t.Fatalf("CompareCurrent() failed: %v", err)
+	}
+	got = nil
+	for _, c := range changes {
+		got = append(got, c.Kind.String()+" "+c.RelPath)
+	}
+	want = "added .bashrc|changed .gitconfig|removed .tmux.conf|unchanged .zshrc"
+	if strings.Join(got, "|") != want {
+		t.Errorf("CompareCurrent() = %q, want %q", strings.Join(got, "|"), want)
+	}
+
+	if _, err := Compare(older, filepath.Join(Dir(), "missing")); err == nil {
+		t.Error("Compare() with a missing backup should fail")
+	}
+}
+
+func TestUsage(t *testing.T) {
+	dir := testutil.TempConfigDir(t)
+	write := func(rel string, size int) {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("backups/20260101-120000/.zshrc", 600)
+	write("backups/20260102-120000/.zshrc", 400)
+	write("tools/manage.json", 300)
+	write("global.json", 100)
+
+	usage, err := Usage()
+	if err != nil {
+		t.Fatalf("Usage() error: %v", err)
+	}
+	if usage.Total != 1400 {
+		t.Errorf("Total = %d, want 1400", usage.Total)
+	}
+	want := []UsagePart{{"backups", 1000}, {"tools", 300}, {"other", 100}}
+	if len(usage.Parts) != len(want) {
+		t.Fatalf("Parts = %v, want %v", usage.Parts, want)
+	}
+	for i, p := range want {
+		if usage.Parts[i] != p {
+			t.Errorf("Parts[%d] = %v, want %v", i, usage.Parts[i], p)
+		}
+	}
+	if !usage.BackupsDominate() {
+		t.Error("BackupsDominate() = false, want true")
+	}
+}
@@ -0,0 +1,152 @@
+package backup
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tekierz/dotfiles/internal/config"
+)
+
+// ChangeKind describes how a file differs between two snapshots
+type ChangeKind int
+
+const (
+	Unchanged ChangeKind = iota // Same content in both
+	Added                       // Only in the newer snapshot
+	Removed                     // Only in the older snapshot
+	Changed                     // In both, with different content or link target
+)
+
+// String returns a short label for the change
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	}
+	return "unchanged"
+}
+
+// FileChange is one file's difference between two snapshots
+type FileChange struct {
+	RelPath string     // Path relative to home
+	Kind    ChangeKind // How the file changed
+	Old     []byte     // Contents in the older snapshot (nil if Added)
+	New     []byte     // Contents in the newer snapshot (nil if Removed)
+}
+
+// snapshotFile is a file's contents and symlink target in one snapshot
+type snapshotFile struct {
+	data       []byte
+	linkTarget string
+}
+
+// Compare reports, for every file present in either backup, whether it was
+// added, removed or changed going from oldPath to newPath. Results are
+// sorted by path.
+func Compare(oldPath, newPath string) ([]FileChange, error) {
+	older, err := readSnapshot(oldPath)
+	if err != nil {
+		return nil, err
+	}
+	newer, err := readSnapshot(newPath)
+	if err != nil {
+		return nil, err
+	}
+	return compareSnapshots(older, newer), nil
+}
+
+// CompareCurrent compares a backup against the live files on disk: the
+// files it contains plus everything a new backup would capture
+func CompareCurrent(backupPath string) ([]FileChange, error) {
+	older, err := readSnapshot(backupPath)
+	if err != nil {
+		return nil, err
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	paths := append([]string{}, DefaultFiles...)
+	for relPath := range older {
+		paths = append(paths, relPath)
+	}
+
+	current := make(map[string]snapshotFile)
+	for _, relPath := range paths {
+		path := config.HomePath(home, relPath)
+		info, err := os.Lstat(path)
+		if err != nil {
+			continue
+		}
+		var f snapshotFile
+		if info.Mode()&os.ModeSymlink != 0 {
+			f.linkTarget, _ = os.Readlink(path)
+		}
+		if f.data, err = os.ReadFile(path); err != nil && f.linkTarget == "" {
+			continue
+		}
+		current[relPath] = f
+	}
+	return compareSnapshots(older, current), nil
+}
+
+// readSnapshot reads every file stored in a backup, keyed by its path
+// relative to home. Paths come from the manifest when present, falling back
+// to the flat-name convention like Plan.
+func readSnapshot(backupPath string) (map[string]snapshotFile, error) {
+	entries, err := os.ReadDir(backupPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	known := manifestPaths(backupPath)
+
+	files := make(map[string]snapshotFile)
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Name() == ManifestName {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(backupPath, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+		listed, ok := known[entry.Name()]
+		relPath := listed.Path
+		if !ok {
+			relPath = strings.ReplaceAll(entry.Name(), "_", string(os.PathSeparator))
+		}
+		files[relPath] = snapshotFile{data: data, linkTarget: listed.LinkTarget}
+	}
+	return files, nil
+}
+
+// compareSnapshots diffs two snapshots keyed by relative path
+func compareSnapshots(older, newer map[string]snapshotFile) []FileChange {
+	var changes []FileChange
+	for relPath, o := range older {
+		n, ok := newer[relPath]
+		switch {
+		case !ok:
+			changes = append(changes, FileChange{RelPath: relPath, Kind: Removed, Old: o.data})
+		case !bytes.Equal(o.data, n.data) || o.linkTarget != n.linkTarget:
+			changes = append(changes, FileChange{RelPath: relPath, Kind: Changed, Old: o.data, New: n.data})
+		default:
+			changes = append(changes, FileChange{RelPath: relPath, Kind: Unchanged, Old: o.data, New: n.data})
+		}
+	}
+	for relPath, n := range newer {
+		if _, ok := older[relPath]; !ok {
+			changes = append(changes, FileChange{RelPath: relPath, Kind: Added, New: n.data})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].RelPath < changes[j].RelPath })
+	return changes
+}
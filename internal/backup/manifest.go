@@ -0,0 +1,165 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ManifestEntry is one backed-up file listed in a manifest
+type ManifestEntry struct {
+	Path       string // Path relative to home
+	Checksum   string // Hex SHA-256 of the stored file ("" in older backups)
+	LinkTarget string // Symlink target, if the file was a symlink (stored file holds its contents)
+}
+
+// linkSeparator joins a symlinked path to its target on a manifest line
+const linkSeparator = " -> "
+
+// flatName is the name a path is stored under inside the backup directory
+func flatName(relPath string) string {
+	return strings.ReplaceAll(relPath, string(os.PathSeparator), "_")
+}
+
+// checksum returns the hex SHA-256 of data
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// labelPrefix marks the comment line holding a backup's optional label
+const labelPrefix = "# label: "
+
+// reasonPrefix marks the comment line recording why an automatic backup was taken
+const reasonPrefix = "# reason: "
+
+// formatManifest renders entries in sha256sum style ("<hash>  <path>"), with
+// " -> <target>" appended for symlinks and the label, reason and exclusions
+// as comments
+func formatManifest(label, reason string, entries []ManifestEntry, excluded []string) string {
+	var lines []string
+	if label = strings.TrimSpace(label); label != "" {
+		lines = append(lines, labelPrefix+label)
+	}
+	if reason = strings.TrimSpace(reason); reason != "" {
+		lines = append(lines, reasonPrefix+reason)
+	}
+	for _, e := range entries {
+		line := e.Path
+		if e.LinkTarget != "" {
+			line += linkSeparator + e.LinkTarget
+		}
+		if e.Checksum != "" {
+			line = e.Checksum + "  " + line
+		}
+		lines = append(lines, line)
+	}
+	for _, relPath := range excluded {
+		lines = append(lines, "# excluded: "+relPath)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseManifestLine parses a manifest line. Older backups list bare paths
+// without a checksum.
+func parseManifestLine(line string) ManifestEntry {
+	var e ManifestEntry
+	if hash, path, ok := strings.Cut(line, "  "); ok && len(hash) == sha256.Size*2 {
+		if _, err := hex.DecodeString(hash); err == nil {
+			e.Checksum = hash
+			line = path
+		}
+	}
+	e.Path, e.LinkTarget, _ = strings.Cut(line, linkSeparator)
+	return e
+}
+
+// ReadManifest returns the files listed in a backup's manifest
+func ReadManifest(backupPath string) ([]ManifestEntry, error) {
+	data, err := os.ReadFile(filepath.Join(backupPath, ManifestName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var entries []ManifestEntry
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries = append(entries, parseManifestLine(line))
+	}
+	return entries, nil
+}
+
+// ReadLabel returns a backup's label, or "" if it has none
+func ReadLabel(backupPath string) string {
+	return readManifestComment(backupPath, labelPrefix)
+}
+
+// ReadReason returns why a backup was taken automatically, or "" for
+// manual backups and backups made before reasons were recorded
+func ReadReason(backupPath string) string {
+	return readManifestComment(backupPath, reasonPrefix)
+}
+
+// readManifestComment returns the value of the first manifest comment line
+// starting with prefix
+func readManifestComment(backupPath, prefix string) string {
+	data, err := os.ReadFile(filepath.Join(backupPath, ManifestName))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), prefix); ok {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// Mismatch is a backed-up file that failed verification
+type Mismatch struct {
+	Path   string
+	Reason string
+}
+
+// VerifyResult summarizes a backup integrity check
+type VerifyResult struct {
+	Verified   int        // Files whose checksum matched
+	Unchecked  int        // Files with no recorded checksum (older backups)
+	Mismatches []Mismatch // Missing or corrupted files
+}
+
+// OK reports whether no problems were found
+func (r VerifyResult) OK() bool {
+	return len(r.Mismatches) == 0
+}
+
+// Verify checks every file in a backup against the checksums in its manifest
+func Verify(backupPath string) (VerifyResult, error) {
+	var result VerifyResult
+
+	entries, err := ReadManifest(backupPath)
+	if err != nil {
+		return result, err
+	}
+
+	for _, e := range entries {
+		data, err := os.ReadFile(filepath.Join(backupPath, flatName(e.Path)))
+		switch {
+		case err != nil:
+			result.Mismatches = append(result.Mismatches, Mismatch{Path: e.Path, Reason: "missing"})
+		case e.Checksum == "":
+			result.Unchecked++
+		case checksum(data) != e.Checksum:
+			result.Mismatches = append(result.Mismatches, Mismatch{Path: e.Path, Reason: "checksum mismatch"})
+		default:
+			result.Verified++
+		}
+	}
+	return result, nil
+}
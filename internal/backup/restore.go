@@ -0,0 +1,321 @@
+package backup
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tekierz/dotfiles/internal/config"
+)
+
+// FileStatus describes how a backed-up file compares to the file on disk
+type FileStatus int
+
+const (
+	FileNew       FileStatus = iota // Not present on disk; restore creates it
+	FileIdentical                   // Same content on disk; restore is a no-op
+	FileDiffers                     // Different content on disk; restore overwrites it
+)
+
+// String returns a short label for the status
+func (s FileStatus) String() string {
+	switch s {
+	case FileIdentical:
+		return "identical"
+	case FileDiffers:
+		return "differs"
+	}
+	return "new"
+}
+
+// RestoreFile maps one file stored in a backup to its restore destination
+type RestoreFile struct {
+	Src     string     // Path inside the backup directory
+	Dest    string     // Absolute destination path
+	RelPath string     // Destination relative to home (for display)
+	Status  FileStatus // Comparison against the file currently on disk
+	Corrupt bool       // Stored file does not match its manifest checksum
+	// Symlink target recorded at backup time; restore recreates the link
+	// instead of writing the stored contents
+	LinkTarget string
+	// Newer is set when a differing file on disk was modified after the
+	// backup was taken, so restoring would revert recent edits
+	Newer bool
+	// Keep leaves the file on disk untouched (e.g. a newer file the user
+	// chose to keep)
+	Keep bool
+}
+
+// RestoreOptions controls how Restore resolves conflicts. The zero value
+// overwrites everything that differs.
+type RestoreOptions struct {
+	// KeepNewer keeps every file that is newer on disk, as if each had
+	// Keep set
+	KeepNewer bool
+}
+
+// Kept reports whether restoring with opts leaves f alone
+func (f RestoreFile) Kept(opts RestoreOptions) bool {
+	return f.Keep || (opts.KeepNewer && f.Newer)
+}
+
+// Plan lists the files a restore of backupPath would write and compares each
+// against what is on disk. Destinations come from the manifest when present,
+// falling back to the flat-name convention (underscores for separators), and
+// files that fail their manifest checksum are flagged as Corrupt.
+// Files under .config/ restore into $XDG_CONFIG_HOME when it is set; files
+// that would land outside the home and config directories are skipped.
+func Plan(backupPath string) ([]RestoreFile, error) {
+	entries, err := os.ReadDir(backupPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	known := manifestPaths(backupPath)
+
+	var files []RestoreFile
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Name() == ManifestName {
+			continue
+		}
+
+		listed, ok := known[entry.Name()]
+		relPath := listed.Path
+		if !ok {
+			relPath = strings.ReplaceAll(entry.Name(), "_", string(os.PathSeparator))
+		}
+		dest := config.HomePath(home, relPath)
+
+		// Security: never write outside the home or config directory
+		if !isWithin(dest, home) && !isWithin(dest, config.ConfigHome()) {
+			continue
+		}
+
+		src := filepath.Join(backupPath, entry.Name())
+		status := compareFile(src, dest)
+		if listed.LinkTarget != "" {
+			status = compareLink(listed.LinkTarget, dest)
+		}
+		files = append(files, RestoreFile{
+			Src:        src,
+			Dest:       dest,
+			RelPath:    relPath,
+			Status:     status,
+			Corrupt:    listed.Checksum != "" && !fileMatches(src, listed.Checksum),
+			LinkTarget: listed.LinkTarget,
+			Newer:      status == FileDiffers && modifiedAfter(dest, src),
+		})
+	}
+
+	return files, nil
+}
+
+// isWithin reports whether path lies strictly inside dir
+func isWithin(path, dir string) bool {
+	return dir != "" && strings.HasPrefix(path, filepath.Clean(dir)+string(os.PathSeparator))
+}
+
+// manifestPaths maps flat backup file names to their manifest entries
+func manifestPaths(backupPath string) map[string]ManifestEntry {
+	paths := make(map[string]ManifestEntry)
+	entries, _ := ReadManifest(backupPath)
+	for _, e := range entries {
+		paths[flatName(e.Path)] = e
+	}
+	return paths
+}
+
+// fileMatches reports whether the file at path has the given checksum
+func fileMatches(path, sum string) bool {
+	data, err := os.ReadFile(path)
+	return err == nil && checksum(data) == sum
+}
+
+// compareFile reports how src differs from dest
+func compareFile(src, dest string) FileStatus {
+	current, err := os.ReadFile(dest)
+	if err != nil {
+		return FileNew
+	}
+	stored, err := os.ReadFile(src)
+	if err != nil || !bytes.Equal(stored, current) {
+		return FileDiffers
+	}
+	return FileIdentical
+}
+
+// modifiedAfter reports whether dest was modified after the backup copy src
+// was written (i.e. after the backup was taken)
+func modifiedAfter(dest, src string) bool {
+	current, err := os.Lstat(dest)
+	if err != nil {
+		return false
+	}
+	stored, err := os.Stat(src)
+	return err == nil && current.ModTime().After(stored.ModTime())
+}
+
+// NewerFiles returns the plan's files that are newer on disk than the backup
+func NewerFiles(files []RestoreFile) []RestoreFile {
+	var out []RestoreFile
+	for _, f := range files {
+		if f.Newer {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// compareLink reports how a recorded symlink differs from dest: identical
+// only if dest is a symlink to the same target
+func compareLink(target, dest string) FileStatus {
+	if _, err := os.Lstat(dest); err != nil {
+		return FileNew
+	}
+	if current, err := os.Readlink(dest); err == nil && current == target {
+		return FileIdentical
+	}
+	return FileDiffers
+}
+
+// restoreLink recreates a symlink at dest. A missing link target (e.g. a
+// dotfiles repo that isn't cloned yet) is an error and dest is left alone,
+// rather than replacing a working file with a dangling link.
+func restoreLink(f RestoreFile) error {
+	target := f.LinkTarget
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(f.Dest), target)
+	}
+	if _, err := os.Stat(target); err != nil {
+		return fmt.Errorf("symlink target %s is missing, skipped", f.LinkTarget)
+	}
+	if info, err := os.Lstat(f.Dest); err == nil {
+		if info.IsDir() {
+			return fmt.Errorf("%s is a directory", f.Dest)
+		}
+		if err := os.Remove(f.Dest); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(f.Dest), 0755); err != nil {
+		return err
+	}
+	return os.Symlink(f.LinkTarget, f.Dest)
+}
+
+// writeStored copies a file stored in a backup to dest with its recorded
+// permissions. WriteFile only applies them to new files, so an existing dest
+// is chmodded too.
+func writeStored(dest, src string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	mode := storedMode(src)
+	if err := os.WriteFile(dest, data, mode); err != nil {
+		return err
+	}
+	return os.Chmod(dest, mode)
+}
+
+// storedMode returns the permissions recorded with a file stored in a
+// backup (Create copies them from the original), or 0644 if unknown
+func storedMode(src string) os.FileMode {
+	if info, err := os.Stat(src); err == nil {
+		return info.Mode().Perm()
+	}
+	return 0644
+}
+
+// Summarize describes a restore plan, e.g. "3 files differ, 2 identical, 1 new",
+// noting any files that failed their checksum
+func Summarize(files []RestoreFile) string {
+	counts := map[FileStatus]int{}
+	corrupt, newer := 0, 0
+	for _, f := range files {
+		counts[f.Status]++
+		if f.Corrupt {
+			corrupt++
+		}
+		if f.Newer {
+			newer++
+		}
+	}
+
+	noun := "files differ"
+	if counts[FileDiffers] == 1 {
+		noun = "file differs"
+	}
+	summary := fmt.Sprintf("%d %s, %d identical, %d new", counts[FileDiffers], noun, counts[FileIdentical], counts[FileNew])
+	if newer > 0 {
+		summary += fmt.Sprintf(", %d newer on disk", newer)
+	}
+	if corrupt > 0 {
+		summary += fmt.Sprintf(", %d failed checksum", corrupt)
+	}
+	return summary
+}
+
+// Restore writes the plan's files to their destinations, skipping identical
+// and kept ones and recreating recorded symlinks. It returns how many files
+// were written.
+func Restore(files []RestoreFile, opts RestoreOptions) (int, error) {
+	restored := 0
+	var firstErr error
+	for _, f := range files {
+		if f.Status == FileIdentical || f.Kept(opts) {
+			continue
+		}
+		var err error
+		if f.LinkTarget != "" {
+			err = restoreLink(f)
+		} else {
+			err = os.MkdirAll(filepath.Dir(f.Dest), 0755)
+			if err == nil {
+				err = writeStored(f.Dest, f.Src)
+			}
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to restore %s: %w", f.RelPath, err)
+			}
+			continue
+		}
+		restored++
+	}
+	return restored, firstErr
+}
+
+// Extract writes the plan's files under dir at their home-relative paths
+// instead of their destinations, recreating recorded symlinks, so a backup
+// can be inspected without touching the live files. It returns how many
+// files were written.
+func Extract(files []RestoreFile, dir string) (int, error) {
+	extracted := 0
+	for _, f := range files {
+		dest := filepath.Join(dir, f.RelPath)
+		if !isWithin(dest, dir) {
+			continue
+		}
+		err := os.MkdirAll(filepath.Dir(dest), 0700)
+		if err == nil {
+			if f.LinkTarget != "" {
+				err = os.Symlink(f.LinkTarget, dest)
+			} else {
+				err = writeStored(dest, f.Src)
+			}
+		}
+		if err != nil {
+			return extracted, fmt.Errorf("failed to extract %s: %w", f.RelPath, err)
+		}
+		extracted++
+	}
+	return extracted, nil
+}
@@ -0,0 +1,81 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/tekierz/dotfiles/internal/config"
+)
+
+// UsagePart is the disk space used by one part of the config directory
+type UsagePart struct {
+	Name  string // "backups", "logs", "tools", "users" or "other"
+	Bytes int64
+}
+
+// DiskUsage reports the disk space used by the config directory
+type DiskUsage struct {
+	Dir   string
+	Total int64
+	Parts []UsagePart // Largest first; empty parts are omitted
+}
+
+// Part returns the size of the named part (0 if absent)
+func (u DiskUsage) Part(name string) int64 {
+	for _, p := range u.Parts {
+		if p.Name == name {
+			return p.Bytes
+		}
+	}
+	return 0
+}
+
+// BackupsDominate reports whether backups take up more than half the space
+func (u DiskUsage) BackupsDominate() bool {
+	return u.Total > 0 && u.Part("backups")*2 > u.Total
+}
+
+// usageParts are the subdirectories reported separately; everything else
+// (global.json, stray files) is counted as "other"
+var usageParts = []string{"backups", "logs", "tools", "users"}
+
+// Usage walks the config directory and sums file sizes per part
+func Usage() (DiskUsage, error) {
+	dir := config.ConfigDir()
+	usage := DiskUsage{Dir: dir}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return usage, nil
+	}
+	if err != nil {
+		return usage, err
+	}
+
+	sizes := make(map[string]int64)
+	for _, entry := range entries {
+		part := "other"
+		for _, name := range usageParts {
+			if entry.IsDir() && entry.Name() == name {
+				part = name
+			}
+		}
+		sizes[part] += DirSize(filepath.Join(dir, entry.Name()))
+	}
+
+	for name, size := range sizes {
+		if size == 0 {
+			continue
+		}
+		usage.Parts = append(usage.Parts, UsagePart{Name: name, Bytes: size})
+		usage.Total += size
+	}
+	sort.Slice(usage.Parts, func(i, j int) bool {
+		if usage.Parts[i].Bytes != usage.Parts[j].Bytes {
+			return usage.Parts[i].Bytes > usage.Parts[j].Bytes
+		}
+		return usage.Parts[i].Name < usage.Parts[j].Name
+	})
+	return usage, nil
+}
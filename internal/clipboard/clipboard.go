@@ -0,0 +1,66 @@
+// Package clipboard copies text to the system clipboard across platforms.
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrUnavailable is returned when no clipboard utility is installed
+var ErrUnavailable = errors.New("no clipboard utility found (install pbcopy, wl-copy, xclip or xsel)")
+
+// candidate is a clipboard command and its arguments
+type candidate struct {
+	name string
+	args []string
+}
+
+// candidates returns clipboard commands to try, in preference order
+func candidates() []candidate {
+	if runtime.GOOS == "darwin" {
+		return []candidate{{"pbcopy", nil}}
+	}
+
+	var list []candidate
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		list = append(list, candidate{"wl-copy", nil})
+	}
+	list = append(list,
+		candidate{"xclip", []string{"-selection", "clipboard"}},
+		candidate{"xsel", []string{"--clipboard", "--input"}},
+		candidate{"wl-copy", nil},
+	)
+	return list
+}
+
+// Available reports whether a clipboard utility is installed
+func Available() bool {
+	for _, c := range candidates() {
+		if _, err := exec.LookPath(c.name); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Copy writes text to the system clipboard using the first available utility
+func Copy(text string) error {
+	for _, c := range candidates() {
+		path, err := exec.LookPath(c.name)
+		if err != nil {
+			continue
+		}
+
+		cmd := exec.Command(path, c.args...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%s failed: %w", c.name, err)
+		}
+		return nil
+	}
+	return ErrUnavailable
+}
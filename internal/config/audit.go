@@ -0,0 +1,151 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"os/user"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// AuditRecord is one saved config change in the audit log
+type AuditRecord struct {
+	Time    time.Time `json:"time"`
+	User    string    `json:"user"`              // OS account that saved the file
+	Profile string    `json:"profile,omitempty"` // Active dotfiles user profile
+	File    string    `json:"file"`              // Path relative to ConfigDir()
+	Keys    []string  `json:"keys,omitempty"`    // Dotted paths of the keys that changed
+	Created bool      `json:"created,omitempty"` // The save created the file
+}
+
+// auditIgnoredKeys change on every save and would bury the real changes
+var auditIgnoredKeys = map[string]bool{
+	"updated_at": true,
+}
+
+// AuditLogPath is where config saves are recorded, one JSON record per line
+func AuditLogPath() string {
+	return filepath.Join(ConfigDir(), "logs", "config-audit.jsonl")
+}
+
+// recordConfigChange appends a save of path to the audit log, listing the
+// keys that differ between before (the file's previous contents, nil if it
+// didn't exist) and what is on disk now. A new file is recorded as created
+// rather than listing every key; saves that change nothing aren't recorded.
+// Best-effort: an audit log that can't be written never fails the save.
+func recordConfigChange(path string, before []byte) {
+	after, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	created := before == nil
+	var keys []string
+	if !created {
+		if keys = changedKeys(before, after); len(keys) == 0 {
+			return
+		}
+	}
+
+	file := path
+	if rel, err := filepath.Rel(ConfigDir(), path); err == nil {
+		file = filepath.ToSlash(rel)
+	}
+	data, err := json.Marshal(AuditRecord{
+		Time:    time.Now(),
+		User:    auditUser(),
+		Profile: ActiveUsername(),
+		File:    file,
+		Keys:    keys,
+		Created: created,
+	})
+	if err != nil {
+		return
+	}
+
+	logPath := AuditLogPath()
+	if err := os.MkdirAll(filepath.Dir(logPath), 0700); err != nil {
+		return
+	}
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	f.Write(append(data, '\n'))
+}
+
+// auditUser names the OS account making a change
+func auditUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// changedKeys compares two JSON documents and returns the sorted dotted
+// paths of the keys added, removed or changed. Objects are compared key by
+// key; anything else (including arrays) is compared as a whole. A document
+// that can't be parsed counts as empty.
+func changedKeys(before, after []byte) []string {
+	var old, cur map[string]any
+	json.Unmarshal(before, &old)
+	json.Unmarshal(after, &cur)
+
+	var keys []string
+	diffObjects("", old, cur, &keys)
+	sort.Strings(keys)
+	return keys
+}
+
+// diffObjects appends the paths under prefix where old and cur differ
+func diffObjects(prefix string, old, cur map[string]any, keys *[]string) {
+	seen := make(map[string]bool)
+	for _, m := range []map[string]any{old, cur} {
+		for k := range m {
+			if seen[k] || auditIgnoredKeys[k] {
+				continue
+			}
+			seen[k] = true
+			path := k
+			if prefix != "" {
+				path = prefix + "." + k
+			}
+
+			oldObj, oldOK := old[k].(map[string]any)
+			curObj, curOK := cur[k].(map[string]any)
+			if oldOK && curOK {
+				diffObjects(path, oldObj, curObj, keys)
+			} else if !reflect.DeepEqual(old[k], cur[k]) {
+				*keys = append(*keys, path)
+			}
+		}
+	}
+}
+
+// LoadAuditLog reads the config audit log, oldest first. Lines that can't be
+// parsed are skipped; a missing log is empty.
+func LoadAuditLog() ([]AuditRecord, error) {
+	f, err := os.Open(AuditLogPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []AuditRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var r AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil || r.File == "" {
+			continue
+		}
+		records = append(records, r)
+	}
+	return records, scanner.Err()
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestConfigAuditLog(t *testing.T) {
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+
+	if records, err := LoadAuditLog(); err != nil || len(records) != 0 {
+		t.Fatalf("LoadAuditLog() with no log = %v, %v; want none", records, err)
+	}
+
+	cfg := DefaultTmuxConfig()
+	if err := SaveToolConfig("tmux", cfg); err != nil {
+		t.Fatal(err)
+	}
+	// Saving the same settings again changes nothing and isn't logged
+	if err := SaveToolConfig("tmux", cfg); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Prefix = "C-a"
+	cfg.MouseMode = !cfg.MouseMode
+	if err := SaveToolConfig("tmux", cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	hk := &HotkeysConfig{Users: map[string]*UserHotkeys{}}
+	hk.GetUserHotkeys("alice").Favorites["tmux"] = []string{"prefix |"}
+	if err := SaveHotkeysConfig(hk); err != nil {
+		t.Fatal(err)
+	}
+	hk.GetUserHotkeys("alice").Aliases["gs"] = "git status"
+	if err := SaveHotkeysConfig(hk); err != nil {
+		t.Fatal(err)
+	}
+
+	// A bad line in the log is skipped
+	f, err := os.OpenFile(AuditLogPath(), os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("not json\n")
+	f.Close()
+
+	records, err := LoadAuditLog()
+	if err != nil {
+		t.Fatalf("LoadAuditLog failed: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("LoadAuditLog() = %v, want two tmux and two hotkeys saves", records)
+	}
+	if records[0].File != "tools/tmux.json" || !records[0].Created || len(records[0].Keys) != 0 {
+		t.Errorf("first record = %+v, want tools/tmux.json created", records[0])
+	}
+	if want := []string{"mouse_mode", "prefix"}; !reflect.DeepEqual(records[1].Keys, want) {
+		t.Errorf("change keys = %v, want %v", records[1].Keys, want)
+	}
+	if records[1].Time.IsZero() || records[1].Profile != "default" {
+		t.Errorf("change record = %+v, want a time and the default profile", records[1])
+	}
+	// Nested objects are compared key by key
+	if want := []string{"users.alice.aliases.gs"}; records[3].File != "hotkeys.json" ||
+		!reflect.DeepEqual(records[3].Keys, want) {
+		t.Errorf("hotkeys record = %+v, want hotkeys.json with %v", records[3], want)
+	}
+}
+
+func TestChangedKeysIgnoresUpdatedAt(t *testing.T) {
+	before := []byte(`{"name": "alice", "updated_at": "2020-01-01T00:00:00Z", "theme": {"name": "nord"}}`)
+	after := []byte(`{"name": "alice", "updated_at": "2026-01-01T00:00:00Z", "theme": {"name": "dracula"}}`)
+	if got, want := changedKeys(before, after), []string{"theme.name"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("changedKeys() = %v, want %v", got, want)
+	}
+}
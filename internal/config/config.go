@@ -2,17 +2,58 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 )
 
 // GlobalConfig holds global dotfiles settings
 type GlobalConfig struct {
+	// SchemaVersion is the config file format version (see migrate.go)
+	SchemaVersion int `json:"schema_version"`
+
 	Theme             string `json:"theme"`
 	NavStyle          string `json:"nav_style"`
 	ActiveUser        string `json:"active_user,omitempty"`
 	DisableAnimations bool   `json:"disable_animations,omitempty"`
+	// PauseAnimationsOnBattery stops the UI tick loop while on battery power
+	PauseAnimationsOnBattery bool `json:"pause_animations_on_battery,omitempty"`
+	// FollowSystemAccent replaces the theme accent with the macOS accent color
+	FollowSystemAccent bool `json:"follow_system_accent,omitempty"`
+	// UpdateSort is the Update screen ordering: "" or "name"
+	UpdateSort string `json:"update_sort,omitempty"`
+	// PinnedPackages are held at their current version and skipped by updates
+	PinnedPackages []string `json:"pinned_packages,omitempty"`
+	// SkipUpdateCheck lists tool IDs whose packages the update check ignores
+	SkipUpdateCheck []string `json:"skip_update_check,omitempty"`
+	// HiddenDeepDiveCategories lists deep dive menu item keys (e.g.
+	// "gui-apps") left out of the installer's deep dive menu
+	HiddenDeepDiveCategories []string `json:"hidden_deep_dive_categories,omitempty"`
+	// Keybindings overrides TUI keys per action, e.g. {"save": "ctrl+s"};
+	// several keys are separated by commas
+	Keybindings map[string]string `json:"keybindings,omitempty"`
+	// Shell picks the rc file written on install: "zsh", "bash" or "fish".
+	// Empty uses the login shell.
+	Shell string `json:"shell,omitempty"`
+	// IdleTimeout exits the TUI after this many minutes without input
+	// (never during an install or update); 0 disables it
+	IdleTimeout int `json:"idle_timeout,omitempty"`
+	// SkipIntro always starts past the intro, like passing --skip-intro
+	SkipIntro bool `json:"skip_intro,omitempty"`
+	// SkipDurdrawCheck stops the intro probing PATH for durdraw
+	SkipDurdrawCheck bool `json:"skip_durdraw_check,omitempty"`
+
+	// DotfilesRepo is a git repository the generated config files are copied
+	// into and committed after each install; empty turns mirroring off
+	DotfilesRepo string `json:"dotfiles_repo,omitempty"`
+
+	// PostInstallHooks are shell commands run (in order) after a successful install.
+	// The first failure aborts the rest unless PostInstallContinueOnError is set.
+	PostInstallHooks           []string `json:"post_install_hooks,omitempty"`
+	PostInstallContinueOnError bool     `json:"post_install_continue_on_error,omitempty"`
 
 	// Backup settings
 	AutoBackup       bool `json:"auto_backup"`         // Create backup before install/config changes
@@ -23,6 +64,7 @@ type GlobalConfig struct {
 // DefaultGlobalConfig returns default global settings
 func DefaultGlobalConfig() *GlobalConfig {
 	return &GlobalConfig{
+		SchemaVersion:    SchemaVersion,
 		Theme:            "catppuccin-mocha",
 		NavStyle:         "emacs",
 		AutoBackup:       true, // Auto-backup enabled by default
@@ -31,11 +73,11 @@ func DefaultGlobalConfig() *GlobalConfig {
 	}
 }
 
-// ConfigDir returns the dotfiles config directory path
-// Returns empty string if HOME is not set and XDG_CONFIG_HOME is not available
-func ConfigDir() string {
+// ConfigHome returns the base directory for user config files: $XDG_CONFIG_HOME
+// when set, otherwise ~/.config. Returns empty string if neither is available.
+func ConfigHome() string {
 	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
-		return filepath.Clean(filepath.Join(xdg, "dotfiles"))
+		return filepath.Clean(xdg)
 	}
 	home := os.Getenv("HOME")
 	if home == "" {
@@ -46,7 +88,30 @@ func ConfigDir() string {
 			return ""
 		}
 	}
-	return filepath.Clean(filepath.Join(home, ".config", "dotfiles"))
+	return filepath.Join(home, ".config")
+}
+
+// ConfigDir returns the dotfiles config directory path
+// Returns empty string if HOME is not set and XDG_CONFIG_HOME is not available
+func ConfigDir() string {
+	base := ConfigHome()
+	if base == "" {
+		return ""
+	}
+	return filepath.Join(base, "dotfiles")
+}
+
+// HomePath resolves a dotfile path written relative to home (e.g.
+// ".config/nvim/init.lua") to its absolute location, placing anything under
+// .config/ in ConfigHome so XDG_CONFIG_HOME is honored
+func HomePath(home, relPath string) string {
+	relPath = filepath.Clean(relPath)
+	if rest, ok := strings.CutPrefix(relPath, ".config"+string(os.PathSeparator)); ok {
+		if base := ConfigHome(); base != "" {
+			return filepath.Join(base, rest)
+		}
+	}
+	return filepath.Join(home, relPath)
 }
 
 // ToolsDir returns the per-tool config directory path
@@ -54,6 +119,12 @@ func ToolsDir() string {
 	return filepath.Join(ConfigDir(), "tools")
 }
 
+// ToolConfigExists reports whether a tool config has been saved
+func ToolConfigExists(toolName string) bool {
+	_, err := os.Stat(filepath.Join(ToolsDir(), toolName+".json"))
+	return err == nil
+}
+
 // EnsureDirs creates config directories if they don't exist
 func EnsureDirs() error {
 	dirs := []string{
@@ -70,7 +141,8 @@ func EnsureDirs() error {
 	return nil
 }
 
-// LoadToolConfig loads a tool config from JSON file, returning defaults if not found
+// LoadToolConfig loads a tool config from JSON file, returning defaults if not
+// found. A file that can't be decoded is reported as a *CorruptError.
 func LoadToolConfig[T any](toolName string, defaultFn func() *T) (*T, error) {
 	path := filepath.Join(ToolsDir(), toolName+".json")
 
@@ -83,12 +155,16 @@ func LoadToolConfig[T any](toolName string, defaultFn func() *T) (*T, error) {
 		return nil, fmt.Errorf("failed to read %s: %w", path, err)
 	}
 
-	var cfg T
-	if err := json.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	// Files older than SchemaVersion are upgraded and rewritten
+	cfg, m, err := decodeVersioned(path, data, defaultFn(), false)
+	if err != nil {
+		return nil, &CorruptError{Path: path, Err: describeJSONError(data, err)}
+	}
+	if err := applyMigration(m, cfg); err != nil {
+		return nil, err
 	}
 
-	return &cfg, nil
+	return cfg, nil
 }
 
 // SaveToolConfig saves a tool config to JSON file
@@ -98,37 +174,55 @@ func SaveToolConfig[T any](toolName string, cfg *T) error {
 	}
 
 	path := filepath.Join(ToolsDir(), toolName+".json")
+	before, _ := os.ReadFile(path)
+	stampSchemaVersion(cfg, SchemaVersion)
+	if err := writeConfigFile(path, cfg); err != nil {
+		return err
+	}
+	recordConfigChange(path, before)
+	return nil
+}
 
-	data, err := json.MarshalIndent(cfg, "", "  ")
+// LoadGlobalConfig loads global config from settings file. A file that can't
+// be decoded is reported as a *CorruptError.
+func LoadGlobalConfig() (*GlobalConfig, error) {
+	cfg, m, err := readGlobalConfig()
 	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+		return nil, err
 	}
-
-	if err := os.WriteFile(path, data, 0600); err != nil {
-		return fmt.Errorf("failed to write %s: %w", path, err)
+	// Files older than SchemaVersion are upgraded and rewritten
+	if err := applyMigration(m, cfg); err != nil {
+		return nil, err
 	}
+	return cfg, nil
+}
 
-	return nil
+// ReadGlobalConfig is LoadGlobalConfig for read-only callers (dotfiles
+// serve): a file older than SchemaVersion is upgraded in memory but never
+// rewritten
+func ReadGlobalConfig() (*GlobalConfig, error) {
+	cfg, _, err := readGlobalConfig()
+	return cfg, err
 }
 
-// LoadGlobalConfig loads global config from settings file
-func LoadGlobalConfig() (*GlobalConfig, error) {
+// readGlobalConfig decodes global.json, returning the migration it needs
+// (nil if none)
+func readGlobalConfig() (*GlobalConfig, *Migration, error) {
 	path := filepath.Join(ConfigDir(), "global.json")
 
 	data, err := os.ReadFile(path)
 	if os.IsNotExist(err) {
-		return DefaultGlobalConfig(), nil
+		return DefaultGlobalConfig(), nil, nil
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to read global config: %w", err)
+		return nil, nil, fmt.Errorf("failed to read global config: %w", err)
 	}
 
-	var cfg GlobalConfig
-	if err := json.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse global config: %w", err)
+	cfg, m, err := decodeVersioned(path, data, DefaultGlobalConfig(), false)
+	if err != nil {
+		return nil, nil, &CorruptError{Path: path, Err: describeJSONError(data, err)}
 	}
-
-	return &cfg, nil
+	return cfg, m, nil
 }
 
 // SaveGlobalConfig saves global config to settings file
@@ -139,14 +233,17 @@ func SaveGlobalConfig(cfg *GlobalConfig) error {
 
 	path := filepath.Join(ConfigDir(), "global.json")
 
+	cfg.SchemaVersion = max(cfg.SchemaVersion, SchemaVersion)
 	data, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal global config: %w", err)
 	}
 
+	before, _ := os.ReadFile(path)
 	if err := os.WriteFile(path, data, 0600); err != nil {
 		return fmt.Errorf("failed to write global config: %w", err)
 	}
+	recordConfigChange(path, before)
 
 	return nil
 }
@@ -164,49 +261,53 @@ type AllToolConfigs struct {
 	Utilities *UtilitiesConfig `json:"utilities"`
 }
 
-// LoadAllToolConfigs loads all tool configs with defaults
+// LoadAllToolConfigs loads all tool configs with defaults. A corrupt file
+// doesn't stop the others loading: its tool gets defaults, and the returned
+// error (alongside the configs) lists every *CorruptError.
 func LoadAllToolConfigs() (*AllToolConfigs, error) {
-	ghostty, err := LoadToolConfig("ghostty", DefaultGhosttyConfig)
+	var corrupt []error
+
+	ghostty, err := loadToolConfigOrDefault("ghostty", DefaultGhosttyConfig, &corrupt)
 	if err != nil {
 		return nil, err
 	}
 
-	tmux, err := LoadToolConfig("tmux", DefaultTmuxConfig)
+	tmux, err := loadToolConfigOrDefault("tmux", DefaultTmuxConfig, &corrupt)
 	if err != nil {
 		return nil, err
 	}
 
-	zsh, err := LoadToolConfig("zsh", DefaultZshConfig)
+	zsh, err := loadToolConfigOrDefault("zsh", DefaultZshConfig, &corrupt)
 	if err != nil {
 		return nil, err
 	}
 
-	neovim, err := LoadToolConfig("neovim", DefaultNeovimConfig)
+	neovim, err := loadToolConfigOrDefault("neovim", DefaultNeovimConfig, &corrupt)
 	if err != nil {
 		return nil, err
 	}
 
-	git, err := LoadToolConfig("git", DefaultGitConfig)
+	git, err := loadToolConfigOrDefault("git", DefaultGitConfig, &corrupt)
 	if err != nil {
 		return nil, err
 	}
 
-	yazi, err := LoadToolConfig("yazi", DefaultYaziConfig)
+	yazi, err := loadToolConfigOrDefault("yazi", DefaultYaziConfig, &corrupt)
 	if err != nil {
 		return nil, err
 	}
 
-	fzf, err := LoadToolConfig("fzf", DefaultFzfConfig)
+	fzf, err := loadToolConfigOrDefault("fzf", DefaultFzfConfig, &corrupt)
 	if err != nil {
 		return nil, err
 	}
 
-	apps, err := LoadToolConfig("apps", DefaultAppsConfig)
+	apps, err := loadToolConfigOrDefault("apps", DefaultAppsConfig, &corrupt)
 	if err != nil {
 		return nil, err
 	}
 
-	utilities, err := LoadToolConfig("utilities", DefaultUtilitiesConfig)
+	utilities, err := loadToolConfigOrDefault("utilities", DefaultUtilitiesConfig, &corrupt)
 	if err != nil {
 		return nil, err
 	}
@@ -221,7 +322,19 @@ func LoadAllToolConfigs() (*AllToolConfigs, error) {
 		Fzf:       fzf,
 		Apps:      apps,
 		Utilities: utilities,
-	}, nil
+	}, errors.Join(corrupt...)
+}
+
+// loadToolConfigOrDefault loads a tool config like LoadToolConfig, but
+// returns defaults for a corrupt file, recording its error in corrupt
+func loadToolConfigOrDefault[T any](toolName string, defaultFn func() *T, corrupt *[]error) (*T, error) {
+	cfg, err := LoadToolConfig(toolName, defaultFn)
+	var ce *CorruptError
+	if errors.As(err, &ce) {
+		*corrupt = append(*corrupt, err)
+		return defaultFn(), nil
+	}
+	return cfg, err
 }
 
 // SaveAllToolConfigs saves all tool configs
@@ -276,12 +389,13 @@ var AvailableThemes = []string{
 	"neon-seapunk",
 }
 
-// IsValidTheme checks if a theme name is valid
+// IsValidTheme checks if a theme name is valid: built in, or a custom theme
+// in ThemesDir
 func IsValidTheme(theme string) bool {
 	for _, t := range AvailableThemes {
 		if t == theme {
 			return true
 		}
 	}
-	return false
+	return slices.Contains(CustomThemeNames(), theme)
 }
@@ -54,6 +54,29 @@ func TestConfigDir(t *testing.T) {
 	}
 }
 
+func TestHomePath(t *testing.T) {
+	t.Setenv("HOME", "/home/testuser")
+
+	tests := []struct {
+		xdg  string
+		rel  string
+		want string
+	}{
+		{"", ".config/nvim/init.lua", "/home/testuser/.config/nvim/init.lua"},
+		{"", ".zshrc", "/home/testuser/.zshrc"},
+		{"/custom/config", ".config/nvim/init.lua", "/custom/config/nvim/init.lua"},
+		{"/custom/config", ".config/ghostty/config", "/custom/config/ghostty/config"},
+		{"/custom/config", ".zshrc", "/home/testuser/.zshrc"},
+		{"/custom/config", ".configure", "/home/testuser/.configure"},
+	}
+	for _, tt := range tests {
+		t.Setenv("XDG_CONFIG_HOME", tt.xdg)
+		if got := HomePath("/home/testuser", tt.rel); got != tt.want {
+			t.Errorf("HomePath(%q) with XDG_CONFIG_HOME=%q = %q, want %q", tt.rel, tt.xdg, got, tt.want)
+		}
+	}
+}
+
 func TestToolsDir(t *testing.T) {
 	origXDG := os.Getenv("XDG_CONFIG_HOME")
 	defer func() {
@@ -301,3 +324,60 @@ func TestSaveAllToolConfigs(t *testing.T) {
 		t.Errorf("FontSize = %d, want 20", loaded.Ghostty.FontSize)
 	}
 }
+
+func TestPinUnpinPackage(t *testing.T) {
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+
+	if added, err := PinPackage("neovim"); err != nil || !added {
+		t.Fatalf("PinPackage(neovim) = %v, %v; want true, nil", added, err)
+	}
+	if added, err := PinPackage("neovim"); err != nil || added {
+		t.Errorf("PinPackage(neovim) again = %v, %v; want false, nil", added, err)
+	}
+	if _, err := PinPackage("bat"); err != nil {
+		t.Fatalf("PinPackage(bat) failed: %v", err)
+	}
+
+	cfg, err := LoadGlobalConfig()
+	if err != nil {
+		t.Fatalf("LoadGlobalConfig failed: %v", err)
+	}
+	if len(cfg.PinnedPackages) != 2 || cfg.PinnedPackages[0] != "bat" {
+		t.Errorf("PinnedPackages = %v, want [bat neovim]", cfg.PinnedPackages)
+	}
+
+	if removed, err := UnpinPackage("neovim"); err != nil || !removed {
+		t.Errorf("UnpinPackage(neovim) = %v, %v; want true, nil", removed, err)
+	}
+	if removed, err := UnpinPackage("neovim"); err != nil || removed {
+		t.Errorf("UnpinPackage(neovim) again = %v, %v; want false, nil", removed, err)
+	}
+}
+
+func TestSetSkipUpdateCheck(t *testing.T) {
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+
+	for _, id := range []string{"tmux", "bat", "tmux"} {
+		if err := SetSkipUpdateCheck(id, true); err != nil {
+			t.Fatalf("SetSkipUpdateCheck(%s, true) failed: %v", id, err)
+		}
+	}
+
+	cfg, err := LoadGlobalConfig()
+	if err != nil {
+		t.Fatalf("LoadGlobalConfig failed: %v", err)
+	}
+	if len(cfg.SkipUpdateCheck) != 2 || cfg.SkipUpdateCheck[0] != "bat" {
+		t.Errorf("SkipUpdateCheck = %v, want [bat tmux]", cfg.SkipUpdateCheck)
+	}
+
+	if err := SetSkipUpdateCheck("bat", false); err != nil {
+		t.Fatalf("SetSkipUpdateCheck(bat, false) failed: %v", err)
+	}
+	cfg, _ = LoadGlobalConfig()
+	if len(cfg.SkipUpdateCheck) != 1 || cfg.SkipUpdateCheck[0] != "tmux" {
+		t.Errorf("SkipUpdateCheck = %v, want [tmux]", cfg.SkipUpdateCheck)
+	}
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// InstallRecord is one successful tool install in the install history
+type InstallRecord struct {
+	Tool    string    `json:"tool"`              // Tool ID
+	Version string    `json:"version,omitempty"` // Version requested, if one was pinned
+	Time    time.Time `json:"time"`
+}
+
+// InstallHistoryPath is where successful installs are recorded, one JSON
+// record per line
+func InstallHistoryPath() string {
+	return filepath.Join(ConfigDir(), "logs", "installs.jsonl")
+}
+
+// RecordInstall appends a tool install to the install history (best-effort:
+// a history that can't be written never fails the install)
+func RecordInstall(toolID, version string) {
+	path := InstallHistoryPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	data, err := json.Marshal(InstallRecord{Tool: toolID, Version: version, Time: time.Now()})
+	if err != nil {
+		return
+	}
+	f.Write(append(data, '\n'))
+}
+
+// LoadInstallHistory reads the install history, oldest first. Lines that
+// can't be parsed are skipped; a missing history is empty.
+func LoadInstallHistory() ([]InstallRecord, error) {
+	f, err := os.Open(InstallHistoryPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []InstallRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r InstallRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil || r.Tool == "" {
+			continue
+		}
+		records = append(records, r)
+	}
+	return records, scanner.Err()
+}
+
+// RecentInstalls returns the tools installed since the given time, newest
+// first and at most max of them. A tool installed more than once appears
+// once, with its latest install.
+func RecentInstalls(since time.Time, max int) ([]InstallRecord, error) {
+	history, err := LoadInstallHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]InstallRecord)
+	for _, r := range history {
+		if r.Time.Before(since) {
+			continue
+		}
+		if prev, ok := latest[r.Tool]; !ok || r.Time.After(prev.Time) {
+			latest[r.Tool] = r
+		}
+	}
+
+	recent := make([]InstallRecord, 0, len(latest))
+	for _, r := range latest {
+		recent = append(recent, r)
+	}
+	sort.Slice(recent, func(i, j int) bool { return recent[i].Time.After(recent[j].Time) })
+	if len(recent) > max {
+		recent = recent[:max]
+	}
+	return recent, nil
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecentInstalls(t *testing.T) {
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+
+	if recent, err := RecentInstalls(time.Time{}, 10); err != nil || len(recent) != 0 {
+		t.Fatalf("RecentInstalls() with no history = %v, %v; want none", recent, err)
+	}
+
+	path := InstallHistoryPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	old := `{"tool": "bat", "time": "2020-01-01T00:00:00Z"}` + "\n" +
+		`not json` + "\n" +
+		`{"tool": "fzf", "time": "` + time.Now().Add(-48*time.Hour).Format(time.RFC3339) + `"}` + "\n"
+	if err := os.WriteFile(path, []byte(old), 0600); err != nil {
+		t.Fatal(err)
+	}
+	RecordInstall("ripgrep", "")
+	RecordInstall("fzf", "0.44")
+
+	recent, err := RecentInstalls(time.Now().AddDate(0, 0, -7), 10)
+	if err != nil {
+		t.Fatalf("RecentInstalls failed: %v", err)
+	}
+	if len(recent) != 2 {
+		t.Fatalf("RecentInstalls() = %v, want fzf and ripgrep once each", recent)
+	}
+	// Newest first; fzf's reinstall replaces its older record
+	if recent[0].Tool != "fzf" || recent[0].Version != "0.44" || recent[1].Tool != "ripgrep" {
+		t.Errorf("RecentInstalls() = %v, want fzf@0.44 then ripgrep", recent)
+	}
+
+	if recent, _ := RecentInstalls(time.Time{}, 1); len(recent) != 1 {
+		t.Errorf("RecentInstalls(max 1) returned %d records", len(recent))
+	}
+}
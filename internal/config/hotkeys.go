@@ -20,11 +20,7 @@ type UserHotkeys struct {
 
 // LoadHotkeysConfig loads hotkeys config from ~/.config/dotfiles/hotkeys.json
 func LoadHotkeysConfig() (*HotkeysConfig, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return nil, err
-	}
-	path := filepath.Join(home, ".config", "dotfiles", "hotkeys.json")
+	path := filepath.Join(ConfigDir(), "hotkeys.json")
 
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -46,11 +42,7 @@ func LoadHotkeysConfig() (*HotkeysConfig, error) {
 
 // SaveHotkeysConfig saves hotkeys config
 func SaveHotkeysConfig(cfg *HotkeysConfig) error {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return err
-	}
-	dir := filepath.Join(home, ".config", "dotfiles")
+	dir := ConfigDir()
 	if err := os.MkdirAll(dir, 0700); err != nil {
 		return err
 	}
@@ -60,7 +52,12 @@ func SaveHotkeysConfig(cfg *HotkeysConfig) error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, data, 0600)
+	before, _ := os.ReadFile(path)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return err
+	}
+	recordConfigChange(path, before)
+	return nil
 }
 
 // GetUserHotkeys gets or creates hotkeys for a specific user.
@@ -134,3 +131,26 @@ func (u *UserHotkeys) GetFavoriteCount() int {
 	}
 	return count
 }
+
+// RemoveFavorite removes a hotkey item from the user's favorites.
+// Returns false if the item was not a favorite.
+func (u *UserHotkeys) RemoveFavorite(categoryID, itemKey string) bool {
+	if !u.IsFavorite(categoryID, itemKey) {
+		return false
+	}
+	u.ToggleFavorite(categoryID, itemKey)
+	if len(u.Favorites[categoryID]) == 0 {
+		delete(u.Favorites, categoryID)
+	}
+	return true
+}
+
+// ClearFavorites removes all favorites in a category and returns how many were removed
+func (u *UserHotkeys) ClearFavorites(categoryID string) int {
+	if u == nil || u.Favorites == nil {
+		return 0
+	}
+	n := len(u.Favorites[categoryID])
+	delete(u.Favorites, categoryID)
+	return n
+}
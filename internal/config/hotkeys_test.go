@@ -0,0 +1,37 @@
+package config
+
+import "testing"
+
+func TestUserHotkeysRemoveAndClearFavorites(t *testing.T) {
+	cfg := &HotkeysConfig{}
+	u := cfg.GetUserHotkeys("alice")
+	u.ToggleFavorite("tmux", "prefix |")
+	u.ToggleFavorite("tmux", "prefix -")
+	u.ToggleFavorite("zsh", "ctrl+r")
+
+	if u.RemoveFavorite("tmux", "missing") {
+		t.Error("RemoveFavorite should return false for non-favorite")
+	}
+	if !u.RemoveFavorite("tmux", "prefix |") {
+		t.Error("RemoveFavorite should return true for favorite")
+	}
+	if u.IsFavorite("tmux", "prefix |") {
+		t.Error("item should no longer be a favorite")
+	}
+	if got := u.GetFavoriteCount(); got != 2 {
+		t.Errorf("GetFavoriteCount() = %d, want 2", got)
+	}
+
+	if got := u.ClearFavorites("tmux"); got != 1 {
+		t.Errorf("ClearFavorites(tmux) = %d, want 1", got)
+	}
+	if _, ok := u.Favorites["tmux"]; ok {
+		t.Error("tmux category should be removed after clear")
+	}
+	if got := u.ClearFavorites("tmux"); got != 0 {
+		t.Errorf("ClearFavorites(tmux) again = %d, want 0", got)
+	}
+	if !u.IsFavorite("zsh", "ctrl+r") {
+		t.Error("other categories should be untouched")
+	}
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// InstallProfile is a named set of tools to install, e.g. "work" or
+// "minimal". Unlike UserProfile it carries no appearance settings, so the
+// same selection can be reused across machines with different themes.
+type InstallProfile struct {
+	Name      string   `json:"name"`
+	Tools     []string `json:"tools"` // Selected tool and helper script IDs
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+// InstallProfilesDir returns the install profiles directory path
+func InstallProfilesDir() string {
+	return filepath.Join(ConfigDir(), "profiles")
+}
+
+// ValidateProfileName checks if an install profile name is valid. Names
+// follow the same rules as usernames.
+func ValidateProfileName(name string) error {
+	if name == "" {
+		return fmt.Errorf("profile name cannot be empty")
+	}
+	if !usernameRegex.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: must start with a letter, contain only alphanumeric characters, underscores, or hyphens, and be at most 32 characters", name)
+	}
+	return nil
+}
+
+// LoadInstallProfile loads an install profile from disk
+func LoadInstallProfile(name string) (*InstallProfile, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(InstallProfilesDir(), name+".json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("install profile %q does not exist", name)
+		}
+		return nil, fmt.Errorf("failed to read install profile: %w", err)
+	}
+
+	var profile InstallProfile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("failed to parse install profile: %w", describeJSONError(data, err))
+	}
+	return &profile, nil
+}
+
+// SaveInstallProfile writes an install profile, replacing any existing
+// profile with the same name (its creation time is kept)
+func SaveInstallProfile(profile *InstallProfile) error {
+	if err := ValidateProfileName(profile.Name); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(InstallProfilesDir(), 0700); err != nil {
+		return fmt.Errorf("failed to create profiles directory: %w", err)
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	if existing, err := LoadInstallProfile(profile.Name); err == nil && existing.CreatedAt != "" {
+		profile.CreatedAt = existing.CreatedAt
+	} else if profile.CreatedAt == "" {
+		profile.CreatedAt = now
+	}
+	profile.UpdatedAt = now
+
+	tools := append([]string(nil), profile.Tools...)
+	sort.Strings(tools)
+	profile.Tools = tools
+
+	return writeConfigFile(filepath.Join(InstallProfilesDir(), profile.Name+".json"), profile)
+}
+
+// ListInstallProfiles returns the names of all saved install profiles
+func ListInstallProfiles() ([]string, error) {
+	entries, err := os.ReadDir(InstallProfilesDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read profiles directory: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), ".json"); ok && !e.IsDir() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
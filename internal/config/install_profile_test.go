@@ -0,0 +1,62 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInstallProfileRoundTrip(t *testing.T) {
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+
+	if names, err := ListInstallProfiles(); err != nil || len(names) != 0 {
+		t.Fatalf("ListInstallProfiles() = %v, %v, want none", names, err)
+	}
+
+	if err := SaveInstallProfile(&InstallProfile{Name: "work", Tools: []string{"lazygit", "bat"}}); err != nil {
+		t.Fatalf("SaveInstallProfile failed: %v", err)
+	}
+	if err := SaveInstallProfile(&InstallProfile{Name: "minimal"}); err != nil {
+		t.Fatalf("SaveInstallProfile failed: %v", err)
+	}
+
+	profile, err := LoadInstallProfile("work")
+	if err != nil {
+		t.Fatalf("LoadInstallProfile failed: %v", err)
+	}
+	if len(profile.Tools) != 2 || profile.Tools[0] != "bat" || profile.Tools[1] != "lazygit" {
+		t.Errorf("Tools = %v, want [bat lazygit]", profile.Tools)
+	}
+	if profile.CreatedAt == "" || profile.UpdatedAt == "" {
+		t.Errorf("timestamps not set: %+v", profile)
+	}
+
+	names, err := ListInstallProfiles()
+	if err != nil {
+		t.Fatalf("ListInstallProfiles failed: %v", err)
+	}
+	if len(names) != 2 || names[0] != "minimal" || names[1] != "work" {
+		t.Errorf("ListInstallProfiles() = %v, want [minimal work]", names)
+	}
+
+	info, err := os.Stat(filepath.Join(InstallProfilesDir(), "work.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("profile mode = %o, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestInstallProfileErrors(t *testing.T) {
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+
+	if err := SaveInstallProfile(&InstallProfile{Name: "../escape"}); err == nil {
+		t.Error("SaveInstallProfile should reject invalid names")
+	}
+	if _, err := LoadInstallProfile("missing"); err == nil {
+		t.Error("LoadInstallProfile should fail for a missing profile")
+	}
+}
@@ -0,0 +1,260 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SchemaVersion is the current config file schema. Bump it when a config
+// struct gains a field whose zero value is not a sensible default, or drops
+// one, so files written by older versions are upgraded on load.
+const SchemaVersion = 1
+
+// Migration records what upgrading one config file changed
+type Migration struct {
+	Path    string
+	From    int      // Schema version the file was written with (0 = unversioned)
+	To      int      // Schema version after migrating
+	Added   []string // Fields the file lacked, filled with their defaults
+	Removed []string // Fields no longer in the schema, dropped
+}
+
+// Changed reports whether migrating altered the file
+func (m Migration) Changed() bool {
+	return m.From != m.To || len(m.Added) > 0 || len(m.Removed) > 0
+}
+
+// String summarizes the migration, e.g.
+// "global.json: v0 → v1; added backup_max_count; dropped old_field"
+func (m Migration) String() string {
+	var parts []string
+	if m.From != m.To {
+		parts = append(parts, fmt.Sprintf("v%d → v%d", m.From, m.To))
+	}
+	if len(m.Added) > 0 {
+		parts = append(parts, "added "+strings.Join(m.Added, ", "))
+	}
+	if len(m.Removed) > 0 {
+		parts = append(parts, "dropped "+strings.Join(m.Removed, ", "))
+	}
+	return filepath.Base(m.Path) + ": " + strings.Join(parts, "; ")
+}
+
+// jsonField is a struct field as it appears in a config file
+type jsonField struct {
+	name      string // JSON key
+	goName    string
+	omitEmpty bool
+}
+
+// jsonFields lists the JSON keys a config struct reads and writes
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, jsonField{name: name, goName: f.Name, omitEmpty: strings.Contains(opts, "omitempty")})
+	}
+	return fields
+}
+
+// lookupKey finds key in raw the way encoding/json matches fields
+// (case-insensitively)
+func lookupKey(raw map[string]json.RawMessage, key string) (json.RawMessage, bool) {
+	if v, ok := raw[key]; ok {
+		return v, true
+	}
+	for k, v := range raw {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// fileVersion returns the schema version recorded in a config file, and
+// whether the config type records one at all (has a SchemaVersion field)
+func fileVersion(raw map[string]json.RawMessage, fields []jsonField) (int, bool) {
+	for _, f := range fields {
+		if f.goName != "SchemaVersion" {
+			continue
+		}
+		var v int
+		if data, ok := lookupKey(raw, f.name); ok {
+			json.Unmarshal(data, &v)
+		}
+		return v, true
+	}
+	return 0, false
+}
+
+// stampSchemaVersion raises cfg's SchemaVersion field (if it has one) to v
+func stampSchemaVersion(cfg any, v int) {
+	field := reflect.ValueOf(cfg).Elem().FieldByName("SchemaVersion")
+	if field.IsValid() && field.CanSet() && field.Kind() == reflect.Int && field.Int() < int64(v) {
+		field.SetInt(int64(v))
+	}
+}
+
+// decodeVersioned decodes a config file. Files older than SchemaVersion (or
+// any file, when force is set) are migrated: they are decoded over defaults
+// so fields they predate get their default instead of the zero value, and
+// the returned Migration lists the fields added and the unknown ones that
+// rewriting the file will drop. Types without a SchemaVersion field are only
+// migrated when forced.
+func decodeVersioned[T any](path string, data []byte, defaults *T, force bool) (*T, *Migration, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, err
+	}
+	fields := jsonFields(reflect.TypeFor[T]())
+	from, versioned := fileVersion(raw, fields)
+
+	if !force && (!versioned || from >= SchemaVersion) {
+		var cfg T
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, nil, err
+		}
+		return &cfg, nil, nil
+	}
+
+	cfg := defaults
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, nil, err
+	}
+
+	m := &Migration{Path: path, From: from, To: from}
+	if versioned {
+		m.To = max(from, SchemaVersion)
+		stampSchemaVersion(cfg, m.To)
+	}
+	known := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		known[strings.ToLower(f.name)] = true
+		// omitempty fields are legitimately absent when zero
+		if _, ok := lookupKey(raw, f.name); !ok && !f.omitEmpty && f.goName != "SchemaVersion" {
+			m.Added = append(m.Added, f.name)
+		}
+	}
+	for key := range raw {
+		if !known[strings.ToLower(key)] {
+			m.Removed = append(m.Removed, key)
+		}
+	}
+	slices.Sort(m.Removed)
+	return cfg, m, nil
+}
+
+// writeConfigFile writes cfg as indented JSON with owner-only permissions
+func writeConfigFile(path string, cfg any) error {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyMigration rewrites a migrated config file and logs what changed
+func applyMigration(m *Migration, cfg any) error {
+	if m == nil || !m.Changed() {
+		return nil
+	}
+	if err := writeConfigFile(m.Path, cfg); err != nil {
+		return err
+	}
+	logMigration(*m)
+	return nil
+}
+
+// MigrationLogPath is where automatic and forced migrations are recorded
+func MigrationLogPath() string {
+	return filepath.Join(ConfigDir(), "logs", "migrations.log")
+}
+
+// logMigration appends a timestamped line to the migration log (best-effort)
+func logMigration(m Migration) {
+	path := MigrationLogPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintf(f, "%s %s\n", time.Now().Format(time.RFC3339), m)
+}
+
+// migrateFile forces a migration of one config file, rewriting it if
+// anything changed. It returns nil if the file does not exist.
+func migrateFile[T any](path string, defaults *T) (*Migration, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	cfg, m, err := decodeVersioned(path, data, defaults, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := applyMigration(m, cfg); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// MigrateToolConfig forces a migration of a tool config file: missing fields
+// are filled from defaultFn, unknown ones dropped and the schema version
+// updated. It returns nil if the file does not exist.
+func MigrateToolConfig[T any](toolName string, defaultFn func() *T) (*Migration, error) {
+	return migrateFile(filepath.Join(ToolsDir(), toolName+".json"), defaultFn())
+}
+
+// MigrateAllConfigs forces a migration of the global config and every tool
+// config, returning the files that changed
+func MigrateAllConfigs() ([]Migration, error) {
+	steps := []func() (*Migration, error){
+		func() (*Migration, error) {
+			return migrateFile(filepath.Join(ConfigDir(), "global.json"), DefaultGlobalConfig())
+		},
+		func() (*Migration, error) { return MigrateToolConfig("ghostty", DefaultGhosttyConfig) },
+		func() (*Migration, error) { return MigrateToolConfig("tmux", DefaultTmuxConfig) },
+		func() (*Migration, error) { return MigrateToolConfig("zsh", DefaultZshConfig) },
+		func() (*Migration, error) { return MigrateToolConfig("neovim", DefaultNeovimConfig) },
+		func() (*Migration, error) { return MigrateToolConfig("git", DefaultGitConfig) },
+		func() (*Migration, error) { return MigrateToolConfig("yazi", DefaultYaziConfig) },
+		func() (*Migration, error) { return MigrateToolConfig("fzf", DefaultFzfConfig) },
+		func() (*Migration, error) { return MigrateToolConfig("apps", DefaultAppsConfig) },
+		func() (*Migration, error) { return MigrateToolConfig("utilities", DefaultUtilitiesConfig) },
+	}
+
+	var migrations []Migration
+	for _, migrate := range steps {
+		m, err := migrate()
+		if err != nil {
+			return migrations, err
+		}
+		if m != nil && m.Changed() {
+			migrations = append(migrations, *m)
+		}
+	}
+	return migrations, nil
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadGlobalConfigMigratesOldFile(t *testing.T) {
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+
+	path := filepath.Join(ConfigDir(), "global.json")
+	old := `{"theme": "nord", "nav_style": "vim", "auto_backup": false, "removed_setting": true}`
+	if err := os.WriteFile(path, []byte(old), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadGlobalConfig()
+	if err != nil {
+		t.Fatalf("LoadGlobalConfig failed: %v", err)
+	}
+	if cfg.Theme != "nord" || cfg.AutoBackup {
+		t.Errorf("existing settings lost: theme=%q auto_backup=%v", cfg.Theme, cfg.AutoBackup)
+	}
+	if cfg.BackupMaxCount != 10 || cfg.BackupMaxAgeDays != 30 {
+		t.Errorf("missing fields = %d/%d, want defaults 10/30", cfg.BackupMaxCount, cfg.BackupMaxAgeDays)
+	}
+	if cfg.SchemaVersion != SchemaVersion {
+		t.Errorf("SchemaVersion = %d, want %d", cfg.SchemaVersion, SchemaVersion)
+	}
+
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "removed_setting") || !strings.Contains(string(data), `"schema_version": 1`) {
+		t.Errorf("file not rewritten in current schema:\n%s", data)
+	}
+
+	log, err := os.ReadFile(MigrationLogPath())
+	if err != nil {
+		t.Fatalf("migration not logged: %v", err)
+	}
+	want := "global.json: v0 → v1; added backup_max_count, backup_max_age_days; dropped removed_setting"
+	if !strings.Contains(string(log), want) {
+		t.Errorf("log = %q, want it to contain %q", log, want)
+	}
+}
+
+func TestReadGlobalConfigDoesNotMigrate(t *testing.T) {
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+
+	path := filepath.Join(ConfigDir(), "global.json")
+	old := `{"theme": "nord", "removed_setting": true}`
+	if err := os.WriteFile(path, []byte(old), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := ReadGlobalConfig()
+	if err != nil {
+		t.Fatalf("ReadGlobalConfig failed: %v", err)
+	}
+	if cfg.Theme != "nord" || cfg.BackupMaxCount != 10 {
+		t.Errorf("theme=%q backup_max_count=%d, want nord upgraded in memory", cfg.Theme, cfg.BackupMaxCount)
+	}
+	if data, _ := os.ReadFile(path); string(data) != old {
+		t.Errorf("old file was rewritten:\n%s", data)
+	}
+	if _, err := os.Stat(MigrationLogPath()); !os.IsNotExist(err) {
+		t.Errorf("migration logged by a read-only load: %v", err)
+	}
+}
+
+func TestLoadCurrentSchemaIsUntouched(t *testing.T) {
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+
+	path := filepath.Join(ConfigDir(), "global.json")
+	current := `{"schema_version": 1, "theme": "nord", "backup_max_count": 0}`
+	if err := os.WriteFile(path, []byte(current), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadGlobalConfig()
+	if err != nil {
+		t.Fatalf("LoadGlobalConfig failed: %v", err)
+	}
+	if cfg.BackupMaxCount != 0 {
+		t.Errorf("BackupMaxCount = %d, want 0 (explicitly unlimited)", cfg.BackupMaxCount)
+	}
+	if data, _ := os.ReadFile(path); string(data) != current {
+		t.Errorf("current-schema file was rewritten:\n%s", data)
+	}
+}
+
+func TestMigrateAllConfigs(t *testing.T) {
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+
+	if err := os.MkdirAll(ToolsDir(), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(ToolsDir(), "tmux.json"), []byte(`{"prefix": "C-a", "legacy": 1}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	migrations, err := MigrateAllConfigs()
+	if err != nil {
+		t.Fatalf("MigrateAllConfigs failed: %v", err)
+	}
+	if len(migrations) != 1 {
+		t.Fatalf("len(migrations) = %d, want 1 (only tmux.json exists)", len(migrations))
+	}
+	if got := migrations[0].Removed; len(got) != 1 || got[0] != "legacy" {
+		t.Errorf("Removed = %v, want [legacy]", got)
+	}
+
+	tmux, err := LoadToolConfig("tmux", DefaultTmuxConfig)
+	if err != nil {
+		t.Fatalf("LoadToolConfig failed: %v", err)
+	}
+	if def := DefaultTmuxConfig(); tmux.Prefix != "C-a" || tmux.StatusBar != def.StatusBar || tmux.MouseMode != def.MouseMode {
+		t.Errorf("tmux = %+v, want prefix kept and other fields defaulted", tmux)
+	}
+
+	if again, _ := MigrateAllConfigs(); len(again) != 0 {
+		t.Errorf("second run migrated %v, want nothing", again)
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import "sort"
+
+// PinPackage adds a package to the pinned list so updates skip it.
+// Returns false if it was already pinned.
+func PinPackage(name string) (bool, error) {
+	cfg, err := LoadGlobalConfig()
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range cfg.PinnedPackages {
+		if p == name {
+			return false, nil
+		}
+	}
+
+	cfg.PinnedPackages = append(cfg.PinnedPackages, name)
+	sort.Strings(cfg.PinnedPackages)
+	return true, SaveGlobalConfig(cfg)
+}
+
+// UnpinPackage removes a package from the pinned list.
+// Returns false if it wasn't pinned.
+func UnpinPackage(name string) (bool, error) {
+	cfg, err := LoadGlobalConfig()
+	if err != nil {
+		return false, err
+	}
+
+	kept := cfg.PinnedPackages[:0]
+	found := false
+	for _, p := range cfg.PinnedPackages {
+		if p == name {
+			found = true
+			continue
+		}
+		kept = append(kept, p)
+	}
+	if !found {
+		return false, nil
+	}
+
+	cfg.PinnedPackages = kept
+	return true, SaveGlobalConfig(cfg)
+}
+
+// SetSkipUpdateCheck adds (skip=true) or removes a tool ID from the list of
+// tools excluded from update checks.
+func SetSkipUpdateCheck(toolID string, skip bool) error {
+	cfg, err := LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+
+	kept := cfg.SkipUpdateCheck[:0]
+	for _, id := range cfg.SkipUpdateCheck {
+		if id != toolID {
+			kept = append(kept, id)
+		}
+	}
+	if skip {
+		kept = append(kept, toolID)
+		sort.Strings(kept)
+	}
+
+	cfg.SkipUpdateCheck = kept
+	return SaveGlobalConfig(cfg)
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// CorruptError reports a config file that exists but can't be decoded.
+// `dotfiles reset --config` replaces such files with defaults.
+type CorruptError struct {
+	Path string
+	Err  error
+}
+
+func (e *CorruptError) Error() string {
+	return fmt.Sprintf("failed to parse %s: %v", e.Path, e.Err)
+}
+
+func (e *CorruptError) Unwrap() error {
+	return e.Err
+}
+
+// configTypes maps config files (relative to ConfigDir) to a constructor
+// for their defaults, which also gives the type they must decode as
+var configTypes = map[string]func() any{
+	"global.json":          func() any { return DefaultGlobalConfig() },
+	"hotkeys.json":         func() any { return &HotkeysConfig{Users: make(map[string]*UserHotkeys)} },
+	"tools/ghostty.json":   func() any { return DefaultGhosttyConfig() },
+	"tools/tmux.json":      func() any { return DefaultTmuxConfig() },
+	"tools/zsh.json":       func() any { return DefaultZshConfig() },
+	"tools/neovim.json":    func() any { return DefaultNeovimConfig() },
+	"tools/git.json":       func() any { return DefaultGitConfig() },
+	"tools/yazi.json":      func() any { return DefaultYaziConfig() },
+	"tools/fzf.json":       func() any { return DefaultFzfConfig() },
+	"tools/apps.json":      func() any { return DefaultAppsConfig() },
+	"tools/utilities.json": func() any { return DefaultUtilitiesConfig() },
+}
+
+// FindCorruptConfigs checks every JSON file in the config directory (the
+// top level, tools/ and users/) and returns those that can't be decoded:
+// invalid JSON, or values of the wrong type for known files. Results are
+// sorted by path.
+func FindCorruptConfigs() []CorruptError {
+	var found []CorruptError
+	for _, dir := range []string{ConfigDir(), ToolsDir(), UsersDir()} {
+		paths, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+		for _, path := range paths {
+			if err := checkConfigFile(path); err != nil {
+				found = append(found, CorruptError{Path: path, Err: err})
+			}
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Path < found[j].Path })
+	return found
+}
+
+// checkConfigFile decodes a config file as its known type, or as a JSON
+// object if it has none
+func checkConfigFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var target any = &map[string]json.RawMessage{}
+	if newFn := configTypes[configRelPath(path)]; newFn != nil {
+		target = newFn()
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return describeJSONError(data, err)
+	}
+	return nil
+}
+
+// configRelPath returns path relative to ConfigDir, with forward slashes
+func configRelPath(path string) string {
+	rel, err := filepath.Rel(ConfigDir(), path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// DefaultsFor returns the default contents for a known config file, or nil
+// if the file has none here (it is then simply recreated on next save)
+func DefaultsFor(path string) any {
+	if newFn := configTypes[configRelPath(path)]; newFn != nil {
+		return newFn()
+	}
+	return nil
+}
+
+// ResetConfigFile moves a config file aside to <path>.corrupt (numbered if
+// that is taken) and, if defaults is non-nil, writes them in its place.
+// It returns where the old file was moved.
+func ResetConfigFile(path string, defaults any) (string, error) {
+	moved := path + ".corrupt"
+	for i := 1; ; i++ {
+		if _, err := os.Lstat(moved); os.IsNotExist(err) {
+			break
+		}
+		moved = fmt.Sprintf("%s.corrupt.%d", path, i)
+	}
+	if err := os.Rename(path, moved); err != nil {
+		return "", fmt.Errorf("failed to move %s aside: %w", filepath.Base(path), err)
+	}
+	if defaults == nil {
+		return moved, nil
+	}
+	stampSchemaVersion(defaults, SchemaVersion)
+	if err := writeConfigFile(path, defaults); err != nil {
+		return moved, err
+	}
+	return moved, nil
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindCorruptConfigsAndReset(t *testing.T) {
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+
+	if err := EnsureDirs(); err != nil {
+		t.Fatalf("EnsureDirs failed: %v", err)
+	}
+	if err := SaveToolConfig("zsh", DefaultZshConfig()); err != nil {
+		t.Fatalf("SaveToolConfig failed: %v", err)
+	}
+	tmuxPath := filepath.Join(ToolsDir(), "tmux.json")
+	if err := os.WriteFile(tmuxPath, []byte(`{"prefix": "ctrl-a",`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// Valid JSON, wrong type for a known file
+	globalPath := filepath.Join(ConfigDir(), "global.json")
+	if err := os.WriteFile(globalPath, []byte(`{"theme": 42}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	found := FindCorruptConfigs()
+	if len(found) != 2 || found[0].Path != globalPath || found[1].Path != tmuxPath {
+		t.Fatalf("FindCorruptConfigs() = %v, want global.json and tools/tmux.json", found)
+	}
+
+	// One bad tool file doesn't stop the others loading
+	cfgs, err := LoadAllToolConfigs()
+	var ce *CorruptError
+	if !errors.As(err, &ce) || ce.Path != tmuxPath {
+		t.Fatalf("LoadAllToolConfigs() error = %v, want a CorruptError for tmux.json", err)
+	}
+	if cfgs == nil || cfgs.Tmux == nil || cfgs.Zsh == nil {
+		t.Fatal("LoadAllToolConfigs() should return defaults alongside the error")
+	}
+
+	moved, err := ResetConfigFile(tmuxPath, DefaultsFor(tmuxPath))
+	if err != nil {
+		t.Fatalf("ResetConfigFile failed: %v", err)
+	}
+	if moved != tmuxPath+".corrupt" {
+		t.Errorf("moved = %q, want %q", moved, tmuxPath+".corrupt")
+	}
+	if data, err := os.ReadFile(moved); err != nil || string(data) != `{"prefix": "ctrl-a",` {
+		t.Errorf("corrupt copy = %q, %v; want the original contents", data, err)
+	}
+	tmux, err := LoadToolConfig("tmux", DefaultTmuxConfig)
+	if err != nil {
+		t.Fatalf("LoadToolConfig after reset failed: %v", err)
+	}
+	if tmux.Prefix != DefaultTmuxConfig().Prefix {
+		t.Errorf("Prefix = %q, want the default", tmux.Prefix)
+	}
+
+	// A second reset doesn't overwrite the first corrupt copy
+	if err := os.WriteFile(tmuxPath, []byte(`nope`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	moved, err = ResetConfigFile(tmuxPath, nil)
+	if err != nil {
+		t.Fatalf("ResetConfigFile failed: %v", err)
+	}
+	if moved != tmuxPath+".corrupt.1" {
+		t.Errorf("moved = %q, want %q", moved, tmuxPath+".corrupt.1")
+	}
+	if _, err := os.Stat(tmuxPath); !os.IsNotExist(err) {
+		t.Errorf("with nil defaults the file should be left missing, stat err = %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// SnapshotVersion is the current machine snapshot format version
+const SnapshotVersion = 1
+
+// Snapshot records what's needed to reproduce a machine's setup: written by
+// "dotfiles snapshot" and read back by "dotfiles install --from". Tools and
+// Utilities use the same IDs as InstallProfile.Tools.
+type Snapshot struct {
+	SchemaVersion int    `json:"schema_version"`
+	CreatedAt     string `json:"created_at"`
+	Hostname      string `json:"hostname,omitempty"`
+	Platform      string `json:"platform,omitempty"`
+
+	Theme         string `json:"theme"`
+	NavStyle      string `json:"nav_style"`
+	KeyboardStyle string `json:"keyboard_style,omitempty"` // From the active user profile, if any
+
+	Tools     []string `json:"tools"`               // Installed tools the installer can select
+	Utilities []string `json:"utilities,omitempty"` // Helper scripts installed to ~/.local/bin
+
+	// Manage holds the Manage screen settings as saved in tools/manage.json
+	Manage json.RawMessage `json:"manage,omitempty"`
+}
+
+// Selection returns the tool and helper script IDs to select when installing
+// from the snapshot
+func (s *Snapshot) Selection() []string {
+	return append(append([]string(nil), s.Tools...), s.Utilities...)
+}
+
+// LoadSnapshot reads a machine snapshot
+func LoadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", describeJSONError(data, err))
+	}
+	if snap.SchemaVersion > SnapshotVersion {
+		return nil, fmt.Errorf("snapshot format %d is newer than this version of dotfiles supports (%d); upgrade dotfiles", snap.SchemaVersion, SnapshotVersion)
+	}
+	return &snap, nil
+}
+
+// SaveSnapshot writes a machine snapshot to path
+func SaveSnapshot(path string, snap *Snapshot) error {
+	snap.SchemaVersion = SnapshotVersion
+	return writeConfigFile(path, snap)
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadSnapshotRejectsNewerFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "machine.json")
+	if err := os.WriteFile(path, []byte(`{"schema_version": 99, "tools": ["bat"]}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSnapshot(path); err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Errorf("LoadSnapshot() error = %v, want a newer-format error", err)
+	}
+
+	if err := SaveSnapshot(path, &Snapshot{Tools: []string{"bat"}, Utilities: []string{"hk"}}); err != nil {
+		t.Fatalf("SaveSnapshot() failed: %v", err)
+	}
+	snap, err := LoadSnapshot(path)
+	if err != nil {
+		t.Fatalf("LoadSnapshot() failed: %v", err)
+	}
+	if snap.SchemaVersion != SnapshotVersion || strings.Join(snap.Selection(), ",") != "bat,hk" {
+		t.Errorf("snapshot = %+v, want version %d selecting bat,hk", snap, SnapshotVersion)
+	}
+}
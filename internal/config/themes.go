@@ -0,0 +1,97 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// CustomTheme is a user-defined theme, e.g. one generated from a wallpaper
+type CustomTheme struct {
+	Name   string `json:"name"`
+	Light  bool   `json:"light"`            // Light background (tools pick their light variants)
+	Source string `json:"source,omitempty"` // What it was generated from, e.g. an image path
+	// Colors maps palette fields (accent, accent_alt, info, success, warning,
+	// error, bg, surface, overlay, border, text, text_muted, text_bright) to
+	// hex colors. Missing fields fall back to a built-in theme.
+	Colors map[string]string `json:"colors"`
+}
+
+// ThemesDir returns the directory custom themes are stored in
+func ThemesDir() string {
+	return filepath.Join(ConfigDir(), "themes")
+}
+
+// SaveCustomTheme writes a custom theme to ThemesDir/<name>.json. Built-in
+// theme names are reserved.
+func SaveCustomTheme(theme *CustomTheme) error {
+	if err := ValidateProfileName(theme.Name); err != nil {
+		return fmt.Errorf("invalid theme name: %w", err)
+	}
+	if slices.Contains(AvailableThemes, theme.Name) {
+		return fmt.Errorf("%q is a built-in theme; choose another name", theme.Name)
+	}
+	if err := os.MkdirAll(ThemesDir(), 0700); err != nil {
+		return fmt.Errorf("failed to create themes directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(theme, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal theme: %w", err)
+	}
+	return os.WriteFile(filepath.Join(ThemesDir(), theme.Name+".json"), data, 0600)
+}
+
+// LoadCustomThemes reads every custom theme, sorted by name. Themes that
+// can't be read are skipped and reported in the returned error.
+func LoadCustomThemes() ([]*CustomTheme, error) {
+	entries, err := os.ReadDir(ThemesDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var themes []*CustomTheme
+	var bad []string
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok || e.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(ThemesDir(), e.Name()))
+		var theme CustomTheme
+		if err == nil {
+			err = json.Unmarshal(data, &theme)
+		}
+		if err != nil || ValidateProfileName(name) != nil || slices.Contains(AvailableThemes, name) {
+			bad = append(bad, e.Name())
+			continue
+		}
+		theme.Name = name // the file name wins, so the theme is found by it
+		themes = append(themes, &theme)
+	}
+	if len(bad) > 0 {
+		return themes, fmt.Errorf("skipped unreadable themes: %s", strings.Join(bad, ", "))
+	}
+	return themes, nil
+}
+
+// CustomThemeNames returns the names of the custom themes, sorted
+func CustomThemeNames() []string {
+	themes, _ := LoadCustomThemes()
+	names := make([]string, len(themes))
+	for i, t := range themes {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// ThemeNames returns the built-in themes followed by the custom ones
+func ThemeNames() []string {
+	return append(slices.Clone(AvailableThemes), CustomThemeNames()...)
+}
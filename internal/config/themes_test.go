@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestCustomThemeRoundTrip(t *testing.T) {
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+
+	if IsValidTheme("dunes") {
+		t.Fatal("dunes should not be a theme before it is saved")
+	}
+	theme := &CustomTheme{Name: "dunes", Light: true, Source: "/tmp/dunes.jpg", Colors: map[string]string{"accent": "#c87533"}}
+	if err := SaveCustomTheme(theme); err != nil {
+		t.Fatalf("SaveCustomTheme failed: %v", err)
+	}
+
+	themes, err := LoadCustomThemes()
+	if err != nil || len(themes) != 1 {
+		t.Fatalf("LoadCustomThemes() = %v, %v, want one theme", themes, err)
+	}
+	if got := themes[0]; got.Name != "dunes" || !got.Light || got.Colors["accent"] != "#c87533" {
+		t.Errorf("loaded theme = %+v", got)
+	}
+	if !IsValidTheme("dunes") {
+		t.Error("a saved custom theme should be valid")
+	}
+	if names := ThemeNames(); names[len(names)-1] != "dunes" || !slices.Contains(names, "catppuccin-mocha") {
+		t.Errorf("ThemeNames() = %v, want built-ins then dunes", names)
+	}
+}
+
+func TestSaveCustomThemeRejectsBadNames(t *testing.T) {
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+
+	for _, name := range []string{"", "../evil", "catppuccin-mocha"} {
+		if err := SaveCustomTheme(&CustomTheme{Name: name}); err == nil {
+			t.Errorf("SaveCustomTheme(%q) should fail", name)
+		}
+	}
+}
+
+func TestLoadCustomThemesSkipsBadFiles(t *testing.T) {
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+
+	if err := SaveCustomTheme(&CustomTheme{Name: "good"}); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(ThemesDir(), "broken.json"), []byte("{"), 0600)
+	os.WriteFile(filepath.Join(ThemesDir(), "nord.json"), []byte("{}"), 0600)
+	os.WriteFile(filepath.Join(ThemesDir(), "notes.txt"), []byte("hi"), 0600)
+
+	themes, err := LoadCustomThemes()
+	if len(themes) != 1 || themes[0].Name != "good" {
+		t.Errorf("LoadCustomThemes() = %v, want only good", themes)
+	}
+	if err == nil {
+		t.Error("LoadCustomThemes() should report broken.json and nord.json")
+	}
+}
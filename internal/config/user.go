@@ -2,6 +2,7 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -19,6 +20,74 @@ type UserProfile struct {
 	KeyboardStyle string `json:"keyboard_style"` // "macos" or "linux"
 	CreatedAt     string `json:"created_at"`
 	UpdatedAt     string `json:"updated_at"`
+
+	// Hosts overrides settings on particular machines, keyed by hostname
+	// (e.g. a light theme on "work-laptop"), so one synced profile can adapt
+	Hosts map[string]HostOverride `json:"hosts,omitempty"`
+}
+
+// HostOverride is a profile's settings for one machine. Empty fields keep
+// the base profile's value.
+type HostOverride struct {
+	Theme         string `json:"theme,omitempty"`
+	NavStyle      string `json:"nav_style,omitempty"`
+	KeyboardStyle string `json:"keyboard_style,omitempty"`
+}
+
+// valid reports whether every field set in o is a valid value
+func (o HostOverride) valid() bool {
+	return (o.Theme == "" || IsValidTheme(o.Theme)) &&
+		(o.NavStyle == "" || IsValidNavStyle(o.NavStyle)) &&
+		(o.KeyboardStyle == "" || IsValidKeyboardStyle(o.KeyboardStyle))
+}
+
+// ForHost returns the profile as it applies on hostname: a copy with the
+// matching Hosts override laid over the base settings. Hostnames match
+// case-insensitively, either in full or by their first label, so "laptop"
+// also matches "laptop.local"; a full match wins over a first-label one.
+func (p *UserProfile) ForHost(hostname string) *UserProfile {
+	effective := *p
+	short, _, _ := strings.Cut(hostname, ".")
+	o, ok := p.hostOverride(hostname)
+	if !ok {
+		o, ok = p.hostOverride(short)
+	}
+	if !ok {
+		return &effective
+	}
+	if o.Theme != "" {
+		effective.Theme = o.Theme
+	}
+	if o.NavStyle != "" {
+		effective.NavStyle = o.NavStyle
+	}
+	if o.KeyboardStyle != "" {
+		effective.KeyboardStyle = o.KeyboardStyle
+	}
+	return &effective
+}
+
+// hostOverride returns the Hosts entry for host, preferring an exact match
+// and otherwise taking the first case-insensitive one in sorted order, so the
+// result doesn't depend on map iteration
+func (p *UserProfile) hostOverride(host string) (HostOverride, bool) {
+	if host == "" {
+		return HostOverride{}, false
+	}
+	if o, ok := p.Hosts[host]; ok {
+		return o, true
+	}
+	names := make([]string, 0, len(p.Hosts))
+	for name := range p.Hosts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.EqualFold(name, host) {
+			return p.Hosts[name], true
+		}
+	}
+	return HostOverride{}, false
 }
 
 // usernameRegex validates username format
@@ -91,12 +160,121 @@ func LoadUserProfile(name string) (*UserProfile, error) {
 
 	var profile UserProfile
 	if err := json.Unmarshal(data, &profile); err != nil {
-		return nil, fmt.Errorf("failed to parse user profile: %w", err)
+		return nil, fmt.Errorf("failed to parse user profile: %w", describeJSONError(data, err))
 	}
 
 	return &profile, nil
 }
 
+// describeJSONError adds the failing field or line/column to a JSON decode error
+func describeJSONError(data []byte, err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		field := typeErr.Field
+		if field == "" {
+			field = "(root)"
+		}
+		return fmt.Errorf("field %q: expected %s, got JSON %s: %w", field, typeErr.Type, typeErr.Value, err)
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		line, col := 1, 1
+		for i := 0; i < int(syntaxErr.Offset) && i < len(data); i++ {
+			if data[i] == '\n' {
+				line++
+				col = 1
+			} else {
+				col++
+			}
+		}
+		return fmt.Errorf("invalid JSON at line %d, column %d: %w", line, col, err)
+	}
+
+	return err
+}
+
+// RepairUserProfile rewrites a user profile, keeping valid fields and
+// resetting missing, mistyped or invalid ones to defaults. It returns the
+// repaired profile and the JSON names of the fields that were reset.
+func RepairUserProfile(name string) (*UserProfile, []string, error) {
+	if err := ValidateUsername(name); err != nil {
+		return nil, nil, err
+	}
+
+	path := filepath.Join(UsersDir(), name+".json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, fmt.Errorf("user %q does not exist", name)
+		}
+		return nil, nil, fmt.Errorf("failed to read user profile: %w", err)
+	}
+
+	// Decode field-by-field so one bad value doesn't discard the rest.
+	// If the document itself is unreadable, every field is reset.
+	raw := map[string]json.RawMessage{}
+	_ = json.Unmarshal(data, &raw)
+
+	defaults := DefaultUserProfile(name)
+	profile := &UserProfile{Name: name}
+	var repaired []string
+
+	fields := []struct {
+		key   string
+		dst   *string
+		def   string
+		valid func(string) bool
+	}{
+		{"theme", &profile.Theme, defaults.Theme, IsValidTheme},
+		{"nav_style", &profile.NavStyle, defaults.NavStyle, IsValidNavStyle},
+		{"keyboard_style", &profile.KeyboardStyle, defaults.KeyboardStyle, IsValidKeyboardStyle},
+		{"created_at", &profile.CreatedAt, "", validTimestamp},
+	}
+	for _, f := range fields {
+		var v string
+		if msg, ok := raw[f.key]; ok && json.Unmarshal(msg, &v) == nil && f.valid(v) {
+			*f.dst = v
+			continue
+		}
+		*f.dst = f.def
+		repaired = append(repaired, f.key)
+	}
+
+	// Keep the host overrides that are valid
+	if msg, ok := raw["hosts"]; ok {
+		var hosts map[string]HostOverride
+		if json.Unmarshal(msg, &hosts) != nil {
+			repaired = append(repaired, "hosts")
+		}
+		for host, o := range hosts {
+			if !o.valid() {
+				delete(hosts, host)
+				repaired = append(repaired, "hosts."+host)
+			}
+		}
+		if len(hosts) > 0 {
+			profile.Hosts = hosts
+		}
+	}
+
+	var storedName string
+	if msg, ok := raw["name"]; !ok || json.Unmarshal(msg, &storedName) != nil || storedName != name {
+		repaired = append(repaired, "name")
+	}
+
+	if err := SaveUserProfile(profile); err != nil {
+		return nil, nil, err
+	}
+	return profile, repaired, nil
+}
+
+// validTimestamp reports whether s is an RFC3339 timestamp
+func validTimestamp(s string) bool {
+	_, err := time.Parse(time.RFC3339, s)
+	return err == nil
+}
+
 // SaveUserProfile saves a user profile to disk
 func SaveUserProfile(profile *UserProfile) error {
 	if err := ValidateUsername(profile.Name); err != nil {
@@ -119,9 +297,11 @@ func SaveUserProfile(profile *UserProfile) error {
 		return fmt.Errorf("failed to marshal user profile: %w", err)
 	}
 
+	before, _ := os.ReadFile(path)
 	if err := os.WriteFile(path, data, 0600); err != nil {
 		return fmt.Errorf("failed to write user profile: %w", err)
 	}
+	recordConfigChange(path, before)
 
 	return nil
 }
@@ -170,6 +350,36 @@ func ListUserProfiles() ([]string, error) {
 	return users, nil
 }
 
+// MatchUserProfiles finds profiles matching a quick-switch query.
+// An exact match wins, then a case-insensitive exact match, then
+// case-insensitive prefix matches (sorted). Multiple results mean the
+// query is ambiguous.
+func MatchUserProfiles(query string) ([]string, error) {
+	users, err := ListUserProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	lower := strings.ToLower(query)
+	var folded, prefixed []string
+	for _, u := range users {
+		if u == query {
+			return []string{u}, nil
+		}
+		lu := strings.ToLower(u)
+		if lu == lower {
+			folded = append(folded, u)
+		} else if strings.HasPrefix(lu, lower) {
+			prefixed = append(prefixed, u)
+		}
+	}
+
+	if len(folded) > 0 {
+		return folded, nil
+	}
+	return prefixed, nil
+}
+
 // DefaultUserProfile returns a new profile with default settings
 func DefaultUserProfile(name string) *UserProfile {
 	return &UserProfile{
@@ -180,13 +390,17 @@ func DefaultUserProfile(name string) *UserProfile {
 	}
 }
 
-// ApplyUserProfile applies a user profile's settings to the global config
+// ApplyUserProfile applies a user profile's settings to the global config,
+// with any override for this machine's hostname laid over them. The keyboard
+// style has no global setting; read it through GetActiveUserForHost.
 func ApplyUserProfile(profile *UserProfile) error {
 	cfg, err := LoadGlobalConfig()
 	if err != nil {
 		return err
 	}
 
+	profile = profile.forThisHost()
+
 	cfg.Theme = profile.Theme
 	cfg.NavStyle = profile.NavStyle
 	cfg.ActiveUser = profile.Name
@@ -212,6 +426,35 @@ func GetActiveUser() (*UserProfile, error) {
 	return LoadUserProfile(cfg.ActiveUser)
 }
 
+// GetActiveUserForHost returns the active user profile as it applies on this
+// machine, with its Hosts override laid over it. Use it to read settings;
+// changes are saved to the GetActiveUser profile, so an override never leaks
+// into the base settings.
+func GetActiveUserForHost() (*UserProfile, error) {
+	profile, err := GetActiveUser()
+	if err != nil || profile == nil {
+		return profile, err
+	}
+	return profile.forThisHost(), nil
+}
+
+// forThisHost returns the profile as it applies on this machine
+func (p *UserProfile) forThisHost() *UserProfile {
+	if hostname, err := os.Hostname(); err == nil {
+		return p.ForHost(hostname)
+	}
+	return p
+}
+
+// ActiveUsername returns the active user's name, or "default" if none is set
+func ActiveUsername() string {
+	cfg, err := LoadGlobalConfig()
+	if err != nil || cfg == nil || cfg.ActiveUser == "" {
+		return "default"
+	}
+	return cfg.ActiveUser
+}
+
 // ClearActiveUser clears the active user setting
 func ClearActiveUser() error {
 	cfg, err := LoadGlobalConfig()
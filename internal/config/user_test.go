@@ -224,6 +224,42 @@ func TestUserProfileCRUD(t *testing.T) {
 	}
 }
 
+func TestMatchUserProfiles(t *testing.T) {
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+
+	for _, name := range []string{"Alice", "Alex", "bob", "Bobby"} {
+		if err := SaveUserProfile(DefaultUserProfile(name)); err != nil {
+			t.Fatalf("SaveUserProfile(%q) failed: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"Alice", []string{"Alice"}},      // exact
+		{"alice", []string{"Alice"}},      // case-insensitive
+		{"ali", []string{"Alice"}},        // unique prefix
+		{"al", []string{"Alex", "Alice"}}, // ambiguous prefix
+		{"bob", []string{"bob"}},          // exact beats prefix of Bobby
+		{"BOB", []string{"bob"}},          // folded exact beats prefix
+		{"carol", nil},                    // no match
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := MatchUserProfiles(tt.query)
+			if err != nil {
+				t.Fatalf("MatchUserProfiles(%q) failed: %v", tt.query, err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("MatchUserProfiles(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestLoadUserProfile_Errors(t *testing.T) {
 	_, cleanup := setupTestConfigDir(t)
 	defer cleanup()
@@ -287,6 +323,69 @@ func TestApplyUserProfile(t *testing.T) {
 	}
 }
 
+func TestUserProfileForHost(t *testing.T) {
+	profile := DefaultUserProfile("testuser")
+	profile.Hosts = map[string]HostOverride{
+		"work-laptop": {Theme: "catppuccin-latte"},
+		"desktop":     {NavStyle: "vim"},
+	}
+
+	work := profile.ForHost("Work-Laptop.local")
+	if work.Theme != "catppuccin-latte" || work.NavStyle != "emacs" {
+		t.Errorf("work-laptop: theme %q nav %q, want the theme overridden only", work.Theme, work.NavStyle)
+	}
+	if profile.Theme != "catppuccin-mocha" {
+		t.Error("ForHost should not modify the base profile")
+	}
+	if other := profile.ForHost("elsewhere"); other.Theme != profile.Theme || other.NavStyle != profile.NavStyle {
+		t.Error("an unlisted host should get the base profile")
+	}
+
+	// The full hostname wins over its first label, every time
+	profile.Hosts["laptop"] = HostOverride{Theme: "nord"}
+	profile.Hosts["laptop.example.com"] = HostOverride{Theme: "dracula"}
+	for i := 0; i < 20; i++ {
+		if got := profile.ForHost("laptop.example.com").Theme; got != "dracula" {
+			t.Fatalf("laptop.example.com: theme %q, want the full-name override", got)
+		}
+	}
+	if got := profile.ForHost("laptop.local").Theme; got != "nord" {
+		t.Errorf("laptop.local: theme %q, want the first-label override", got)
+	}
+
+	// ApplyUserProfile overlays the override for this machine
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Skip("no hostname")
+	}
+	profile.Hosts[hostname] = HostOverride{Theme: "nord"}
+	if err := ApplyUserProfile(profile); err != nil {
+		t.Fatalf("ApplyUserProfile failed: %v", err)
+	}
+	cfg, err := LoadGlobalConfig()
+	if err != nil {
+		t.Fatalf("LoadGlobalConfig failed: %v", err)
+	}
+	if cfg.Theme != "nord" || cfg.NavStyle != "emacs" {
+		t.Errorf("global theme %q nav %q, want this host's nord over emacs", cfg.Theme, cfg.NavStyle)
+	}
+
+	// The keyboard style is read from the profile with this host's override
+	profile.Hosts[hostname] = HostOverride{KeyboardStyle: "macos"}
+	if err := SaveUserProfile(profile); err != nil {
+		t.Fatal(err)
+	}
+	user, err := GetActiveUserForHost()
+	if err != nil || user == nil || user.KeyboardStyle != "macos" {
+		t.Errorf("GetActiveUserForHost() = %+v, %v; want this host's macos keyboard", user, err)
+	}
+	if base, _ := GetActiveUser(); base == nil || base.KeyboardStyle != "linux" {
+		t.Errorf("GetActiveUser() = %+v, want the base linux keyboard", base)
+	}
+}
+
 func TestGetActiveUser(t *testing.T) {
 	_, cleanup := setupTestConfigDir(t)
 	defer cleanup()
@@ -349,3 +448,69 @@ func TestClearActiveUser(t *testing.T) {
 		t.Errorf("ActiveUser = %q, want empty", cfg.ActiveUser)
 	}
 }
+
+func TestLoadUserProfile_ParseErrorDetail(t *testing.T) {
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+
+	path := filepath.Join(UsersDir(), "alice.json")
+	os.WriteFile(path, []byte(`{"name": "alice", "theme": 42}`), 0600)
+
+	_, err := LoadUserProfile("alice")
+	if err == nil {
+		t.Fatal("expected parse error")
+	}
+	if !strings.Contains(err.Error(), `field "theme"`) {
+		t.Errorf("error %q should name the failing field", err)
+	}
+
+	os.WriteFile(path, []byte("{\n  \"name\": \"alice\",\n  oops\n}"), 0600)
+	_, err = LoadUserProfile("alice")
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("error %v should report the line number", err)
+	}
+}
+
+func TestRepairUserProfile(t *testing.T) {
+	_, cleanup := setupTestConfigDir(t)
+	defer cleanup()
+
+	path := filepath.Join(UsersDir(), "alice.json")
+	os.WriteFile(path, []byte(`{"name": "alice", "theme": "dracula", "nav_style": 7, "keyboard_style": "qwerty",
+		"hosts": {"work": {"theme": "nord"}, "home": {"nav_style": "helix"}}}`), 0600)
+
+	profile, repaired, err := RepairUserProfile("alice")
+	if err != nil {
+		t.Fatalf("RepairUserProfile failed: %v", err)
+	}
+	if profile.Theme != "dracula" {
+		t.Errorf("Theme = %q, want valid value preserved", profile.Theme)
+	}
+	if profile.NavStyle != "emacs" {
+		t.Errorf("NavStyle = %q, want default", profile.NavStyle)
+	}
+	if profile.KeyboardStyle != "linux" {
+		t.Errorf("KeyboardStyle = %q, want default", profile.KeyboardStyle)
+	}
+
+	got := strings.Join(repaired, ",")
+	for _, field := range []string{"nav_style", "keyboard_style", "created_at"} {
+		if !strings.Contains(got, field) {
+			t.Errorf("repaired = %v, want to include %s", repaired, field)
+		}
+	}
+	if strings.Contains(got, "theme") {
+		t.Errorf("repaired = %v, should not include theme", repaired)
+	}
+	if profile.Hosts["work"].Theme != "nord" || !strings.Contains(got, "hosts.home") {
+		t.Errorf("hosts = %v, repaired = %v; want the valid override kept and home dropped", profile.Hosts, repaired)
+	}
+
+	if _, err := LoadUserProfile("alice"); err != nil {
+		t.Errorf("profile should load after repair: %v", err)
+	}
+
+	if _, _, err := RepairUserProfile("nobody"); err == nil {
+		t.Error("repairing a missing profile should fail")
+	}
+}
@@ -0,0 +1,189 @@
+// Package diagnostics builds a redacted zip bundle for bug reports.
+package diagnostics
+
+import (
+	"archive/zip"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/pkg"
+	"github.com/tekierz/dotfiles/internal/tools"
+)
+
+// Options controls what goes into a diagnostics bundle
+type Options struct {
+	Out          string // Destination zip path
+	Version      string // dotfiles version reported in env.txt
+	IncludeEmail bool   // Keep email addresses (e.g. git user.email) unredacted
+}
+
+// Redacted replaces any value considered sensitive
+const Redacted = "[REDACTED]"
+
+// maxFileSize skips unexpectedly large files in the config dir
+const maxFileSize = 1 << 20
+
+var (
+	// secretKeyPattern matches `"key": "value"`, `key = value` and `KEY=value`
+	// assignments whose key name suggests a credential.
+	secretKeyPattern = regexp.MustCompile(`(?i)("?[\w.-]*(?:token|secret|passw(?:or)?d|api[_-]?key|auth|credential|private[_-]?key)[\w.-]*"?\s*[:=]\s*)("[^"]*"|'[^']*'|[^\s,}]+)`)
+
+	// secretValuePatterns match well-known token formats regardless of key
+	secretValuePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`gh[pousr]_[A-Za-z0-9]{20,}`),
+		regexp.MustCompile(`github_pat_[A-Za-z0-9_]{20,}`),
+		regexp.MustCompile(`sk-[A-Za-z0-9_-]{20,}`),
+		regexp.MustCompile(`AKIA[0-9A-Z]{16}`),
+		regexp.MustCompile(`xox[abprs]-[A-Za-z0-9-]{10,}`),
+	}
+
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+// Redact strips anything that looks like a secret from text.
+// Email addresses are redacted unless includeEmail is set.
+func Redact(text string, includeEmail bool) string {
+	text = secretKeyPattern.ReplaceAllStringFunc(text, func(m string) string {
+		parts := secretKeyPattern.FindStringSubmatch(m)
+		value := parts[2]
+		if strings.HasPrefix(value, `"`) {
+			return parts[1] + `"` + Redacted + `"`
+		}
+		return parts[1] + Redacted
+	})
+	for _, re := range secretValuePatterns {
+		text = re.ReplaceAllString(text, Redacted)
+	}
+	if !includeEmail {
+		text = emailPattern.ReplaceAllString(text, Redacted)
+	}
+	return text
+}
+
+// Create writes a diagnostics bundle to opts.Out containing environment info
+// and the redacted contents of the dotfiles config directory (including any
+// logs or history stored there). Backups are excluded since they hold raw
+// copies of user dotfiles. Returns the list of files added.
+func Create(opts Options) ([]string, error) {
+	if opts.Out == "" {
+		return nil, fmt.Errorf("output path is required")
+	}
+
+	f, err := os.OpenFile(opts.Out, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bundle: %w", err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	var added []string
+
+	add := func(name, content string) error {
+		w, err := zw.Create(name)
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %w", name, err)
+		}
+		if _, err := w.Write([]byte(Redact(content, opts.IncludeEmail))); err != nil {
+			return fmt.Errorf("failed to add %s: %w", name, err)
+		}
+		added = append(added, name)
+		return nil
+	}
+
+	if err := add("env.txt", EnvInfo(opts.Version)); err != nil {
+		return nil, err
+	}
+
+	files, err := configFiles(config.ConfigDir())
+	if err != nil {
+		return nil, err
+	}
+	for _, rel := range files {
+		data, err := os.ReadFile(filepath.Join(config.ConfigDir(), rel))
+		if err != nil {
+			continue
+		}
+		if err := add(filepath.ToSlash(filepath.Join("config", rel)), string(data)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize bundle: %w", err)
+	}
+	return added, nil
+}
+
+// configFiles lists regular files under dir (relative, sorted), skipping backups
+func configFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		if d.IsDir() {
+			if rel == "backups" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if info, err := d.Info(); err != nil || info.Size() > maxFileSize {
+			return nil
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config directory: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// EnvInfo describes the runtime environment for bug reports
+func EnvInfo(version string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Generated:  %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&b, "Version:    %s\n", version)
+	fmt.Fprintf(&b, "OS/Arch:    %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "Go:         %s\n", runtime.Version())
+	fmt.Fprintf(&b, "Platform:   %s\n", pkg.DetectPlatform())
+	mgrName := "none"
+	if mgr := pkg.DetectManager(); mgr != nil {
+		mgrName = mgr.Name()
+	}
+	fmt.Fprintf(&b, "Manager:    %s\n", mgrName)
+	fmt.Fprintf(&b, "Config dir: %s\n", config.ConfigDir())
+	for _, key := range []string{"SHELL", "TERM", "TERM_PROGRAM", "COLORTERM", "TMUX", "LANG"} {
+		fmt.Fprintf(&b, "%-11s %s\n", key+":", os.Getenv(key))
+	}
+
+	registry := tools.GetRegistry()
+	var installed []string
+	for _, t := range registry.Installed() {
+		installed = append(installed, t.ID())
+	}
+	sort.Strings(installed)
+	fmt.Fprintf(&b, "\nInstalled tools (%d/%d):\n", len(installed), registry.CountForPlatform())
+	for _, id := range installed {
+		fmt.Fprintf(&b, "  %s\n", id)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,139 @@
+// Package diff computes simple line-based diffs for previews in the TUI and CLI.
+package diff
+
+import "strings"
+
+// Op is the kind of change a diff line represents
+type Op byte
+
+const (
+	Equal  Op = ' '
+	Delete Op = '-'
+	Insert Op = '+'
+)
+
+// Line is a single line of a diff
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// String renders the line in unified-diff style ("+ text", "- text", "  text")
+func (l Line) String() string {
+	return string(l.Op) + " " + l.Text
+}
+
+// maxCells bounds the LCS table; larger inputs fall back to delete-all/insert-all
+const maxCells = 4_000_000
+
+// Lines returns the line diff turning old into new
+func Lines(old, new string) []Line {
+	a := splitLines(old)
+	b := splitLines(new)
+
+	if len(a)*len(b) > maxCells {
+		out := make([]Line, 0, len(a)+len(b))
+		for _, s := range a {
+			out = append(out, Line{Delete, s})
+		}
+		for _, s := range b {
+			out = append(out, Line{Insert, s})
+		}
+		return out
+	}
+
+	// lcs[i][j] = length of the LCS of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out []Line
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, Line{Equal, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, Line{Delete, a[i]})
+			i++
+		default:
+			out = append(out, Line{Insert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, Line{Delete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		out = append(out, Line{Insert, b[j]})
+	}
+	return out
+}
+
+// Changed reports whether a diff contains any insertions or deletions
+func Changed(lines []Line) bool {
+	for _, l := range lines {
+		if l.Op != Equal {
+			return true
+		}
+	}
+	return false
+}
+
+// Stats counts inserted and deleted lines
+func Stats(lines []Line) (added, removed int) {
+	for _, l := range lines {
+		switch l.Op {
+		case Insert:
+			added++
+		case Delete:
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// Hunks groups a diff's changes with up to context unchanged lines around
+// each, like a unified diff. Changes closer than 2*context lines share a hunk.
+func Hunks(lines []Line, context int) [][]Line {
+	var hunks [][]Line
+	start, end := -1, -1
+	for i, l := range lines {
+		if l.Op == Equal {
+			continue
+		}
+		from := max(0, i-context)
+		if start >= 0 && from > end {
+			hunks = append(hunks, lines[start:end])
+			start = -1
+		}
+		if start < 0 {
+			start = from
+		}
+		end = min(len(lines), i+context+1)
+	}
+	if start >= 0 {
+		hunks = append(hunks, lines[start:end])
+	}
+	return hunks
+}
+
+// splitLines splits text into lines, ignoring a single trailing newline
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
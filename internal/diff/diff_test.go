@@ -0,0 +1,70 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want string
+	}{
+		{"identical", "a\nb\n", "a\nb\n", "  a|  b"},
+		{"insert", "a\nc\n", "a\nb\nc\n", "  a|+ b|  c"},
+		{"delete", "a\nb\nc\n", "a\nc\n", "  a|- b|  c"},
+		{"replace", "a\nold\n", "a\nnew\n", "  a|- old|+ new"},
+		{"from empty", "", "x\n", "+ x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var parts []string
+			for _, l := range Lines(tt.old, tt.new) {
+				parts = append(parts, l.String())
+			}
+			if got := strings.Join(parts, "|"); got != tt.want {
+				t.Errorf("Lines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStats(t *testing.T) {
+	lines := Lines("a\nb\nc\n", "a\nB\nc\nd\n")
+
+	added, removed := Stats(lines)
+	if added != 2 || removed != 1 {
+		t.Errorf("Stats() = +%d -%d, want +2 -1", added, removed)
+	}
+	if !Changed(lines) {
+		t.Error("Changed() = false, want true")
+	}
+	if Changed(Lines("same", "same")) {
+		t.Error("Changed() = true for identical input")
+	}
+}
+
+func TestHunks(t *testing.T) {
+	lines := Lines("1\n2\n3\n4\n5\n6\n7\n8\n", "1\nX\n3\n4\n5\n6\n7\nY\n")
+
+	hunks := Hunks(lines, 1)
+	if len(hunks) != 2 {
+		t.Fatalf("len(Hunks(1)) = %d, want 2", len(hunks))
+	}
+	var first []string
+	for _, l := range hunks[0] {
+		first = append(first, l.String())
+	}
+	if got := strings.Join(first, "|"); got != "  1|- 2|+ X|  3" {
+		t.Errorf("hunks[0] = %q", got)
+	}
+	if len(Hunks(lines, 3)) != 1 {
+		t.Error("Hunks(3) should merge changes 5 lines apart")
+	}
+	if Hunks(Lines("same", "same"), 3) != nil {
+		t.Error("Hunks() of identical input should be nil")
+	}
+}
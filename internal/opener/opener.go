@@ -0,0 +1,52 @@
+// Package opener opens directories in the desktop file manager or, from a
+// terminal, in yazi, and links in the default browser.
+package opener
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// ErrUnavailable is returned when there is nothing to open a directory with,
+// e.g. on a headless machine without yazi
+var ErrUnavailable = errors.New("no file manager available")
+
+// FileManager returns a command that opens dir in the OS file manager (open
+// on macOS, xdg-open on Linux). Linux without a display is treated as
+// headless, since xdg-open would only fall back to a terminal browser there.
+func FileManager(dir string) (*exec.Cmd, error) {
+	return desktopOpen(dir)
+}
+
+// Browser returns a command that opens url in the default browser, with the
+// same headless handling as FileManager
+func Browser(url string) (*exec.Cmd, error) {
+	return desktopOpen(url)
+}
+
+// desktopOpen returns a command that hands target to the desktop's opener
+func desktopOpen(target string) (*exec.Cmd, error) {
+	name := "xdg-open"
+	if runtime.GOOS == "darwin" {
+		name = "open"
+	} else if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+		return nil, ErrUnavailable
+	}
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return nil, ErrUnavailable
+	}
+	return exec.Command(path, target), nil
+}
+
+// Yazi returns a command that browses dir in yazi. It takes over the
+// terminal, so it must run in the foreground.
+func Yazi(dir string) (*exec.Cmd, error) {
+	path, err := exec.LookPath("yazi")
+	if err != nil {
+		return nil, ErrUnavailable
+	}
+	return exec.Command(path, dir), nil
+}
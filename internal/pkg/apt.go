@@ -37,7 +37,7 @@ func (a *AptManager) Install(packages ...string) error {
 	args := []string{"apt", "install", "-y"}
 	args = append(args, packages...)
 	cmd := exec.Command("sudo", args...)
-	return cmd.Run()
+	return run(cmd)
 }
 
 func (a *AptManager) Uninstall(packages ...string) error {
@@ -48,7 +48,7 @@ func (a *AptManager) Uninstall(packages ...string) error {
 	args := []string{"apt", "remove", "-y"}
 	args = append(args, packages...)
 	cmd := exec.Command("sudo", args...)
-	return cmd.Run()
+	return run(cmd)
 }
 
 func (a *AptManager) IsInstalled(pkg string) bool {
@@ -62,7 +62,7 @@ func (a *AptManager) GetVersion(pkg string) (string, error) {
 	cmd.Stdout = &out
 
 	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("package %s not installed", pkg)
+		return "", fmt.Errorf("%s: %w", pkg, ErrNotInstalled)
 	}
 
 	// Parse dpkg output for Version line
@@ -138,19 +138,19 @@ func (a *AptManager) Update(packages ...string) error {
 	args := []string{"apt", "install", "-y"}
 	args = append(args, packages...)
 	cmd := exec.Command("sudo", args...)
-	return cmd.Run()
+	return run(cmd)
 }
 
 func (a *AptManager) UpdateAll() error {
 	// Update package lists
 	updateCmd := exec.Command("sudo", "apt", "update")
-	if err := updateCmd.Run(); err != nil {
+	if err := run(updateCmd); err != nil {
 		return err
 	}
 
 	// Upgrade all packages
 	upgradeCmd := exec.Command("sudo", "apt", "upgrade", "-y")
-	return upgradeCmd.Run()
+	return run(upgradeCmd)
 }
 
 func (a *AptManager) Search(query string) ([]Package, error) {
@@ -260,7 +260,7 @@ func (a *AptManager) NeedsSudo() bool {
 // InstallStreaming installs packages with real-time output streaming
 func (a *AptManager) InstallStreaming(ctx context.Context, packages ...string) (*runner.StreamingCmd, error) {
 	if len(packages) == 0 {
-		return nil, fmt.Errorf("no packages specified")
+		return nil, ErrNoPackages
 	}
 
 	args := []string{"install", "-y"}
@@ -268,10 +268,21 @@ func (a *AptManager) InstallStreaming(ctx context.Context, packages ...string) (
 	return runner.RunStreamingWithSudo(ctx, a.aptPath, args...)
 }
 
+// ReinstallStreaming reinstalls packages with real-time output streaming
+func (a *AptManager) ReinstallStreaming(ctx context.Context, packages ...string) (*runner.StreamingCmd, error) {
+	if len(packages) == 0 {
+		return nil, ErrNoPackages
+	}
+
+	args := []string{"install", "--reinstall", "-y"}
+	args = append(args, packages...)
+	return runner.RunStreamingWithSudo(ctx, a.aptPath, args...)
+}
+
 // UpdateStreaming updates packages with real-time output streaming
 func (a *AptManager) UpdateStreaming(ctx context.Context, packages ...string) (*runner.StreamingCmd, error) {
 	if len(packages) == 0 {
-		return nil, fmt.Errorf("no packages specified")
+		return nil, ErrNoPackages
 	}
 
 	args := []string{"install", "-y"}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
 	"strings"
 
@@ -16,9 +17,39 @@ type BrewManager struct {
 	brewPath string
 }
 
+// BrewLocations are where Homebrew installs itself (Apple Silicon, Intel
+// macOS, Linux). A fresh install isn't on PATH until its shellenv line is
+// added to the shell config.
+var BrewLocations = []string{
+	"/opt/homebrew/bin/brew",
+	"/usr/local/bin/brew",
+	"/home/linuxbrew/.linuxbrew/bin/brew",
+}
+
+// FindBrew returns the path to brew, falling back to BrewLocations when it
+// isn't on PATH; onPath reports whether PATH lookup found it. path is ""
+// when Homebrew isn't installed.
+func FindBrew() (path string, onPath bool) {
+	if path, err := exec.LookPath("brew"); err == nil {
+		return path, true
+	}
+	return BrewInstallLocation(), false
+}
+
+// BrewInstallLocation returns the first of BrewLocations where brew is
+// installed, whether or not it is on PATH, and "" otherwise
+func BrewInstallLocation() string {
+	for _, loc := range BrewLocations {
+		if info, err := os.Stat(loc); err == nil && !info.IsDir() && info.Mode()&0111 != 0 {
+			return loc
+		}
+	}
+	return ""
+}
+
 // NewBrewManager creates a new Homebrew manager
 func NewBrewManager() *BrewManager {
-	path, _ := exec.LookPath("brew")
+	path, _ := FindBrew()
 	return &BrewManager{brewPath: path}
 }
 
@@ -38,8 +69,7 @@ func (b *BrewManager) Install(packages ...string) error {
 	args := append([]string{"install"}, packages...)
 	cmd := exec.Command(b.brewPath, args...)
 	cmd.Stdout = nil
-	cmd.Stderr = nil
-	return cmd.Run()
+	return run(cmd)
 }
 
 func (b *BrewManager) Uninstall(packages ...string) error {
@@ -49,7 +79,7 @@ func (b *BrewManager) Uninstall(packages ...string) error {
 
 	args := append([]string{"uninstall"}, packages...)
 	cmd := exec.Command(b.brewPath, args...)
-	return cmd.Run()
+	return run(cmd)
 }
 
 func (b *BrewManager) IsInstalled(pkg string) bool {
@@ -94,7 +124,7 @@ func (b *BrewManager) GetVersion(pkg string) (string, error) {
 		return info.Casks[0].Installed, nil
 	}
 
-	return "", fmt.Errorf("package %s not installed", pkg)
+	return "", fmt.Errorf("%s: %w", pkg, ErrNotInstalled)
 }
 
 func (b *BrewManager) CheckOutdated() ([]Package, error) {
@@ -159,12 +189,12 @@ func (b *BrewManager) Update(packages ...string) error {
 
 	args := append([]string{"upgrade"}, packages...)
 	cmd := exec.Command(b.brewPath, args...)
-	return cmd.Run()
+	return run(cmd)
 }
 
 func (b *BrewManager) UpdateAll() error {
 	cmd := exec.Command(b.brewPath, "upgrade")
-	return cmd.Run()
+	return run(cmd)
 }
 
 func (b *BrewManager) Search(query string) ([]Package, error) {
@@ -223,17 +253,27 @@ func (b *BrewManager) NeedsSudo() bool {
 // InstallStreaming installs packages with real-time output streaming
 func (b *BrewManager) InstallStreaming(ctx context.Context, packages ...string) (*runner.StreamingCmd, error) {
 	if len(packages) == 0 {
-		return nil, fmt.Errorf("no packages specified")
+		return nil, ErrNoPackages
 	}
 
 	args := append([]string{"install"}, packages...)
 	return runner.RunStreaming(ctx, b.brewPath, args...)
 }
 
+// ReinstallStreaming reinstalls packages with real-time output streaming
+func (b *BrewManager) ReinstallStreaming(ctx context.Context, packages ...string) (*runner.StreamingCmd, error) {
+	if len(packages) == 0 {
+		return nil, ErrNoPackages
+	}
+
+	args := append([]string{"reinstall"}, packages...)
+	return runner.RunStreaming(ctx, b.brewPath, args...)
+}
+
 // UpdateStreaming updates packages with real-time output streaming
 func (b *BrewManager) UpdateStreaming(ctx context.Context, packages ...string) (*runner.StreamingCmd, error) {
 	if len(packages) == 0 {
-		return nil, fmt.Errorf("no packages specified")
+		return nil, ErrNoPackages
 	}
 
 	args := append([]string{"upgrade"}, packages...)
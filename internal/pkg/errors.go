@@ -0,0 +1,106 @@
+package pkg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Sentinel errors returned (wrapped) by the package layer. Use errors.Is to
+// test for them.
+var (
+	ErrNoManager          = errors.New("no package manager available")
+	ErrNoPackages         = errors.New("no packages specified")
+	ErrNotInstalled       = errors.New("package not installed")
+	ErrPackageNotFound    = errors.New("package not found")
+	ErrNeedsSudo          = errors.New("sudo authentication failed")
+	ErrNetwork            = errors.New("network error")
+	ErrVersionUnsupported = errors.New("package manager can't install a specific version")
+)
+
+// outputPatterns maps lowercase substrings of package manager output to the
+// error they indicate
+var outputPatterns = []struct {
+	substr string
+	err    error
+}{
+	// sudo
+	{"a password is required", ErrNeedsSudo},
+	{"a terminal is required", ErrNeedsSudo},
+	{"incorrect password attempt", ErrNeedsSudo},
+	{"is not in the sudoers file", ErrNeedsSudo},
+	{"no tty present", ErrNeedsSudo},
+	// network
+	{"temporary failure resolving", ErrNetwork},
+	{"could not resolve", ErrNetwork},
+	{"failed to fetch", ErrNetwork},
+	{"failed retrieving file", ErrNetwork},
+	{"connection timed out", ErrNetwork},
+	{"connection refused", ErrNetwork},
+	{"network is unreachable", ErrNetwork},
+	// missing package
+	{"unable to locate package", ErrPackageNotFound},
+	{"target not found", ErrPackageNotFound},
+	{"no available formula", ErrPackageNotFound},
+	{"no formulae or casks found", ErrPackageNotFound},
+	{"no cask with this name", ErrPackageNotFound},
+}
+
+// Classify wraps err with the sentinel matching the command's output, so
+// callers can tell e.g. a network failure from a missing package. err is
+// returned unchanged if it is nil or the output is not recognized.
+func Classify(err error, output string) error {
+	if err == nil {
+		return nil
+	}
+	lower := strings.ToLower(output)
+	for _, p := range outputPatterns {
+		if strings.Contains(lower, p.substr) && !errors.Is(err, p.err) {
+			return fmt.Errorf("%w: %w", p.err, err)
+		}
+	}
+	return err
+}
+
+// run runs cmd, classifying any failure by what it wrote to stderr
+func run(cmd *exec.Cmd) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	return Classify(cmd.Run(), stderr.String())
+}
+
+// IsRetryable reports whether retrying the operation that returned err could
+// succeed. Missing managers and packages are permanent; anything else
+// (network trouble, a failed sudo prompt, unknown failures) may be transient.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, permanent := range []error{ErrNoManager, ErrNoPackages, ErrNotInstalled, ErrPackageNotFound, ErrVersionUnsupported} {
+		if errors.Is(err, permanent) {
+			return false
+		}
+	}
+	return true
+}
+
+// Hint returns a one-line suggestion for resolving err, or "" if there is none
+func Hint(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrNoManager):
+		return "Install Homebrew (macOS), pacman/paru (Arch) or apt (Debian) and try again."
+	case errors.Is(err, ErrNeedsSudo):
+		return "Run `sudo -v` in a terminal to cache your password, then retry."
+	case errors.Is(err, ErrNetwork):
+		return "Check your internet connection and retry."
+	case errors.Is(err, ErrPackageNotFound):
+		return "The package is not in your package manager's repositories; refresh its index or install it manually."
+	case errors.Is(err, ErrVersionUnsupported):
+		return "Install without a version, or pin the current one with `dotfiles update pin`."
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	base := errors.New("exit status 100")
+
+	tests := []struct {
+		name   string
+		output string
+		want   error
+	}{
+		{"apt missing package", "E: Unable to locate package nosuchtool", ErrPackageNotFound},
+		{"pacman missing package", "error: target not found: nosuchtool", ErrPackageNotFound},
+		{"brew missing formula", "Error: No available formula with the name \"nosuchtool\".", ErrPackageNotFound},
+		{"sudo without tty", "sudo: a terminal is required to read the password", ErrNeedsSudo},
+		{"sudo wrong password", "sudo: 3 incorrect password attempts", ErrNeedsSudo},
+		{"dns failure", "Temporary failure resolving 'deb.debian.org'", ErrNetwork},
+		{"fetch failure", "E: Failed to fetch http://deb.debian.org/pool/main/t/tmux.deb", ErrNetwork},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Classify(base, tt.output)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Classify() = %v, want %v", err, tt.want)
+			}
+			if !errors.Is(err, base) {
+				t.Errorf("Classify() = %v, lost the original error", err)
+			}
+		})
+	}
+}
+
+func TestClassifyUnrecognized(t *testing.T) {
+	base := errors.New("exit status 1")
+	if err := Classify(base, "something else went wrong"); err != base {
+		t.Errorf("Classify() = %v, want the original error", err)
+	}
+	if err := Classify(nil, "E: Unable to locate package"); err != nil {
+		t.Errorf("Classify(nil) = %v, want nil", err)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{ErrNetwork, true},
+		{ErrNeedsSudo, true},
+		{errors.New("exit status 1"), true},
+		{ErrNoManager, false},
+		{ErrNoPackages, false},
+		{ErrPackageNotFound, false},
+		{Classify(errors.New("exit status 100"), "Unable to locate package x"), false},
+	}
+
+	for _, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.want {
+			t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestHint(t *testing.T) {
+	for _, err := range []error{ErrNoManager, ErrNeedsSudo, ErrNetwork, ErrPackageNotFound} {
+		if Hint(err) == "" {
+			t.Errorf("Hint(%v) is empty", err)
+		}
+	}
+	if got := Hint(errors.New("exit status 1")); got != "" {
+		t.Errorf("Hint(unknown) = %q, want empty", got)
+	}
+}
+
+func TestStreamingNoPackages(t *testing.T) {
+	managers := []PackageManager{&BrewManager{}, &AptManager{}, &PacmanManager{}}
+	for _, mgr := range managers {
+		if _, err := mgr.InstallStreaming(context.Background()); !errors.Is(err, ErrNoPackages) {
+			t.Errorf("%s InstallStreaming() error = %v, want ErrNoPackages", mgr.Name(), err)
+		}
+		if _, err := mgr.ReinstallStreaming(context.Background()); !errors.Is(err, ErrNoPackages) {
+			t.Errorf("%s ReinstallStreaming() error = %v, want ErrNoPackages", mgr.Name(), err)
+		}
+		if _, err := mgr.UpdateStreaming(context.Background()); !errors.Is(err, ErrNoPackages) {
+			t.Errorf("%s UpdateStreaming() error = %v, want ErrNoPackages", mgr.Name(), err)
+		}
+	}
+}
+
+func TestUpdatePackagesUnknownManager(t *testing.T) {
+	results := UpdatePackages([]Package{{Name: "vim", InstalledBy: "nosuchmanager"}})
+	if len(results) != 1 {
+		t.Fatalf("UpdatePackages() returned %d results, want 1", len(results))
+	}
+	if !errors.Is(results[0].Error, ErrNoManager) {
+		t.Errorf("UpdatePackages() error = %v, want ErrNoManager", results[0].Error)
+	}
+}
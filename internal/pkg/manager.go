@@ -22,6 +22,10 @@ var (
 
 	cachedManager     PackageManager
 	cachedManagerOnce sync.Once
+
+	// managerOverride replaces detection when set (see SetManager)
+	managerOverride   *PackageManager
+	managerOverrideMu sync.RWMutex
 )
 
 // Package represents a package with version info
@@ -32,6 +36,7 @@ type Package struct {
 	Outdated       bool   `json:"outdated"`
 	InstalledBy    string `json:"installed_by"` // brew, pacman, apt, manual
 	Description    string `json:"description,omitempty"`
+	Pinned         bool   `json:"pinned,omitempty"` // Held at current version (excluded from updates)
 }
 
 // PackageManager defines the interface for package management operations
@@ -75,6 +80,9 @@ type PackageManager interface {
 	// InstallStreaming installs packages with real-time output streaming
 	InstallStreaming(ctx context.Context, packages ...string) (*runner.StreamingCmd, error)
 
+	// ReinstallStreaming reinstalls packages (even if already installed) with real-time output streaming
+	ReinstallStreaming(ctx context.Context, packages ...string) (*runner.StreamingCmd, error)
+
 	// UpdateStreaming updates packages with real-time output streaming
 	UpdateStreaming(ctx context.Context, packages ...string) (*runner.StreamingCmd, error)
 
@@ -125,12 +133,37 @@ func detectPlatformImpl() Platform {
 
 // DetectManager returns the appropriate package manager for the current system (cached after first call)
 func DetectManager() PackageManager {
+	managerOverrideMu.RLock()
+	override := managerOverride
+	managerOverrideMu.RUnlock()
+	if override != nil {
+		return *override
+	}
+
 	cachedManagerOnce.Do(func() {
 		cachedManager = detectManagerImpl()
 	})
 	return cachedManager
 }
 
+// SetManager makes DetectManager return m instead of the system's manager,
+// so tests can drive install and update flows with a MockPackageManager.
+// A nil m simulates a system without a package manager. Call the returned
+// function to restore the previous behavior:
+//
+//	defer pkg.SetManager(pkg.NewMockPackageManager())()
+func SetManager(m PackageManager) (restore func()) {
+	managerOverrideMu.Lock()
+	defer managerOverrideMu.Unlock()
+	previous := managerOverride
+	managerOverride = &m
+	return func() {
+		managerOverrideMu.Lock()
+		defer managerOverrideMu.Unlock()
+		managerOverride = previous
+	}
+}
+
 // detectManagerImpl performs the actual package manager detection
 func detectManagerImpl() PackageManager {
 	platform := DetectPlatform()
@@ -158,6 +191,23 @@ func detectManagerImpl() PackageManager {
 	return nil
 }
 
+// InstallCommandLine returns the shell command that installs packages with
+// the named manager, as a user would type it, e.g. "brew install tmux"
+func InstallCommandLine(manager string, packages ...string) string {
+	var prefix string
+	switch manager {
+	case "pacman":
+		prefix = "sudo pacman -S --needed"
+	case "paru":
+		prefix = "paru -S --needed"
+	case "apt":
+		prefix = "sudo apt install"
+	default:
+		prefix = manager + " install"
+	}
+	return prefix + " " + strings.Join(packages, " ")
+}
+
 // AllManagers returns all available package managers on the system
 func AllManagers() []PackageManager {
 	var managers []PackageManager
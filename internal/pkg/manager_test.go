@@ -1,6 +1,7 @@
 package pkg
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -131,8 +132,8 @@ func TestMockPackageManager_GetVersion(t *testing.T) {
 
 	// Package not installed
 	_, err := mock.GetVersion("vim")
-	if err == nil {
-		t.Error("GetVersion should fail for uninstalled package")
+	if !errors.Is(err, ErrNotInstalled) {
+		t.Errorf("GetVersion error = %v, want ErrNotInstalled", err)
 	}
 
 	// Install with version
@@ -293,6 +294,24 @@ func TestMockPackageManager_Reset(t *testing.T) {
 	}
 }
 
+func TestMarkPinned(t *testing.T) {
+	packages := []Package{{Name: "bat"}, {Name: "neovim"}, {Name: "eza", Pinned: true}}
+
+	MarkPinned(packages, []string{"neovim"})
+
+	want := map[string]bool{"bat": false, "neovim": true, "eza": false}
+	for _, p := range packages {
+		if p.Pinned != want[p.Name] {
+			t.Errorf("%s.Pinned = %v, want %v", p.Name, p.Pinned, want[p.Name])
+		}
+	}
+
+	unpinned := Unpinned(packages)
+	if len(unpinned) != 2 {
+		t.Errorf("Unpinned() returned %d packages, want 2", len(unpinned))
+	}
+}
+
 // Sentinel errors for testing
 var (
 	ErrMockInstallFailed   = &mockError{"mock install failed"}
@@ -309,3 +328,110 @@ type mockError struct {
 func (e *mockError) Error() string {
 	return e.msg
 }
+
+func TestCheckDotfilesUpdatesExceptAll(t *testing.T) {
+	// Excluding every managed package must not query any package manager
+	updates, err := CheckDotfilesUpdatesExcept(DotfilesPackages, nil)
+	if err != nil || updates != nil {
+		t.Errorf("CheckDotfilesUpdatesExcept(all) = %v, %v; want nil, nil", updates, err)
+	}
+}
+
+func TestInstallCommandLine(t *testing.T) {
+	tests := []struct {
+		manager  string
+		packages []string
+		want     string
+	}{
+		{"brew", []string{"tmux"}, "brew install tmux"},
+		{"apt", []string{"zsh", "zsh-autosuggestions"}, "sudo apt install zsh zsh-autosuggestions"},
+		{"pacman", []string{"bat"}, "sudo pacman -S --needed bat"},
+		{"paru", []string{"yazi"}, "paru -S --needed yazi"},
+	}
+	for _, tt := range tests {
+		if got := InstallCommandLine(tt.manager, tt.packages...); got != tt.want {
+			t.Errorf("InstallCommandLine(%s, %v) = %q, want %q", tt.manager, tt.packages, got, tt.want)
+		}
+	}
+}
+
+func TestSetManager(t *testing.T) {
+	mock := NewMockPackageManager()
+	restore := SetManager(mock)
+	if DetectManager() != mock {
+		t.Fatal("DetectManager() did not return the injected manager")
+	}
+	if err := InstallPackages("ripgrep", "fd"); err != nil {
+		t.Fatalf("InstallPackages() error = %v", err)
+	}
+	if len(mock.InstallCalls) != 1 || !IsPackageInstalled("fd") {
+		t.Errorf("InstallCalls = %v, want one call through the injected manager", mock.InstallCalls)
+	}
+
+	// Nested overrides restore in order; nil means no manager
+	restoreNil := SetManager(nil)
+	if err := InstallPackage("fd"); !errors.Is(err, ErrNoManager) {
+		t.Errorf("InstallPackage() with no manager = %v, want ErrNoManager", err)
+	}
+	restoreNil()
+	if DetectManager() != mock {
+		t.Error("restore did not bring back the previous override")
+	}
+	restore()
+	if DetectManager() == mock {
+		t.Error("restore left the override in place")
+	}
+}
+
+func TestPassthroughCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		m    PackageManager
+		want string
+	}{
+		{"brew", &BrewManager{brewPath: "/opt/homebrew/bin/brew"}, "/opt/homebrew/bin/brew services list"},
+		{"pacman", &PacmanManager{pacmanPath: "/usr/bin/pacman"}, "sudo /usr/bin/pacman services list"},
+		{"paru", &PacmanManager{pacmanPath: "/usr/bin/paru", useParu: true}, "/usr/bin/paru services list"},
+		{"apt", &AptManager{aptPath: "/usr/bin/apt"}, "sudo /usr/bin/apt services list"},
+	}
+	for _, tt := range tests {
+		if got := PassthroughCommandLine(tt.m, "services", "list"); got != tt.want {
+			t.Errorf("%s: PassthroughCommandLine() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if _, _, err := PassthroughCommand(nil, "list"); !errors.Is(err, ErrNoManager) {
+		t.Errorf("PassthroughCommand(nil) error = %v, want ErrNoManager", err)
+	}
+	if _, _, err := PassthroughCommand(NewMockPackageManager(), "list"); err == nil {
+		t.Error("PassthroughCommand(mock) should fail")
+	}
+	if _, _, err := PassthroughCommand(&AptManager{aptPath: "/usr/bin/apt"}); err == nil {
+		t.Error("PassthroughCommand() without args should fail")
+	}
+}
+
+func TestVersionedPackage(t *testing.T) {
+	tests := []struct {
+		name string
+		m    PackageManager
+		want string
+	}{
+		{"brew", &BrewManager{}, "node@20"},
+		{"apt", &AptManager{}, "node=20"},
+		{"mock", NewMockPackageManager(), "node@20"},
+	}
+	for _, tt := range tests {
+		got, err := VersionedPackage(tt.m, "node", "20")
+		if err != nil || got != tt.want {
+			t.Errorf("%s: VersionedPackage() = %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+
+	if _, err := VersionedPackage(&PacmanManager{}, "node", "20"); !errors.Is(err, ErrVersionUnsupported) {
+		t.Errorf("VersionedPackage(pacman) error = %v, want ErrVersionUnsupported", err)
+	}
+	if got, err := VersionedPackage(&PacmanManager{}, "node", ""); err != nil || got != "node" {
+		t.Errorf("VersionedPackage(pacman, no version) = %q, %v; want %q", got, err, "node")
+	}
+}
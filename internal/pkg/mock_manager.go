@@ -83,7 +83,7 @@ func (m *MockPackageManager) GetVersion(pkg string) (string, error) {
 	if version, ok := m.InstalledPkgs[pkg]; ok {
 		return version, nil
 	}
-	return "", fmt.Errorf("package %s not installed", pkg)
+	return "", fmt.Errorf("%s: %w", pkg, ErrNotInstalled)
 }
 
 // CheckOutdated returns outdated packages.
@@ -145,6 +145,11 @@ func (m *MockPackageManager) InstallStreaming(ctx context.Context, packages ...s
 	return nil, nil
 }
 
+// ReinstallStreaming reinstalls packages with streaming output.
+func (m *MockPackageManager) ReinstallStreaming(ctx context.Context, packages ...string) (*runner.StreamingCmd, error) {
+	return m.InstallStreaming(ctx, packages...)
+}
+
 // UpdateStreaming updates packages with streaming output.
 func (m *MockPackageManager) UpdateStreaming(ctx context.Context, packages ...string) (*runner.StreamingCmd, error) {
 	m.UpdateCalls = append(m.UpdateCalls, packages)
@@ -61,7 +61,7 @@ func (p *PacmanManager) Install(packages ...string) error {
 		cmd = exec.Command("sudo", append([]string{p.pacmanPath}, args...)...)
 	}
 
-	return cmd.Run()
+	return run(cmd)
 }
 
 func (p *PacmanManager) Uninstall(packages ...string) error {
@@ -72,7 +72,7 @@ func (p *PacmanManager) Uninstall(packages ...string) error {
 	args := []string{"-R", "--noconfirm"}
 	args = append(args, packages...)
 	cmd := exec.Command("sudo", append([]string{p.pacmanPath}, args...)...)
-	return cmd.Run()
+	return run(cmd)
 }
 
 func (p *PacmanManager) IsInstalled(pkg string) bool {
@@ -86,7 +86,7 @@ func (p *PacmanManager) GetVersion(pkg string) (string, error) {
 	cmd.Stdout = &out
 
 	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("package %s not installed", pkg)
+		return "", fmt.Errorf("%s: %w", pkg, ErrNotInstalled)
 	}
 
 	// Output format: "package-name version"
@@ -177,7 +177,7 @@ func (p *PacmanManager) Update(packages ...string) error {
 		cmd = exec.Command("sudo", append([]string{p.pacmanPath}, args...)...)
 	}
 
-	return cmd.Run()
+	return run(cmd)
 }
 
 func (p *PacmanManager) UpdateAll() error {
@@ -187,7 +187,7 @@ func (p *PacmanManager) UpdateAll() error {
 	} else {
 		cmd = exec.Command("sudo", p.pacmanPath, "-Syu", "--noconfirm")
 	}
-	return cmd.Run()
+	return run(cmd)
 }
 
 func (p *PacmanManager) Search(query string) ([]Package, error) {
@@ -265,7 +265,7 @@ func (p *PacmanManager) NeedsSudo() bool {
 // InstallStreaming installs packages with real-time output streaming
 func (p *PacmanManager) InstallStreaming(ctx context.Context, packages ...string) (*runner.StreamingCmd, error) {
 	if len(packages) == 0 {
-		return nil, fmt.Errorf("no packages specified")
+		return nil, ErrNoPackages
 	}
 
 	if p.useParu {
@@ -282,10 +282,30 @@ func (p *PacmanManager) InstallStreaming(ctx context.Context, packages ...string
 	return runner.RunStreamingWithSudo(ctx, p.pacmanPath, args...)
 }
 
+// ReinstallStreaming reinstalls packages with real-time output streaming.
+// This is InstallStreaming without --needed, which skips installed packages.
+func (p *PacmanManager) ReinstallStreaming(ctx context.Context, packages ...string) (*runner.StreamingCmd, error) {
+	if len(packages) == 0 {
+		return nil, ErrNoPackages
+	}
+
+	if p.useParu {
+		// paru should NOT be run with sudo - it handles sudo internally
+		args := []string{"-S", "--noconfirm", "--skipreview", "--noprovides", "--removemake"}
+		args = append(args, packages...)
+		return runner.RunStreaming(ctx, p.pacmanPath, args...)
+	}
+
+	// pacman needs sudo
+	args := []string{"-S", "--noconfirm"}
+	args = append(args, packages...)
+	return runner.RunStreamingWithSudo(ctx, p.pacmanPath, args...)
+}
+
 // UpdateStreaming updates packages with real-time output streaming
 func (p *PacmanManager) UpdateStreaming(ctx context.Context, packages ...string) (*runner.StreamingCmd, error) {
 	if len(packages) == 0 {
-		return nil, fmt.Errorf("no packages specified")
+		return nil, ErrNoPackages
 	}
 
 	if p.useParu {
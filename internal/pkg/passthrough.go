@@ -0,0 +1,53 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tekierz/dotfiles/internal/runner"
+)
+
+// PassthroughCommand returns the command that runs args with m's own CLI,
+// for operations dotfiles doesn't model. pacman and apt run under sudo like
+// their installs do; brew and paru run as the user (paru calls sudo itself).
+func PassthroughCommand(m PackageManager, args ...string) (name string, cmdArgs []string, err error) {
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("no arguments to pass to the package manager")
+	}
+	switch m := m.(type) {
+	case *BrewManager:
+		return m.brewPath, args, nil
+	case *PacmanManager:
+		if m.useParu {
+			return m.pacmanPath, args, nil
+		}
+		return "sudo", append([]string{m.pacmanPath}, args...), nil
+	case *AptManager:
+		return "sudo", append([]string{m.aptPath}, args...), nil
+	case nil:
+		return "", nil, ErrNoManager
+	}
+	return "", nil, fmt.Errorf("%s doesn't support passthrough commands", m.Name())
+}
+
+// PassthroughCommandLine returns PassthroughCommand as a user would type it
+func PassthroughCommandLine(m PackageManager, args ...string) string {
+	name, cmdArgs, err := PassthroughCommand(m, args...)
+	if err != nil {
+		return ""
+	}
+	return strings.Join(append([]string{name}, cmdArgs...), " ")
+}
+
+// PassthroughStreaming runs args with m's own CLI and streams its output.
+// Input isn't forwarded, so commands that prompt need their manager's
+// non-interactive flag (-y, --noconfirm). Cache sudo credentials first when
+// m.NeedsSudo().
+func PassthroughStreaming(ctx context.Context, m PackageManager, args ...string) (*runner.StreamingCmd, error) {
+	name, cmdArgs, err := PassthroughCommand(m, args...)
+	if err != nil {
+		return nil, err
+	}
+	return runner.RunStreaming(ctx, name, cmdArgs...)
+}
@@ -1,6 +1,7 @@
 package pkg
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 )
@@ -18,7 +19,7 @@ func CheckAllUpdates() ([]Package, error) {
 
 	managers := AllManagers()
 	if len(managers) == 0 {
-		return nil, fmt.Errorf("no package managers available")
+		return nil, ErrNoManager
 	}
 
 	for _, mgr := range managers {
@@ -67,7 +68,7 @@ func UpdatePackages(packages []Package) []UpdateResult {
 				results = append(results, UpdateResult{
 					Package: Package{Name: name, InstalledBy: managerName},
 					Success: false,
-					Error:   fmt.Errorf("package manager %s not available", managerName),
+					Error:   fmt.Errorf("%s: %w", managerName, ErrNoManager),
 				})
 			}
 			continue
@@ -90,7 +91,7 @@ func UpdatePackages(packages []Package) []UpdateResult {
 func UpdateAllPackages() error {
 	managers := AllManagers()
 	if len(managers) == 0 {
-		return fmt.Errorf("no package managers available")
+		return ErrNoManager
 	}
 
 	var errs []error
@@ -100,11 +101,7 @@ func UpdateAllPackages() error {
 		}
 	}
 
-	if len(errs) > 0 {
-		return fmt.Errorf("update errors: %v", errs)
-	}
-
-	return nil
+	return errors.Join(errs...)
 }
 
 // getManagerByName returns the appropriate manager for a given name
@@ -126,7 +123,7 @@ func getManagerByName(name string) PackageManager {
 func InstallPackage(name string) error {
 	mgr := DetectManager()
 	if mgr == nil {
-		return fmt.Errorf("no package manager available")
+		return ErrNoManager
 	}
 	return mgr.Install(name)
 }
@@ -135,7 +132,7 @@ func InstallPackage(name string) error {
 func InstallPackages(names ...string) error {
 	mgr := DetectManager()
 	if mgr == nil {
-		return fmt.Errorf("no package manager available")
+		return ErrNoManager
 	}
 	return mgr.Install(names...)
 }
@@ -185,18 +182,32 @@ var DotfilesPackages = []string{
 	"fswatch",
 }
 
-// CheckDotfilesUpdates checks for updates only for dotfiles-managed packages
-func CheckDotfilesUpdates() ([]Package, error) {
-	allUpdates, err := CheckAllUpdates()
-	if err != nil {
-		return nil, err
-	}
+// CheckDotfilesUpdates checks for updates only for dotfiles-managed packages.
+// Packages named in pinned are returned with Pinned set.
+func CheckDotfilesUpdates(pinned []string) ([]Package, error) {
+	return CheckDotfilesUpdatesExcept(nil, pinned)
+}
 
+// CheckDotfilesUpdatesExcept is CheckDotfilesUpdates with the named packages
+// left out of the scan. If every managed package is excluded, no package
+// manager is queried.
+func CheckDotfilesUpdatesExcept(exclude, pinned []string) ([]Package, error) {
 	// Filter to only dotfiles packages
 	dotfilesSet := make(map[string]bool)
 	for _, pkg := range DotfilesPackages {
 		dotfilesSet[pkg] = true
 	}
+	for _, name := range exclude {
+		delete(dotfilesSet, name)
+	}
+	if len(dotfilesSet) == 0 {
+		return nil, nil
+	}
+
+	allUpdates, err := CheckAllUpdates()
+	if err != nil {
+		return nil, err
+	}
 
 	var filtered []Package
 	for _, pkg := range allUpdates {
@@ -205,5 +216,28 @@ func CheckDotfilesUpdates() ([]Package, error) {
 		}
 	}
 
+	MarkPinned(filtered, pinned)
 	return filtered, nil
 }
+
+// MarkPinned sets Pinned on every package whose name is in pinned
+func MarkPinned(packages []Package, pinned []string) {
+	set := make(map[string]bool, len(pinned))
+	for _, name := range pinned {
+		set[name] = true
+	}
+	for i := range packages {
+		packages[i].Pinned = set[packages[i].Name]
+	}
+}
+
+// Unpinned returns the packages that are not pinned
+func Unpinned(packages []Package) []Package {
+	var out []Package
+	for _, p := range packages {
+		if !p.Pinned {
+			out = append(out, p)
+		}
+	}
+	return out
+}
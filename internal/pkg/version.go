@@ -0,0 +1,24 @@
+package pkg
+
+import "fmt"
+
+// VersionedPackage returns the package name that installs version of name
+// with m: brew's versioned formulae are "name@version" and apt takes
+// "name=version", where version must be the full Debian version string
+// (e.g. "14.1.0-1", as apt-cache policy lists it). pacman and paru only install what their repositories
+// currently ship, so a version is rejected with ErrVersionUnsupported. An
+// empty version returns name unchanged on every manager.
+func VersionedPackage(m PackageManager, name, version string) (string, error) {
+	if version == "" {
+		return name, nil
+	}
+	switch m.(type) {
+	case *BrewManager, *MockPackageManager:
+		return name + "@" + version, nil
+	case *AptManager:
+		return name + "=" + version, nil
+	case nil:
+		return "", ErrNoManager
+	}
+	return "", fmt.Errorf("%w: %s can't install %s@%s; it only installs the version in its repositories", ErrVersionUnsupported, m.Name(), name, version)
+}
@@ -0,0 +1,69 @@
+// Package power detects whether the machine is running on battery.
+package power
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// sysPowerSupply is where Linux exposes power supply state
+const sysPowerSupply = "/sys/class/power_supply"
+
+// OnBattery reports whether the system is currently running on battery power.
+// Desktops and unsupported platforms report false.
+func OnBattery() (bool, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		out, err := exec.Command("pmset", "-g", "batt").Output()
+		if err != nil {
+			return false, err
+		}
+		return parsePmset(string(out)), nil
+	case "linux":
+		return onBatteryLinux(sysPowerSupply), nil
+	}
+	return false, nil
+}
+
+// parsePmset interprets `pmset -g batt` output
+// (e.g. "Now drawing from 'Battery Power'")
+func parsePmset(out string) bool {
+	return strings.Contains(out, "'Battery Power'")
+}
+
+// onBatteryLinux inspects a /sys/class/power_supply style directory.
+// Any online mains adapter means AC; otherwise a discharging battery means battery.
+func onBatteryLinux(root string) bool {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return false
+	}
+
+	discharging := false
+	for _, e := range entries {
+		dir := filepath.Join(root, e.Name())
+		switch readValue(dir, "type") {
+		case "Mains", "USB":
+			if readValue(dir, "online") == "1" {
+				return false
+			}
+		case "Battery":
+			if readValue(dir, "status") == "Discharging" {
+				discharging = true
+			}
+		}
+	}
+	return discharging
+}
+
+// readValue reads a trimmed sysfs attribute, returning "" on error
+func readValue(dir, name string) string {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,73 @@
+package power
+
+import (
+	"testing"
+
+	"github.com/tekierz/dotfiles/internal/testutil"
+)
+
+func TestParsePmset(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want bool
+	}{
+		{"battery", "Now drawing from 'Battery Power'\n -InternalBattery-0\t85%; discharging", true},
+		{"ac", "Now drawing from 'AC Power'\n -InternalBattery-0\t100%; charged", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parsePmset(tt.out); got != tt.want {
+				t.Errorf("parsePmset() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOnBatteryLinux(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  bool
+	}{
+		{
+			name: "discharging battery",
+			files: map[string]string{
+				"AC/type": "Mains", "AC/online": "0",
+				"BAT0/type": "Battery", "BAT0/status": "Discharging",
+			},
+			want: true,
+		},
+		{
+			name: "plugged in",
+			files: map[string]string{
+				"AC/type": "Mains", "AC/online": "1",
+				"BAT0/type": "Battery", "BAT0/status": "Charging",
+			},
+			want: false,
+		},
+		{
+			name:  "desktop without battery",
+			files: map[string]string{"AC/type": "Mains", "AC/online": "1"},
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for name, content := range tt.files {
+				testutil.CreateTempFile(t, root, name, content+"\n")
+			}
+			if got := onBatteryLinux(root); got != tt.want {
+				t.Errorf("onBatteryLinux() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if onBatteryLinux(t.TempDir() + "/missing") {
+		t.Error("missing power_supply dir should report AC")
+	}
+}
@@ -357,9 +357,45 @@ func RunStreaming(ctx context.Context, name string, args ...string) (*StreamingC
 	}, nil
 }
 
+// Run executes a command to completion and returns its output lines
+// (stdout and stderr interleaved), for short commands whose output is
+// parsed rather than shown live
+func Run(ctx context.Context, name string, args ...string) ([]string, error) {
+	cmd, err := RunStreaming(ctx, name, args...)
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	for line := range cmd.Output {
+		lines = append(lines, line)
+	}
+	return lines, cmd.Wait()
+}
+
 // RunStreamingWithSudo executes a command with sudo and streams output
 // The sudo credentials should be cached before calling this function
 func RunStreamingWithSudo(ctx context.Context, name string, args ...string) (*StreamingCmd, error) {
 	sudoArgs := append([]string{name}, args...)
 	return RunStreaming(ctx, "sudo", sudoArgs...)
 }
+
+// ValidateShell checks a bash snippet's syntax without running it
+func ValidateShell(script string) error {
+	if strings.TrimSpace(script) == "" {
+		return fmt.Errorf("empty command")
+	}
+	out, err := exec.Command("bash", "-n", "-c", script).CombinedOutput()
+	if err != nil {
+		msg := strings.TrimSpace(string(out))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return fmt.Errorf("syntax error: %s", msg)
+	}
+	return nil
+}
+
+// RunShellStreaming runs a bash snippet and streams its output
+func RunShellStreaming(ctx context.Context, script string) (*StreamingCmd, error) {
+	return RunStreaming(ctx, "bash", "-c", script)
+}
@@ -0,0 +1,90 @@
+package runner
+
+import (
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// SudoKeepAliveInterval is how often cached sudo credentials are refreshed.
+// sudo's default timestamp_timeout is 5 minutes, so this leaves plenty of
+// margin for a slow refresh.
+const SudoKeepAliveInterval = time.Minute
+
+// SudoKeepAlive periodically refreshes cached sudo credentials
+// (sudo -n -v) in the background, so an install that outlasts the sudo
+// timeout doesn't stop mid-way for a password it can't prompt for. Start and
+// Stop are idempotent; a nil *SudoKeepAlive does nothing.
+type SudoKeepAlive struct {
+	interval time.Duration
+	refresh  func() error
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewSudoKeepAlive returns a stopped keep-alive that refreshes with sudo -n -v
+func NewSudoKeepAlive() *SudoKeepAlive {
+	return &SudoKeepAlive{
+		interval: SudoKeepAliveInterval,
+		refresh: func() error {
+			// -n: never prompt; if the cache already expired this just fails
+			return exec.Command("sudo", "-n", "-v").Run()
+		},
+	}
+}
+
+// Start begins refreshing credentials, if not already running
+func (k *SudoKeepAlive) Start() {
+	if k == nil {
+		return
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.stop != nil {
+		return
+	}
+	k.stop = make(chan struct{})
+	k.done = make(chan struct{})
+	go k.loop(k.stop, k.done)
+}
+
+// Stop ends refreshing and waits for the background loop to exit
+func (k *SudoKeepAlive) Stop() {
+	if k == nil {
+		return
+	}
+	k.mu.Lock()
+	stop, done := k.stop, k.done
+	k.stop, k.done = nil, nil
+	k.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// Running reports whether the keep-alive is active
+func (k *SudoKeepAlive) Running() bool {
+	if k == nil {
+		return false
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.stop != nil
+}
+
+func (k *SudoKeepAlive) loop(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(k.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			k.refresh()
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package runner
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSudoKeepAlive(t *testing.T) {
+	var refreshes atomic.Int32
+	k := &SudoKeepAlive{
+		interval: time.Millisecond,
+		refresh: func() error {
+			refreshes.Add(1)
+			return nil
+		},
+	}
+
+	k.Start()
+	k.Start() // idempotent
+	if !k.Running() {
+		t.Fatal("Running() = false after Start")
+	}
+	deadline := time.Now().Add(time.Second)
+	for refreshes.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if refreshes.Load() < 2 {
+		t.Fatalf("refreshes = %d, want periodic refreshes", refreshes.Load())
+	}
+
+	k.Stop()
+	k.Stop() // idempotent
+	if k.Running() {
+		t.Fatal("Running() = true after Stop")
+	}
+	after := refreshes.Load()
+	time.Sleep(10 * time.Millisecond)
+	if got := refreshes.Load(); got != after {
+		t.Errorf("refreshes continued after Stop: %d → %d", after, got)
+	}
+
+	var nilKeepAlive *SudoKeepAlive
+	nilKeepAlive.Start()
+	nilKeepAlive.Stop()
+}
@@ -0,0 +1,199 @@
+// Package serve answers read-only status queries over a unix socket, so
+// status bars (sketchybar, polybar, tmux) can poll cheaply without starting
+// the CLI each time.
+//
+// The protocol is one JSON object per line in each direction:
+//
+//	→ {"method": "status"}
+//	← {"result": {"theme": "nord", "active_user": "ada", "pending_updates": 3}}
+//
+// Methods are "status" (everything), "theme", "user" and "updates".
+package serve
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/pkg"
+)
+
+// DefaultUpdateTTL is how long an update check is reused before the next
+// request triggers a new one. Package manager queries take seconds, far too
+// slow to run per poll.
+const DefaultUpdateTTL = 15 * time.Minute
+
+// Request is a line sent by a client
+type Request struct {
+	Method string `json:"method"`
+}
+
+// Response is the reply to a request; exactly one field is set
+type Response struct {
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Status is the result of the "status" method. The other methods return the
+// same object with only their fields set.
+type Status struct {
+	Theme      string `json:"theme,omitempty"`
+	ActiveUser string `json:"active_user,omitempty"`
+	// PendingUpdates counts outdated, unpinned packages as of UpdatesCheckedAt
+	PendingUpdates   *int   `json:"pending_updates,omitempty"`
+	UpdatesCheckedAt string `json:"updates_checked_at,omitempty"`
+}
+
+// Server answers status queries. The zero value is not usable; see New.
+type Server struct {
+	// CheckUpdates lists outdated packages (normally tools.CheckUpdates)
+	CheckUpdates func() ([]pkg.Package, error)
+	UpdateTTL    time.Duration
+
+	mu        sync.Mutex
+	updates   int
+	checkedAt time.Time
+	checking  bool
+}
+
+// New returns a server that checks for updates with check
+func New(check func() ([]pkg.Package, error)) *Server {
+	return &Server{CheckUpdates: check, UpdateTTL: DefaultUpdateTTL}
+}
+
+// DefaultSocketPath returns $XDG_RUNTIME_DIR/dotfiles.sock, or a per-user
+// socket in the temp directory when XDG_RUNTIME_DIR is unset
+func DefaultSocketPath() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "dotfiles.sock")
+	}
+	return filepath.Join(os.TempDir(), "dotfiles-"+strconv.Itoa(os.Getuid())+".sock")
+}
+
+// ListenAndServe listens on the unix socket at path, readable only by the
+// current user, until ctx is done. A stale socket left by a previous run is
+// replaced; one still being served is an error.
+func (s *Server) ListenAndServe(ctx context.Context, path string) error {
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return fmt.Errorf("%s is already being served", path)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+
+	// The socket is created owner-only: chmod after Listen would leave a
+	// window where other users could connect
+	mask := syscall.Umask(0177)
+	ln, err := net.Listen("unix", path)
+	syscall.Umask(mask)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	defer os.Remove(path)
+
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+
+	// Start the first update check now so early polls have a count sooner
+	s.refreshUpdatesIfStale()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go s.handle(conn)
+	}
+}
+
+// handle answers requests on conn until the client closes it
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	enc := json.NewEncoder(conn)
+	for scanner.Scan() {
+		var req Request
+		var resp Response
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			resp.Error = "invalid request: " + err.Error()
+		} else if result, err := s.Call(req.Method); err != nil {
+			resp.Error = err.Error()
+		} else {
+			resp.Result = result
+		}
+		if err := enc.Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+// Call answers a single method
+func (s *Server) Call(method string) (Status, error) {
+	var st Status
+	switch method {
+	case "status", "theme", "user":
+		cfg, err := config.ReadGlobalConfig()
+		if err != nil {
+			return st, err
+		}
+		if method != "user" {
+			st.Theme = cfg.Theme
+		}
+		if method != "theme" {
+			st.ActiveUser = cfg.ActiveUser
+		}
+		if method != "status" {
+			return st, nil
+		}
+		fallthrough
+	case "updates":
+		s.refreshUpdatesIfStale()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !s.checkedAt.IsZero() {
+			n := s.updates
+			st.PendingUpdates = &n
+			st.UpdatesCheckedAt = s.checkedAt.Format(time.RFC3339)
+		}
+		return st, nil
+	}
+	return st, fmt.Errorf("unknown method %q (valid: status, theme, user, updates)", method)
+}
+
+// refreshUpdatesIfStale starts a background update check when the last one
+// is older than UpdateTTL. Callers get the previous count meanwhile; before
+// the first check finishes there is no count at all.
+func (s *Server) refreshUpdatesIfStale() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.checking || (!s.checkedAt.IsZero() && time.Since(s.checkedAt) < s.UpdateTTL) {
+		return
+	}
+	s.checking = true
+	go func() {
+		packages, err := s.CheckUpdates()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.checking = false
+		if err != nil {
+			return // keep the last good count; retried on the next request
+		}
+		s.updates = len(pkg.Unpinned(packages))
+		s.checkedAt = time.Now()
+	}()
+}
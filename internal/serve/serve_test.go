@@ -0,0 +1,115 @@
+package serve
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/pkg"
+	"github.com/tekierz/dotfiles/internal/testutil"
+)
+
+func TestServe(t *testing.T) {
+	testutil.TempConfigDir(t)
+	cfg := config.DefaultGlobalConfig()
+	cfg.Theme = "nord"
+	cfg.ActiveUser = "ada"
+	if err := config.SaveGlobalConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	// Unix socket paths are limited to ~100 bytes, too short for t.TempDir()
+	dir, err := os.MkdirTemp("", "dfserve")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "s.sock")
+
+	checked := make(chan struct{})
+	s := New(func() ([]pkg.Package, error) {
+		defer close(checked)
+		return []pkg.Package{{Name: "bat"}, {Name: "fzf"}, {Name: "tmux", Pinned: true}}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.ListenAndServe(ctx, socket) }()
+
+	var conn net.Conn
+	for i := 0; i < 100; i++ {
+		if conn, err = net.Dial("unix", socket); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	<-checked
+
+	if info, err := os.Stat(socket); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("socket mode = %v, %v; want 0600", info.Mode().Perm(), err)
+	}
+
+	r := bufio.NewReader(conn)
+	call := func(line string) map[string]any {
+		t.Helper()
+		if _, err := conn.Write([]byte(line + "\n")); err != nil {
+			t.Fatal(err)
+		}
+		reply, err := r.ReadBytes('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		var resp map[string]any
+		if err := json.Unmarshal(reply, &resp); err != nil {
+			t.Fatalf("reply %q: %v", reply, err)
+		}
+		return resp
+	}
+
+	// The background check may finish just after the callback returns
+	var result map[string]any
+	for i := 0; i < 100; i++ {
+		result, _ = call(`{"method": "status"}`)["result"].(map[string]any)
+		if result["pending_updates"] != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if result["theme"] != "nord" || result["active_user"] != "ada" || result["pending_updates"] != 2.0 {
+		t.Errorf("status = %v, want nord/ada/2 (pinned tmux not counted)", result)
+	}
+
+	if result, _ := call(`{"method": "theme"}`)["result"].(map[string]any); len(result) != 1 || result["theme"] != "nord" {
+		t.Errorf("theme = %v, want only the theme", result)
+	}
+	if resp := call(`{"method": "restart"}`); !strings.Contains(resp["error"].(string), "unknown method") {
+		t.Errorf("unknown method reply = %v", resp)
+	}
+	if resp := call(`not json`); !strings.Contains(resp["error"].(string), "invalid request") {
+		t.Errorf("bad request reply = %v", resp)
+	}
+
+	// A second server can't take over a live socket
+	if err := New(nil).ListenAndServe(context.Background(), socket); err == nil || !strings.Contains(err.Error(), "already being served") {
+		t.Errorf("second ListenAndServe() error = %v, want already being served", err)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("ListenAndServe() = %v, want nil after cancel", err)
+	}
+	if _, err := os.Stat(socket); !os.IsNotExist(err) {
+		t.Errorf("socket still exists after shutdown: %v", err)
+	}
+}
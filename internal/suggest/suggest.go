@@ -0,0 +1,85 @@
+// Package suggest offers "did you mean?" corrections for mistyped tool and
+// theme names.
+package suggest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxSuggestions caps how many corrections are offered
+const maxSuggestions = 3
+
+// Distance returns the Levenshtein edit distance between a and b, ignoring
+// case
+func Distance(a, b string) int {
+	ra := []rune(strings.ToLower(a))
+	rb := []rune(strings.ToLower(b))
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// Closest returns the candidates close enough to name to likely be what was
+// meant, closest first. Longer names tolerate more edits (a swapped pair of
+// letters is two), but never so many that any short candidate matches.
+func Closest(name string, candidates []string) []string {
+	n := len([]rune(name))
+	limit := min(max(2, n/3), n-1)
+
+	type match struct {
+		name string
+		dist int
+	}
+	var matches []match
+	for _, c := range candidates {
+		if d := Distance(name, c); d > 0 && d <= limit {
+			matches = append(matches, match{c, d})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].dist != matches[j].dist {
+			return matches[i].dist < matches[j].dist
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	var closest []string
+	for _, m := range matches {
+		if len(closest) == maxSuggestions {
+			break
+		}
+		closest = append(closest, m.name)
+	}
+	return closest
+}
+
+// DidYouMean formats the closest candidates as `did you mean "tmux"?`, or
+// returns "" when none are close
+func DidYouMean(name string, candidates []string) string {
+	closest := Closest(name, candidates)
+	if len(closest) == 0 {
+		return ""
+	}
+	quoted := make([]string, len(closest))
+	for i, c := range closest {
+		quoted[i] = fmt.Sprintf("%q", c)
+	}
+	return "did you mean " + strings.Join(quoted, " or ") + "?"
+}
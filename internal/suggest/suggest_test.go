@@ -0,0 +1,57 @@
+package suggest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"tmux", "tmux", 0},
+		{"tmxu", "tmux", 2},
+		{"Neovim", "neovim", 0},
+		{"nvim", "neovim", 2},
+		{"", "fzf", 3},
+		{"catppuccin-moca", "catppuccin-mocha", 1},
+	}
+	for _, tt := range tests {
+		if got := Distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("Distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestClosest(t *testing.T) {
+	tools := []string{"bat", "fd", "fzf", "git", "neovim", "tmux", "zsh"}
+
+	if got := Closest("tmxu", tools); !reflect.DeepEqual(got, []string{"tmux"}) {
+		t.Errorf("Closest(tmxu) = %v, want [tmux]", got)
+	}
+	if got := Closest("tmux", tools); got != nil {
+		t.Errorf("an exact match should not be suggested, got %v", got)
+	}
+	if got := Closest("kubectl", tools); got != nil {
+		t.Errorf("Closest(kubectl) = %v, want none", got)
+	}
+	// Two-letter names allow one edit, so "fx" doesn't match all of them
+	if got := Closest("fx", tools); !reflect.DeepEqual(got, []string{"fd"}) {
+		t.Errorf("Closest(fx) = %v, want [fd]", got)
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	themes := []string{"nord", "dracula", "gruvbox-dark", "gruvbox-light"}
+
+	if got, want := DidYouMean("gruvbox-drak", themes), `did you mean "gruvbox-dark"?`; got != want {
+		t.Errorf("DidYouMean = %q, want %q", got, want)
+	}
+	if got, want := DidYouMean("gruvbox", themes), ""; got != want {
+		t.Errorf("DidYouMean(gruvbox) = %q, want %q", got, want)
+	}
+	if got := DidYouMean("nrod", themes); got != `did you mean "nord"?` {
+		t.Errorf("DidYouMean(nrod) = %q", got)
+	}
+}
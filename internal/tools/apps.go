@@ -22,6 +22,7 @@ func NewZenBrowserTool() *ZenBrowserTool {
 			id:          "zen-browser",
 			name:        "Zen Browser",
 			description: "Privacy-focused browser based on Firefox",
+			url:         "https://zen-browser.app",
 			icon:        "󰖟",
 			category:    CategoryApp,
 			packages: map[pkg.Platform][]string{
@@ -78,6 +79,7 @@ func NewCursorTool() *CursorTool {
 			id:          "cursor",
 			name:        "Cursor",
 			description: "AI-first code editor",
+			url:         "https://cursor.com",
 			icon:        "󰦨",
 			category:    CategoryApp,
 			packages: map[pkg.Platform][]string{
@@ -125,6 +127,7 @@ func NewLMStudioTool() *LMStudioTool {
 			id:          "lm-studio",
 			name:        "LM Studio",
 			description: "Local LLM runner",
+			url:         "https://lmstudio.ai",
 			icon:        "󰚩",
 			category:    CategoryApp,
 			packages: map[pkg.Platform][]string{
@@ -186,6 +189,7 @@ func NewOBSTool() *OBSTool {
 			id:          "obs",
 			name:        "OBS Studio",
 			description: "Streaming and recording software",
+			url:         "https://obsproject.com",
 			icon:        "",
 			category:    CategoryApp,
 			packages: map[pkg.Platform][]string{
@@ -230,6 +234,7 @@ func NewRectangleTool() *RectangleTool {
 			id:          "rectangle",
 			name:        "Rectangle",
 			description: "Window management for macOS",
+			url:         "https://rectangleapp.com",
 			icon:        "󰍹",
 			category:    CategoryApp,
 			packages: map[pkg.Platform][]string{
@@ -267,6 +272,7 @@ func NewRaycastTool() *RaycastTool {
 			id:          "raycast",
 			name:        "Raycast",
 			description: "Productivity launcher for macOS",
+			url:         "https://www.raycast.com",
 			icon:        "󰈸",
 			category:    CategoryApp,
 			packages: map[pkg.Platform][]string{
@@ -304,6 +310,7 @@ func NewIINATool() *IINATool {
 			id:          "iina",
 			name:        "IINA",
 			description: "Modern media player for macOS",
+			url:         "https://iina.io",
 			icon:        "󰕼",
 			category:    CategoryApp,
 			packages: map[pkg.Platform][]string{
@@ -341,6 +348,7 @@ func NewAppCleanerTool() *AppCleanerTool {
 			id:          "appcleaner",
 			name:        "AppCleaner",
 			description: "Thoroughly uninstall macOS apps",
+			url:         "https://freemacsoft.net/appcleaner/",
 			icon:        "󰃢",
 			category:    CategoryApp,
 			packages: map[pkg.Platform][]string{
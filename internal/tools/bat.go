@@ -1,9 +1,9 @@
 package tools
 
 import (
-	"os"
 	"path/filepath"
 
+	"github.com/tekierz/dotfiles/internal/config"
 	"github.com/tekierz/dotfiles/internal/pkg"
 )
 
@@ -14,12 +14,12 @@ type BatTool struct {
 
 // NewBatTool creates a new bat tool
 func NewBatTool() *BatTool {
-	home, _ := os.UserHomeDir()
 	return &BatTool{
 		BaseTool: BaseTool{
 			id:          "bat",
 			name:        "bat",
 			description: "A cat clone with syntax highlighting",
+			url:         "https://github.com/sharkdp/bat",
 			icon:        "󰭟",
 			category:    CategoryUtility,
 			packages: map[pkg.Platform][]string{
@@ -28,7 +28,12 @@ func NewBatTool() *BatTool {
 				pkg.PlatformDebian: {"bat"},
 			},
 			configPaths: []string{
-				filepath.Join(home, ".config", "bat", "config"),
+				filepath.Join(config.ConfigHome(), "bat", "config"),
+			},
+			// Rebuild the theme cache so a new theme is picked up (Debian
+			// ships the binary as batcat)
+			postApply: []string{
+				"$(command -v bat || command -v batcat) cache --build",
 			},
 			// UI metadata
 			uiGroup:        UIGroupCLIUtilities,
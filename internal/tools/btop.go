@@ -6,12 +6,13 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/tekierz/dotfiles/internal/config"
 	"github.com/tekierz/dotfiles/internal/pkg"
 )
 
 // BtopConfig holds btop configuration settings
 type BtopConfig struct {
-	Theme     string // "auto", "Default", "TTY", custom theme name
+	Theme     string // "auto" (follow the global theme), "Default", "TTY", or a btop theme name
 	UpdateMs  int    // Update interval in milliseconds
 	ShowTemp  bool   // Show temperature sensors
 	GraphType string // "braille", "block", "tty"
@@ -24,12 +25,12 @@ type BtopTool struct {
 
 // NewBtopTool creates a new btop tool
 func NewBtopTool() *BtopTool {
-	home, _ := os.UserHomeDir()
 	return &BtopTool{
 		BaseTool: BaseTool{
 			id:          "btop",
 			name:        "btop",
 			description: "Resource monitor with TUI",
+			url:         "https://github.com/aristocratos/btop",
 			icon:        "󰄨",
 			category:    CategoryUtility,
 			packages: map[pkg.Platform][]string{
@@ -38,7 +39,7 @@ func NewBtopTool() *BtopTool {
 				pkg.PlatformDebian: {"btop"},
 			},
 			configPaths: []string{
-				filepath.Join(home, ".config", "btop", "btop.conf"),
+				filepath.Join(config.ConfigHome(), "btop", "btop.conf"),
 			},
 			heavyTool: true, // Skip on low-memory systems (Pi Zero 2)
 			// UI metadata
@@ -59,11 +60,7 @@ func GenerateBtopConfig(cfg BtopConfig, theme string) string {
 
 	// Color theme
 	sb.WriteString("#* Color theme\n")
-	btopTheme := cfg.Theme
-	if btopTheme == "auto" {
-		btopTheme = "Default"
-	}
-	sb.WriteString(fmt.Sprintf("color_theme = \"%s\"\n\n", btopTheme))
+	sb.WriteString(fmt.Sprintf("color_theme = \"%s\"\n\n", BtopThemeFor(cfg.Theme, theme)))
 
 	// Update interval
 	sb.WriteString("#* Update time in milliseconds\n")
@@ -128,12 +125,10 @@ func GenerateBtopConfig(cfg BtopConfig, theme string) string {
 
 // WriteBtopConfig writes the btop config to disk
 func WriteBtopConfig(cfg BtopConfig, theme string) error {
-	home, err := os.UserHomeDir()
+	configDir, err := userConfigDir("btop")
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
-
-	configDir := filepath.Join(home, ".config", "btop")
 	if err := os.MkdirAll(configDir, 0700); err != nil {
 		return fmt.Errorf("failed to create btop config directory: %w", err)
 	}
@@ -141,7 +136,7 @@ func WriteBtopConfig(cfg BtopConfig, theme string) error {
 	configPath := filepath.Join(configDir, "btop.conf")
 	content := GenerateBtopConfig(cfg, theme)
 
-	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+	if err := WriteGeneratedFile(configPath, content, "#", 0600); err != nil {
 		return fmt.Errorf("failed to write btop config: %w", err)
 	}
 
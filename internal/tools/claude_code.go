@@ -22,6 +22,7 @@ func NewClaudeCodeTool() *ClaudeCodeTool {
 			id:          "claude-code",
 			name:        "Claude Code",
 			description: "AI-powered coding assistant",
+			url:         "https://docs.anthropic.com/en/docs/claude-code",
 			icon:        "󰚩",
 			category:    CategoryUtility,
 			packages: map[pkg.Platform][]string{
@@ -47,6 +48,12 @@ func (t *ClaudeCodeTool) IsInstalled() bool {
 	return err == nil
 }
 
+// InstalledVersion is unknown: the packages are Node.js, which npm installs
+// Claude Code with, not Claude Code itself
+func (t *ClaudeCodeTool) InstalledVersion(versions map[string]string) string {
+	return ""
+}
+
 // ApplyConfig applies MCP server configuration
 func (t *ClaudeCodeTool) ApplyConfig(theme string) error {
 	// Use default MCPs when called without specific selections
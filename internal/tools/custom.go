@@ -0,0 +1,221 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/pkg"
+)
+
+// customToolSpec is the JSON shape of a user-defined tool, e.g.
+//
+//	{"id": "jq", "name": "jq", "category": "utility",
+//	 "packages": {"macos": ["jq"], "arch": ["jq"], "debian": ["jq"]}}
+type customToolSpec struct {
+	ID          string              `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	URL         string              `json:"url"` // Homepage or docs, opened with o in Manage
+	Icon        string              `json:"icon"`
+	Category    string              `json:"category"`
+	Packages    map[string][]string `json:"packages"` // Platform ("macos", "arch", "debian", "all") → packages
+	Version     string              `json:"version"`  // Version of the first package to install, where the manager can pin one
+	ConfigPaths []string            `json:"config_paths"`
+	PostApply   []string            `json:"post_apply"` // Shell commands run after its config is applied
+}
+
+// CustomTool is a tool defined in a tools.d file
+type CustomTool struct {
+	BaseTool
+	Source string // File the tool was defined in
+}
+
+// CustomToolsDir is where user-defined tools live, one JSON file per tool
+func CustomToolsDir() string {
+	return filepath.Join(config.ConfigDir(), "tools.d")
+}
+
+// Conflict is a problem found while merging user-defined tools into the
+// registry. Precedence is fixed: a user-defined tool overrides the built-in
+// with the same ID, and among tools.d files the later one (by file name)
+// wins.
+type Conflict struct {
+	ID      string
+	Sources []string // File names (or "built-in") involved, lowest precedence first
+	Message string
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s: %s (%s)", c.ID, c.Message, strings.Join(c.Sources, ", "))
+}
+
+// LoadCustomTools reads every *.json tool definition in dir, in file name
+// order. Files that can't be read or are invalid are returned as errors and
+// skipped. A missing dir is not an error.
+func LoadCustomTools(dir string) ([]*CustomTool, []error) {
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	sort.Strings(paths)
+
+	var loaded []*CustomTool
+	var errs []error
+	for _, path := range paths {
+		t, err := loadCustomTool(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filepath.Base(path), err))
+			continue
+		}
+		loaded = append(loaded, t)
+	}
+	return loaded, errs
+}
+
+// loadCustomTool parses and validates one tools.d file
+func loadCustomTool(path string) (*CustomTool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var spec customToolSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	spec.ID = strings.TrimSpace(spec.ID)
+	if spec.ID == "" {
+		return nil, fmt.Errorf("missing \"id\"")
+	}
+	if spec.Name == "" {
+		spec.Name = spec.ID
+	}
+	cat := CategoryUtility
+	if spec.Category != "" {
+		if cat, err = ParseCategory(spec.Category); err != nil {
+			return nil, err
+		}
+	}
+	packages := make(map[pkg.Platform][]string, len(spec.Packages))
+	for platform, pkgs := range spec.Packages {
+		switch p := pkg.Platform(platform); p {
+		case pkg.PlatformMacOS, pkg.PlatformArch, pkg.PlatformDebian, pkg.PlatformPi, "all":
+			packages[p] = pkgs
+		default:
+			return nil, fmt.Errorf("unknown platform %q in \"packages\" (valid: macos, arch, debian, pi, all)", platform)
+		}
+	}
+	if len(packages) == 0 {
+		return nil, fmt.Errorf("no \"packages\" defined")
+	}
+
+	var configPaths []string
+	for _, p := range spec.ConfigPaths {
+		if !filepath.IsAbs(p) {
+			p = config.HomePath(homeDir(), strings.TrimPrefix(p, "~/"))
+		}
+		configPaths = append(configPaths, p)
+	}
+
+	return &CustomTool{
+		BaseTool: BaseTool{
+			id:          spec.ID,
+			name:        spec.Name,
+			description: spec.Description,
+			url:         strings.TrimSpace(spec.URL),
+			icon:        spec.Icon,
+			category:    cat,
+			packages:    packages,
+			version:     strings.TrimSpace(spec.Version),
+			configPaths: configPaths,
+			postApply:   spec.PostApply,
+		},
+		Source: path,
+	}, nil
+}
+
+// homeDir returns the user's home directory, or "" if unknown
+func homeDir() string {
+	home, _ := os.UserHomeDir()
+	return home
+}
+
+// MergeCustom registers user-defined tools over the built-ins and returns
+// the conflicts it resolved: IDs defined more than once, and packages that
+// two different tools both claim on the same platform (both tools are kept,
+// but installing either installs the other's package).
+func (r *Registry) MergeCustom(custom []*CustomTool) []Conflict {
+	var conflicts []Conflict
+
+	sources := make(map[string][]string)
+	for _, t := range custom {
+		if prev, ok := r.tools[t.ID()]; ok {
+			if len(sources[t.ID()]) == 0 {
+				sources[t.ID()] = []string{toolSource(prev)}
+			}
+		}
+		sources[t.ID()] = append(sources[t.ID()], toolSource(t))
+		r.tools[t.ID()] = t
+	}
+	for id, srcs := range sources {
+		if len(srcs) < 2 {
+			continue
+		}
+		conflicts = append(conflicts, Conflict{
+			ID:      id,
+			Sources: srcs,
+			Message: fmt.Sprintf("defined %d times, using %s", len(srcs), srcs[len(srcs)-1]),
+		})
+	}
+
+	// Packages claimed by more than one tool, only reported when a
+	// user-defined tool is involved
+	owners := make(map[string][]Tool)
+	for _, t := range r.All() {
+		for platform, pkgs := range t.Packages() {
+			for _, p := range pkgs {
+				key := string(platform) + "/" + p
+				owners[key] = append(owners[key], t)
+			}
+		}
+	}
+	for key, ts := range owners {
+		if len(ts) < 2 || !slices.ContainsFunc(ts, isCustom) {
+			continue
+		}
+		var srcs []string
+		for _, t := range ts {
+			srcs = append(srcs, t.ID()+" in "+toolSource(t))
+		}
+		conflicts = append(conflicts, Conflict{
+			ID:      ts[slices.IndexFunc(ts, isCustom)].ID(),
+			Sources: srcs,
+			Message: fmt.Sprintf("package %s is also claimed by another tool", key),
+		})
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].ID != conflicts[j].ID {
+			return conflicts[i].ID < conflicts[j].ID
+		}
+		return conflicts[i].Message < conflicts[j].Message
+	})
+	return conflicts
+}
+
+// isCustom reports whether t came from tools.d
+func isCustom(t Tool) bool {
+	_, ok := t.(*CustomTool)
+	return ok
+}
+
+// toolSource names where a tool was defined, for conflict reports
+func toolSource(t Tool) string {
+	if c, ok := t.(*CustomTool); ok {
+		return filepath.Base(c.Source)
+	}
+	return "built-in"
+}
@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tekierz/dotfiles/internal/pkg"
+)
+
+func writeToolFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadCustomTools(t *testing.T) {
+	dir := writeToolFiles(t, map[string]string{
+		"jq.json":       `{"id": "jq", "category": "utility", "packages": {"all": ["jq"]}}`,
+		"no-id.json":    `{"packages": {"all": ["x"]}}`,
+		"bad-cat.json":  `{"id": "x", "category": "games", "packages": {"all": ["x"]}}`,
+		"bad-plat.json": `{"id": "x", "packages": {"windows": ["x"]}}`,
+		"broken.json":   `{`,
+		"notes.txt":     `ignored`,
+	})
+
+	loaded, errs := LoadCustomTools(dir)
+	if len(loaded) != 1 || loaded[0].ID() != "jq" || loaded[0].Name() != "jq" {
+		t.Fatalf("loaded = %v, want only jq", loaded)
+	}
+	if len(errs) != 4 {
+		t.Errorf("len(errs) = %d, want 4: %v", len(errs), errs)
+	}
+
+	if loaded, errs := LoadCustomTools(filepath.Join(dir, "missing")); len(loaded) != 0 || len(errs) != 0 {
+		t.Errorf("missing dir: loaded=%v errs=%v, want nothing", loaded, errs)
+	}
+}
+
+func TestMergeCustomPrecedence(t *testing.T) {
+	dir := writeToolFiles(t, map[string]string{
+		"10-bat.json": `{"id": "bat", "name": "My Bat", "packages": {"all": ["bat"]}}`,
+		"20-bat.json": `{"id": "bat", "name": "Later Bat", "packages": {"all": ["bat"]}}`,
+		"30-jq.json":  `{"id": "jq", "packages": {"debian": ["jq"]}}`,
+	})
+	loaded, errs := LoadCustomTools(dir)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	r := NewRegistry()
+	conflicts := r.MergeCustom(loaded)
+
+	// User overrides built-in, later file wins
+	bat, _ := r.Get("bat")
+	if bat.Name() != "Later Bat" {
+		t.Errorf("bat = %q, want the definition from the later file", bat.Name())
+	}
+	if _, ok := r.Get("jq"); !ok {
+		t.Error("jq should be registered")
+	}
+
+	if len(conflicts) != 1 {
+		t.Fatalf("conflicts = %v, want 1", conflicts)
+	}
+	c := conflicts[0]
+	if c.ID != "bat" || strings.Join(c.Sources, ",") != "built-in,10-bat.json,20-bat.json" {
+		t.Errorf("conflict = %+v, want bat from built-in,10-bat.json,20-bat.json", c)
+	}
+	if !strings.Contains(c.String(), "using 20-bat.json") {
+		t.Errorf("conflict = %q, want it to name the winning file", c)
+	}
+}
+
+func TestMergeCustomSharedPackage(t *testing.T) {
+	dir := writeToolFiles(t, map[string]string{
+		"rg.json": `{"id": "rg", "packages": {"debian": ["ripgrep"]}}`,
+	})
+	loaded, _ := LoadCustomTools(dir)
+
+	r := NewRegistry()
+	conflicts := r.MergeCustom(loaded)
+	if len(conflicts) != 1 {
+		t.Fatalf("conflicts = %v, want 1", conflicts)
+	}
+	want := "package " + string(pkg.PlatformDebian) + "/ripgrep"
+	if conflicts[0].ID != "rg" || !strings.Contains(conflicts[0].Message, want) {
+		t.Errorf("conflict = %v, want rg sharing %s", conflicts[0], want)
+	}
+	if _, ok := r.Get("ripgrep"); !ok {
+		t.Error("tools sharing a package should both be kept")
+	}
+}
+
+func TestCustomToolVersion(t *testing.T) {
+	dir := writeToolFiles(t, map[string]string{
+		"node.json": `{"id": "node", "version": "20", "packages": {"all": ["node", "corepack"]}}`,
+	})
+	loaded, errs := LoadCustomTools(dir)
+	if len(errs) != 0 || len(loaded) != 1 {
+		t.Fatalf("LoadCustomTools() = %v, %v", loaded, errs)
+	}
+	node := loaded[0]
+	if node.Version() != "20" {
+		t.Errorf("Version() = %q, want %q", node.Version(), "20")
+	}
+
+	mgr := pkg.NewMockPackageManager()
+	got, err := InstallPackages(node, mgr, pkg.PlatformArch, "")
+	if err != nil || strings.Join(got, " ") != "node@20 corepack" {
+		t.Errorf("InstallPackages() = %v, %v; want the definition's version on the first package", got, err)
+	}
+	got, err = InstallPackages(node, mgr, pkg.PlatformArch, "22")
+	if err != nil || strings.Join(got, " ") != "node@22 corepack" {
+		t.Errorf("InstallPackages(22) = %v, %v; want the requested version to win", got, err)
+	}
+	if _, err := InstallPackages(node, &pkg.PacmanManager{}, pkg.PlatformArch, ""); !errors.Is(err, pkg.ErrVersionUnsupported) {
+		t.Errorf("InstallPackages(pacman) error = %v, want ErrVersionUnsupported", err)
+	}
+}
@@ -16,6 +16,7 @@ func NewDeltaTool() *DeltaTool {
 			id:          "delta",
 			name:        "Delta",
 			description: "Syntax-highlighting pager for git diffs",
+			url:         "https://dandavison.github.io/delta/",
 			icon:        "󰘧",
 			category:    CategoryGit,
 			packages: map[pkg.Platform][]string{
@@ -16,6 +16,7 @@ func NewEzaTool() *EzaTool {
 			id:          "eza",
 			name:        "eza",
 			description: "Modern replacement for ls",
+			url:         "https://eza.rocks",
 			icon:        "󰙅",
 			category:    CategoryUtility,
 			packages: map[pkg.Platform][]string{
@@ -16,6 +16,7 @@ func NewFdTool() *FdTool {
 			id:          "fd",
 			name:        "fd",
 			description: "Simple, fast find alternative",
+			url:         "https://github.com/sharkdp/fd",
 			icon:        "󰈞",
 			category:    CategoryUtility,
 			packages: map[pkg.Platform][]string{
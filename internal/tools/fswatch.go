@@ -16,6 +16,7 @@ func NewFswatchTool() *FswatchTool {
 			id:          "fswatch",
 			name:        "fswatch",
 			description: "Cross-platform file change monitor",
+			url:         "https://github.com/emcrisostomo/fswatch",
 			icon:        "󱄄",
 			category:    CategoryUtility,
 			packages: map[pkg.Platform][]string{
@@ -28,6 +28,7 @@ func NewFzfTool() *FzfTool {
 			id:          "fzf",
 			name:        "fzf",
 			description: "Command-line fuzzy finder",
+			url:         "https://junegunn.github.io/fzf/",
 			icon:        "󰍉",
 			category:    CategoryUtility,
 			packages: map[pkg.Platform][]string{
@@ -114,12 +115,10 @@ func GenerateFzfConfig(cfg FzfConfig, theme string) string {
 
 // WriteFzfConfig writes the fzf configuration to a sourceable file
 func WriteFzfConfig(cfg FzfConfig, theme string) error {
-	home, err := os.UserHomeDir()
+	configDir, err := userConfigDir("fzf")
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
-
-	configDir := filepath.Join(home, ".config", "fzf")
 	if err := os.MkdirAll(configDir, 0700); err != nil {
 		return fmt.Errorf("failed to create fzf config directory: %w", err)
 	}
@@ -127,7 +126,7 @@ func WriteFzfConfig(cfg FzfConfig, theme string) error {
 	configPath := filepath.Join(configDir, "fzf.zsh")
 	content := GenerateFzfConfig(cfg, theme)
 
-	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+	if err := WriteGeneratedFile(configPath, content, "#", 0600); err != nil {
 		return fmt.Errorf("failed to write fzf config: %w", err)
 	}
 
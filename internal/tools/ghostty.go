@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/tekierz/dotfiles/internal/config"
 	"github.com/tekierz/dotfiles/internal/pkg"
 )
 
@@ -27,12 +28,12 @@ type GhosttyTool struct {
 
 // NewGhosttyTool creates a new Ghostty tool
 func NewGhosttyTool() *GhosttyTool {
-	home, _ := os.UserHomeDir()
 	return &GhosttyTool{
 		BaseTool: BaseTool{
 			id:          "ghostty",
 			name:        "Ghostty",
 			description: "GPU-accelerated terminal emulator",
+			url:         "https://ghostty.org/docs",
 			icon:        "󰆍",
 			category:    CategoryTerminal,
 			packages: map[pkg.Platform][]string{
@@ -41,7 +42,7 @@ func NewGhosttyTool() *GhosttyTool {
 				// Debian: not yet in repos, manual install
 			},
 			configPaths: []string{
-				filepath.Join(home, ".config", "ghostty", "config"),
+				filepath.Join(config.ConfigHome(), "ghostty", "config"),
 			},
 			// UI metadata
 			uiGroup:        UIGroupNone,
@@ -120,12 +121,10 @@ func GenerateGhosttyConfig(cfg GhosttyConfig, theme string) string {
 
 // WriteGhosttyConfig writes the Ghostty config file to disk
 func WriteGhosttyConfig(cfg GhosttyConfig, theme string) error {
-	home, err := os.UserHomeDir()
+	configDir, err := userConfigDir("ghostty")
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
-
-	configDir := filepath.Join(home, ".config", "ghostty")
 	if err := os.MkdirAll(configDir, 0700); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
@@ -133,7 +132,7 @@ func WriteGhosttyConfig(cfg GhosttyConfig, theme string) error {
 	configPath := filepath.Join(configDir, "config")
 	content := GenerateGhosttyConfig(cfg, theme)
 
-	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+	if err := WriteGeneratedFile(configPath, content, "#", 0600); err != nil {
 		return fmt.Errorf("failed to write ghostty config: %w", err)
 	}
 
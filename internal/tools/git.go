@@ -11,12 +11,19 @@ import (
 
 // GitConfig holds Git configuration settings
 type GitConfig struct {
+	Template         string // GitTemplates entry to start from ("" for the default)
 	DeltaSideBySide  bool
 	DefaultBranch    string
 	Aliases          []string // "st", "co", "br", "ci", "lg"
 	PullRebase       bool
 	SignCommits      bool
 	CredentialHelper string // "cache", "store", "osxkeychain"
+	AutoSetupRemote  bool   // push.autoSetupRemote: first push creates the upstream branch
+
+	// Identity and settings imported from an existing .gitconfig
+	UserName  string
+	UserEmail string
+	Extra     []GitConfigEntry // Written back verbatim after the generated sections
 }
 
 // GitTool represents Git version control
@@ -32,6 +39,7 @@ func NewGitTool() *GitTool {
 			id:          "git",
 			name:        "Git",
 			description: "Distributed version control system",
+			url:         "https://git-scm.com/doc",
 			icon:        "",
 			category:    CategoryGit,
 			packages: map[pkg.Platform][]string{
@@ -50,13 +58,29 @@ func NewGitTool() *GitTool {
 	}
 }
 
-// GenerateGitConfig builds the .gitconfig content
+// GenerateGitConfig builds the .gitconfig content from cfg.Template with
+// cfg's settings applied
 func GenerateGitConfig(cfg GitConfig, theme string) string {
 	var sb strings.Builder
+	template := resolveTemplate(GitTemplates, cfg.Template)
 
 	// Header comment
 	sb.WriteString("# Generated by dotfiles TUI\n")
-	sb.WriteString(fmt.Sprintf("# Theme: %s\n\n", theme))
+	sb.WriteString(fmt.Sprintf("# Theme: %s\n", theme))
+	writeTemplateComment(&sb, GitTemplates, template)
+	sb.WriteString("\n")
+
+	// Identity
+	if cfg.UserName != "" || cfg.UserEmail != "" {
+		sb.WriteString("[user]\n")
+		if cfg.UserName != "" {
+			sb.WriteString(fmt.Sprintf("\tname = %s\n", formatGitValue(cfg.UserName)))
+		}
+		if cfg.UserEmail != "" {
+			sb.WriteString(fmt.Sprintf("\temail = %s\n", formatGitValue(cfg.UserEmail)))
+		}
+		sb.WriteString("\n")
+	}
 
 	// Core settings
 	sb.WriteString("[init]\n")
@@ -83,27 +107,25 @@ func GenerateGitConfig(cfg GitConfig, theme string) string {
 		sb.WriteString("\tgpgsign = true\n\n")
 	}
 
-	// Delta pager (if delta is available)
-	sb.WriteString("[core]\n")
-	sb.WriteString("\tpager = delta\n")
-	sb.WriteString("\teditor = nvim\n\n")
-
-	sb.WriteString("[interactive]\n")
-	sb.WriteString("\tdiffFilter = delta --color-only\n\n")
-
-	sb.WriteString("[delta]\n")
-	sb.WriteString("\tnavigate = true\n")
-	if cfg.DeltaSideBySide {
-		sb.WriteString("\tside-by-side = true\n")
+	if cfg.AutoSetupRemote {
+		sb.WriteString("[push]\n")
+		sb.WriteString("\tautoSetupRemote = true\n\n")
 	}
-	sb.WriteString("\tline-numbers = true\n")
-	sb.WriteString("\tsyntax-theme = Dracula\n\n")
 
-	sb.WriteString("[merge]\n")
-	sb.WriteString("\tconflictstyle = diff3\n\n")
+	if template == "work" {
+		sb.WriteString("[fetch]\n")
+		sb.WriteString("\tprune = true\n\n")
+		sb.WriteString("[rerere]\n")
+		sb.WriteString("\tenabled = true\n\n")
+		// Work identity (user.email etc.) for repos under ~/work
+		sb.WriteString("[includeIf \"gitdir:~/work/\"]\n")
+		sb.WriteString("\tpath = ~/.gitconfig-work\n\n")
+	}
 
-	sb.WriteString("[diff]\n")
-	sb.WriteString("\tcolorMoved = default\n\n")
+	// Minimal leaves paging, diffs and merges to git's defaults
+	if template != "minimal" {
+		writeGitPagerSections(&sb, cfg)
+	}
 
 	// Aliases
 	sb.WriteString("[alias]\n")
@@ -122,9 +144,37 @@ func GenerateGitConfig(cfg GitConfig, theme string) string {
 		}
 	}
 
+	// Settings the editors don't cover, carried over from the old file
+	writeGitExtra(&sb, cfg.Extra)
+
 	return sb.String()
 }
 
+// writeGitPagerSections writes the delta pager, merge and diff sections
+func writeGitPagerSections(sb *strings.Builder, cfg GitConfig) {
+	// Delta pager (if delta is available)
+	sb.WriteString("[core]\n")
+	sb.WriteString("\tpager = delta\n")
+	sb.WriteString("\teditor = nvim\n\n")
+
+	sb.WriteString("[interactive]\n")
+	sb.WriteString("\tdiffFilter = delta --color-only\n\n")
+
+	sb.WriteString("[delta]\n")
+	sb.WriteString("\tnavigate = true\n")
+	if cfg.DeltaSideBySide {
+		sb.WriteString("\tside-by-side = true\n")
+	}
+	sb.WriteString("\tline-numbers = true\n")
+	sb.WriteString("\tsyntax-theme = Dracula\n\n")
+
+	sb.WriteString("[merge]\n")
+	sb.WriteString("\tconflictstyle = diff3\n\n")
+
+	sb.WriteString("[diff]\n")
+	sb.WriteString("\tcolorMoved = default\n\n")
+}
+
 // WriteGitConfig writes the .gitconfig file to disk
 func WriteGitConfig(cfg GitConfig, theme string) error {
 	home, err := os.UserHomeDir()
@@ -135,15 +185,23 @@ func WriteGitConfig(cfg GitConfig, theme string) error {
 	configPath := filepath.Join(home, ".gitconfig")
 	content := GenerateGitConfig(cfg, theme)
 
-	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+	if err := WriteGeneratedFile(configPath, content, "#", 0600); err != nil {
 		return fmt.Errorf("failed to write .gitconfig: %w", err)
 	}
 
 	return nil
 }
 
-// GenerateConfig implements Tool interface (uses defaults)
-func (t *GitTool) GenerateConfig(theme string) string {
+// GitConfigUpToDate reports whether ~/.gitconfig already matches cfg, in
+// which case WriteGitConfig leaves it untouched
+func GitConfigUpToDate(cfg GitConfig, theme string) bool {
+	return UpToDate(filepath.Join(homeDir(), ".gitconfig"), GenerateGitConfig(cfg, theme))
+}
+
+// defaultGitConfig is the Tool-interface config: the editors' defaults with
+// whatever an existing ~/.gitconfig sets layered on top, so applying doesn't
+// lose the user's identity or custom settings
+func defaultGitConfig() GitConfig {
 	cfg := GitConfig{
 		DeltaSideBySide:  true,
 		DefaultBranch:    "main",
@@ -151,19 +209,20 @@ func (t *GitTool) GenerateConfig(theme string) string {
 		PullRebase:       true,
 		SignCommits:      false,
 		CredentialHelper: "cache",
+		AutoSetupRemote:  true,
+	}
+	if imp, ok := LoadGitImport(); ok {
+		imp.ApplyTo(&cfg)
 	}
-	return GenerateGitConfig(cfg, theme)
+	return cfg
+}
+
+// GenerateConfig implements Tool interface (uses defaults)
+func (t *GitTool) GenerateConfig(theme string) string {
+	return GenerateGitConfig(defaultGitConfig(), theme)
 }
 
 // ApplyConfig implements Tool interface (uses defaults)
 func (t *GitTool) ApplyConfig(theme string) error {
-	cfg := GitConfig{
-		DeltaSideBySide:  true,
-		DefaultBranch:    "main",
-		Aliases:          []string{"st", "co", "br", "ci", "lg"},
-		PullRebase:       true,
-		SignCommits:      false,
-		CredentialHelper: "cache",
-	}
-	return WriteGitConfig(cfg, theme)
+	return WriteGitConfig(defaultGitConfig(), theme)
 }
@@ -0,0 +1,298 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// GitConfigEntry is one "key = value" line of a gitconfig file
+type GitConfigEntry struct {
+	Section    string // Lowercased, e.g. "credential"
+	Subsection string // Case-sensitive, e.g. "https://github.com" (empty if none)
+	Key        string // Lowercased, e.g. "helper"
+	Value      string
+}
+
+// ParseGitConfig parses gitconfig content into its entries, in file order.
+// It understands [section] and [section "subsection"] headers, the legacy
+// [section.subsection] form, quoted values with escapes, # and ; comments,
+// and bare keys (which git reads as true). Malformed lines are skipped.
+func ParseGitConfig(content string) []GitConfigEntry {
+	var entries []GitConfigEntry
+	var section, subsection string
+
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if line[0] == '[' {
+			end := strings.LastIndex(line, "]")
+			if end < 0 {
+				continue
+			}
+			section, subsection = parseGitSection(line[1:end])
+			// A header may be followed by a key on the same line
+			line = strings.TrimSpace(line[end+1:])
+			if line == "" || line[0] == '#' || line[0] == ';' {
+				continue
+			}
+		}
+		if section == "" {
+			continue
+		}
+
+		key, raw, hasValue := strings.Cut(line, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" || strings.ContainsAny(key, " \t") {
+			continue
+		}
+		value := "true"
+		if hasValue {
+			// Trailing backslashes continue the value on the next line
+			for strings.HasSuffix(strings.TrimRight(raw, " \t"), `\`) && i+1 < len(lines) {
+				raw = strings.TrimSuffix(strings.TrimRight(raw, " \t"), `\`)
+				i++
+				raw += lines[i]
+			}
+			value = parseGitValue(raw)
+		}
+		entries = append(entries, GitConfigEntry{Section: section, Subsection: subsection, Key: key, Value: value})
+	}
+	return entries
+}
+
+// parseGitSection splits a section header's contents into the lowercased
+// section name and the subsection
+func parseGitSection(header string) (string, string) {
+	header = strings.TrimSpace(header)
+	if name, rest, ok := strings.Cut(header, " "); ok {
+		sub := strings.TrimSpace(rest)
+		sub = strings.TrimSuffix(strings.TrimPrefix(sub, `"`), `"`)
+		sub = strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(sub)
+		return strings.ToLower(name), sub
+	}
+	// Deprecated [section.subsection] syntax, where the subsection is lowercased
+	if name, sub, ok := strings.Cut(header, "."); ok {
+		return strings.ToLower(name), strings.ToLower(sub)
+	}
+	return strings.ToLower(header), ""
+}
+
+// parseGitValue unquotes a raw value, dropping inline comments and
+// surrounding whitespace outside quotes
+func parseGitValue(raw string) string {
+	raw = strings.TrimLeft(raw, " \t")
+	var sb strings.Builder
+	inQuote := false
+	pendingSpace := ""
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		switch {
+		case c == '"':
+			inQuote = !inQuote
+			continue
+		case c == '\\' && i+1 < len(raw):
+			i++
+			sb.WriteString(pendingSpace)
+			pendingSpace = ""
+			switch raw[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(raw[i])
+			}
+			continue
+		case !inQuote && (c == '#' || c == ';'):
+			return sb.String()
+		case !inQuote && (c == ' ' || c == '\t'):
+			pendingSpace += string(c)
+			continue
+		}
+		sb.WriteString(pendingSpace)
+		pendingSpace = ""
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// formatGitValue quotes a value when writing it back would otherwise change it
+func formatGitValue(v string) string {
+	if v == "" || strings.ContainsAny(v, "#;\"\\\n\t") || strings.TrimSpace(v) != v {
+		r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`)
+		return `"` + r.Replace(v) + `"`
+	}
+	return v
+}
+
+// generatedGitKeys are the section.key pairs GenerateGitConfig writes itself.
+// Anything else found in an existing .gitconfig is carried over verbatim.
+var generatedGitKeys = map[string]bool{
+	"init.defaultbranch":     true,
+	"pull.rebase":            true,
+	"credential.helper":      true,
+	"commit.gpgsign":         true,
+	"push.autosetupremote":   true,
+	"user.name":              true,
+	"user.email":             true,
+	"core.pager":             true,
+	"core.editor":            true,
+	"interactive.difffilter": true,
+	"delta.navigate":         true,
+	"delta.side-by-side":     true,
+	"delta.line-numbers":     true,
+	"delta.syntax-theme":     true,
+	"merge.conflictstyle":    true,
+	"diff.colormoved":        true,
+	"alias.st":               true,
+	"alias.co":               true,
+	"alias.br":               true,
+	"alias.ci":               true,
+	"alias.lg":               true,
+}
+
+// GitCredentialHelpers are the helpers the Git editors offer
+var GitCredentialHelpers = []string{"cache", "store", "osxkeychain"}
+
+// GitImport is what an existing .gitconfig says about the settings the Git
+// editors expose. Unset values are left nil/empty so callers keep their
+// defaults for them.
+type GitImport struct {
+	DefaultBranch    string
+	PullRebase       *bool
+	SignCommits      *bool
+	DeltaSideBySide  *bool
+	AutoSetupRemote  *bool
+	CredentialHelper string // One of GitCredentialHelpers, or "none"
+	UserName         string
+	UserEmail        string
+	Extra            []GitConfigEntry // Settings the generator does not write, preserved on regeneration
+}
+
+// ImportGitConfig extracts the editable settings from gitconfig content. A
+// credential helper the editors cannot represent (e.g. "!gh auth
+// git-credential") is kept in Extra and reported as "none", so regenerating
+// writes it back unchanged instead of replacing it.
+func ImportGitConfig(content string) GitImport {
+	var imp GitImport
+	for _, e := range ParseGitConfig(content) {
+		if e.Subsection != "" || !generatedGitKeys[e.Section+"."+e.Key] {
+			imp.Extra = append(imp.Extra, e)
+			continue
+		}
+		switch e.Section + "." + e.Key {
+		case "init.defaultbranch":
+			imp.DefaultBranch = e.Value
+		case "pull.rebase":
+			// "merges" and "interactive" are rebase variants too
+			v := gitBool(e.Value) || e.Value == "merges" || e.Value == "interactive" || e.Value == "i" || e.Value == "m"
+			imp.PullRebase = &v
+		case "commit.gpgsign":
+			v := gitBool(e.Value)
+			imp.SignCommits = &v
+		case "delta.side-by-side":
+			v := gitBool(e.Value)
+			imp.DeltaSideBySide = &v
+		case "push.autosetupremote":
+			v := gitBool(e.Value)
+			imp.AutoSetupRemote = &v
+		case "credential.helper":
+			if slices.Contains(GitCredentialHelpers, e.Value) {
+				imp.CredentialHelper = e.Value
+			} else {
+				imp.Extra = append(imp.Extra, e)
+				imp.CredentialHelper = "none"
+			}
+		case "user.name":
+			imp.UserName = e.Value
+		case "user.email":
+			imp.UserEmail = e.Value
+		}
+	}
+	return imp
+}
+
+// LoadGitImport reads and imports ~/.gitconfig. ok is false if there is no
+// existing file.
+func LoadGitImport() (GitImport, bool) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return GitImport{}, false
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".gitconfig"))
+	if err != nil {
+		return GitImport{}, false
+	}
+	return ImportGitConfig(string(data)), true
+}
+
+// ApplyTo overwrites the settings in cfg that the import found
+func (imp GitImport) ApplyTo(cfg *GitConfig) {
+	if imp.DefaultBranch != "" {
+		cfg.DefaultBranch = imp.DefaultBranch
+	}
+	if imp.PullRebase != nil {
+		cfg.PullRebase = *imp.PullRebase
+	}
+	if imp.SignCommits != nil {
+		cfg.SignCommits = *imp.SignCommits
+	}
+	if imp.DeltaSideBySide != nil {
+		cfg.DeltaSideBySide = *imp.DeltaSideBySide
+	}
+	if imp.AutoSetupRemote != nil {
+		cfg.AutoSetupRemote = *imp.AutoSetupRemote
+	}
+	if imp.CredentialHelper != "" {
+		cfg.CredentialHelper = imp.CredentialHelper
+	}
+	if imp.UserName != "" {
+		cfg.UserName = imp.UserName
+	}
+	if imp.UserEmail != "" {
+		cfg.UserEmail = imp.UserEmail
+	}
+	cfg.Extra = imp.Extra
+}
+
+// gitBool interprets a gitconfig boolean
+func gitBool(v string) bool {
+	switch strings.ToLower(v) {
+	case "true", "yes", "on", "1":
+		return true
+	}
+	return false
+}
+
+// writeGitExtra appends preserved entries, grouped under their section headers
+func writeGitExtra(sb *strings.Builder, extra []GitConfigEntry) {
+	if len(extra) == 0 {
+		return
+	}
+	sb.WriteString("\n# Preserved from your previous .gitconfig\n")
+	var order []string
+	groups := make(map[string][]GitConfigEntry)
+	for _, e := range extra {
+		header := "[" + e.Section + "]"
+		if e.Subsection != "" {
+			sub := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(e.Subsection)
+			header = "[" + e.Section + " \"" + sub + "\"]"
+		}
+		if _, ok := groups[header]; !ok {
+			order = append(order, header)
+		}
+		groups[header] = append(groups[header], e)
+	}
+	for _, header := range order {
+		sb.WriteString(header + "\n")
+		for _, e := range groups[header] {
+			sb.WriteString("\t" + e.Key + " = " + formatGitValue(e.Value) + "\n")
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package tools
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseGitConfig(t *testing.T) {
+	content := `# global config
+[user]
+	name = Ada Lovelace
+	email = ada@example.com ; inline comment
+[Core]
+	AutoCRLF = input
+	excludesFile = "~/.gitignore global"
+[credential "https://github.com"]
+	helper = !gh auth git-credential
+[branch.main]
+	remote = origin
+[filter "lfs"]
+	required
+	clean = git-lfs clean -- %f
+[alias]
+	quoted = "log --format=\"%h # %s\""
+	wrapped = log \
+--oneline
+`
+	want := []GitConfigEntry{
+		{"user", "", "name", "Ada Lovelace"},
+		{"user", "", "email", "ada@example.com"},
+		{"core", "", "autocrlf", "input"},
+		{"core", "", "excludesfile", "~/.gitignore global"},
+		{"credential", "https://github.com", "helper", "!gh auth git-credential"},
+		{"branch", "main", "remote", "origin"},
+		{"filter", "lfs", "required", "true"},
+		{"filter", "lfs", "clean", "git-lfs clean -- %f"},
+		{"alias", "", "quoted", `log --format="%h # %s"`},
+		{"alias", "", "wrapped", "log --oneline"},
+	}
+
+	got := ParseGitConfig(content)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseGitConfig() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestImportGitConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		check   func(t *testing.T, imp GitImport)
+	}{
+		{
+			name:    "empty file keeps defaults",
+			content: "",
+			check: func(t *testing.T, imp GitImport) {
+				if imp.DefaultBranch != "" || imp.PullRebase != nil || imp.CredentialHelper != "" || len(imp.Extra) != 0 {
+					t.Errorf("import = %+v, want nothing set", imp)
+				}
+			},
+		},
+		{
+			name: "common settings",
+			content: `[init]
+	defaultBranch = trunk
+[pull]
+	rebase = merges
+[commit]
+	gpgSign = false
+[credential]
+	helper = osxkeychain
+[delta]
+	side-by-side = false
+`,
+			check: func(t *testing.T, imp GitImport) {
+				if imp.DefaultBranch != "trunk" {
+					t.Errorf("DefaultBranch = %q, want %q", imp.DefaultBranch, "trunk")
+				}
+				if imp.PullRebase == nil || !*imp.PullRebase {
+					t.Errorf("PullRebase = %v, want true", imp.PullRebase)
+				}
+				if imp.SignCommits == nil || *imp.SignCommits {
+					t.Errorf("SignCommits = %v, want false", imp.SignCommits)
+				}
+				if imp.DeltaSideBySide == nil || *imp.DeltaSideBySide {
+					t.Errorf("DeltaSideBySide = %v, want false", imp.DeltaSideBySide)
+				}
+				if imp.CredentialHelper != "osxkeychain" {
+					t.Errorf("CredentialHelper = %q, want %q", imp.CredentialHelper, "osxkeychain")
+				}
+				if len(imp.Extra) != 0 {
+					t.Errorf("Extra = %v, want none", imp.Extra)
+				}
+			},
+		},
+		{
+			name: "unrepresentable helper is preserved",
+			content: `[credential]
+	helper = /usr/lib/git-core/git-credential-libsecret
+`,
+			check: func(t *testing.T, imp GitImport) {
+				if imp.CredentialHelper != "none" {
+					t.Errorf("CredentialHelper = %q, want %q", imp.CredentialHelper, "none")
+				}
+				if len(imp.Extra) != 1 || imp.Extra[0].Value != "/usr/lib/git-core/git-credential-libsecret" {
+					t.Errorf("Extra = %v, want the helper", imp.Extra)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, ImportGitConfig(tt.content))
+		})
+	}
+}
+
+func TestGenerateGitConfigPreservesImported(t *testing.T) {
+	existing := `[user]
+	name = Ada Lovelace
+	email = ada@example.com
+[core]
+	pager = less
+	autocrlf = input
+[url "git@github.com:"]
+	insteadOf = https://github.com/
+[alias]
+	st = status -sb
+	amend = commit --amend --no-edit
+`
+	imp := ImportGitConfig(existing)
+	cfg := GitConfig{DefaultBranch: "main", Aliases: []string{"st"}}
+	imp.ApplyTo(&cfg)
+	out := GenerateGitConfig(cfg, "nord")
+
+	for _, want := range []string{
+		"\tname = Ada Lovelace\n",
+		"\temail = ada@example.com\n",
+		"\tautocrlf = input\n",
+		"[url \"git@github.com:\"]\n\tinsteadof = https://github.com/\n",
+		"\tamend = commit --amend --no-edit\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated config missing %q:\n%s", want, out)
+		}
+	}
+	// Keys the generator owns are regenerated, not duplicated
+	if strings.Contains(out, "pager = less") {
+		t.Errorf("generated config kept overridden core.pager:\n%s", out)
+	}
+
+	// Importing the result again yields the same preserved settings
+	again := ImportGitConfig(out)
+	if !reflect.DeepEqual(again.Extra, imp.Extra) {
+		t.Errorf("re-imported Extra = %v, want %v", again.Extra, imp.Extra)
+	}
+	if again.UserName != "Ada Lovelace" || again.UserEmail != "ada@example.com" {
+		t.Errorf("re-imported user = %q <%q>", again.UserName, again.UserEmail)
+	}
+}
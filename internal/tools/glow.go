@@ -12,7 +12,7 @@ import (
 // GlowConfig holds Glow configuration settings
 type GlowConfig struct {
 	Pager string // "auto", "less", "never"
-	Style string // "auto", "dark", "light", "dracula", etc.
+	Style string // "auto" (follow the global theme), "dark", "light", "dracula", etc.
 	Width int    // Max width for rendering (0 = terminal width)
 }
 
@@ -28,6 +28,7 @@ func NewGlowTool() *GlowTool {
 			id:          "glow",
 			name:        "Glow",
 			description: "Render markdown on the CLI",
+			url:         "https://github.com/charmbracelet/glow",
 			icon:        "󰈙",
 			category:    CategoryUtility,
 			packages: map[pkg.Platform][]string{
@@ -53,11 +54,7 @@ func GenerateGlowConfig(cfg GlowConfig, theme string) string {
 	sb.WriteString(fmt.Sprintf("# Theme: %s\n\n", theme))
 
 	// Style
-	glowStyle := cfg.Style
-	if glowStyle == "auto" {
-		glowStyle = "dark"
-	}
-	sb.WriteString(fmt.Sprintf("style: \"%s\"\n", glowStyle))
+	sb.WriteString(fmt.Sprintf("style: \"%s\"\n", GlowStyleFor(cfg.Style, theme)))
 
 	// Pager
 	if cfg.Pager == "never" {
@@ -85,12 +82,10 @@ func GenerateGlowConfig(cfg GlowConfig, theme string) string {
 
 // WriteGlowConfig writes the glow config to disk
 func WriteGlowConfig(cfg GlowConfig, theme string) error {
-	home, err := os.UserHomeDir()
+	configDir, err := userConfigDir("glow")
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
-
-	configDir := filepath.Join(home, ".config", "glow")
 	if err := os.MkdirAll(configDir, 0700); err != nil {
 		return fmt.Errorf("failed to create glow config directory: %w", err)
 	}
@@ -98,7 +93,7 @@ func WriteGlowConfig(cfg GlowConfig, theme string) error {
 	configPath := filepath.Join(configDir, "glow.yml")
 	content := GenerateGlowConfig(cfg, theme)
 
-	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+	if err := WriteGeneratedFile(configPath, content, "#", 0600); err != nil {
 		return fmt.Errorf("failed to write glow config: %w", err)
 	}
 
@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// healthCheck describes how to tell that an installed tool actually works:
+// one of the binaries must be on PATH and exit cleanly when run with args
+type healthCheck struct {
+	binaries []string // Candidate names, e.g. fd is "fdfind" on Debian
+	args     []string
+}
+
+// healthChecks covers the CLI tools. GUI apps and casks often have no binary
+// on PATH, so they are left unchecked rather than reported as broken.
+var healthChecks = map[string]healthCheck{
+	"bat":         {[]string{"bat", "batcat"}, []string{"--version"}},
+	"btop":        {[]string{"btop"}, []string{"--version"}},
+	"claude-code": {[]string{"claude"}, []string{"--version"}},
+	"delta":       {[]string{"delta"}, []string{"--version"}},
+	"eza":         {[]string{"eza"}, []string{"--version"}},
+	"fd":          {[]string{"fd", "fdfind"}, []string{"--version"}},
+	"fswatch":     {[]string{"fswatch"}, []string{"--version"}},
+	"fzf":         {[]string{"fzf"}, []string{"--version"}},
+	"git":         {[]string{"git"}, []string{"--version"}},
+	"glow":        {[]string{"glow"}, []string{"--version"}},
+	"lazydocker":  {[]string{"lazydocker"}, []string{"--version"}},
+	"lazygit":     {[]string{"lazygit"}, []string{"--version"}},
+	"neovim":      {[]string{"nvim"}, []string{"--version"}},
+	"ripgrep":     {[]string{"rg"}, []string{"--version"}},
+	"tailscale":   {[]string{"tailscale"}, []string{"version"}},
+	"tmux":        {[]string{"tmux"}, []string{"-V"}},
+	"yazi":        {[]string{"yazi"}, []string{"--version"}},
+	"zoxide":      {[]string{"zoxide"}, []string{"--version"}},
+	"zsh":         {[]string{"zsh"}, []string{"--version"}},
+}
+
+// healthTimeout bounds each version check so a hung binary counts as broken
+const healthTimeout = 5 * time.Second
+
+// HealthStatus is the result of checking whether a tool works
+type HealthStatus int
+
+const (
+	HealthOK           HealthStatus = iota // Installed and the binary runs
+	HealthBroken                           // Installed per the package manager, but the binary is missing or fails
+	HealthNotInstalled                     // Not installed
+	HealthUnchecked                        // Installed, but there is no check for it (e.g. GUI apps)
+)
+
+// String returns a short label for the status
+func (s HealthStatus) String() string {
+	switch s {
+	case HealthOK:
+		return "ok"
+	case HealthBroken:
+		return "broken"
+	case HealthNotInstalled:
+		return "not installed"
+	}
+	return "unchecked"
+}
+
+// Health is the verification result for one tool
+type Health struct {
+	Tool   Tool
+	Status HealthStatus
+	Detail string // Why the tool is broken, or the binary that was run
+}
+
+// CheckHealth verifies that an installed tool's binary is on PATH and runs
+func CheckHealth(t Tool) Health {
+	if !t.IsInstalled() {
+		return Health{Tool: t, Status: HealthNotInstalled}
+	}
+	check, ok := healthChecks[t.ID()]
+	if !ok {
+		return Health{Tool: t, Status: HealthUnchecked}
+	}
+
+	var path string
+	for _, name := range check.binaries {
+		if p, err := exec.LookPath(name); err == nil {
+			path = p
+			break
+		}
+	}
+	if path == "" {
+		return Health{Tool: t, Status: HealthBroken, Detail: fmt.Sprintf("%s not found on PATH", check.binaries[0])}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), healthTimeout)
+	defer cancel()
+	if err := exec.CommandContext(ctx, path, check.args...).Run(); err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("timed out after %s", healthTimeout)
+		}
+		return Health{Tool: t, Status: HealthBroken, Detail: fmt.Sprintf("%s failed: %v", path, err)}
+	}
+	return Health{Tool: t, Status: HealthOK, Detail: path}
+}
+
+// VerifyInstalled checks every installed tool
+func (r *Registry) VerifyInstalled() []Health {
+	var results []Health
+	for _, t := range r.Installed() {
+		results = append(results, CheckHealth(t))
+	}
+	return results
+}
+
+// Broken returns the tools whose check failed
+func Broken(results []Health) []Tool {
+	var broken []Tool
+	for _, h := range results {
+		if h.Status == HealthBroken {
+			broken = append(broken, h.Tool)
+		}
+	}
+	return broken
+}
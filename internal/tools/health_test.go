@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckHealth(t *testing.T) {
+	healthChecks["test-ok"] = healthCheck{[]string{"missing-binary", "sh"}, []string{"-c", "exit 0"}}
+	healthChecks["test-fails"] = healthCheck{[]string{"sh"}, []string{"-c", "exit 3"}}
+	healthChecks["test-missing"] = healthCheck{[]string{"definitely-not-a-binary"}, nil}
+	defer func() {
+		delete(healthChecks, "test-ok")
+		delete(healthChecks, "test-fails")
+		delete(healthChecks, "test-missing")
+	}()
+
+	tests := []struct {
+		tool       *mockTool
+		want       HealthStatus
+		wantDetail string
+	}{
+		{&mockTool{id: "test-ok", installed: true}, HealthOK, "sh"},
+		{&mockTool{id: "test-fails", installed: true}, HealthBroken, "exit status 3"},
+		{&mockTool{id: "test-missing", installed: true}, HealthBroken, "not found on PATH"},
+		{&mockTool{id: "test-ok", installed: false}, HealthNotInstalled, ""},
+		{&mockTool{id: "some-app", installed: true}, HealthUnchecked, ""},
+	}
+
+	for _, tt := range tests {
+		h := CheckHealth(tt.tool)
+		if h.Status != tt.want {
+			t.Errorf("CheckHealth(%s) status = %v, want %v", tt.tool.id, h.Status, tt.want)
+		}
+		if !strings.Contains(h.Detail, tt.wantDetail) {
+			t.Errorf("CheckHealth(%s) detail = %q, want it to contain %q", tt.tool.id, h.Detail, tt.wantDetail)
+		}
+	}
+}
+
+func TestBroken(t *testing.T) {
+	ok := &mockTool{id: "ok"}
+	bad := &mockTool{id: "bad"}
+	results := []Health{
+		{Tool: ok, Status: HealthOK},
+		{Tool: bad, Status: HealthBroken},
+		{Tool: &mockTool{id: "app"}, Status: HealthUnchecked},
+	}
+
+	broken := Broken(results)
+	if len(broken) != 1 || broken[0].ID() != "bad" {
+		t.Errorf("Broken() = %v, want only bad", broken)
+	}
+}
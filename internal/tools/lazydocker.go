@@ -1,9 +1,9 @@
 package tools
 
 import (
-	"os"
 	"path/filepath"
 
+	"github.com/tekierz/dotfiles/internal/config"
 	"github.com/tekierz/dotfiles/internal/pkg"
 )
 
@@ -14,12 +14,12 @@ type LazyDockerTool struct {
 
 // NewLazyDockerTool creates a new LazyDocker tool
 func NewLazyDockerTool() *LazyDockerTool {
-	home, _ := os.UserHomeDir()
 	return &LazyDockerTool{
 		BaseTool: BaseTool{
 			id:          "lazydocker",
 			name:        "LazyDocker",
 			description: "Simple terminal UI for Docker",
+			url:         "https://github.com/jesseduffield/lazydocker",
 			icon:        "",
 			category:    CategoryContainer,
 			packages: map[pkg.Platform][]string{
@@ -28,7 +28,7 @@ func NewLazyDockerTool() *LazyDockerTool {
 				pkg.PlatformDebian: {"lazydocker"},
 			},
 			configPaths: []string{
-				filepath.Join(home, ".config", "lazydocker", "config.yml"),
+				filepath.Join(config.ConfigHome(), "lazydocker", "config.yml"),
 			},
 			heavyTool: true, // Skip on low-memory systems (Pi Zero 2)
 			// UI metadata
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/tekierz/dotfiles/internal/config"
 	"github.com/tekierz/dotfiles/internal/pkg"
 )
 
@@ -13,7 +14,7 @@ import (
 type LazyGitConfig struct {
 	SideBySide bool
 	MouseMode  bool
-	Theme      string // "auto", "dark", "light"
+	Theme      string // "auto" (follow the global theme), "dark", "light"
 }
 
 // LazyGitTool represents LazyGit TUI for Git
@@ -23,12 +24,12 @@ type LazyGitTool struct {
 
 // NewLazyGitTool creates a new LazyGit tool
 func NewLazyGitTool() *LazyGitTool {
-	home, _ := os.UserHomeDir()
 	return &LazyGitTool{
 		BaseTool: BaseTool{
 			id:          "lazygit",
 			name:        "LazyGit",
 			description: "Simple terminal UI for Git commands",
+			url:         "https://github.com/jesseduffield/lazygit",
 			icon:        "󰊢",
 			category:    CategoryGit,
 			packages: map[pkg.Platform][]string{
@@ -37,7 +38,7 @@ func NewLazyGitTool() *LazyGitTool {
 				pkg.PlatformDebian: {"lazygit"},
 			},
 			configPaths: []string{
-				filepath.Join(home, ".config", "lazygit", "config.yml"),
+				filepath.Join(config.ConfigHome(), "lazygit", "config.yml"),
 			},
 			// UI metadata
 			uiGroup:        UIGroupCLITools,
@@ -69,13 +70,20 @@ func GenerateLazyGitConfig(cfg LazyGitConfig, theme string) string {
 	sb.WriteString("  showRandomTip: false\n")
 	sb.WriteString("  showCommandLog: true\n")
 	sb.WriteString("  showBottomLine: true\n")
-	sb.WriteString("  nerdFontsVersion: \"3\"\n\n")
+	sb.WriteString("  nerdFontsVersion: \"3\"\n")
+	if accent := themeAccent(theme); accent != "" {
+		sb.WriteString("  theme:\n")
+		sb.WriteString("    activeBorderColor:\n")
+		sb.WriteString(fmt.Sprintf("      - \"%s\"\n", accent))
+		sb.WriteString("      - bold\n")
+	}
+	sb.WriteString("\n")
 
 	// Git settings
 	sb.WriteString("git:\n")
 	sb.WriteString("  paging:\n")
 	sb.WriteString("    colorArg: always\n")
-	sb.WriteString("    pager: delta --dark --paging=never\n")
+	sb.WriteString(fmt.Sprintf("    pager: delta --%s --paging=never\n", lazyGitBackground(cfg.Theme, theme)))
 	sb.WriteString("  commit:\n")
 	sb.WriteString("    signOff: false\n")
 	sb.WriteString("  merging:\n")
@@ -110,14 +118,23 @@ func GenerateLazyGitConfig(cfg LazyGitConfig, theme string) string {
 	return sb.String()
 }
 
+// lazyGitBackground resolves the lazygit theme setting to "dark" or "light"
+func lazyGitBackground(setting, theme string) string {
+	if setting == "dark" || setting == "light" {
+		return setting
+	}
+	if IsLightTheme(theme) {
+		return "light"
+	}
+	return "dark"
+}
+
 // WriteLazyGitConfig writes the lazygit config to disk
 func WriteLazyGitConfig(cfg LazyGitConfig, theme string) error {
-	home, err := os.UserHomeDir()
+	configDir, err := userConfigDir("lazygit")
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
-
-	configDir := filepath.Join(home, ".config", "lazygit")
 	if err := os.MkdirAll(configDir, 0700); err != nil {
 		return fmt.Errorf("failed to create lazygit config directory: %w", err)
 	}
@@ -125,7 +142,7 @@ func WriteLazyGitConfig(cfg LazyGitConfig, theme string) error {
 	configPath := filepath.Join(configDir, "config.yml")
 	content := GenerateLazyGitConfig(cfg, theme)
 
-	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+	if err := WriteGeneratedFile(configPath, content, "#", 0600); err != nil {
 		return fmt.Errorf("failed to write lazygit config: %w", err)
 	}
 
@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"os/exec"
+	"slices"
+
+	"github.com/tekierz/dotfiles/internal/pkg"
+)
+
+// LSPRuntime is a language toolchain some LSP servers need: Mason installs
+// pyright and tsserver with npm and builds gopls with go, so without them the
+// server never appears, and rust-analyzer needs cargo to load a project.
+type LSPRuntime struct {
+	ID       string
+	Name     string
+	Command  string   // On PATH when the runtime is installed
+	LSPs     []string // Servers (NeovimConfig.LSPs IDs) that need it
+	Packages map[pkg.Platform][]string
+}
+
+// LSPRuntimes are the runtimes behind the Neovim LSP choices. lua_ls and
+// clangd are prebuilt binaries and need none.
+var LSPRuntimes = []LSPRuntime{
+	{
+		ID: "node", Name: "Node.js", Command: "npm", LSPs: []string{"pyright", "tsserver"},
+		Packages: map[pkg.Platform][]string{
+			pkg.PlatformMacOS:  {"node"},
+			pkg.PlatformArch:   {"nodejs", "npm"},
+			pkg.PlatformDebian: {"nodejs", "npm"},
+			pkg.PlatformPi:     {"nodejs", "npm"},
+		},
+	},
+	{
+		ID: "go", Name: "Go", Command: "go", LSPs: []string{"gopls"},
+		Packages: map[pkg.Platform][]string{
+			pkg.PlatformMacOS:  {"go"},
+			pkg.PlatformArch:   {"go"},
+			pkg.PlatformDebian: {"golang-go"},
+			pkg.PlatformPi:     {"golang-go"},
+		},
+	},
+	{
+		ID: "rust", Name: "Rust", Command: "cargo", LSPs: []string{"rust_analyzer"},
+		Packages: map[pkg.Platform][]string{
+			pkg.PlatformMacOS:  {"rust"},
+			pkg.PlatformArch:   {"rust"},
+			pkg.PlatformDebian: {"rustc", "cargo"},
+			pkg.PlatformPi:     {"rustc", "cargo"},
+		},
+	},
+}
+
+// lspLookPath finds runtime commands (replaced in tests)
+var lspLookPath = exec.LookPath
+
+// Installed reports whether the runtime's command is on PATH
+func (r LSPRuntime) Installed() bool {
+	_, err := lspLookPath(r.Command)
+	return err == nil
+}
+
+// NeededBy returns the servers in lsps that need the runtime
+func (r LSPRuntime) NeededBy(lsps []string) []string {
+	var needed []string
+	for _, id := range r.LSPs {
+		if slices.Contains(lsps, id) {
+			needed = append(needed, id)
+		}
+	}
+	return needed
+}
+
+// LSPRuntimeByID looks up a runtime in LSPRuntimes
+func LSPRuntimeByID(id string) (LSPRuntime, bool) {
+	for _, r := range LSPRuntimes {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return LSPRuntime{}, false
+}
@@ -0,0 +1,44 @@
+package tools
+
+import (
+	"os/exec"
+	"slices"
+	"testing"
+
+	"github.com/tekierz/dotfiles/internal/pkg"
+)
+
+func TestLSPRuntimes(t *testing.T) {
+	saved := lspLookPath
+	defer func() { lspLookPath = saved }()
+	lspLookPath = func(cmd string) (string, error) {
+		if cmd == "npm" {
+			return "/usr/bin/npm", nil
+		}
+		return "", exec.ErrNotFound
+	}
+
+	node, ok := LSPRuntimeByID("node")
+	if !ok || !node.Installed() {
+		t.Fatalf("node runtime = %+v, %v, want found and installed", node, ok)
+	}
+	if got := node.NeededBy([]string{"lua_ls", "tsserver", "pyright"}); !slices.Equal(got, []string{"pyright", "tsserver"}) {
+		t.Errorf("NeededBy = %v, want [pyright tsserver]", got)
+	}
+
+	golang, _ := LSPRuntimeByID("go")
+	if golang.Installed() {
+		t.Error("go should not be installed when go isn't on PATH")
+	}
+	if len(golang.NeededBy([]string{"lua_ls", "clangd"})) != 0 {
+		t.Error("lua_ls and clangd don't need go")
+	}
+
+	for _, r := range LSPRuntimes {
+		for _, p := range []pkg.Platform{pkg.PlatformMacOS, pkg.PlatformArch, pkg.PlatformDebian, pkg.PlatformPi} {
+			if len(r.Packages[p]) == 0 {
+				t.Errorf("%s has no %s packages", r.ID, p)
+			}
+		}
+	}
+}
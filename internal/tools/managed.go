@@ -0,0 +1,260 @@
+package tools
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tekierz/dotfiles/internal/config"
+)
+
+// Markers delimiting the dotfiles-owned block inside user-editable files
+const (
+	ManagedBegin = "# >>> dotfiles managed >>>"
+	ManagedEnd   = "# <<< dotfiles managed <<<"
+)
+
+// generatedHeader starts files written whole by older versions (before markers)
+const generatedHeader = "# Generated by dotfiles TUI"
+
+// MarkerTag identifies the ownership marker line dotfiles stamps into generated configs:
+//
+//	# dotfiles:managed version=2.0.1 generated=2024-01-02T15:04:05Z sha256=0123456789ab
+//
+// The hash covers the generated content (without the marker) so hand edits can be detected.
+const MarkerTag = "dotfiles:managed"
+
+// Version is recorded in ownership markers; the CLI sets it at startup
+var Version = "dev"
+
+// Marker is a parsed ownership marker
+type Marker struct {
+	Version   string
+	Generated string
+	Hash      string
+}
+
+// ManagedState describes who owns a config file
+type ManagedState string
+
+const (
+	StateManaged   ManagedState = "managed"   // Marker present, content unchanged since generation
+	StateEdited    ManagedState = "edited"    // Marker present, content changed by hand
+	StateLegacy    ManagedState = "legacy"    // Written by an older dotfiles (header, no marker)
+	StateUnmanaged ManagedState = "unmanaged" // No dotfiles marker
+	StateMissing   ManagedState = "missing"   // File does not exist
+)
+
+// contentHash returns a short sha256 of content
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// splitMarker removes the first marker line from text, returning the parsed
+// marker and the remaining body
+func splitMarker(text string) (Marker, string, bool) {
+	lines := strings.SplitAfter(text, "\n")
+	for i, line := range lines {
+		idx := strings.Index(line, MarkerTag)
+		if idx < 0 {
+			continue
+		}
+		var m Marker
+		for _, field := range strings.Fields(line[idx+len(MarkerTag):]) {
+			key, value, _ := strings.Cut(field, "=")
+			switch key {
+			case "version":
+				m.Version = value
+			case "generated":
+				m.Generated = value
+			case "sha256":
+				m.Hash = value
+			}
+		}
+		body := strings.Join(lines[:i], "") + strings.Join(lines[i+1:], "")
+		return m, body, true
+	}
+	return Marker{}, text, false
+}
+
+// stampMarker prefixes content with an ownership marker using the given
+// comment prefix. If previous already carries a marker for identical content,
+// that marker is reused so rewriting unchanged content is byte-identical.
+func stampMarker(content, commentPrefix, previous string) string {
+	hash := contentHash(content)
+	if m, _, ok := splitMarker(previous); ok && m.Hash == hash && m.Version == Version {
+		return fmt.Sprintf("%s %s version=%s generated=%s sha256=%s\n", commentPrefix, MarkerTag, m.Version, m.Generated, hash) + content
+	}
+	generated := time.Now().UTC().Format(time.RFC3339)
+	return fmt.Sprintf("%s %s version=%s generated=%s sha256=%s\n", commentPrefix, MarkerTag, Version, generated, hash) + content
+}
+
+// managedBlock returns the content between the managed block markers
+func managedBlock(text string) (string, bool) {
+	start := strings.Index(text, ManagedBegin)
+	if start < 0 {
+		return "", false
+	}
+	rest := text[start+len(ManagedBegin):]
+	end := strings.Index(rest, ManagedEnd)
+	if end < 0 {
+		return "", false
+	}
+	return strings.TrimPrefix(rest[:end], "\n"), true
+}
+
+// WrapManaged surrounds content with the managed block markers
+func WrapManaged(content string) string {
+	return ManagedBegin + "\n" + strings.TrimRight(content, "\n") + "\n" + ManagedEnd + "\n"
+}
+
+// ApplyManagedBlock returns existing with its managed block replaced by content.
+// If no markers are present the block is appended once, preserving user content.
+func ApplyManagedBlock(existing, content string) string {
+	block := WrapManaged(content)
+
+	if start := strings.Index(existing, ManagedBegin); start >= 0 {
+		if rel := strings.Index(existing[start:], ManagedEnd); rel >= 0 {
+			end := start + rel + len(ManagedEnd)
+			if end < len(existing) && existing[end] == '\n' {
+				end++
+			}
+			return existing[:start] + block + existing[end:]
+		}
+	}
+
+	if strings.TrimSpace(existing) == "" {
+		return block
+	}
+	if !strings.HasSuffix(existing, "\n") {
+		existing += "\n"
+	}
+	return existing + "\n" + block
+}
+
+// WriteManagedFile writes content into the managed block of path, keeping
+// anything the user added outside the markers. Files generated whole by older
+// versions (no markers, generated header) are replaced rather than appended to.
+func WriteManagedFile(path, content string, perm os.FileMode) error {
+	existing := ""
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err == nil {
+		existing = string(data)
+	}
+
+	content = strings.TrimRight(content, "\n") + "\n"
+	if UpToDate(path, content) {
+		return nil
+	}
+
+	if !strings.Contains(existing, ManagedBegin) && strings.HasPrefix(existing, generatedHeader) {
+		existing = ""
+	}
+
+	previous, _ := managedBlock(existing)
+	content = stampMarker(content, "#", previous)
+
+	if err := os.WriteFile(path, []byte(ApplyManagedBlock(existing, content)), perm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// WriteGeneratedFile writes a whole generated config file stamped with an
+// ownership marker (commentPrefix is the file's comment syntax, e.g. "#" or "--").
+// Errors are returned unwrapped so callers can describe the file themselves.
+func WriteGeneratedFile(path, content, commentPrefix string, perm os.FileMode) error {
+	if UpToDate(path, content) {
+		return nil
+	}
+	previous, _ := os.ReadFile(path)
+	return os.WriteFile(path, []byte(stampMarker(content, commentPrefix, string(previous))), perm)
+}
+
+// UpToDate reports whether path already holds content exactly as dotfiles
+// generated it: the marker's hash matches and the file hasn't been edited
+// since. The Write functions skip such files entirely, so repeated installs
+// leave them (and their modification times) alone.
+func UpToDate(path, content string) bool {
+	info := InspectManaged(path)
+	return info.State == StateManaged && info.Marker.Hash == contentHash(content)
+}
+
+// ManagedFile describes the ownership of one config file
+type ManagedFile struct {
+	Path   string
+	State  ManagedState
+	Marker Marker
+}
+
+// InspectManaged reports whether path was written by dotfiles and whether it
+// has been edited since. For files with a managed block only the block counts.
+func InspectManaged(path string) ManagedFile {
+	info := ManagedFile{Path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		info.State = StateMissing
+		if !os.IsNotExist(err) {
+			info.State = StateUnmanaged
+		}
+		return info
+	}
+
+	text := string(data)
+	if block, ok := managedBlock(text); ok {
+		text = block
+	}
+
+	marker, body, ok := splitMarker(text)
+	switch {
+	case ok:
+		info.Marker = marker
+		info.State = StateManaged
+		if contentHash(body) != marker.Hash {
+			info.State = StateEdited
+		}
+	case strings.Contains(text, "Generated by dotfiles TUI"):
+		info.State = StateLegacy
+	default:
+		info.State = StateUnmanaged
+	}
+	return info
+}
+
+// extraGeneratedPaths are files dotfiles writes that aren't listed in a
+// tool's ConfigPaths (relative to home)
+var extraGeneratedPaths = map[string][]string{
+	"fzf":    {".config/fzf/fzf.zsh"},
+	"glow":   {".config/glow/glow.yml"},
+	"neovim": {".config/nvim/lua/custom/options.lua"},
+}
+
+// ConfigFile is a config path attributed to the tool that owns it
+type ConfigFile struct {
+	ToolID string
+	Path   string
+}
+
+// KnownConfigFiles lists every config file dotfiles may generate, by tool
+func KnownConfigFiles() []ConfigFile {
+	home, _ := os.UserHomeDir()
+
+	var files []ConfigFile
+	for _, t := range GetRegistry().All() {
+		for _, p := range t.ConfigPaths() {
+			files = append(files, ConfigFile{ToolID: t.ID(), Path: p})
+		}
+		for _, rel := range extraGeneratedPaths[t.ID()] {
+			files = append(files, ConfigFile{ToolID: t.ID(), Path: config.HomePath(home, rel)})
+		}
+	}
+	return files
+}
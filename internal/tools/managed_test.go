@@ -0,0 +1,210 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tekierz/dotfiles/internal/testutil"
+)
+
+func TestApplyManagedBlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		content  string
+		want     string
+	}{
+		{
+			name:     "empty file",
+			existing: "",
+			content:  "a\n",
+			want:     ManagedBegin + "\na\n" + ManagedEnd + "\n",
+		},
+		{
+			name:     "append to user content",
+			existing: "export FOO=1",
+			content:  "a",
+			want:     "export FOO=1\n\n" + ManagedBegin + "\na\n" + ManagedEnd + "\n",
+		},
+		{
+			name:     "replace between markers",
+			existing: "before\n" + ManagedBegin + "\nold\n" + ManagedEnd + "\nafter\n",
+			content:  "new",
+			want:     "before\n" + ManagedBegin + "\nnew\n" + ManagedEnd + "\nafter\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ApplyManagedBlock(tt.existing, tt.content)
+			if got != tt.want {
+				t.Errorf("ApplyManagedBlock() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteZshConfigIdempotent(t *testing.T) {
+	testutil.TempConfigDir(t)
+	home, _ := os.UserHomeDir()
+	zshrc := filepath.Join(home, ".zshrc")
+
+	// User content that must survive
+	testutil.CreateTempFile(t, home, ".zshrc", "# my stuff\nexport EDITOR=nvim\n")
+
+	cfg := ZshConfig{
+		PromptStyle:     "starship",
+		Plugins:         []string{"zsh-autosuggestions"},
+		Aliases:         map[string]bool{"ll": true},
+		HistorySize:     10000,
+		AutoCD:          true,
+		SyntaxHighlight: true,
+		Autosuggestions: true,
+	}
+
+	if err := WriteZshConfig(cfg, "dracula"); err != nil {
+		t.Fatalf("first WriteZshConfig failed: %v", err)
+	}
+	first := testutil.MustReadFile(t, zshrc)
+
+	if err := WriteZshConfig(cfg, "dracula"); err != nil {
+		t.Fatalf("second WriteZshConfig failed: %v", err)
+	}
+	second := testutil.MustReadFile(t, zshrc)
+
+	if first != second {
+		t.Errorf("consecutive runs differ:\n--- first ---\n%s\n--- second ---\n%s", first, second)
+	}
+	if n := strings.Count(second, ManagedBegin); n != 1 {
+		t.Errorf("managed block appears %d times, want 1", n)
+	}
+	testutil.RequireContains(t, second, "export EDITOR=nvim", "user content should be preserved")
+}
+
+func TestInspectManaged(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config")
+
+	if got := InspectManaged(path).State; got != StateMissing {
+		t.Errorf("missing file state = %q, want %q", got, StateMissing)
+	}
+
+	if err := WriteGeneratedFile(path, "font-size = 14\n", "#", 0600); err != nil {
+		t.Fatalf("WriteGeneratedFile failed: %v", err)
+	}
+	first := testutil.MustReadFile(t, path)
+	testutil.RequireContains(t, first, MarkerTag+" version="+Version, "marker should record version")
+
+	info := InspectManaged(path)
+	if info.State != StateManaged {
+		t.Errorf("generated file state = %q, want %q", info.State, StateManaged)
+	}
+	if info.Marker.Generated == "" {
+		t.Error("marker should record a timestamp")
+	}
+
+	// Rewriting identical content keeps the file byte-identical
+	if err := WriteGeneratedFile(path, "font-size = 14\n", "#", 0600); err != nil {
+		t.Fatalf("WriteGeneratedFile failed: %v", err)
+	}
+	if second := testutil.MustReadFile(t, path); second != first {
+		t.Errorf("rewrite changed file:\n%s\nvs\n%s", first, second)
+	}
+
+	testutil.CreateTempFile(t, dir, "config", first+"font-family = Iosevka\n")
+	if got := InspectManaged(path).State; got != StateEdited {
+		t.Errorf("hand-edited file state = %q, want %q", got, StateEdited)
+	}
+
+	testutil.CreateTempFile(t, dir, "config", generatedHeader+"\nfoo\n")
+	if got := InspectManaged(path).State; got != StateLegacy {
+		t.Errorf("legacy file state = %q, want %q", got, StateLegacy)
+	}
+
+	testutil.CreateTempFile(t, dir, "config", "hand written\n")
+	if got := InspectManaged(path).State; got != StateUnmanaged {
+		t.Errorf("hand-written file state = %q, want %q", got, StateUnmanaged)
+	}
+}
+
+func TestInspectManagedBlock(t *testing.T) {
+	dir := t.TempDir()
+	path := testutil.CreateTempFile(t, dir, ".zshrc", "export EDITOR=nvim\n")
+
+	if err := WriteManagedFile(path, "alias ll='eza -l'\n", 0600); err != nil {
+		t.Fatalf("WriteManagedFile failed: %v", err)
+	}
+	if got := InspectManaged(path).State; got != StateManaged {
+		t.Errorf("managed block state = %q, want %q", got, StateManaged)
+	}
+
+	// Edits outside the block don't count as drift
+	content := testutil.MustReadFile(t, path)
+	testutil.CreateTempFile(t, dir, ".zshrc", content+"export PAGER=less\n")
+	if got := InspectManaged(path).State; got != StateManaged {
+		t.Errorf("state after outside edit = %q, want %q", got, StateManaged)
+	}
+
+	edited := strings.Replace(content, "eza -l", "ls -l", 1)
+	testutil.CreateTempFile(t, dir, ".zshrc", edited)
+	if got := InspectManaged(path).State; got != StateEdited {
+		t.Errorf("state after block edit = %q, want %q", got, StateEdited)
+	}
+}
+
+func TestWriteUnchangedConfigIsNoop(t *testing.T) {
+	testutil.TempConfigDir(t)
+	home, _ := os.UserHomeDir()
+	tmuxCfg := TmuxConfig{Prefix: "ctrl-a", MouseMode: true}
+	gitCfg := GitConfig{DefaultBranch: "main", PullRebase: true}
+
+	writes := []struct {
+		path     string
+		write    func() error
+		upToDate func() bool
+	}{
+		{filepath.Join(home, ".tmux.conf"), func() error { return WriteTmuxConfig(tmuxCfg, "nord") }, func() bool { return TmuxConfigUpToDate(tmuxCfg, "nord") }},
+		{filepath.Join(home, ".gitconfig"), func() error { return WriteGitConfig(gitCfg, "nord") }, func() bool { return GitConfigUpToDate(gitCfg, "nord") }},
+	}
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for _, w := range writes {
+		if w.upToDate() {
+			t.Errorf("%s up to date before it was written", w.path)
+		}
+		if err := w.write(); err != nil {
+			t.Fatalf("first write of %s failed: %v", w.path, err)
+		}
+		if !w.upToDate() {
+			t.Errorf("%s not up to date after writing it", w.path)
+		}
+		first := testutil.MustReadFile(t, w.path)
+		if err := os.Chtimes(w.path, old, old); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := w.write(); err != nil {
+			t.Fatalf("second write of %s failed: %v", w.path, err)
+		}
+		info, err := os.Stat(w.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !info.ModTime().Equal(old) {
+			t.Errorf("%s was rewritten (mtime %v, want %v)", w.path, info.ModTime(), old)
+		}
+		if got := testutil.MustReadFile(t, w.path); got != first {
+			t.Errorf("%s changed on the no-op run", w.path)
+		}
+
+		// A hand edit means the file is no longer up to date
+		if err := os.WriteFile(w.path, []byte(first+"# edited\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if w.upToDate() {
+			t.Errorf("%s reported up to date after an edit", w.path)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/tekierz/dotfiles/internal/runner"
+)
+
+// MirrorResult reports what MirrorToRepo committed
+type MirrorResult struct {
+	Repo   string   // Repository path, with ~ expanded
+	Files  []string // Files committed, relative to the repo
+	Commit string   // Short hash of the new commit ("" if nothing changed)
+}
+
+// MirrorToRepo copies the config files dotfiles generated into the git repo
+// at repo, at their paths relative to the home directory (~/.tmux.conf →
+// <repo>/.tmux.conf), and commits the ones that changed. reason describes
+// what generated them, for the commit message (e.g. "install").
+//
+// Only the mirrored files are staged and committed, so other work in the
+// repo is left alone. It refuses a path that isn't a git work tree, and
+// refuses to overwrite mirrored files that have uncommitted changes there.
+func MirrorToRepo(ctx context.Context, repo, reason string) (MirrorResult, error) {
+	return mirrorToRepo(ctx, repo, reason, homeDir(), KnownConfigFiles())
+}
+
+// mirrorToRepo is MirrorToRepo for the given home directory and files
+func mirrorToRepo(ctx context.Context, repo, reason, home string, files []ConfigFile) (MirrorResult, error) {
+	if rest, ok := strings.CutPrefix(repo, "~/"); ok {
+		repo = filepath.Join(home, rest)
+	}
+	result := MirrorResult{Repo: repo}
+	if _, err := exec.LookPath("git"); err != nil {
+		return result, fmt.Errorf("git is not installed")
+	}
+
+	git := func(args ...string) ([]string, error) {
+		return runner.Run(ctx, "git", append([]string{"-C", repo}, args...)...)
+	}
+	if _, err := git("rev-parse", "--is-inside-work-tree"); err != nil {
+		return result, fmt.Errorf("%s is not a git repository (run 'git init' there first)", repo)
+	}
+
+	sources := mirrorSources(home, files)
+	if len(sources) == 0 {
+		return result, nil
+	}
+	rels := make([]string, 0, len(sources))
+	for rel := range sources {
+		rels = append(rels, rel)
+	}
+
+	dirty, err := git(append([]string{"status", "--porcelain", "--"}, rels...)...)
+	if err != nil {
+		return result, fmt.Errorf("git status failed: %w", err)
+	}
+	if len(dirty) > 0 {
+		return result, fmt.Errorf("%s has uncommitted changes to mirrored files (%s); commit or discard them first",
+			repo, strings.Join(porcelainPaths(dirty), ", "))
+	}
+
+	for rel, src := range sources {
+		if err := copyMirrorFile(src, filepath.Join(repo, rel)); err != nil {
+			return result, err
+		}
+	}
+
+	if _, err := git(append([]string{"add", "--"}, rels...)...); err != nil {
+		return result, fmt.Errorf("git add failed: %w", err)
+	}
+	changed, err := git(append([]string{"diff", "--cached", "--name-only", "--"}, rels...)...)
+	if err != nil {
+		return result, fmt.Errorf("git diff failed: %w", err)
+	}
+	if len(changed) == 0 {
+		return result, nil
+	}
+
+	message := fmt.Sprintf("dotfiles: update %d config file(s) after %s\n\n%s\n",
+		len(changed), reason, strings.Join(changed, "\n"))
+	if _, err := git(append([]string{"commit", "-m", message, "--"}, changed...)...); err != nil {
+		return result, fmt.Errorf("git commit failed: %w", err)
+	}
+	result.Files = changed
+	if head, err := git("rev-parse", "--short", "HEAD"); err == nil && len(head) > 0 {
+		result.Commit = head[0]
+	}
+	return result, nil
+}
+
+// mirrorSources maps the repo-relative path of each of files that dotfiles
+// generated (managed, edited or legacy) under home to its location
+func mirrorSources(home string, files []ConfigFile) map[string]string {
+	sources := make(map[string]string)
+	for _, f := range files {
+		switch InspectManaged(f.Path).State {
+		case StateMissing, StateUnmanaged:
+			continue
+		}
+		rel, err := filepath.Rel(home, f.Path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		sources[filepath.ToSlash(rel)] = f.Path
+	}
+	return sources
+}
+
+// porcelainPaths returns the paths from `git status --porcelain` lines
+func porcelainPaths(lines []string) []string {
+	paths := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if len(line) > 3 {
+			paths = append(paths, line[3:])
+		}
+	}
+	return paths
+}
+
+// copyMirrorFile copies src to dst, creating dst's directory
+func copyMirrorFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", src, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(dst), err)
+	}
+	if err := os.WriteFile(dst, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", dst, err)
+	}
+	return nil
+}
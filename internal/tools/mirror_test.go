@@ -0,0 +1,68 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMirrorToRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	home := t.TempDir()
+	repo := filepath.Join(home, "dotfiles")
+	ctx := context.Background()
+
+	tmuxConf := filepath.Join(home, ".tmux.conf")
+	if err := WriteGeneratedFile(tmuxConf, "set -g mouse on\n", "#", 0600); err != nil {
+		t.Fatal(err)
+	}
+	handWritten := filepath.Join(home, ".gitconfig")
+	if err := os.WriteFile(handWritten, []byte("[user]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	files := []ConfigFile{
+		{ToolID: "tmux", Path: tmuxConf},
+		{ToolID: "git", Path: handWritten},
+		{ToolID: "yazi", Path: filepath.Join(home, ".config", "yazi", "yazi.toml")},
+	}
+
+	if _, err := mirrorToRepo(ctx, repo, "install", home, files); err == nil || !strings.Contains(err.Error(), "not a git repository") {
+		t.Fatalf("mirror into a missing repo: err = %v, want a not-a-git-repository error", err)
+	}
+
+	if err := exec.Command("git", "init", "-q", repo).Run(); err != nil {
+		t.Fatal(err)
+	}
+	result, err := mirrorToRepo(ctx, repo, "install", home, files)
+	if err != nil {
+		t.Fatalf("mirrorToRepo failed: %v", err)
+	}
+	// Only the generated file is mirrored; the hand-written one isn't ours
+	if len(result.Files) != 1 || result.Files[0] != ".tmux.conf" || result.Commit == "" {
+		t.Fatalf("result = %+v, want .tmux.conf committed", result)
+	}
+
+	// Nothing changed: no new commit
+	if result, err := mirrorToRepo(ctx, repo, "install", home, files); err != nil || result.Commit != "" {
+		t.Errorf("second mirror = %+v, %v; want no commit", result, err)
+	}
+
+	// Uncommitted edits in the repo are never overwritten
+	if err := os.WriteFile(filepath.Join(repo, ".tmux.conf"), []byte("edited\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mirrorToRepo(ctx, repo, "install", home, files); err == nil || !strings.Contains(err.Error(), "uncommitted changes") {
+		t.Errorf("mirror over a dirty file: err = %v, want an uncommitted-changes error", err)
+	}
+}
@@ -16,6 +16,7 @@ func NewMoonlightTool() *MoonlightTool {
 			id:          "moonlight",
 			name:        "Moonlight",
 			description: "Open-source game streaming client",
+			url:         "https://moonlight-stream.org",
 			icon:        "🌙",
 			category:    CategoryUtility,
 			packages: map[pkg.Platform][]string{
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/tekierz/dotfiles/internal/config"
 	"github.com/tekierz/dotfiles/internal/pkg"
 )
 
@@ -28,12 +29,12 @@ type NeovimTool struct {
 
 // NewNeovimTool creates a new Neovim tool
 func NewNeovimTool() *NeovimTool {
-	home, _ := os.UserHomeDir()
 	return &NeovimTool{
 		BaseTool: BaseTool{
 			id:          "neovim",
 			name:        "Neovim",
 			description: "Hyperextensible Vim-based text editor",
+			url:         "https://neovim.io/doc/",
 			icon:        "",
 			category:    CategoryEditor,
 			packages: map[pkg.Platform][]string{
@@ -42,7 +43,7 @@ func NewNeovimTool() *NeovimTool {
 				pkg.PlatformDebian: {"neovim"},
 			},
 			configPaths: []string{
-				filepath.Join(home, ".config", "nvim", "init.lua"),
+				filepath.Join(config.ConfigHome(), "nvim", "init.lua"),
 			},
 			// UI metadata
 			uiGroup:        UIGroupNone,
@@ -114,13 +115,11 @@ func GenerateNeovimConfig(cfg NeovimConfig, theme string) string {
 
 // WriteNeovimConfig writes the neovim configuration to disk
 func WriteNeovimConfig(cfg NeovimConfig, theme string) error {
-	home, err := os.UserHomeDir()
+	nvimDir, err := userConfigDir("nvim")
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
 
-	nvimDir := filepath.Join(home, ".config", "nvim")
-
 	// Handle preset configurations
 	switch cfg.ConfigPreset {
 	case "kickstart", "lazyvim":
@@ -176,7 +175,7 @@ func writeNeovimUserPrefs(cfg NeovimConfig, theme, nvimDir string) error {
 	prefsPath := filepath.Join(luaDir, "options.lua")
 	content := GenerateNeovimConfig(cfg, theme)
 
-	if err := os.WriteFile(prefsPath, []byte(content), 0600); err != nil {
+	if err := WriteGeneratedFile(prefsPath, content, "--", 0600); err != nil {
 		return fmt.Errorf("failed to write user preferences: %w", err)
 	}
 
@@ -204,7 +203,7 @@ func writeMinimalNeovimConfig(cfg NeovimConfig, theme, nvimDir string) error {
 	initPath := filepath.Join(nvimDir, "init.lua")
 	content := GenerateNeovimConfig(cfg, theme)
 
-	if err := os.WriteFile(initPath, []byte(content), 0600); err != nil {
+	if err := WriteGeneratedFile(initPath, content, "--", 0600); err != nil {
 		return fmt.Errorf("failed to write init.lua: %w", err)
 	}
 
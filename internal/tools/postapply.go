@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tekierz/dotfiles/internal/runner"
+)
+
+// postApplyTimeout bounds each PostApply command, so one waiting on input
+// that never comes can't hang an install
+const postApplyTimeout = 30 * time.Second
+
+// RunPostApply runs t's PostApply commands in order through bash and
+// returns an error for each one that failed. A failure doesn't stop the
+// commands after it: the config is already written, so these only make it
+// take effect sooner and callers report them as warnings.
+func RunPostApply(ctx context.Context, t Tool) []error {
+	var errs []error
+	for _, command := range t.PostApply() {
+		if err := runPostApplyCommand(ctx, command); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %q: %w", t.ID(), command, err))
+		}
+	}
+	return errs
+}
+
+// runPostApplyCommand runs one command, folding its last line of output
+// into the error when it fails
+func runPostApplyCommand(ctx context.Context, command string) error {
+	if err := runner.ValidateShell(command); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, postApplyTimeout)
+	defer cancel()
+
+	lines, err := runner.Run(ctx, "bash", "-c", command)
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %s", postApplyTimeout)
+	}
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			return fmt.Errorf("%w: %s", err, line)
+		}
+	}
+	return err
+}
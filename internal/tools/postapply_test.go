@@ -0,0 +1,46 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRunPostApply(t *testing.T) {
+	dir := writeToolFiles(t, map[string]string{
+		"reload.json": `{"id": "reload", "packages": {"all": ["reload"]},
+			"post_apply": ["true", "echo cache missing >&2; exit 3", "if then", "true"]}`,
+	})
+	loaded, errs := LoadCustomTools(dir)
+	if len(errs) != 0 || len(loaded) != 1 {
+		t.Fatalf("LoadCustomTools() = %v, %v", loaded, errs)
+	}
+	if got := loaded[0].PostApply(); len(got) != 4 {
+		t.Fatalf("PostApply() = %v, want the 4 post_apply commands", got)
+	}
+
+	// Failures are collected and don't stop the commands after them
+	errs = RunPostApply(context.Background(), loaded[0])
+	if len(errs) != 2 {
+		t.Fatalf("RunPostApply() = %v, want the exit 3 and syntax error failures", errs)
+	}
+	if !strings.Contains(errs[0].Error(), "cache missing") {
+		t.Errorf("errs[0] = %v, want the command's output in the error", errs[0])
+	}
+	if !strings.Contains(errs[1].Error(), "syntax error") {
+		t.Errorf("errs[1] = %v, want a syntax error", errs[1])
+	}
+}
+
+func TestPostApplyDefaults(t *testing.T) {
+	reg := NewRegistry()
+	for _, id := range []string{"tmux", "bat"} {
+		tool, ok := reg.Get(id)
+		if !ok {
+			t.Fatalf("%s not registered", id)
+		}
+		if len(tool.PostApply()) == 0 {
+			t.Errorf("%s has no PostApply commands, want a default", id)
+		}
+	}
+}
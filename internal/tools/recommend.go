@@ -0,0 +1,43 @@
+package tools
+
+import "sort"
+
+// Recommendations maps a tool to tools that work well alongside it
+var Recommendations = map[string][]string{
+	"git":     {"delta", "lazygit"},
+	"lazygit": {"delta"},
+	"neovim":  {"ripgrep", "fd"},
+	"fzf":     {"fd", "bat"},
+	"yazi":    {"fd", "ripgrep", "zoxide"},
+	"zsh":     {"zoxide", "eza"},
+	"glow":    {"bat"},
+}
+
+// Recommendation is a tool suggested by others the user has or picked
+type Recommendation struct {
+	ID      string
+	Because []string // Sorted IDs of the tools that recommend it
+}
+
+// Recommend returns the tools the user lacks that their tools recommend, sorted by ID
+func Recommend(have func(id string) bool) []Recommendation {
+	because := make(map[string][]string)
+	for id, recs := range Recommendations {
+		if !have(id) {
+			continue
+		}
+		for _, rec := range recs {
+			if !have(rec) {
+				because[rec] = append(because[rec], id)
+			}
+		}
+	}
+
+	out := make([]Recommendation, 0, len(because))
+	for id, from := range because {
+		sort.Strings(from)
+		out = append(out, Recommendation{ID: id, Because: from})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
@@ -0,0 +1,37 @@
+package tools
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRecommend(t *testing.T) {
+	have := map[string]bool{"lazygit": true, "git": true, "neovim": true, "ripgrep": true}
+	recs := Recommend(func(id string) bool { return have[id] })
+
+	got := make(map[string][]string)
+	for _, r := range recs {
+		got[r.ID] = r.Because
+	}
+	if !slices.Equal(got["delta"], []string{"git", "lazygit"}) {
+		t.Errorf("delta because %v, want [git lazygit]", got["delta"])
+	}
+	if !slices.Equal(got["fd"], []string{"neovim"}) {
+		t.Errorf("fd because %v, want [neovim]", got["fd"])
+	}
+	if _, ok := got["ripgrep"]; ok {
+		t.Error("ripgrep is already there and shouldn't be recommended")
+	}
+	if len(recs) != 2 || recs[0].ID != "delta" {
+		t.Errorf("recommendations = %+v, want delta and fd, sorted", recs)
+	}
+
+	reg := NewRegistry()
+	for id, recs := range Recommendations {
+		for _, rec := range append([]string{id}, recs...) {
+			if _, ok := reg.Get(rec); !ok {
+				t.Errorf("recommendation %s → %s names an unknown tool %s", id, recs, rec)
+			}
+		}
+	}
+}
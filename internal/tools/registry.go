@@ -24,6 +24,10 @@ type Registry struct {
 	installedCache map[string]bool
 	cachePopulated bool
 	cacheMu        sync.RWMutex
+
+	// Problems found loading user-defined tools from tools.d
+	conflicts    []Conflict
+	customErrors []error
 }
 
 // GetRegistry returns the global singleton registry.
@@ -31,6 +35,9 @@ type Registry struct {
 func GetRegistry() *Registry {
 	globalRegistryOnce.Do(func() {
 		globalRegistry = NewRegistry()
+		custom, errs := LoadCustomTools(CustomToolsDir())
+		globalRegistry.customErrors = errs
+		globalRegistry.conflicts = globalRegistry.MergeCustom(custom)
 	})
 	return globalRegistry
 }
@@ -98,6 +105,17 @@ func (r *Registry) Register(t Tool) {
 	r.tools[t.ID()] = t
 }
 
+// Conflicts returns the duplicate IDs and shared packages resolved when
+// merging user-defined tools
+func (r *Registry) Conflicts() []Conflict {
+	return r.conflicts
+}
+
+// CustomErrors returns the tools.d files that could not be loaded
+func (r *Registry) CustomErrors() []error {
+	return r.customErrors
+}
+
 // Get returns a tool by ID
 func (r *Registry) Get(id string) (Tool, bool) {
 	t, ok := r.tools[id]
@@ -1,6 +1,8 @@
 package tools
 
 import (
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/tekierz/dotfiles/internal/pkg"
@@ -62,6 +64,14 @@ func TestRegistry_All(t *testing.T) {
 	}
 }
 
+func TestRegistry_URLs(t *testing.T) {
+	for _, tool := range NewRegistry().All() {
+		if url := tool.URL(); !strings.HasPrefix(url, "https://") {
+			t.Errorf("%s URL = %q, want an https docs link", tool.ID(), url)
+		}
+	}
+}
+
 func TestRegistry_ByCategory(t *testing.T) {
 	r := NewRegistry()
 
@@ -119,23 +129,27 @@ type mockTool struct {
 	installed   bool
 }
 
-func (t *mockTool) ID() string                           { return t.id }
-func (t *mockTool) Name() string                         { return t.name }
-func (t *mockTool) Description() string                  { return t.description }
-func (t *mockTool) Icon() string                         { return t.icon }
-func (t *mockTool) Category() Category                   { return t.category }
-func (t *mockTool) Packages() map[pkg.Platform][]string  { return t.packages }
-func (t *mockTool) IsInstalled() bool                    { return t.installed }
-func (t *mockTool) Install(mgr pkg.PackageManager) error { return nil }
-func (t *mockTool) ConfigPaths() []string                { return nil }
-func (t *mockTool) HasConfig() bool                      { return false }
-func (t *mockTool) GenerateConfig(theme string) string   { return "" }
-func (t *mockTool) ApplyConfig(theme string) error       { return nil }
-func (t *mockTool) IsHeavy() bool                        { return false }
-func (t *mockTool) UIGroup() UIGroup                     { return UIGroupNone }
-func (t *mockTool) ConfigScreen() int                    { return 0 }
-func (t *mockTool) DefaultEnabled() bool                 { return false }
-func (t *mockTool) PlatformFilter() pkg.Platform         { return "" }
+func (t *mockTool) ID() string                                { return t.id }
+func (t *mockTool) Name() string                              { return t.name }
+func (t *mockTool) Description() string                       { return t.description }
+func (t *mockTool) URL() string                               { return "" }
+func (t *mockTool) Icon() string                              { return t.icon }
+func (t *mockTool) Category() Category                        { return t.category }
+func (t *mockTool) Packages() map[pkg.Platform][]string       { return t.packages }
+func (t *mockTool) IsInstalled() bool                         { return t.installed }
+func (t *mockTool) Install(mgr pkg.PackageManager) error      { return nil }
+func (t *mockTool) Version() string                           { return "" }
+func (t *mockTool) InstalledVersion(map[string]string) string { return "" }
+func (t *mockTool) ConfigPaths() []string                     { return nil }
+func (t *mockTool) HasConfig() bool                           { return false }
+func (t *mockTool) GenerateConfig(theme string) string        { return "" }
+func (t *mockTool) ApplyConfig(theme string) error            { return nil }
+func (t *mockTool) PostApply() []string                       { return nil }
+func (t *mockTool) IsHeavy() bool                             { return false }
+func (t *mockTool) UIGroup() UIGroup                          { return UIGroupNone }
+func (t *mockTool) ConfigScreen() int                         { return 0 }
+func (t *mockTool) DefaultEnabled() bool                      { return false }
+func (t *mockTool) PlatformFilter() pkg.Platform              { return "" }
 
 func TestCategoryConstants(t *testing.T) {
 	// Verify all category constants are distinct
@@ -238,3 +252,41 @@ func TestToolConfigPaths(t *testing.T) {
 		}
 	}
 }
+
+func TestToolConfigPathsFollowXDG(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	r := NewRegistry()
+
+	for _, id := range []string{"neovim", "ghostty", "yazi"} {
+		tool, ok := r.Get(id)
+		if !ok {
+			t.Fatalf("tool %s not registered", id)
+		}
+		for _, p := range tool.ConfigPaths() {
+			if !strings.HasPrefix(p, xdg+string(filepath.Separator)) {
+				t.Errorf("%s config path %q is not under XDG_CONFIG_HOME %q", id, p, xdg)
+			}
+		}
+	}
+}
+
+func TestPackageNames(t *testing.T) {
+	got := PackageNames([]string{"lazygit", "does-not-exist"})
+	if len(got) != 1 || got[0] != "lazygit" {
+		t.Errorf("PackageNames(lazygit) = %v, want [lazygit]", got)
+	}
+	if got := PackageNames(nil); len(got) != 0 {
+		t.Errorf("PackageNames(nil) = %v, want empty", got)
+	}
+}
+
+func TestParseCategory(t *testing.T) {
+	if c, err := ParseCategory(" Shell "); err != nil || c != CategoryShell {
+		t.Errorf("ParseCategory(Shell) = %q, %v; want %q", c, err, CategoryShell)
+	}
+	_, err := ParseCategory("bogus")
+	if err == nil || !strings.Contains(err.Error(), "valid: shell, terminal") {
+		t.Errorf("ParseCategory(bogus) error = %v, want list of valid categories", err)
+	}
+}
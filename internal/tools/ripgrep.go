@@ -1,9 +1,9 @@
 package tools
 
 import (
-	"os"
 	"path/filepath"
 
+	"github.com/tekierz/dotfiles/internal/config"
 	"github.com/tekierz/dotfiles/internal/pkg"
 )
 
@@ -14,12 +14,12 @@ type RipgrepTool struct {
 
 // NewRipgrepTool creates a new ripgrep tool
 func NewRipgrepTool() *RipgrepTool {
-	home, _ := os.UserHomeDir()
 	return &RipgrepTool{
 		BaseTool: BaseTool{
 			id:          "ripgrep",
 			name:        "ripgrep",
 			description: "Fast recursive grep alternative",
+			url:         "https://github.com/BurntSushi/ripgrep",
 			icon:        "󰑐",
 			category:    CategoryUtility,
 			packages: map[pkg.Platform][]string{
@@ -28,7 +28,7 @@ func NewRipgrepTool() *RipgrepTool {
 				pkg.PlatformDebian: {"ripgrep"},
 			},
 			configPaths: []string{
-				filepath.Join(home, ".config", "ripgrep", "config"),
+				filepath.Join(config.ConfigHome(), "ripgrep", "config"),
 			},
 			// UI metadata
 			uiGroup:        UIGroupCLIUtilities,
@@ -0,0 +1,189 @@
+package tools
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/tekierz/dotfiles/internal/pkg"
+)
+
+// LoginShell returns the current user's login shell from the user database
+// (getent on Linux, dscl on macOS), falling back to $SHELL
+func LoginShell() string {
+	if u, err := user.Current(); err == nil {
+		var cmd *exec.Cmd
+		var parse func(string) string
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("dscl", ".", "-read", "/Users/"+u.Username, "UserShell")
+			parse = parseDsclShell
+		case "linux":
+			cmd = exec.Command("getent", "passwd", u.Username)
+			parse = parsePasswdShell
+		}
+		if cmd != nil {
+			var out bytes.Buffer
+			cmd.Stdout = &out
+			if cmd.Run() == nil {
+				if shell := parse(out.String()); shell != "" {
+					return shell
+				}
+			}
+		}
+	}
+	return os.Getenv("SHELL")
+}
+
+// parsePasswdShell returns the shell (last field) of a passwd entry
+func parsePasswdShell(entry string) string {
+	fields := strings.Split(strings.TrimSpace(entry), ":")
+	if len(fields) < 7 {
+		return ""
+	}
+	return fields[6]
+}
+
+// parseDsclShell returns the shell from `dscl . -read <user> UserShell` output
+func parseDsclShell(output string) string {
+	shell, ok := strings.CutPrefix(strings.TrimSpace(output), "UserShell:")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(shell)
+}
+
+// isZsh reports whether a shell path refers to zsh
+func isZsh(shell string) bool {
+	return filepath.Base(shell) == "zsh"
+}
+
+// ZshNotDefault returns the path to zsh if it is installed but is not the
+// login shell, and "" otherwise
+func ZshNotDefault() string {
+	zsh, err := exec.LookPath("zsh")
+	if err != nil || isZsh(LoginShell()) {
+		return ""
+	}
+	return zsh
+}
+
+// ChshCommand returns the command that makes shell the login shell. chsh may
+// prompt for a password, so run it attached to the terminal.
+func ChshCommand(shell string) *exec.Cmd {
+	return exec.Command("chsh", "-s", shell)
+}
+
+// BrewNotOnPath returns the path to brew if Homebrew is installed but not on
+// PATH and shell's rc file doesn't set it up yet, and "" otherwise
+func BrewNotOnPath(shell Shell) string {
+	brew, onPath := pkg.FindBrew()
+	if brew == "" || onPath {
+		return ""
+	}
+	if data, err := os.ReadFile(shell.RCPath()); err == nil && strings.Contains(string(data), "brew shellenv") {
+		return ""
+	}
+	return brew
+}
+
+// BrewShellenvLine returns the line that puts brew on PATH in shell. It
+// checks brew is still there first, so a shared rc file doesn't break on
+// machines without Homebrew.
+func BrewShellenvLine(shell Shell, brew string) string {
+	if shell == ShellFish {
+		return fmt.Sprintf("test -x %s; and %s shellenv | source", brew, brew)
+	}
+	return fmt.Sprintf(`[ -x %s ] && eval "$(%s shellenv)"`, brew, brew)
+}
+
+// AddBrewShellenv appends brew's shellenv line to shell's rc file, creating
+// it if needed. It takes effect in new shells.
+func AddBrewShellenv(shell Shell, brew string) error {
+	path := shell.RCPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", shell.DisplayPath(), err)
+	}
+	defer f.Close()
+	if _, err := fmt.Fprintf(f, "\n# Homebrew (added by dotfiles)\n%s\n", BrewShellenvLine(shell, brew)); err != nil {
+		return fmt.Errorf("failed to update %s: %w", shell.DisplayPath(), err)
+	}
+	return nil
+}
+
+// LocalBinState is whether ~/.local/bin, where dotfiles installs its own
+// commands (dotfiles, hk, caff, sshh), is on PATH
+type LocalBinState int
+
+const (
+	LocalBinOK           LocalBinState = iota // on PATH, or nothing installed there
+	LocalBinNeedsRestart                      // the rc file adds it, but this shell started before that
+	LocalBinMissing                           // nothing adds it to PATH
+)
+
+// LocalBinDir returns ~/.local/bin
+func LocalBinDir() string {
+	return filepath.Join(homeDir(), ".local", "bin")
+}
+
+// CheckLocalBin reports whether ~/.local/bin is on PATH, and if not whether
+// shell's rc file already adds it
+func CheckLocalBin(shell Shell) LocalBinState {
+	dir := LocalBinDir()
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return LocalBinOK
+	}
+	for _, p := range filepath.SplitList(os.Getenv("PATH")) {
+		if filepath.Clean(p) == dir {
+			return LocalBinOK
+		}
+	}
+	if data, err := os.ReadFile(shell.RCPath()); err == nil && strings.Contains(string(data), ".local/bin") {
+		return LocalBinNeedsRestart
+	}
+	return LocalBinMissing
+}
+
+// LocalBinPathLine returns the line that puts ~/.local/bin on PATH in shell
+func LocalBinPathLine(shell Shell) string {
+	if shell == ShellFish {
+		return "set -gx PATH $HOME/.local/bin $PATH"
+	}
+	return `export PATH="$HOME/.local/bin:$PATH"`
+}
+
+// AddLocalBinToPath appends LocalBinPathLine to shell's rc file, creating it
+// if needed. It does nothing if the rc file already adds ~/.local/bin. It
+// takes effect in new shells.
+func AddLocalBinToPath(shell Shell) error {
+	path := shell.RCPath()
+	if data, err := os.ReadFile(path); err == nil && strings.Contains(string(data), ".local/bin") {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", shell.DisplayPath(), err)
+	}
+	defer f.Close()
+	if _, err := fmt.Fprintf(f, "\n# ~/.local/bin (added by dotfiles)\n%s\n", LocalBinPathLine(shell)); err != nil {
+		return fmt.Errorf("failed to update %s: %w", shell.DisplayPath(), err)
+	}
+	return nil
+}
+
+// zshrcPath returns the path to ~/.zshrc
+func zshrcPath() string {
+	return filepath.Join(homeDir(), ".zshrc")
+}
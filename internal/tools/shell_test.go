@@ -0,0 +1,255 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tekierz/dotfiles/internal/pkg"
+)
+
+func TestParsePasswdShell(t *testing.T) {
+	tests := []struct {
+		entry string
+		want  string
+	}{
+		{"alice:x:1000:1000:Alice,,,:/home/alice:/usr/bin/zsh\n", "/usr/bin/zsh"},
+		{"bob:x:1001:1001::/home/bob:/bin/bash", "/bin/bash"},
+		{"broken:x:1002", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := parsePasswdShell(tt.entry); got != tt.want {
+			t.Errorf("parsePasswdShell(%q) = %q, want %q", tt.entry, got, tt.want)
+		}
+	}
+}
+
+func TestParseDsclShell(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{"UserShell: /bin/zsh\n", "/bin/zsh"},
+		{"UserShell: /opt/homebrew/bin/bash", "/opt/homebrew/bin/bash"},
+		{"No such key: UserShell", ""},
+	}
+	for _, tt := range tests {
+		if got := parseDsclShell(tt.output); got != tt.want {
+			t.Errorf("parseDsclShell(%q) = %q, want %q", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestIsZsh(t *testing.T) {
+	for shell, want := range map[string]bool{
+		"/bin/zsh":                true,
+		"/opt/homebrew/bin/zsh":   true,
+		"/bin/bash":               false,
+		"/usr/bin/zsh-completion": false,
+		"":                        false,
+	} {
+		if got := isZsh(shell); got != want {
+			t.Errorf("isZsh(%q) = %v, want %v", shell, got, want)
+		}
+	}
+}
+
+func TestBrewNotOnPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("PATH", t.TempDir())
+
+	brew := filepath.Join(t.TempDir(), "brew")
+	if err := os.WriteFile(brew, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	orig := pkg.BrewLocations
+	pkg.BrewLocations = []string{filepath.Join(home, "missing"), brew}
+	t.Cleanup(func() { pkg.BrewLocations = orig })
+
+	if path, onPath := pkg.FindBrew(); path != brew || onPath {
+		t.Fatalf("FindBrew() = %q, %v; want %q off PATH", path, onPath, brew)
+	}
+	for _, shell := range Shells {
+		if got := BrewNotOnPath(shell); got != brew {
+			t.Fatalf("BrewNotOnPath(%s) = %q, want %q", shell, got, brew)
+		}
+	}
+
+	// The line goes to the resolved shell's rc file, not always ~/.zshrc
+	if err := AddBrewShellenv(ShellFish, brew); err != nil {
+		t.Fatalf("AddBrewShellenv() error: %v", err)
+	}
+	data, _ := os.ReadFile(ShellFish.RCPath())
+	if !strings.Contains(string(data), BrewShellenvLine(ShellFish, brew)) {
+		t.Errorf("config.fish = %q, want the shellenv line", data)
+	}
+	if got := BrewNotOnPath(ShellFish); got != "" {
+		t.Errorf("BrewNotOnPath(fish) after adding = %q, want \"\"", got)
+	}
+	if got := BrewNotOnPath(ShellZsh); got != brew {
+		t.Errorf("BrewNotOnPath(zsh) after adding to fish = %q, want %q", got, brew)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".zshrc")); !os.IsNotExist(err) {
+		t.Errorf(".zshrc was written for fish: %v", err)
+	}
+}
+
+func TestShellConfigBrewShellenvOnPath(t *testing.T) {
+	binDir := t.TempDir()
+	brew := filepath.Join(binDir, "brew")
+	if err := os.WriteFile(brew, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir)
+	orig := pkg.BrewLocations
+	pkg.BrewLocations = []string{brew}
+	t.Cleanup(func() { pkg.BrewLocations = orig })
+
+	// brew being on PATH now doesn't mean every shell will have it there
+	want := "[ -x " + brew + " ] && eval \"$(" + brew + " shellenv)\""
+	for _, shell := range []Shell{ShellZsh, ShellBash} {
+		if got := GenerateShellConfig(shell, ZshConfig{}, "nord"); !strings.Contains(got, want) {
+			t.Errorf("%s config is missing %q:\n%s", shell, want, got)
+		}
+	}
+	fish := "test -x " + brew + "; and " + brew + " shellenv | source"
+	if got := GenerateShellConfig(ShellFish, ZshConfig{}, "nord"); !strings.Contains(got, fish) {
+		t.Errorf("fish config is missing %q:\n%s", fish, got)
+	}
+}
+
+func TestDetectShell(t *testing.T) {
+	tests := []struct {
+		loginShell string
+		want       Shell
+	}{
+		{"/bin/zsh", ShellZsh},
+		{"/usr/bin/bash", ShellBash},
+		{"/opt/homebrew/bin/fish", ShellFish},
+		{"/bin/tcsh", ShellZsh},
+		{"", ShellZsh},
+	}
+	for _, tt := range tests {
+		if got := detectShell(tt.loginShell); got != tt.want {
+			t.Errorf("detectShell(%q) = %q, want %q", tt.loginShell, got, tt.want)
+		}
+	}
+}
+
+func TestResolveShellSetting(t *testing.T) {
+	if got := ResolveShell("fish"); got != ShellFish {
+		t.Errorf("ResolveShell(%q) = %q, want %q", "fish", got, ShellFish)
+	}
+	if _, err := ParseShell("tcsh"); err == nil {
+		t.Error("ParseShell(\"tcsh\") succeeded, want error")
+	}
+}
+
+func TestShellRCPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	tests := map[Shell]string{
+		ShellZsh:  filepath.Join(home, ".zshrc"),
+		ShellBash: filepath.Join(home, ".bashrc"),
+		ShellFish: filepath.Join(home, ".config", "fish", "config.fish"),
+	}
+	for shell, want := range tests {
+		if got := shell.RCPath(); got != want {
+			t.Errorf("%s.RCPath() = %q, want %q", shell, got, want)
+		}
+	}
+	if got, want := ShellFish.DisplayPath(), "~/.config/fish/config.fish"; got != want {
+		t.Errorf("DisplayPath() = %q, want %q", got, want)
+	}
+}
+
+func TestGenerateShellConfigSyntax(t *testing.T) {
+	cfg := ZshConfig{
+		PromptStyle: "starship",
+		Aliases:     map[string]bool{"ll": true, "docker": true},
+		HistorySize: 5000,
+		AutoCD:      true,
+	}
+	tests := []struct {
+		shell   Shell
+		want    []string
+		notWant []string
+	}{
+		{ShellZsh, []string{"alias ll='ls -la'", "setopt AUTO_CD", "starship init zsh", "SAVEHIST=5000"}, []string{"shopt"}},
+		{ShellBash, []string{"alias ll='ls -la'", "alias dc='docker compose'", "shopt -s autocd", "HISTFILESIZE=5000", "starship init bash", "zoxide init bash"}, []string{"setopt", "zstyle"}},
+		{ShellFish, []string{"alias ll 'ls -la'", "set -gx PATH $HOME/.local/bin $PATH", "starship init fish | source", "zoxide init fish | source"}, []string{"export ", "eval ", "alias ll="}},
+	}
+	for _, tt := range tests {
+		got := GenerateShellConfig(tt.shell, cfg, "nord")
+		for _, w := range tt.want {
+			if !strings.Contains(got, w) {
+				t.Errorf("%s config missing %q", tt.shell, w)
+			}
+		}
+		for _, w := range tt.notWant {
+			if strings.Contains(got, w) {
+				t.Errorf("%s config contains %q", tt.shell, w)
+			}
+		}
+	}
+}
+
+func TestWriteShellConfigCreatesFishDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := WriteShellConfig(ShellFish, ZshConfig{PromptStyle: "minimal"}, "nord"); err != nil {
+		t.Fatalf("WriteShellConfig() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".config", "fish", "config.fish"))
+	if err != nil {
+		t.Fatalf("config.fish not written: %v", err)
+	}
+	if !strings.Contains(string(data), ManagedBegin) || !strings.Contains(string(data), "function fish_prompt") {
+		t.Errorf("config.fish = %q, want a managed block with a prompt", data)
+	}
+}
+
+func TestLocalBinPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("PATH", "/usr/bin:/bin")
+
+	if got := CheckLocalBin(ShellZsh); got != LocalBinOK {
+		t.Errorf("CheckLocalBin() without ~/.local/bin = %v, want LocalBinOK", got)
+	}
+	if err := os.MkdirAll(LocalBinDir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got := CheckLocalBin(ShellZsh); got != LocalBinMissing {
+		t.Fatalf("CheckLocalBin() = %v, want LocalBinMissing", got)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := AddLocalBinToPath(ShellZsh); err != nil {
+			t.Fatalf("AddLocalBinToPath() error: %v", err)
+		}
+	}
+	data, _ := os.ReadFile(filepath.Join(home, ".zshrc"))
+	if n := strings.Count(string(data), LocalBinPathLine(ShellZsh)); n != 1 {
+		t.Errorf(".zshrc has the PATH line %d times, want once:\n%s", n, data)
+	}
+	if got := CheckLocalBin(ShellZsh); got != LocalBinNeedsRestart {
+		t.Errorf("CheckLocalBin() after adding = %v, want LocalBinNeedsRestart", got)
+	}
+
+	t.Setenv("PATH", LocalBinDir()+"/:/usr/bin")
+	if got := CheckLocalBin(ShellZsh); got != LocalBinOK {
+		t.Errorf("CheckLocalBin() on PATH = %v, want LocalBinOK", got)
+	}
+
+	if err := AddLocalBinToPath(ShellFish); err != nil {
+		t.Fatalf("AddLocalBinToPath(fish) error: %v", err)
+	}
+	data, _ = os.ReadFile(filepath.Join(home, ".config", "fish", "config.fish"))
+	if !strings.Contains(string(data), "set -gx PATH $HOME/.local/bin $PATH") {
+		t.Errorf("config.fish = %q, want the fish PATH line", data)
+	}
+}
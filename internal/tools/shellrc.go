@@ -0,0 +1,215 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tekierz/dotfiles/internal/pkg"
+)
+
+// Shell is a shell whose rc file dotfiles can generate
+type Shell string
+
+const (
+	ShellZsh  Shell = "zsh"
+	ShellBash Shell = "bash"
+	ShellFish Shell = "fish"
+)
+
+// Shells lists the supported shells
+var Shells = []Shell{ShellZsh, ShellBash, ShellFish}
+
+// shellGenerators builds each shell's rc content from the shared settings.
+// Settings a shell has no equivalent for (zsh plugins in fish, which has
+// both built in; p10k outside zsh) are left out.
+var shellGenerators = map[Shell]func(ZshConfig, string) string{
+	ShellZsh:  GenerateZshConfig,
+	ShellBash: GenerateBashConfig,
+	ShellFish: GenerateFishConfig,
+}
+
+// ParseShell validates a "shell" setting value
+func ParseShell(name string) (Shell, error) {
+	if _, ok := shellGenerators[Shell(name)]; !ok {
+		return "", fmt.Errorf("unsupported shell %q (valid: zsh, bash, fish)", name)
+	}
+	return Shell(name), nil
+}
+
+// ResolveShell returns the configured shell, or the login shell when the
+// setting is empty or invalid. Login shells dotfiles can't generate for fall
+// back to zsh.
+func ResolveShell(setting string) Shell {
+	if shell, err := ParseShell(setting); err == nil {
+		return shell
+	}
+	return detectShell(LoginShell())
+}
+
+// detectShell maps a login shell path to a supported shell
+func detectShell(loginShell string) Shell {
+	if shell, err := ParseShell(filepath.Base(loginShell)); err == nil {
+		return shell
+	}
+	return ShellZsh
+}
+
+// RCPath returns the rc file the shell reads in interactive sessions
+func (s Shell) RCPath() string {
+	switch s {
+	case ShellBash:
+		return filepath.Join(homeDir(), ".bashrc")
+	case ShellFish:
+		return filepath.Join(homeDir(), ".config", "fish", "config.fish")
+	}
+	return zshrcPath()
+}
+
+// DisplayPath returns RCPath relative to ~, for messages
+func (s Shell) DisplayPath() string {
+	return "~" + strings.TrimPrefix(s.RCPath(), homeDir())
+}
+
+// GenerateShellConfig builds the rc content for shell
+func GenerateShellConfig(shell Shell, cfg ZshConfig, theme string) string {
+	generate, ok := shellGenerators[shell]
+	if !ok {
+		generate = GenerateZshConfig
+	}
+	return generate(cfg, theme)
+}
+
+// WriteShellConfig writes the managed block of shell's rc file to disk
+func WriteShellConfig(shell Shell, cfg ZshConfig, theme string) error {
+	path := shell.RCPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	return WriteManagedFile(path, GenerateShellConfig(shell, cfg, theme), 0600)
+}
+
+// GenerateBashConfig builds the .bashrc content
+func GenerateBashConfig(cfg ZshConfig, theme string) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Generated by dotfiles TUI\n")
+	sb.WriteString(fmt.Sprintf("# Theme: %s\n\n", theme))
+
+	if brew := pkg.BrewInstallLocation(); brew != "" {
+		sb.WriteString("# Homebrew\n")
+		sb.WriteString(BrewShellenvLine(ShellBash, brew) + "\n\n")
+	}
+
+	sb.WriteString("# History\n")
+	sb.WriteString(fmt.Sprintf("HISTSIZE=%d\n", cfg.HistorySize))
+	sb.WriteString(fmt.Sprintf("HISTFILESIZE=%d\n", cfg.HistorySize))
+	sb.WriteString("HISTCONTROL=ignoreboth\n")
+	sb.WriteString("shopt -s histappend\n\n")
+
+	if cfg.AutoCD {
+		sb.WriteString("# Auto CD\n")
+		sb.WriteString("shopt -s autocd\n\n")
+	}
+
+	sb.WriteString("# Completion\n")
+	sb.WriteString("for f in /usr/share/bash-completion/bash_completion \"$(brew --prefix 2>/dev/null)/etc/profile.d/bash_completion.sh\"; do\n")
+	sb.WriteString("  [ -r \"$f\" ] && source \"$f\" && break\n")
+	sb.WriteString("done\n\n")
+
+	sb.WriteString("# PATH\n")
+	sb.WriteString("export PATH=\"$HOME/.local/bin:$PATH\"\n\n")
+
+	sb.WriteString("# Aliases\n")
+	writePOSIXAliases(&sb, cfg.Aliases)
+	sb.WriteString("\n")
+
+	sb.WriteString("# Modern tool aliases (if installed)\n")
+	sb.WriteString("command -v eza &>/dev/null && alias ls='eza --icons'\n")
+	sb.WriteString("command -v bat &>/dev/null && alias cat='bat --paging=never'\n")
+	sb.WriteString("command -v zoxide &>/dev/null && eval \"$(zoxide init bash)\"\n\n")
+
+	sb.WriteString("# Prompt\n")
+	switch cfg.PromptStyle {
+	case "starship":
+		sb.WriteString("command -v starship &>/dev/null && eval \"$(starship init bash)\"\n")
+	default:
+		// p10k and pure are zsh-only
+		sb.WriteString("PS1='\\w > '\n")
+	}
+
+	return sb.String()
+}
+
+// GenerateFishConfig builds the config.fish content. Fish has
+// autosuggestions and syntax highlighting built in, and keeps its own
+// history, so those settings have nothing to generate.
+func GenerateFishConfig(cfg ZshConfig, theme string) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Generated by dotfiles TUI\n")
+	sb.WriteString(fmt.Sprintf("# Theme: %s\n\n", theme))
+
+	if brew := pkg.BrewInstallLocation(); brew != "" {
+		sb.WriteString("# Homebrew\n")
+		sb.WriteString(BrewShellenvLine(ShellFish, brew) + "\n\n")
+	}
+
+	sb.WriteString("# PATH\n")
+	sb.WriteString("set -gx PATH $HOME/.local/bin $PATH\n\n")
+
+	sb.WriteString("if status is-interactive\n")
+
+	sb.WriteString("    # Aliases\n")
+	for _, a := range shellAliases {
+		if cfg.Aliases[a.key] {
+			sb.WriteString(fmt.Sprintf("    alias %s '%s'\n", a.name, a.command))
+		}
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("    # Modern tool aliases (if installed)\n")
+	sb.WriteString("    type -q eza; and alias ls 'eza --icons'\n")
+	sb.WriteString("    type -q bat; and alias cat 'bat --paging=never'\n")
+	sb.WriteString("    type -q zoxide; and zoxide init fish | source\n\n")
+
+	sb.WriteString("    # Prompt\n")
+	switch cfg.PromptStyle {
+	case "starship":
+		sb.WriteString("    type -q starship; and starship init fish | source\n")
+	default:
+		// p10k and pure are zsh-only
+		sb.WriteString("    function fish_prompt\n")
+		sb.WriteString("        echo -n (prompt_pwd) '> '\n")
+		sb.WriteString("    end\n")
+	}
+	sb.WriteString("end\n")
+
+	return sb.String()
+}
+
+// shellAlias is an alias toggled by a ZshConfig.Aliases key
+type shellAlias struct {
+	key, name, command string
+}
+
+// shellAliases are the optional aliases, in rc file order
+var shellAliases = []shellAlias{
+	{"ll", "ll", "ls -la"},
+	{"la", "la", "ls -A"},
+	{"gs", "gs", "git status"},
+	{"gp", "gp", "git push"},
+	{"gc", "gc", "git commit"},
+	{"docker", "d", "docker"},
+	{"docker", "dc", "docker compose"},
+}
+
+// writePOSIXAliases writes the enabled aliases in sh syntax
+func writePOSIXAliases(sb *strings.Builder, enabled map[string]bool) {
+	for _, a := range shellAliases {
+		if enabled[a.key] {
+			sb.WriteString(fmt.Sprintf("alias %s='%s'\n", a.name, a.command))
+		}
+	}
+}
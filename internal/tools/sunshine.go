@@ -16,6 +16,7 @@ func NewSunshineTool() *SunshineTool {
 			id:          "sunshine",
 			name:        "Sunshine",
 			description: "Self-hosted game streaming server",
+			url:         "https://docs.lizardbyte.dev/projects/sunshine/",
 			icon:        "☀",
 			category:    CategoryUtility,
 			packages: map[pkg.Platform][]string{
@@ -16,6 +16,7 @@ func NewTailscaleTool() *TailscaleTool {
 			id:          "tailscale",
 			name:        "Tailscale",
 			description: "Mesh VPN for secure networking",
+			url:         "https://tailscale.com/kb",
 			icon:        "󰖂",
 			category:    CategoryUtility,
 			packages: map[pkg.Platform][]string{
@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConfigTemplate is a named starting point a tool's generator can render
+// from; the tool's individual settings are applied on top of it
+type ConfigTemplate struct {
+	Name        string
+	Description string
+}
+
+// TmuxTemplates are the tmux.conf templates. The first is the default.
+var TmuxTemplates = []ConfigTemplate{
+	{"full", "Prefix, splits and status plus Alt shortcuts and TPM plugins"},
+	{"minimal", "Just the prefix, splits, mouse and status bar; no plugins"},
+	{"vim", "Full, with vi copy mode and hjkl pane navigation and resizing"},
+}
+
+// GitTemplates are the .gitconfig templates. The first is the default.
+var GitTemplates = []ConfigTemplate{
+	{"personal", "Delta pager, diff3 merges and the common aliases"},
+	{"work", "Personal, plus pruning fetches, rerere and a ~/work identity include"},
+	{"minimal", "Identity, branch, pull and aliases only; git's own pager and diffs"},
+}
+
+// TemplateNames lists the templates' names, for option pickers
+func TemplateNames(templates []ConfigTemplate) []string {
+	names := make([]string, len(templates))
+	for i, t := range templates {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// resolveTemplate returns name if it is one of templates, or the default
+// (first) template otherwise, so unset or unknown names render as before
+func resolveTemplate(templates []ConfigTemplate, name string) string {
+	for _, t := range templates {
+		if t.Name == name {
+			return name
+		}
+	}
+	return templates[0].Name
+}
+
+// writeTemplateComment notes a non-default template in a generated file's
+// header. Default-template files keep their old header, so existing configs
+// aren't flagged as changed.
+func writeTemplateComment(sb *strings.Builder, templates []ConfigTemplate, name string) {
+	if name != templates[0].Name {
+		sb.WriteString(fmt.Sprintf("# Template: %s\n", name))
+	}
+}
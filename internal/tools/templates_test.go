@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestTmuxTemplates(t *testing.T) {
+	cfg := TmuxConfig{Prefix: "ctrl-a", SplitBinds: "pipes", StatusBar: "bottom", MouseMode: true, TPMEnabled: true}
+
+	full := GenerateTmuxConfig(cfg, "nord")
+	if strings.Contains(full, "# Template:") {
+		t.Error("default template should not be noted in the header")
+	}
+	if cfg.Template = "nonsense"; GenerateTmuxConfig(cfg, "nord") != full {
+		t.Error("unknown template should render the default")
+	}
+
+	cfg.Template = "minimal"
+	minimal := GenerateTmuxConfig(cfg, "nord")
+	if !strings.Contains(minimal, "# Template: minimal") || !strings.Contains(minimal, "set -g prefix C-a") {
+		t.Errorf("minimal should keep the basic settings:\n%s", minimal)
+	}
+	if strings.Contains(minimal, "@plugin") || strings.Contains(minimal, "M-Left") {
+		t.Errorf("minimal should leave out plugins and Alt bindings:\n%s", minimal)
+	}
+
+	cfg.Template = "vim"
+	vim := GenerateTmuxConfig(cfg, "nord")
+	if !strings.Contains(vim, "setw -g mode-keys vi") || !strings.Contains(vim, "bind h select-pane -L") || !strings.Contains(vim, "@plugin") {
+		t.Errorf("vim should add vi bindings to the full template:\n%s", vim)
+	}
+}
+
+func TestTmuxTruecolor(t *testing.T) {
+	cfg := TmuxConfig{Prefix: "ctrl-a", Truecolor: true}
+	on := GenerateTmuxConfig(cfg, "nord")
+	for _, want := range []string{`set -g default-terminal "tmux-256color"`, `set -ga terminal-overrides ",*:Tc"`} {
+		if !strings.Contains(on, want) {
+			t.Errorf("truecolor config is missing %q:\n%s", want, on)
+		}
+	}
+
+	cfg.Truecolor = false
+	if off := GenerateTmuxConfig(cfg, "nord"); strings.Contains(off, "terminal-overrides") {
+		t.Errorf("truecolor off should leave terminal overrides out:\n%s", off)
+	}
+}
+
+func TestTmuxSession(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if err := WriteTmuxSession(); err != nil {
+		t.Fatalf("WriteTmuxSession failed: %v", err)
+	}
+	info, err := os.Stat(TmuxSessionPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("session script mode = %v, want executable", info.Mode())
+	}
+	data, _ := os.ReadFile(TmuxSessionPath())
+	script := string(data)
+	if !strings.HasPrefix(script, "#!/usr/bin/env bash\n") {
+		t.Errorf("session script should start with its shebang:\n%s", script)
+	}
+	for _, want := range []string{"tmux new-session -d", `-n dotfiles "dotfiles manage"`, "switch-client", "attach-session"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("session script is missing %q:\n%s", want, script)
+		}
+	}
+}
+
+func TestGitTemplates(t *testing.T) {
+	cfg := GitConfig{DefaultBranch: "main", Aliases: []string{"st"}, PullRebase: true}
+
+	personal := GenerateGitConfig(cfg, "nord")
+	if !strings.Contains(personal, "pager = delta") || strings.Contains(personal, "[rerere]") {
+		t.Errorf("personal template:\n%s", personal)
+	}
+
+	cfg.Template = "work"
+	work := GenerateGitConfig(cfg, "nord")
+	for _, want := range []string{"# Template: work", "prune = true", "[rerere]", "gitdir:~/work/", "pager = delta"} {
+		if !strings.Contains(work, want) {
+			t.Errorf("work template missing %q:\n%s", want, work)
+		}
+	}
+
+	// push.autoSetupRemote follows its own setting, not the template
+	if strings.Contains(work, "autoSetupRemote") {
+		t.Errorf("work template set autoSetupRemote with it off:\n%s", work)
+	}
+	cfg.Template = ""
+	cfg.AutoSetupRemote = true
+	if got := GenerateGitConfig(cfg, "nord"); !strings.Contains(got, "autoSetupRemote = true") {
+		t.Errorf("personal template with autoSetupRemote on:\n%s", got)
+	}
+	cfg.AutoSetupRemote = false
+
+	cfg.Template = "minimal"
+	minimal := GenerateGitConfig(cfg, "nord")
+	if strings.Contains(minimal, "[delta]") || !strings.Contains(minimal, "st = status") {
+		t.Errorf("minimal should drop delta but keep aliases:\n%s", minimal)
+	}
+}
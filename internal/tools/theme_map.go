@@ -0,0 +1,85 @@
+package tools
+
+// toolTheme is how a global theme maps onto tools that ship their own,
+// smaller sets of themes. Tools set to "auto" use the closest match so the
+// whole environment follows the chosen theme.
+type toolTheme struct {
+	btop   string // btop built-in color_theme
+	glow   string // glow built-in style
+	light  bool   // light background (lazygit, delta)
+	accent string // lazygit active border color
+}
+
+// toolThemes covers every theme in config.AvailableThemes. btop has no
+// catppuccin or rose-pine themes and glow only a few styles, so those fall
+// back to the nearest in feel.
+var toolThemes = map[string]toolTheme{
+	"catppuccin-mocha":     {btop: "tokyo-night", glow: "dark", accent: "#89b4fa"},
+	"catppuccin-latte":     {btop: "paper", glow: "light", light: true, accent: "#1e66f5"},
+	"catppuccin-frappe":    {btop: "tokyo-storm", glow: "dark", accent: "#8caaee"},
+	"catppuccin-macchiato": {btop: "tokyo-night", glow: "dark", accent: "#8aadf4"},
+	"dracula":              {btop: "dracula", glow: "dracula", accent: "#bd93f9"},
+	"gruvbox-dark":         {btop: "gruvbox_dark", glow: "dark", accent: "#83a598"},
+	"gruvbox-light":        {btop: "gruvbox_light", glow: "light", light: true, accent: "#076678"},
+	"nord":                 {btop: "nord", glow: "dark", accent: "#88c0d0"},
+	"tokyo-night":          {btop: "tokyo-night", glow: "tokyo-night", accent: "#7aa2f7"},
+	"solarized-dark":       {btop: "solarized_dark", glow: "dark", accent: "#268bd2"},
+	"solarized-light":      {btop: "solarized_light", glow: "light", light: true, accent: "#268bd2"},
+	"monokai":              {btop: "monokai", glow: "dark", accent: "#66d9ef"},
+	"rose-pine":            {btop: "tokyo-storm", glow: "dark", accent: "#c4a7e7"},
+	"everforest":           {btop: "everforest-dark-medium", glow: "dark", accent: "#a7c080"},
+	"one-dark":             {btop: "onedark", glow: "dark", accent: "#61afef"},
+	"neon-seapunk":         {btop: "horizon", glow: "tokyo-night", accent: "#00F5D4"},
+}
+
+// RegisterCustomTheme adds a custom theme to the tool mappings. Tools with
+// their own theme sets use their default dark or light variant for it.
+func RegisterCustomTheme(name string, light bool, accent string) {
+	t := toolTheme{btop: "Default", glow: "dark", light: light, accent: accent}
+	if light {
+		t.btop, t.glow = "paper", "light"
+	}
+	toolThemes[name] = t
+}
+
+// btopThemeNames maps the shorter names offered in the editors to btop's
+// theme file names
+var btopThemeNames = map[string]string{
+	"gruvbox": "gruvbox_dark",
+}
+
+// BtopThemeFor resolves a btop theme setting: "auto" follows the global
+// theme, anything else is used as given
+func BtopThemeFor(setting, theme string) string {
+	if setting != "auto" {
+		if name, ok := btopThemeNames[setting]; ok {
+			return name
+		}
+		return setting
+	}
+	if t, ok := toolThemes[theme]; ok {
+		return t.btop
+	}
+	return "Default"
+}
+
+// GlowStyleFor resolves a glow style setting: "auto" follows the global theme
+func GlowStyleFor(setting, theme string) string {
+	if setting != "auto" {
+		return setting
+	}
+	if t, ok := toolThemes[theme]; ok {
+		return t.glow
+	}
+	return "dark"
+}
+
+// IsLightTheme reports whether a global theme has a light background
+func IsLightTheme(theme string) bool {
+	return toolThemes[theme].light
+}
+
+// themeAccent returns a global theme's accent color, or "" if unknown
+func themeAccent(theme string) string {
+	return toolThemes[theme].accent
+}
@@ -0,0 +1,43 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tekierz/dotfiles/internal/config"
+)
+
+func TestToolThemesCoverAvailableThemes(t *testing.T) {
+	for _, theme := range config.AvailableThemes {
+		tt, ok := toolThemes[theme]
+		if !ok {
+			t.Errorf("no tool theme mapping for %q", theme)
+			continue
+		}
+		if tt.btop == "" || tt.glow == "" || tt.accent == "" {
+			t.Errorf("incomplete mapping for %q: %+v", theme, tt)
+		}
+	}
+}
+
+func TestGeneratorsFollowGlobalTheme(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"btop auto", GenerateBtopConfig(BtopConfig{Theme: "auto"}, "everforest"), `color_theme = "everforest-dark-medium"`},
+		{"btop explicit", GenerateBtopConfig(BtopConfig{Theme: "gruvbox"}, "everforest"), `color_theme = "gruvbox_dark"`},
+		{"btop unknown theme", GenerateBtopConfig(BtopConfig{Theme: "auto"}, "custom"), `color_theme = "Default"`},
+		{"glow auto", GenerateGlowConfig(GlowConfig{Style: "auto"}, "solarized-light"), `style: "light"`},
+		{"glow explicit", GenerateGlowConfig(GlowConfig{Style: "notty"}, "dracula"), `style: "notty"`},
+		{"lazygit accent", GenerateLazyGitConfig(LazyGitConfig{Theme: "auto"}, "everforest"), `- "#a7c080"`},
+		{"lazygit light", GenerateLazyGitConfig(LazyGitConfig{Theme: "auto"}, "catppuccin-latte"), "delta --light"},
+		{"lazygit forced dark", GenerateLazyGitConfig(LazyGitConfig{Theme: "dark"}, "catppuccin-latte"), "delta --dark"},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(tt.got, tt.want) {
+			t.Errorf("%s: output missing %q", tt.name, tt.want)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/tekierz/dotfiles/internal/config"
 	"github.com/tekierz/dotfiles/internal/pkg"
 )
 
@@ -17,11 +18,16 @@ type TmuxTool struct {
 
 // TmuxConfig holds tmux configuration settings
 type TmuxConfig struct {
+	// Template is the TmuxTemplates entry to start from ("" for the default)
+	Template string
+
 	// Basic settings
 	Prefix     string
 	SplitBinds string
 	StatusBar  string
 	MouseMode  bool
+	Truecolor  bool // tmux-256color with 24-bit color passed through to the terminal
+	Session    bool // write TmuxSessionPath, a script opening dotfiles in a tmux session
 
 	// TPM settings
 	TPMEnabled       bool
@@ -40,6 +46,7 @@ func NewTmuxTool() *TmuxTool {
 			id:          "tmux",
 			name:        "Tmux",
 			description: "Terminal multiplexer",
+			url:         "https://github.com/tmux/tmux/wiki",
 			icon:        "",
 			category:    CategoryTerminal,
 			packages: map[pkg.Platform][]string{
@@ -50,6 +57,10 @@ func NewTmuxTool() *TmuxTool {
 			configPaths: []string{
 				filepath.Join(home, ".tmux.conf"),
 			},
+			// Reload running sessions; without a server there's nothing to reload
+			postApply: []string{
+				"if tmux has-session 2>/dev/null; then tmux source-file ~/.tmux.conf; fi",
+			},
 			// UI metadata
 			uiGroup:        UIGroupNone,
 			configScreen:   10, // ScreenConfigTmux
@@ -104,18 +115,25 @@ func RunTPMInstall() error {
 	return cmd.Run()
 }
 
-// GenerateTmuxConfig builds the tmux.conf content
+// GenerateTmuxConfig builds the tmux.conf content from cfg.Template with
+// cfg's settings applied
 func GenerateTmuxConfig(cfg TmuxConfig, theme string) string {
 	var sb strings.Builder
+	template := resolveTemplate(TmuxTemplates, cfg.Template)
 
 	// Header
 	sb.WriteString("# Generated by dotfiles TUI\n")
-	sb.WriteString(fmt.Sprintf("# Theme: %s\n\n", theme))
+	sb.WriteString(fmt.Sprintf("# Theme: %s\n", theme))
+	writeTemplateComment(&sb, TmuxTemplates, template)
+	sb.WriteString("\n")
 
-	// Terminal settings
-	sb.WriteString("# Terminal settings\n")
-	sb.WriteString("set -g default-terminal \"tmux-256color\"\n")
-	sb.WriteString("set -ga terminal-overrides \",xterm-256color:Tc\"\n\n")
+	// Terminal settings: without the Tc override, themes fall back to 256
+	// colors inside tmux and look washed out
+	if cfg.Truecolor {
+		sb.WriteString("# Terminal settings\n")
+		sb.WriteString("set -g default-terminal \"tmux-256color\"\n")
+		sb.WriteString("set -ga terminal-overrides \",*:Tc\"\n\n")
+	}
 
 	// Prefix key
 	sb.WriteString("# Prefix key\n")
@@ -164,7 +182,29 @@ func GenerateTmuxConfig(cfg TmuxConfig, theme string) string {
 
 	// Reload binding
 	sb.WriteString("# Reload config\n")
-	sb.WriteString("bind r source-file ~/.tmux.conf \\; display \"Config reloaded!\"\n\n")
+	sb.WriteString("bind r source-file ~/.tmux.conf \\; display \"Config reloaded!\"\n")
+
+	if template == "minimal" {
+		return sb.String()
+	}
+	sb.WriteString("\n")
+
+	if template == "vim" {
+		sb.WriteString("# Vi copy mode\n")
+		sb.WriteString("setw -g mode-keys vi\n")
+		sb.WriteString("bind -T copy-mode-vi v send -X begin-selection\n")
+		sb.WriteString("bind -T copy-mode-vi y send -X copy-selection-and-cancel\n\n")
+
+		sb.WriteString("# Pane navigation and resizing (hjkl)\n")
+		sb.WriteString("bind h select-pane -L\n")
+		sb.WriteString("bind j select-pane -D\n")
+		sb.WriteString("bind k select-pane -U\n")
+		sb.WriteString("bind l select-pane -R\n")
+		sb.WriteString("bind -r H resize-pane -L 5\n")
+		sb.WriteString("bind -r J resize-pane -D 5\n")
+		sb.WriteString("bind -r K resize-pane -U 5\n")
+		sb.WriteString("bind -r L resize-pane -R 5\n\n")
+	}
 
 	// Quick window switching
 	sb.WriteString("# Quick window switching (Alt + number)\n")
@@ -245,13 +285,61 @@ func WriteTmuxConfig(cfg TmuxConfig, theme string) error {
 	configPath := filepath.Join(home, ".tmux.conf")
 	content := GenerateTmuxConfig(cfg, theme)
 
-	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+	if err := WriteGeneratedFile(configPath, content, "#", 0600); err != nil {
 		return fmt.Errorf("failed to write tmux.conf: %w", err)
 	}
 
 	return nil
 }
 
+// TmuxConfigUpToDate reports whether ~/.tmux.conf already matches cfg, in
+// which case WriteTmuxConfig leaves it untouched
+func TmuxConfigUpToDate(cfg TmuxConfig, theme string) bool {
+	return UpToDate(filepath.Join(homeDir(), ".tmux.conf"), GenerateTmuxConfig(cfg, theme))
+}
+
+// TmuxSessionPath returns the script that opens the dotfiles tmux session
+func TmuxSessionPath() string {
+	return filepath.Join(config.ConfigDir(), "tmux-session.sh")
+}
+
+// GenerateTmuxSession builds the session script: it creates (or reattaches
+// to) a tmux session with a shell window and the management TUI in a
+// "dotfiles" window, switching to it when run from inside tmux.
+func GenerateTmuxSession() string {
+	var sb strings.Builder
+	sb.WriteString("#!/usr/bin/env bash\n")
+	sb.WriteString("# Generated by dotfiles TUI\n")
+	sb.WriteString("# Opens the dotfiles tmux session: a shell window plus the management TUI\n")
+	sb.WriteString("# in its own window. Usage: tmux-session.sh [session-name]\n\n")
+	sb.WriteString("session=\"${1:-dotfiles}\"\n\n")
+	sb.WriteString("if ! tmux has-session -t \"=$session\" 2>/dev/null; then\n")
+	sb.WriteString("  tmux new-session -d -s \"$session\" -n shell\n")
+	sb.WriteString("fi\n")
+	sb.WriteString("if ! tmux list-windows -t \"=$session\" -F '#{window_name}' | grep -qx dotfiles; then\n")
+	sb.WriteString("  tmux new-window -t \"=$session:\" -n dotfiles \"dotfiles manage\"\n")
+	sb.WriteString("fi\n")
+	sb.WriteString("tmux select-window -t \"=$session:dotfiles\"\n\n")
+	sb.WriteString("if [ -n \"$TMUX\" ]; then\n")
+	sb.WriteString("  exec tmux switch-client -t \"=$session\"\n")
+	sb.WriteString("fi\n")
+	sb.WriteString("exec tmux attach-session -t \"=$session\"\n")
+	return sb.String()
+}
+
+// WriteTmuxSession writes the session script to TmuxSessionPath. It carries
+// no managed-file marker, which would have to precede the shebang.
+func WriteTmuxSession() error {
+	path := TmuxSessionPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(GenerateTmuxSession()), 0700); err != nil {
+		return fmt.Errorf("failed to write tmux session script: %w", err)
+	}
+	return nil
+}
+
 // SetupTPM handles TPM installation and plugin setup
 func SetupTPM(cfg TmuxConfig, theme string) error {
 	// Write config first
@@ -284,6 +372,7 @@ func (t *TmuxTool) GenerateConfig(theme string) string {
 		SplitBinds:       "pipes",
 		StatusBar:        "bottom",
 		MouseMode:        true,
+		Truecolor:        true,
 		TPMEnabled:       true,
 		PluginSensible:   true,
 		PluginResurrect:  true,
@@ -301,6 +390,7 @@ func (t *TmuxTool) ApplyConfig(theme string) error {
 		SplitBinds:       "pipes",
 		StatusBar:        "bottom",
 		MouseMode:        true,
+		Truecolor:        true,
 		TPMEnabled:       true,
 		PluginSensible:   true,
 		PluginResurrect:  true,
@@ -1,6 +1,12 @@
 package tools
 
 import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/tekierz/dotfiles/internal/config"
 	"github.com/tekierz/dotfiles/internal/pkg"
 )
 
@@ -18,6 +24,26 @@ const (
 	CategoryApp       Category = "app"
 )
 
+// AllCategories lists every category in display order
+var AllCategories = []Category{
+	CategoryShell, CategoryTerminal, CategoryEditor, CategoryFile,
+	CategoryGit, CategoryContainer, CategoryUtility, CategoryApp,
+}
+
+// ParseCategory returns the category with the given name (case-insensitive)
+func ParseCategory(name string) (Category, error) {
+	for _, c := range AllCategories {
+		if strings.EqualFold(string(c), strings.TrimSpace(name)) {
+			return c, nil
+		}
+	}
+	valid := make([]string, len(AllCategories))
+	for i, c := range AllCategories {
+		valid[i] = string(c)
+	}
+	return "", fmt.Errorf("unknown category %q (valid: %s)", name, strings.Join(valid, ", "))
+}
+
 // UIGroup represents which installer section a tool belongs to
 type UIGroup string
 
@@ -36,6 +62,7 @@ type Tool interface {
 	ID() string          // Unique identifier (e.g., "ghostty", "lazygit")
 	Name() string        // Display name (e.g., "Ghostty", "LazyGit")
 	Description() string // Short description
+	URL() string         // Homepage or documentation ("" if unknown)
 	Icon() string        // Nerd font icon
 	Category() Category  // Tool category
 
@@ -43,12 +70,15 @@ type Tool interface {
 	Packages() map[pkg.Platform][]string // Platform-specific package names
 	IsInstalled() bool                   // Check if tool is installed
 	Install(mgr pkg.PackageManager) error
+	Version() string                                    // Version to install where the manager can pin one ("" for the latest)
+	InstalledVersion(versions map[string]string) string // Installed version, from a package name → version map ("" if unknown)
 
 	// Configuration
 	ConfigPaths() []string              // Config file paths (e.g., ~/.config/ghostty/config)
 	HasConfig() bool                    // Whether this tool has configurable options
 	GenerateConfig(theme string) string // Generate config content for a theme
 	ApplyConfig(theme string) error     // Write config to disk
+	PostApply() []string                // Shell commands that make a new config take effect (e.g. reload it)
 
 	// Resource requirements
 	IsHeavy() bool // Whether this tool requires significant resources (skip on low-memory systems)
@@ -65,11 +95,14 @@ type BaseTool struct {
 	id          string
 	name        string
 	description string
+	url         string
 	icon        string
 	category    Category
 	packages    map[pkg.Platform][]string
+	version     string // Version of the primary package to install ("" for the latest)
 	configPaths []string
-	heavyTool   bool // If true, tool is skipped on low-memory systems (e.g., Pi Zero 2)
+	heavyTool   bool     // If true, tool is skipped on low-memory systems (e.g., Pi Zero 2)
+	postApply   []string // Run after the config is applied, see RunPostApply
 
 	// UI metadata
 	uiGroup        UIGroup
@@ -81,6 +114,7 @@ type BaseTool struct {
 func (t *BaseTool) ID() string          { return t.id }
 func (t *BaseTool) Name() string        { return t.name }
 func (t *BaseTool) Description() string { return t.description }
+func (t *BaseTool) URL() string         { return t.url }
 func (t *BaseTool) Icon() string        { return t.icon }
 func (t *BaseTool) Category() Category  { return t.category }
 
@@ -88,6 +122,30 @@ func (t *BaseTool) Packages() map[pkg.Platform][]string {
 	return t.packages
 }
 
+func (t *BaseTool) Version() string {
+	return t.version
+}
+
+// InstalledVersion looks up the primary package's version in versions,
+// trying its pinned package (brew's foo@2) first
+func (t *BaseTool) InstalledVersion(versions map[string]string) string {
+	pkgs := t.packages[pkg.DetectPlatform()]
+	if len(pkgs) == 0 {
+		pkgs = t.packages["all"]
+	}
+	if len(pkgs) == 0 {
+		return ""
+	}
+	if t.version != "" {
+		if name, err := pkg.VersionedPackage(pkg.DetectManager(), pkgs[0], t.version); err == nil {
+			if v, ok := versions[name]; ok {
+				return v
+			}
+		}
+	}
+	return versions[pkgs[0]]
+}
+
 func (t *BaseTool) ConfigPaths() []string {
 	return t.configPaths
 }
@@ -96,6 +154,10 @@ func (t *BaseTool) HasConfig() bool {
 	return len(t.configPaths) > 0
 }
 
+func (t *BaseTool) PostApply() []string {
+	return t.postApply
+}
+
 func (t *BaseTool) IsHeavy() bool {
 	return t.heavyTool
 }
@@ -121,15 +183,19 @@ func (t *BaseTool) IsInstalled() bool {
 		return false
 	}
 
+	// A pinned brew formula (foo@2) is a package of its own
+	if versioned, err := pkg.VersionedPackage(mgr, pkgs[0], t.version); err == nil && versioned != pkgs[0] && mgr.IsInstalled(versioned) {
+		return true
+	}
+
 	// Check if primary package is installed
 	return mgr.IsInstalled(pkgs[0])
 }
 
 func (t *BaseTool) Install(mgr pkg.PackageManager) error {
-	platform := pkg.DetectPlatform()
-	pkgs := t.packages[platform]
-	if len(pkgs) == 0 {
-		pkgs = t.packages["all"]
+	pkgs, err := InstallPackages(t, mgr, pkg.DetectPlatform(), "")
+	if err != nil {
+		return err
 	}
 	if len(pkgs) == 0 {
 		return nil // No packages to install for this platform
@@ -137,6 +203,39 @@ func (t *BaseTool) Install(mgr pkg.PackageManager) error {
 	return mgr.Install(pkgs...)
 }
 
+// InstallPackages returns the packages that install t on platform with mgr,
+// with the primary (first) one pinned to version, or to t.Version() when
+// version is "". It returns nil if t has no packages for platform, and
+// wraps pkg.ErrVersionUnsupported if mgr can't pin versions.
+func InstallPackages(t Tool, mgr pkg.PackageManager, platform pkg.Platform, version string) ([]string, error) {
+	pkgs := t.Packages()[platform]
+	if len(pkgs) == 0 {
+		pkgs = t.Packages()["all"]
+	}
+	if len(pkgs) == 0 {
+		return nil, nil
+	}
+	if version == "" {
+		version = t.Version()
+	}
+	primary, err := pkg.VersionedPackage(mgr, pkgs[0], version)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{primary}, pkgs[1:]...), nil
+}
+
+// userConfigDir returns name's directory under the user's config home
+// ($XDG_CONFIG_HOME or ~/.config). It fails when neither XDG_CONFIG_HOME nor
+// HOME is set, rather than writing relative to the working directory.
+func userConfigDir(name string) (string, error) {
+	base := config.ConfigHome()
+	if base == "" {
+		return "", errors.New("failed to get config directory: neither XDG_CONFIG_HOME nor HOME is set")
+	}
+	return filepath.Join(base, name), nil
+}
+
 // GenerateConfig default implementation (override in specific tools)
 func (t *BaseTool) GenerateConfig(theme string) string {
 	return ""
@@ -0,0 +1,37 @@
+package tools
+
+import (
+	"os"
+	"testing"
+)
+
+func TestWriteConfigWithoutConfigHome(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "")
+	cwd := t.TempDir()
+	t.Chdir(cwd)
+
+	writers := map[string]func() error{
+		"btop":    func() error { return WriteBtopConfig(BtopConfig{}, "nord") },
+		"fzf":     func() error { return WriteFzfConfig(FzfConfig{}, "nord") },
+		"ghostty": func() error { return WriteGhosttyConfig(GhosttyConfig{}, "nord") },
+		"glow":    func() error { return WriteGlowConfig(GlowConfig{}, "nord") },
+		"lazygit": func() error { return WriteLazyGitConfig(LazyGitConfig{}, "nord") },
+		"neovim":  func() error { return WriteNeovimConfig(NeovimConfig{}, "nord") },
+		"yazi":    func() error { return WriteYaziConfig(YaziConfig{}, "nord") },
+	}
+	for name, write := range writers {
+		if err := write(); err == nil {
+			t.Errorf("%s: expected an error with no config home", name)
+		}
+	}
+
+	// Nothing may land relative to the working directory
+	entries, err := os.ReadDir(cwd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("config written relative to the working directory: %v", entries)
+	}
+}
@@ -0,0 +1,44 @@
+package tools
+
+import (
+	"sort"
+
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/pkg"
+)
+
+// PackageNames returns the package names of the given tools across all
+// platforms (unknown IDs are ignored)
+func PackageNames(ids []string) []string {
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		t, ok := GetRegistry().Get(id)
+		if !ok {
+			continue
+		}
+		for _, names := range t.Packages() {
+			for _, name := range names {
+				seen[name] = true
+			}
+		}
+	}
+
+	out := make([]string, 0, len(seen))
+	for name := range seen {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// CheckUpdates checks dotfiles-managed packages for updates, leaving out the
+// packages of tools listed in GlobalConfig.SkipUpdateCheck and marking those
+// in GlobalConfig.PinnedPackages as pinned
+func CheckUpdates() ([]pkg.Package, error) {
+	var skip, pinned []string
+	if cfg, err := config.LoadGlobalConfig(); err == nil {
+		skip = PackageNames(cfg.SkipUpdateCheck)
+		pinned = cfg.PinnedPackages
+	}
+	return pkg.CheckDotfilesUpdatesExcept(skip, pinned)
+}
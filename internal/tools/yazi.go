@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/tekierz/dotfiles/internal/config"
 	"github.com/tekierz/dotfiles/internal/pkg"
 )
 
@@ -23,12 +24,12 @@ type YaziTool struct {
 
 // NewYaziTool creates a new Yazi tool
 func NewYaziTool() *YaziTool {
-	home, _ := os.UserHomeDir()
 	return &YaziTool{
 		BaseTool: BaseTool{
 			id:          "yazi",
 			name:        "Yazi",
 			description: "Blazing fast terminal file manager",
+			url:         "https://yazi-rs.github.io",
 			icon:        "󰉋",
 			category:    CategoryFile,
 			packages: map[pkg.Platform][]string{
@@ -36,9 +37,9 @@ func NewYaziTool() *YaziTool {
 				pkg.PlatformArch:  {"yazi", "ffmpegthumbnailer", "unarchiver", "jq", "poppler", "fd", "ripgrep", "fzf", "zoxide", "imagemagick"},
 			},
 			configPaths: []string{
-				filepath.Join(home, ".config", "yazi", "yazi.toml"),
-				filepath.Join(home, ".config", "yazi", "keymap.toml"),
-				filepath.Join(home, ".config", "yazi", "theme.toml"),
+				filepath.Join(config.ConfigHome(), "yazi", "yazi.toml"),
+				filepath.Join(config.ConfigHome(), "yazi", "keymap.toml"),
+				filepath.Join(config.ConfigHome(), "yazi", "theme.toml"),
 			},
 			heavyTool: true, // Skip on low-memory systems (Pi Zero 2)
 			// UI metadata
@@ -151,12 +152,10 @@ func GenerateYaziKeymap(cfg YaziConfig, theme string) string {
 
 // WriteYaziConfig writes all Yazi config files to disk
 func WriteYaziConfig(cfg YaziConfig, theme string) error {
-	home, err := os.UserHomeDir()
+	configDir, err := userConfigDir("yazi")
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
-
-	configDir := filepath.Join(home, ".config", "yazi")
 	if err := os.MkdirAll(configDir, 0700); err != nil {
 		return fmt.Errorf("failed to create yazi config directory: %w", err)
 	}
@@ -164,14 +163,14 @@ func WriteYaziConfig(cfg YaziConfig, theme string) error {
 	// Write yazi.toml
 	yaziPath := filepath.Join(configDir, "yazi.toml")
 	yaziContent := GenerateYaziConfig(cfg, theme)
-	if err := os.WriteFile(yaziPath, []byte(yaziContent), 0600); err != nil {
+	if err := WriteGeneratedFile(yaziPath, yaziContent, "#", 0600); err != nil {
 		return fmt.Errorf("failed to write yazi.toml: %w", err)
 	}
 
 	// Write keymap.toml
 	keymapPath := filepath.Join(configDir, "keymap.toml")
 	keymapContent := GenerateYaziKeymap(cfg, theme)
-	if err := os.WriteFile(keymapPath, []byte(keymapContent), 0600); err != nil {
+	if err := WriteGeneratedFile(keymapPath, keymapContent, "#", 0600); err != nil {
 		return fmt.Errorf("failed to write keymap.toml: %w", err)
 	}
 
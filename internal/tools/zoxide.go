@@ -16,6 +16,7 @@ func NewZoxideTool() *ZoxideTool {
 			id:          "zoxide",
 			name:        "zoxide",
 			description: "Smarter cd command with learning",
+			url:         "https://github.com/ajeetdsouza/zoxide",
 			icon:        "󰄛",
 			category:    CategoryUtility,
 			packages: map[pkg.Platform][]string{
@@ -9,7 +9,8 @@ import (
 	"github.com/tekierz/dotfiles/internal/pkg"
 )
 
-// ZshConfig holds Zsh configuration settings
+// ZshConfig holds shell configuration settings. Despite the name it drives
+// the bash and fish generators too (see GenerateShellConfig).
 type ZshConfig struct {
 	PromptStyle     string          // "p10k", "starship", "pure", "minimal"
 	Plugins         []string        // List of plugins to source
@@ -33,6 +34,7 @@ func NewZshTool() *ZshTool {
 			id:          "zsh",
 			name:        "Zsh",
 			description: "Z shell with plugins and customization",
+			url:         "https://zsh.sourceforge.io/Doc/",
 			icon:        "",
 			category:    CategoryShell,
 			packages: map[pkg.Platform][]string{
@@ -61,6 +63,13 @@ func GenerateZshConfig(cfg ZshConfig, theme string) string {
 	sb.WriteString("# Generated by dotfiles TUI\n")
 	sb.WriteString(fmt.Sprintf("# Theme: %s\n\n", theme))
 
+	// Homebrew's shellenv, so brew is on PATH even where the login
+	// environment doesn't add it (fresh Apple Silicon macs)
+	if brew := pkg.BrewInstallLocation(); brew != "" {
+		sb.WriteString("# Homebrew\n")
+		sb.WriteString(BrewShellenvLine(ShellZsh, brew) + "\n\n")
+	}
+
 	// History settings
 	sb.WriteString("# History\n")
 	sb.WriteString(fmt.Sprintf("HISTSIZE=%d\n", cfg.HistorySize))
@@ -112,25 +121,7 @@ func GenerateZshConfig(cfg ZshConfig, theme string) string {
 
 	// Aliases
 	sb.WriteString("# Aliases\n")
-	if cfg.Aliases["ll"] {
-		sb.WriteString("alias ll='ls -la'\n")
-	}
-	if cfg.Aliases["la"] {
-		sb.WriteString("alias la='ls -A'\n")
-	}
-	if cfg.Aliases["gs"] {
-		sb.WriteString("alias gs='git status'\n")
-	}
-	if cfg.Aliases["gp"] {
-		sb.WriteString("alias gp='git push'\n")
-	}
-	if cfg.Aliases["gc"] {
-		sb.WriteString("alias gc='git commit'\n")
-	}
-	if cfg.Aliases["docker"] {
-		sb.WriteString("alias d='docker'\n")
-		sb.WriteString("alias dc='docker compose'\n")
-	}
+	writePOSIXAliases(&sb, cfg.Aliases)
 	sb.WriteString("\n")
 
 	// Modern tool aliases (if available)
@@ -160,21 +151,19 @@ func GenerateZshConfig(cfg ZshConfig, theme string) string {
 	return sb.String()
 }
 
-// WriteZshConfig writes the .zshrc file to disk
+// WriteZshConfig writes the managed block of .zshrc to disk
 func WriteZshConfig(cfg ZshConfig, theme string) error {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return fmt.Errorf("failed to get home directory: %w", err)
 	}
 
+	// Only the managed block is rewritten so repeated installs don't
+	// duplicate content and user additions outside the markers survive.
 	configPath := filepath.Join(home, ".zshrc")
 	content := GenerateZshConfig(cfg, theme)
 
-	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
-		return fmt.Errorf("failed to write .zshrc: %w", err)
-	}
-
-	return nil
+	return WriteManagedFile(configPath, content, 0600)
 }
 
 // GenerateConfig implements Tool interface (uses defaults)
@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/tekierz/dotfiles/internal/config"
 )
 
 // DurFrame represents a single frame in a .dur animation
@@ -99,7 +101,7 @@ func GetAnimationPath() string {
 	// Check multiple locations
 	paths := []string{
 		"assets/intro.dur",
-		filepath.Join(os.Getenv("HOME"), ".config/dotfiles/intro.dur"),
+		filepath.Join(config.ConfigDir(), "intro.dur"),
 		"/usr/local/share/dotfiles/intro.dur",
 	}
 
@@ -1,17 +1,20 @@
 package ui
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"sort"
-	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tekierz/dotfiles/internal/backup"
 	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/opener"
 	"github.com/tekierz/dotfiles/internal/pkg"
 	"github.com/tekierz/dotfiles/internal/runner"
 	"github.com/tekierz/dotfiles/internal/tools"
@@ -21,6 +24,9 @@ const (
 	introAnimationFrames = 72
 	introAnimationTick   = 70 * time.Millisecond
 	uiTick               = 80 * time.Millisecond
+	// themePreviewInterval is the least time between live theme previews
+	// while scrolling the theme picker
+	themePreviewInterval = 100 * time.Millisecond
 )
 
 // Screen represents different screens in the wizard
@@ -77,6 +83,8 @@ const (
 	ScreenManageGlow
 	ScreenConfigClaudeCode
 	ScreenManageClaudeCode
+	ScreenDeepDiveConfirm // Recap of selections before installing
+	ScreenFavorites       // Favorite hotkeys across all tools
 )
 
 // Available themes
@@ -107,6 +115,7 @@ func (a *App) syncThemeIndex() {
 	for i, t := range themes {
 		if t.name == a.theme {
 			a.themeIndex = i
+			a.appliedTheme = i
 			SetTheme(a.theme) // Apply theme colors for live preview
 			return
 		}
@@ -122,9 +131,28 @@ type App struct {
 	height        int
 	animationDone bool
 
+	// durdraw is probed for during the intro unless skip_durdraw_check is
+	// set; the built-in intro plays whether or not it is found
+	skipDurdrawCheck bool
+
 	// Screen manager for migrated screens (nil during transition)
 	screenMgr *ScreenManager
 
+	// Rebindable keys, from the "keybindings" setting (see keymap.go)
+	keys keymap
+
+	// Back/forward screen history (see nav_history.go)
+	nav navHistory
+
+	// Idle timeout (the idle_timeout setting, 0 = off); lastInput is the
+	// time of the last key or mouse event
+	idleTimeout time.Duration
+	lastInput   time.Time
+
+	// Result of the last global action (screen export, nav toggle, theme
+	// cycle), shown over the last line of any screen until the next key
+	bannerStatus string
+
 	// Animation state
 	animFrame        int
 	animTicker       *time.Ticker
@@ -137,10 +165,24 @@ type App struct {
 	themeIndex int
 	theme      string
 	navStyle   string
+	// appliedTheme is the theme index in effect outside the theme picker;
+	// leaving the picker with Esc reverts its live preview to it
+	appliedTheme int
+	// Live previews in the theme picker are throttled: the cursor moves at
+	// once, but styles are rebuilt at most every themePreviewInterval, with
+	// a trailing preview once scrolling settles
+	themePreviewAt  time.Time
+	themePreviewSeq int
 	// animationsEnabled controls non-essential UI animations (headers/widgets).
 	// When false, we render static UI to reduce motion/jank and CPU usage.
 	animationsEnabled bool
-	deepDive          bool
+	// pauseAnimationsOnBattery stops the UI tick while on battery power;
+	// animationsPaused records that the tick is currently stopped for that reason.
+	pauseAnimationsOnBattery bool
+	animationsPaused         bool
+	// followSystemAccent replaces the theme accent with the macOS accent color.
+	followSystemAccent bool
+	deepDive           bool
 
 	// Deep dive state (installer)
 	deepDiveMenuIndex int
@@ -152,9 +194,24 @@ type App struct {
 	guiAppIndex       int // Currently focused GUI app
 	cliUtilityIndex   int // Currently focused CLI utility (bat, eza, etc.)
 
+	// Whether each tools.LSPRuntimes entry is on PATH, filled on first use
+	lspRuntimeInstalled map[string]bool
+
+	// deepDiveVisible holds the Global setting toggles for each deep dive
+	// menu item, keyed by DeepDiveMenuItem.Key (see deepDiveShown)
+	deepDiveVisible map[string]*bool
+
+	// Generated config preview (tmux/ghostty/git deep dive screens)
+	deepDivePreview       bool
+	deepDivePreviewScroll int
+	// Dragging a deep dive slider (the focused field) with the mouse
+	sliderDragging bool
+
 	// Management state (detailed config)
 	manageConfig *ManageConfig
-	managePane   int // 0 = tools pane, 1 = settings pane (ScreenManage)
+	// install --from snapshot whose settings are saved once the install starts
+	pendingSnapshot *config.Snapshot
+	managePane      int // 0 = tools pane, 1 = settings pane (ScreenManage)
 	// Cached install status for tools to avoid running package-manager checks every render.
 	manageInstalled      map[string]bool
 	manageInstalledReady bool
@@ -167,8 +224,21 @@ type App struct {
 	manageEditValue    string
 	manageEditCursor   int
 	manageEditField    *string
-	manageEditFieldKey string // human label for the field being edited
-	manageStatus       string // transient status line (save result, etc.)
+	manageEditFieldKey string          // human label for the field being edited
+	manageStatus       string          // transient status line (save result, etc.)
+	manageLoadErr      error           // why manage.json couldn't be loaded, if it couldn't
+	manageShowDetails  bool            // expanded description/packages view in the settings pane
+	manageManagerOnly  bool            // list only tools the detected package manager can install (m)
+	manageSkipUpdates  map[string]bool // tool IDs excluded from update checks
+	manageRawJSON      bool            // read-only view of the persisted manage.json
+	manageRawLines     []string        // formatted JSON shown in the raw view
+	manageRawTitle     string
+	manageRawScroll    int  // top line offset in the raw view
+	manageSearching    bool // flat cross-tool settings search is open
+	manageSearchQuery  string
+	manageSearchCursor int
+	// Validator for the text field being edited, if any
+	manageEditValidate func(string) error
 
 	// Installation state
 	installStep     int
@@ -177,6 +247,23 @@ type App struct {
 	installComplete bool
 	installCmd      *exec.Cmd
 	runner          *runner.Runner
+	// Refreshes sudo while any install or update runs (nil where sudo isn't needed)
+	sudoKeepAlive *runner.SudoKeepAlive
+	// Tally of the last install, printed by "dotfiles install" on exit
+	installResult InstallResult
+
+	// Summary screen: zsh path when zsh is installed but not the login shell
+	summaryZshPath string
+	summaryStatus  string
+	// Summary screen: brew path when Homebrew is installed but not on PATH
+	summaryBrewPath string
+	// Summary screen: whether ~/.local/bin is on PATH, and the shell whose
+	// rc file would add it
+	summaryLocalBin tools.LocalBinState
+	summaryShell    tools.Shell
+	// Typing a name to save the selection as an install profile
+	summaryNaming    bool
+	summaryNameInput string
 
 	// Management platform state (new)
 	mainMenuIndex        int                   // Main menu cursor
@@ -191,6 +278,11 @@ type App struct {
 	hotkeysReturn        Screen                // Screen to return to when leaving hotkeys
 	hotkeysFavorites     *config.HotkeysConfig // User hotkey favorites config
 	hotkeysFavoritesOnly bool                  // Filter to show only favorites
+	hotkeysFlat          bool                  // Show every category's items in one list
+	favoritesIndex       int                   // My Favorites screen cursor
+	// Hotkeys favorite undo state
+	hotkeysUndo   [][]hotkeyFavoriteToggle // Favorite toggle operations, newest last
+	hotkeysStatus string                   // Transient status (e.g. after undo)
 	// Hotkeys alias editing state
 	hotkeysAddingAlias  bool   // Currently adding an alias
 	hotkeysAliasName    string // Alias name being entered
@@ -202,10 +294,16 @@ type App struct {
 	backupsLoaded       bool
 	backupsLoading      bool
 	backupConfirmMode   bool
-	backupConfirmType   string // "restore" or "delete"
-	backupStatus        string // Status message for backup operations
-	backupRunning       bool   // Currently running a backup operation
-	backupError         error  // Error from backup operation
+	backupConfirmType   string               // "restore" or "delete"
+	backupPlan          []backup.RestoreFile // Files a pending restore would write
+	backupDiffView      bool                 // Showing per-file diffs for the pending restore
+	backupDiffIndex     int                  // Which differing file is shown
+	backupDiffScroll    int                  // Scroll offset in the diff view
+	backupStatus        string               // Status message for backup operations
+	backupRunning       bool                 // Currently running a backup operation
+	backupError         error                // Error from backup operation
+	backupNaming        bool                 // Typing a label for a new backup
+	backupNameInput     string               // Label being typed
 
 	// Users screen state
 	usersItems      []userItem // Cached user list
@@ -219,13 +317,22 @@ type App struct {
 	usersStatus     string     // Status message
 
 	// Update screen async state
-	updateChecking  bool          // Currently checking for updates
-	updateCheckDone bool          // Check completed (use cached results)
-	updateResults   []pkg.Package // Cached update results
-	updateError     error         // Error from update check
-	updateRunning   bool          // Currently running an update operation
-	updateStatus    string        // Status message for current update operation
-	updateSelected  map[int]bool  // Selected packages for batch update
+	updateChecking  bool            // Currently checking for updates
+	updateCheckDone bool            // Check completed (use cached results)
+	updateResults   []pkg.Package   // Cached update results (in updateSort order)
+	updateOriginal  []pkg.Package   // Update results in the order the manager reported them
+	updateSort      string          // Update list ordering (see updateSort* constants)
+	updateError     error           // Error from update check
+	updateRunning   bool            // Currently running an update operation
+	updateStatus    string          // Status message for current update operation
+	updateSelected  map[string]bool // Selected package names for batch update
+
+	// Update all queue (see update_queue.go)
+	updateQueue        []pkg.Package      // Packages left to update; the head is in flight while updateRunning
+	updateQueueTotal   int                // Packages in the update all, for progress
+	updateQueueResults []pkg.UpdateResult // Packages finished so far
+	updatePausing      bool               // Pause requested: stop after the package in flight
+	updateCancel       context.CancelFunc // Stops the package in flight
 
 	// Install/Update log streaming state
 	installLogs          []string // Circular buffer of log lines (max 500)
@@ -233,7 +340,12 @@ type App struct {
 	installLogAutoScroll bool     // Auto-scroll to bottom during active install
 
 	// Error state
-	lastError error
+	lastError   error
+	errorScroll int // Scroll position in the error details (0 = bottom)
+
+	// deepDiveCategories limits the deep dive menu to these tool categories
+	// (install --category); empty shows everything
+	deepDiveCategories []tools.Category
 }
 
 // AppOption configures optional App parameters
@@ -253,6 +365,60 @@ func WithScreenFactory(factory ScreenFactory) AppOption {
 	}
 }
 
+// WithDeepDiveCategories scopes the install wizard to the given tool
+// categories: it starts in deep dive mode with the menu pre-filtered
+func WithDeepDiveCategories(cats []tools.Category) AppOption {
+	return func(a *App) {
+		if len(cats) > 0 {
+			a.deepDiveCategories = cats
+			a.deepDive = true
+		}
+	}
+}
+
+// WithInstallProfile starts the install wizard in deep dive mode with the
+// profile's tools selected
+func WithInstallProfile(profile *config.InstallProfile) AppOption {
+	return func(a *App) {
+		if profile != nil {
+			a.deepDiveConfig.ApplySelection(profile.Tools)
+			a.deepDive = true
+		}
+	}
+}
+
+// WithSnapshot starts the install wizard in deep dive mode with a machine
+// snapshot's tools, theme, navigation style and Manage settings selected.
+// Nothing is saved until the install starts (see saveSnapshotSettings).
+func WithSnapshot(snap *config.Snapshot) AppOption {
+	return func(a *App) {
+		if snap == nil {
+			return
+		}
+		a.deepDiveConfig.ApplySelection(snap.Selection())
+		a.deepDive = true
+		a.pendingSnapshot = snap
+		if len(snap.Manage) > 0 {
+			manage := NewManageConfig()
+			if err := json.Unmarshal(snap.Manage, manage); err == nil {
+				a.manageConfig = manage
+			}
+		}
+		if config.IsValidTheme(snap.Theme) {
+			a.theme = snap.Theme
+			a.syncThemeIndex()
+			SetTheme(a.theme)
+		}
+		if config.IsValidNavStyle(snap.NavStyle) {
+			a.navStyle = snap.NavStyle
+		}
+	}
+}
+
+// corruptConfigStatus is shown when a saved config can't be read, so the
+// defaults in use don't look like lost settings
+const corruptConfigStatus = "⚠ A config file is corrupt; defaults are in use. Run 'dotfiles reset --config' to repair it."
+
 // NewApp creates a new application instance
 func NewApp(skipIntro bool, opts ...AppOption) *App {
 	app := &App{
@@ -267,9 +433,10 @@ func NewApp(skipIntro bool, opts ...AppOption) *App {
 		managePane:           0,
 		postIntroScreen:      ScreenWelcome,
 		hotkeysReturn:        ScreenMainMenu,
-		updateSelected:       make(map[int]bool),
+		updateSelected:       make(map[string]bool),
 		installLogs:          make([]string, 0, 500),
 		installLogAutoScroll: true,
+		keys:                 defaultKeymap(),
 	}
 
 	// Best-effort: load persisted global settings (theme + nav) if available.
@@ -281,8 +448,35 @@ func NewApp(skipIntro bool, opts ...AppOption) *App {
 			app.navStyle = cfg.NavStyle
 		}
 		app.animationsEnabled = !cfg.DisableAnimations
+		app.pauseAnimationsOnBattery = cfg.PauseAnimationsOnBattery
+		app.followSystemAccent = cfg.FollowSystemAccent
+		app.manageSkipUpdates = make(map[string]bool)
+		for _, id := range cfg.SkipUpdateCheck {
+			app.manageSkipUpdates[id] = true
+		}
+		app.updateSort = cfg.UpdateSort
+		app.idleTimeout = time.Duration(cfg.IdleTimeout) * time.Minute
+		app.skipIntro = app.skipIntro || cfg.SkipIntro
+		app.skipDurdrawCheck = cfg.SkipDurdrawCheck
+		for _, key := range cfg.HiddenDeepDiveCategories {
+			*app.deepDiveShown(key) = false
+		}
+		var keyErrs []error
+		if app.keys, keyErrs = newKeymap(cfg.Keybindings); len(keyErrs) > 0 {
+			app.manageStatus = "⚠ keybindings: " + keyErrs[0].Error()
+		}
+	} else if errors.As(err, new(*config.CorruptError)) {
+		app.manageStatus = corruptConfigStatus
 	}
 
+	// Long installs can outlast sudo's credential cache on Linux
+	if runner.NeedsSudo() {
+		app.sudoKeepAlive = runner.NewSudoKeepAlive()
+	}
+
+	// Best-effort: custom themes join the built-in ones before the theme is applied
+	LoadCustomThemes()
+
 	// Keep the theme picker cursor in sync with the persisted theme.
 	app.syncThemeIndex()
 
@@ -292,6 +486,20 @@ func NewApp(skipIntro bool, opts ...AppOption) *App {
 	// Best-effort: load persisted management settings for deep-dive manager UI.
 	if cfg, err := config.LoadToolConfig("manage", NewManageConfig); err == nil && cfg != nil {
 		app.manageConfig = cfg
+	} else {
+		app.manageLoadErr = err
+		if errors.As(err, new(*config.CorruptError)) {
+			app.manageStatus = corruptConfigStatus
+		}
+	}
+
+	// Reflect an existing .gitconfig in the Git editors. Saved Manage settings
+	// win, since they were already edited from what was imported.
+	if imp, ok := tools.LoadGitImport(); ok {
+		app.deepDiveConfig.ImportGit(imp)
+		if !config.ToolConfigExists("manage") {
+			app.manageConfig.ImportGit(imp)
+		}
 	}
 
 	// Best-effort: load hotkeys favorites config.
@@ -301,7 +509,7 @@ func NewApp(skipIntro bool, opts ...AppOption) *App {
 		app.hotkeysFavorites = &config.HotkeysConfig{Users: make(map[string]*config.UserHotkeys)}
 	}
 
-	if skipIntro {
+	if app.skipIntro {
 		app.screen = ScreenWelcome
 		app.animationDone = true
 	} else {
@@ -322,8 +530,19 @@ func (a *App) Init() tea.Cmd {
 	if a.animationsEnabled {
 		cmds = append(cmds, tickUI())
 	}
+	cmds = append(cmds, checkPowerCmd(a.pauseAnimationsOnBattery))
+	if a.idleTimeout > 0 {
+		a.lastInput = time.Now()
+		cmds = append(cmds, tickIdle())
+	}
+	if a.followSystemAccent {
+		cmds = append(cmds, systemAccentCmd(true))
+	}
 	if a.screen == ScreenAnimation {
-		cmds = append(cmds, tickAnimation(), checkDurdraw())
+		cmds = append(cmds, tickAnimation())
+		if !a.skipDurdrawCheck {
+			cmds = append(cmds, checkDurdraw())
+		}
 	}
 	// Start update check if starting directly on Update screen
 	if a.screen == ScreenUpdate && !a.updateChecking && !a.updateCheckDone {
@@ -359,7 +578,7 @@ func checkSudoAndUpdateCmd(packages []pkg.Package, all bool) tea.Cmd {
 	return func() tea.Msg {
 		mgr := pkg.DetectManager()
 		if mgr == nil {
-			return updateRunDoneMsg{err: fmt.Errorf("no package manager detected")}
+			return updateRunDoneMsg{err: pkg.ErrNoManager}
 		}
 
 		// Check if sudo is needed and not cached
@@ -375,73 +594,16 @@ func checkSudoAndUpdateCmd(packages []pkg.Package, all bool) tea.Cmd {
 // loadBackupsCmd loads the list of available backups asynchronously
 func loadBackupsCmd() tea.Cmd {
 	return func() tea.Msg {
-		backupDir := filepath.Join(config.ConfigDir(), "backups")
-		entries, err := os.ReadDir(backupDir)
+		backups, err := backup.List()
 		if err != nil {
-			if os.IsNotExist(err) {
-				return backupsLoadedMsg{backups: []BackupEntry{}}
-			}
 			return backupsLoadedMsg{err: err}
 		}
-
-		var backups []BackupEntry
-		for _, entry := range entries {
-			if entry.IsDir() {
-				info, _ := entry.Info()
-				path := filepath.Join(backupDir, entry.Name())
-				count := countBackupFiles(path)
-				size := calcDirSize(path)
-
-				timestamp := time.Time{}
-				if info != nil {
-					timestamp = info.ModTime()
-				}
-
-				backups = append(backups, BackupEntry{
-					Name:      entry.Name(),
-					Timestamp: timestamp,
-					FileCount: count,
-					Size:      size,
-					Path:      path,
-				})
-			}
-		}
-
-		// Sort by timestamp descending (newest first)
-		sort.Slice(backups, func(i, j int) bool {
-			return backups[i].Timestamp.After(backups[j].Timestamp)
-		})
-
 		return backupsLoadedMsg{backups: backups}
 	}
 }
 
-// countBackupFiles counts files in a backup directory
-func countBackupFiles(path string) int {
-	count := 0
-	filepath.Walk(path, func(_ string, info os.FileInfo, _ error) error {
-		if info != nil && !info.IsDir() {
-			count++
-		}
-		return nil
-	})
-	return count
-}
-
-// calcDirSize calculates the total size of files in a directory
-func calcDirSize(path string) int64 {
-	var size int64
-	filepath.Walk(path, func(_ string, info os.FileInfo, _ error) error {
-		if info != nil && !info.IsDir() {
-			size += info.Size()
-		}
-		return nil
-	})
-	return size
-}
-
-// formatBytes formats a byte count into a human-readable string
-func formatBytes(bytes int64) string {
+// FormatBytes formats a byte count into a human-readable string
+func FormatBytes(bytes int64) string {
 	const unit = 1024
 	if bytes < unit {
 		return fmt.Sprintf("%d B", bytes)
@@ -454,54 +616,27 @@ func formatBytes(bytes int64) string {
 	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
 
-// restoreBackupCmd restores files from a backup
-func restoreBackupCmd(backup BackupEntry) tea.Cmd {
+// restoreBackupCmd restores the files of a previously computed restore plan
+func restoreBackupCmd(name string, files []backup.RestoreFile) tea.Cmd {
 	return func() tea.Msg {
-		entries, err := os.ReadDir(backup.Path)
-		if err != nil {
-			return backupRestoreDoneMsg{name: backup.Name, err: err}
-		}
-
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return backupRestoreDoneMsg{name: backup.Name, err: err}
-		}
-
-		restored := 0
-		for _, entry := range entries {
-			if entry.IsDir() || entry.Name() == "manifest.txt" {
-				continue
-			}
-
-			srcPath := filepath.Join(backup.Path, entry.Name())
-			// Backup files are named with underscores replacing slashes
-			relPath := strings.ReplaceAll(entry.Name(), "_", string(os.PathSeparator))
-			dstPath := filepath.Clean(filepath.Join(home, relPath))
-
-			// Security: Prevent path traversal attacks
-			if !strings.HasPrefix(dstPath, home+string(os.PathSeparator)) && dstPath != home {
-				continue
+		// Per-file Keep flags carry the user's choices for newer files
+		opts := backup.RestoreOptions{}
+		kept := 0
+		for _, f := range files {
+			if f.Kept(opts) {
+				kept++
 			}
-
-			// Ensure destination directory exists
-			if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
-				continue
-			}
-
-			// Read source and write to destination
-			data, err := os.ReadFile(srcPath)
-			if err != nil {
-				continue
-			}
-
-			if err := os.WriteFile(dstPath, data, 0600); err != nil {
-				continue
-			}
-
-			restored++
 		}
+		restored, err := backup.Restore(files, opts)
+		return backupRestoreDoneMsg{name: name, count: restored, kept: kept, err: err}
+	}
+}
 
-		return backupRestoreDoneMsg{name: backup.Name, count: restored, err: nil}
+// planRestoreCmd compares a backup's files against what is on disk
+func planRestoreCmd(entry BackupEntry) tea.Cmd {
+	return func() tea.Msg {
+		files, err := backup.Plan(entry.Path)
+		return backupPlanMsg{name: entry.Name, reason: entry.Reason, files: files, err: err}
 	}
 }
 
@@ -513,130 +648,56 @@ func deleteBackupCmd(backup BackupEntry) tea.Cmd {
 	}
 }
 
-// createBackupCmd creates a new backup of current dotfiles
-func createBackupCmd() tea.Cmd {
+// openBackupCmd browses path in yazi when it's installed, otherwise opens the
+// backups directory in the OS file manager. With neither available it only
+// reports where the backups are.
+func openBackupCmd(path string) tea.Cmd {
+	if cmd, err := opener.Yazi(path); err == nil {
+		return tea.ExecProcess(cmd, func(err error) tea.Msg {
+			return backupOpenedMsg{path: path, err: err}
+		})
+	}
+	dir := backup.Dir()
 	return func() tea.Msg {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return backupCreateDoneMsg{err: err}
-		}
-
-		// Create backup directory with timestamp
-		timestamp := time.Now().Format("2006-01-02_15-04-05")
-		backupDir := filepath.Join(config.ConfigDir(), "backups", timestamp)
-		if err := os.MkdirAll(backupDir, 0700); err != nil {
-			return backupCreateDoneMsg{err: err}
-		}
-
-		// Files to backup (relative to home)
-		filesToBackup := []string{
-			".zshrc",
-			".tmux.conf",
-			".config/nvim/init.lua",
-			".config/ghostty/config",
-			".config/yazi/yazi.toml",
-			".gitconfig",
+		cmd, err := opener.FileManager(dir)
+		if err == nil {
+			err = cmd.Run()
 		}
-
-		backedUp := []string{}
-		for _, relPath := range filesToBackup {
-			srcPath := filepath.Join(home, relPath)
-			if _, err := os.Stat(srcPath); os.IsNotExist(err) {
-				continue
-			}
-
-			data, err := os.ReadFile(srcPath)
-			if err != nil {
-				continue
-			}
-
-			// Replace path separators with underscores for flat storage
-			safeName := strings.ReplaceAll(relPath, string(os.PathSeparator), "_")
-			dstPath := filepath.Join(backupDir, safeName)
-
-			if err := os.WriteFile(dstPath, data, 0600); err != nil {
-				continue
-			}
-
-			backedUp = append(backedUp, relPath)
-		}
-
-		// Write manifest
-		manifest := strings.Join(backedUp, "\n")
-		manifestPath := filepath.Join(backupDir, "manifest.txt")
-		os.WriteFile(manifestPath, []byte(manifest), 0600)
-
-		// Run backup cleanup based on settings
-		cleanupBackups()
-
-		return backupCreateDoneMsg{name: timestamp, err: nil}
+		return backupOpenedMsg{path: dir, err: err}
 	}
 }
 
-// cleanupBackups removes old backups based on global config settings
-func cleanupBackups() {
-	cfg, err := config.LoadGlobalConfig()
-	if err != nil {
-		return
-	}
-
-	backupsDir := filepath.Join(config.ConfigDir(), "backups")
-	entries, err := os.ReadDir(backupsDir)
-	if err != nil {
-		return
-	}
-
-	type backupInfo struct {
-		name    string
-		modTime time.Time
-	}
-
-	var backups []backupInfo
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
-		}
-		info, err := entry.Info()
-		if err != nil {
-			continue
+// openToolDocsCmd opens a tool's docs in the browser. Without one (e.g. over
+// SSH) the result reports the URL so it can be opened elsewhere.
+func openToolDocsCmd(url string) tea.Cmd {
+	return func() tea.Msg {
+		cmd, err := opener.Browser(url)
+		if err == nil {
+			err = cmd.Run()
 		}
-		backups = append(backups, backupInfo{
-			name:    entry.Name(),
-			modTime: info.ModTime(),
-		})
+		return toolDocsOpenedMsg{url: url, err: err}
 	}
+}
 
-	// Sort by modification time (newest first)
-	sort.Slice(backups, func(i, j int) bool {
-		return backups[i].modTime.After(backups[j].modTime)
-	})
-
-	now := time.Now()
-	for i, backup := range backups {
-		shouldDelete := false
-
-		// Delete if exceeds max count (and max count is set)
-		if cfg.BackupMaxCount > 0 && i >= cfg.BackupMaxCount {
-			shouldDelete = true
-		}
-
-		// Delete if exceeds max age (and max age is set)
-		if cfg.BackupMaxAgeDays > 0 {
-			age := now.Sub(backup.modTime)
-			if age > time.Duration(cfg.BackupMaxAgeDays)*24*time.Hour {
-				shouldDelete = true
-			}
-		}
+// saveInstallProfileCmd saves the selected tools as a named install profile
+func saveInstallProfileCmd(name string, ids []string) tea.Cmd {
+	return func() tea.Msg {
+		err := config.SaveInstallProfile(&config.InstallProfile{Name: name, Tools: ids})
+		return installProfileSavedMsg{name: name, err: err}
+	}
+}
 
-		if shouldDelete {
-			backupPath := filepath.Join(backupsDir, backup.name)
-			os.RemoveAll(backupPath)
-		}
+// createBackupCmd creates a new backup of current dotfiles with an optional label
+func createBackupCmd(label string) tea.Cmd {
+	return func() tea.Msg {
+		name, err := backup.Create(backup.CreateOptions{Label: label})
+		return backupCreateDoneMsg{name: name, err: err}
 	}
 }
 
-// autoBackupIfEnabled creates a backup if auto-backup is enabled in settings
-func autoBackupIfEnabled() error {
+// autoBackupIfEnabled creates a backup if auto-backup is enabled in settings,
+// recording reason (e.g. "auto: pre-install") in its manifest
+func autoBackupIfEnabled(reason string) error {
 	cfg, err := config.LoadGlobalConfig()
 	if err != nil {
 		return err
@@ -646,64 +707,14 @@ func autoBackupIfEnabled() error {
 		return nil
 	}
 
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return err
-	}
-
-	// Create backup directory with timestamp
-	timestamp := time.Now().Format("2006-01-02_15-04-05") + "_auto"
-	backupDir := filepath.Join(config.ConfigDir(), "backups", timestamp)
-	if err := os.MkdirAll(backupDir, 0700); err != nil {
-		return err
-	}
-
-	// Files to backup (relative to home)
-	filesToBackup := []string{
-		".zshrc",
-		".tmux.conf",
-		".config/nvim/init.lua",
-		".config/ghostty/config",
-		".config/yazi/yazi.toml",
-		".gitconfig",
-	}
-
-	backedUp := []string{}
-	for _, relPath := range filesToBackup {
-		srcPath := filepath.Join(home, relPath)
-		if _, err := os.Stat(srcPath); os.IsNotExist(err) {
-			continue
-		}
-
-		data, err := os.ReadFile(srcPath)
-		if err != nil {
-			continue
-		}
-
-		// Replace path separators with underscores for flat storage
-		safeName := strings.ReplaceAll(relPath, string(os.PathSeparator), "_")
-		dstPath := filepath.Join(backupDir, safeName)
-
-		if err := os.WriteFile(dstPath, data, 0600); err != nil {
-			continue
-		}
-
-		backedUp = append(backedUp, relPath)
-	}
-
-	// Write manifest
-	manifest := strings.Join(backedUp, "\n")
-	manifestPath := filepath.Join(backupDir, "manifest.txt")
-	os.WriteFile(manifestPath, []byte(manifest), 0600)
-
-	// Run cleanup after creating backup
-	cleanupBackups()
-
-	return nil
+	_, err = backup.Create(backup.CreateOptions{Suffix: "_auto", Reason: reason})
+	return err
 }
 
 // Update handles messages
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer a.syncSudoKeepAlive()
+
 	// Handle window resize for screen manager
 	if wsm, ok := msg.(tea.WindowSizeMsg); ok {
 		a.width = wsm.Width
@@ -727,10 +738,16 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		a.lastInput = time.Now()
+		a.bannerStatus = ""
 		return a.handleKey(msg)
 
 	case tea.MouseMsg:
-		return a.handleMouse(msg)
+		a.lastInput = time.Now()
+		prev := a.currentNavEntry()
+		model, cmd := a.handleMouse(msg)
+		a.recordNavigation(prev, false)
+		return model, cmd
 
 	case tickMsg:
 		if a.screen == ScreenAnimation {
@@ -762,8 +779,30 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, tickAnimation()
 		}
 
+	case powerCheckMsg:
+		return a, checkPowerCmd(a.pauseAnimationsOnBattery)
+
+	case systemAccentMsg:
+		// Falls back to the theme's accent when the system accent is unavailable.
+		if a.followSystemAccent {
+			SetAccentOverride(msg.color)
+		}
+		return a, nil
+
+	case powerStateMsg:
+		wasPaused := a.animationsPaused
+		a.animationsPaused = a.pauseAnimationsOnBattery && msg.onBattery
+		if wasPaused && !a.animationsPaused && a.animationsEnabled {
+			// Back on AC: restart the UI tick loop.
+			return a, tea.Batch(tickPowerCheck(), tickUI())
+		}
+		return a, tickPowerCheck()
+
+	case idleTickMsg:
+		return a, a.checkIdle(time.Time(msg))
+
 	case uiTickMsg:
-		if !a.animationsEnabled {
+		if !a.animationsEnabled || a.animationsPaused {
 			return a, nil
 		}
 		a.uiFrame++
@@ -772,6 +811,12 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, tickUI()
 
+	case themePreviewMsg:
+		if msg.seq == a.themePreviewSeq {
+			a.previewTheme(a.themeIndex)
+		}
+		return a, nil
+
 	case durdrawAvailableMsg:
 		// Store durdraw availability if needed
 		return a, nil
@@ -798,10 +843,47 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return sudoCachedMsg{err: err}
 		})
 
+	case chshDoneMsg:
+		if msg.err != nil {
+			a.summaryStatus = fmt.Sprintf("chsh failed: %v", msg.err)
+			return a, nil
+		}
+		a.summaryZshPath = tools.ZshNotDefault()
+		if a.summaryZshPath == "" {
+			a.summaryStatus = "✓ zsh is now your login shell (takes effect on next login)"
+		}
+		return a, nil
+
+	case brewShellenvDoneMsg:
+		if msg.err != nil {
+			a.summaryStatus = fmt.Sprintf("Couldn't update %s: %v", a.summaryShell.DisplayPath(), msg.err)
+			return a, nil
+		}
+		a.summaryBrewPath = ""
+		a.summaryStatus = fmt.Sprintf("✓ Added Homebrew to %s (takes effect in new shells)", a.summaryShell.DisplayPath())
+		return a, nil
+
+	case localBinPathDoneMsg:
+		if msg.err != nil {
+			a.summaryStatus = fmt.Sprintf("Couldn't update %s: %v", a.summaryShell.DisplayPath(), msg.err)
+			return a, nil
+		}
+		a.summaryLocalBin = tools.LocalBinOK
+		a.summaryStatus = fmt.Sprintf("✓ Added ~/.local/bin to %s (restart your shell or run: source %s)",
+			a.summaryShell.DisplayPath(), a.summaryShell.DisplayPath())
+		return a, nil
+
+	case installProfileSavedMsg:
+		if msg.err != nil {
+			a.summaryStatus = fmt.Sprintf("Save failed: %v", msg.err)
+		} else {
+			a.summaryStatus = fmt.Sprintf("✓ Saved install profile %q (dotfiles install --profile %s)", msg.name, msg.name)
+		}
+		return a, nil
+
 	case sudoCachedMsg:
 		if msg.err != nil {
-			a.lastError = msg.err
-			a.screen = ScreenError
+			a.showError(msg.err)
 			return a, nil
 		}
 		// Sudo cached successfully, start installation
@@ -831,17 +913,29 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case installDoneMsg:
 		a.installRunning = false
 		a.installComplete = true
+		a.installResult = msg.result
+		if msg.err != nil && a.installResult.Failed == 0 {
+			// Never report a failed run as clean
+			a.installResult.Failed = 1
+		}
 		if msg.err != nil {
 			// Include context in error message for better debugging
 			if msg.context != "" {
-				a.lastError = fmt.Errorf("%v\n\nOutput:\n%s", msg.err, msg.context)
+				a.showError(fmt.Errorf("%v\n\nOutput:\n%s", msg.err, msg.context))
 			} else {
-				a.lastError = msg.err
+				a.showError(msg.err)
 			}
-			a.screen = ScreenError
 		}
 		return a, nil
 
+	case updateSortSavedMsg:
+		a.updateStatus = fmt.Sprintf("Sort not saved: %v", msg.err)
+		return a, nil
+
+	case navStyleSavedMsg:
+		a.bannerStatus = fmt.Sprintf("Navigation not saved: %v", msg.err)
+		return a, nil
+
 	case manageSavedMsg:
 		if msg.err != nil {
 			a.manageStatus = fmt.Sprintf("Save failed: %v", msg.err)
@@ -850,6 +944,14 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, nil
 
+	case manageCopiedMsg:
+		if msg.err != nil {
+			a.manageStatus = fmt.Sprintf("Copy failed: %v", msg.err)
+		} else {
+			a.manageStatus = fmt.Sprintf("Copied %s ✓", msg.what)
+		}
+		return a, nil
+
 	case manageInstallDoneMsg:
 		a.manageInstalling = false
 		a.manageInstallID = ""
@@ -864,7 +966,8 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case updateCheckDoneMsg:
 		a.updateChecking = false
 		a.updateCheckDone = true
-		a.updateResults = msg.updates
+		a.updateOriginal = msg.updates
+		a.updateResults = sortUpdates(msg.updates, a.updateSort)
 		a.updateError = msg.err
 		return a, nil
 
@@ -914,6 +1017,9 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.manageInstalled = msg.installed
 		a.manageInstalledReady = true
 		a.installCacheLoading = false
+		if a.manageStatus == manageCheckingStatus {
+			a.manageStatus = ""
+		}
 		return a, nil
 
 	case updateRunDoneMsg:
@@ -922,25 +1028,11 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			a.updateStatus = fmt.Sprintf("Update failed: %v", msg.err)
 		} else {
-			// Count successes and failures
-			successes := 0
-			failures := 0
-			for _, r := range msg.results {
-				if r.Success {
-					successes++
-				} else {
-					failures++
-				}
-			}
-			if failures > 0 {
-				a.updateStatus = fmt.Sprintf("Updated %d, failed %d", successes, failures)
-			} else if successes > 0 {
-				a.updateStatus = fmt.Sprintf("Updated %d package(s) ✓", successes)
-			} else {
-				a.updateStatus = "Update complete ✓"
-			}
-			// Clear selections and refresh the package list
-			a.updateSelected = make(map[int]bool)
+			a.updateStatus = updateResultsStatus(msg.results)
+			// Clear selections and any leftover queue, and refresh the package list
+			a.updateSelected = make(map[string]bool)
+			a.updateQueue = nil
+			a.updateQueueResults = nil
 			a.updateCheckDone = false
 			a.updateChecking = true
 			return a, checkUpdatesCmd()
@@ -979,14 +1071,21 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, a.streamingInstallToolCmd(msg.toolID)
 
 	case updateStartMsg:
-		// Start the streaming update (sudo already cached)
-		a.clearInstallLogs()
-		a.updateRunning = true
+		// Start the streaming update (sudo already cached). Update all
+		// without packages resumes the paused queue, keeping its log.
 		if msg.all {
-			return a, a.streamingUpdateAllCmd()
+			if msg.packages != nil {
+				a.clearInstallLogs()
+			}
+			return a, a.startUpdateQueue(msg.packages)
 		}
+		a.clearInstallLogs()
+		a.updateRunning = true
 		return a, a.streamingUpdateCmd(msg.packages)
 
+	case updateStepMsg:
+		return a, a.handleUpdateStep(msg)
+
 	case manageInstallWithLogsMsg:
 		// Install completed with logs
 		a.manageInstalling = false
@@ -1017,24 +1116,11 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			a.updateStatus = fmt.Sprintf("Update failed: %v", msg.err)
 		} else {
-			successes := 0
-			failures := 0
-			for _, r := range msg.results {
-				if r.Success {
-					successes++
-				} else {
-					failures++
-				}
-			}
-			if failures > 0 {
-				a.updateStatus = fmt.Sprintf("Updated %d, failed %d", successes, failures)
-			} else if successes > 0 {
-				a.updateStatus = fmt.Sprintf("Updated %d package(s) ✓", successes)
-			} else {
-				a.updateStatus = "Update complete ✓"
-			}
-			// Clear selections and refresh the package list
-			a.updateSelected = make(map[int]bool)
+			a.updateStatus = updateResultsStatus(msg.results)
+			// Clear selections and any leftover queue, and refresh the package list
+			a.updateSelected = make(map[string]bool)
+			a.updateQueue = nil
+			a.updateQueueResults = nil
 			a.updateCheckDone = false
 			a.updateChecking = true
 			return a, checkUpdatesCmd()
@@ -1053,13 +1139,37 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, nil
 
+	case backupPlanMsg:
+		a.backupRunning = false
+		if msg.err != nil {
+			a.backupStatus = fmt.Sprintf("Restore failed: %v", msg.err)
+			return a, nil
+		}
+		a.backupPlan = msg.files
+		a.backupDiffView = false
+		a.backupDiffIndex = 0
+		a.backupDiffScroll = 0
+		a.backupConfirmMode = true
+		a.backupConfirmType = "restore"
+		name := msg.name
+		if msg.reason != "" {
+			name += " (" + msg.reason + ")"
+		}
+		a.backupStatus = fmt.Sprintf("Restore backup '%s'? %s (y/n)", name, backup.Summarize(msg.files))
+		return a, nil
+
 	case backupRestoreDoneMsg:
 		a.backupRunning = false
 		a.backupConfirmMode = false
+		a.backupPlan = nil
+		a.backupDiffView = false
 		if msg.err != nil {
 			a.backupStatus = fmt.Sprintf("Restore failed: %v", msg.err)
 		} else {
 			a.backupStatus = fmt.Sprintf("Restored %d files from %s", msg.count, msg.name)
+			if msg.kept > 0 {
+				a.backupStatus += fmt.Sprintf(" (kept %d newer)", msg.kept)
+			}
 		}
 		return a, nil
 
@@ -1081,6 +1191,36 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, nil
 
+	case backupOpenedMsg:
+		switch {
+		case errors.Is(msg.err, opener.ErrUnavailable):
+			a.backupStatus = fmt.Sprintf("No file manager available — backups are in %s", msg.path)
+		case msg.err != nil:
+			a.backupStatus = fmt.Sprintf("Open failed: %v", msg.err)
+		default:
+			a.backupStatus = fmt.Sprintf("Opened %s", msg.path)
+		}
+		return a, nil
+
+	case screenExportedMsg:
+		if msg.err != nil {
+			a.bannerStatus = fmt.Sprintf("Screen export failed: %v", msg.err)
+		} else {
+			a.bannerStatus = "Screen saved to " + msg.path
+		}
+		return a, nil
+
+	case toolDocsOpenedMsg:
+		switch {
+		case errors.Is(msg.err, opener.ErrUnavailable):
+			a.manageStatus = "No browser available — docs: " + msg.url
+		case msg.err != nil:
+			a.manageStatus = fmt.Sprintf("Open failed (%v) — docs: %s", msg.err, msg.url)
+		default:
+			a.manageStatus = "Opened " + msg.url
+		}
+		return a, nil
+
 	case backupCreateDoneMsg:
 		a.backupRunning = false
 		if msg.err != nil {
@@ -1141,11 +1281,43 @@ func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, tea.Quit
 	}
 
-	// 'q' quits from any screen except during installation
-	if key == "q" && !a.installRunning && !(a.screen == ScreenManage && a.manageEditing) {
+	// The quit key (q) works from any screen except during installation or while typing
+	if a.keys.is(key, actionQuit) && !a.installRunning && !a.deepDivePreview && !a.textInputActive() {
 		return a, tea.Quit
 	}
 
+	// The toggle-nav key (ctrl+n) flips emacs/vim navigation from anywhere
+	if a.keys.is(key, actionToggleNav) && !a.textInputActive() {
+		return a, a.toggleNavStyle()
+	}
+
+	// The cycle-theme key (ctrl+t) previews the next theme from anywhere
+	if a.keys.is(key, actionCycleTheme) && !a.textInputActive() {
+		a.cycleTheme()
+		return a, nil
+	}
+
+	// The export-screen key (ctrl+e) saves the screen as plain text
+	if a.keys.is(key, actionExportScreen) && !a.textInputActive() {
+		return a, a.exportScreenCmd()
+	}
+
+	// The back/forward keys (alt+left/alt+right) walk the screen history
+	if a.keys.is(key, actionBack) && a.canNavigateHistory() {
+		return a, a.navigateBack()
+	}
+	if a.keys.is(key, actionForward) && a.canNavigateHistory() {
+		return a, a.navigateForward()
+	}
+
+	prev := a.currentNavEntry()
+	model, cmd := a.handleScreenKey(msg)
+	a.recordNavigation(prev, key == "esc")
+	return model, cmd
+}
+
+// handleScreenKey delegates a key to the current screen's handler
+func (a *App) handleScreenKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Delegate to screen-specific handlers
 	switch a.screen {
 	// Wizard screens
@@ -1160,20 +1332,116 @@ func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		ScreenManageLazyDocker, ScreenManageBtop, ScreenManageGlow, ScreenManageClaudeCode:
 		return a.handleManagementKey(msg)
 
+	case ScreenFavorites:
+		return a.handleFavoritesKey(msg)
+
 	// Deep dive screens
 	case ScreenDeepDiveMenu, ScreenConfigGhostty, ScreenConfigTmux, ScreenConfigZsh,
 		ScreenConfigNeovim, ScreenConfigGit, ScreenConfigYazi, ScreenConfigFzf,
 		ScreenConfigMacApps, ScreenConfigUtilities, ScreenConfigCLITools,
 		ScreenConfigGUIApps, ScreenConfigCLIUtilities, ScreenConfigLazyGit,
-		ScreenConfigLazyDocker, ScreenConfigBtop, ScreenConfigGlow, ScreenConfigClaudeCode:
+		ScreenConfigLazyDocker, ScreenConfigBtop, ScreenConfigGlow, ScreenConfigClaudeCode,
+		ScreenDeepDiveConfirm:
 		return a.handleDeepDiveKey(msg)
 	}
 
 	return a, nil
 }
 
-// View renders the UI
+// textInputActive reports whether a text field is capturing typed keys
+func (a *App) textInputActive() bool {
+	return (a.screen == ScreenManage && (a.manageEditing || a.manageSearching)) ||
+		(a.screen == ScreenBackups && a.backupNaming) ||
+		(a.screen == ScreenSummary && a.summaryNaming) ||
+		(a.screen == ScreenHotkeys && a.hotkeysAddingAlias) ||
+		(a.screen == ScreenUsers && a.usersCreating)
+}
+
+// toggleNavStyle switches between emacs and vim navigation, refreshing the
+// hotkey categories that depend on it, and persists the choice
+func (a *App) toggleNavStyle() tea.Cmd {
+	if a.navStyle == "vim" {
+		a.navStyle = "emacs"
+	} else {
+		a.navStyle = "vim"
+	}
+	if a.screenMgr != nil {
+		a.screenMgr.Context().NavStyle = a.navStyle
+	}
+
+	// The category list differs per nav style; reset the hotkeys cursor.
+	a.hotkeyCategory = 0
+	a.hotkeyCursor = 0
+	a.hotkeyCatScroll = 0
+	a.hotkeyItemScroll = 0
+
+	a.bannerStatus = "Navigation: " + a.navStyle
+	return saveNavStyleCmd(a.navStyle)
+}
+
+// cycleTheme applies the next theme (wrapping around) so it can be judged
+// against the current screen. The choice is only persisted when saved from
+// Manage (S), like any other theme change there.
+func (a *App) cycleTheme() {
+	a.previewTheme((a.themeIndex + 1) % len(themes))
+	a.appliedTheme = a.themeIndex
+	if a.screenMgr != nil {
+		a.screenMgr.Context().Theme = a.theme
+	}
+	a.bannerStatus = "Theme: " + a.theme + " (S in Manage to save)"
+}
+
+// previewTheme applies theme i for the current session without confirming it
+func (a *App) previewTheme(i int) {
+	a.themeIndex = i
+	a.theme = themes[i].name
+	a.themePreviewSeq++ // supersedes any pending throttled preview
+	a.themePreviewAt = time.Now()
+	SetTheme(a.theme)
+}
+
+// scrollThemePreview moves the theme picker cursor to i, previewing the
+// theme right away unless one was applied within themePreviewInterval. In
+// that case the preview is deferred, and only the latest deferred preview
+// is applied, so holding a key doesn't rebuild styles on every repeat.
+func (a *App) scrollThemePreview(i int) tea.Cmd {
+	if time.Since(a.themePreviewAt) >= themePreviewInterval {
+		a.previewTheme(i)
+		return nil
+	}
+	a.themeIndex = i
+	a.theme = themes[i].name
+	a.themePreviewSeq++
+	seq := a.themePreviewSeq
+	return tea.Tick(themePreviewInterval, func(time.Time) tea.Msg {
+		return themePreviewMsg{seq: seq}
+	})
+}
+
+// saveNavStyleCmd persists the navigation style. A global.json that can't be
+// loaded is left alone, not replaced with defaults.
+func saveNavStyleCmd(nav string) tea.Cmd {
+	return func() tea.Msg {
+		g, err := config.LoadGlobalConfig()
+		if err == nil {
+			g.NavStyle = nav
+			err = config.SaveGlobalConfig(g)
+		}
+		if err != nil {
+			return navStyleSavedMsg{err: err}
+		}
+		return nil
+	}
+}
+
+// View renders the UI, with the idle countdown over the last line when the
+// idle timeout is close (or else the status of the last global action)
 func (a *App) View() string {
+	return a.withIdleBanner(a.withStatusBanner(a.view()), time.Now())
+}
+
+// view renders the current screen
+func (a *App) view() string {
 	// Try screen manager for migrated screens first
 	if a.screenMgr != nil && !a.screenMgr.IsLegacyMode() {
 		if view := a.screenMgr.View(); view != "" {
@@ -1181,6 +1449,12 @@ func (a *App) View() string {
 		}
 	}
 
+	if a.deepDivePreview {
+		if _, _, ok := a.deepDivePreviewText(); ok {
+			return a.renderDeepDivePreview()
+		}
+	}
+
 	switch a.screen {
 	case ScreenAnimation:
 		return a.renderAnimation()
@@ -1201,6 +1475,8 @@ func (a *App) View() string {
 	// Deep dive screens
 	case ScreenDeepDiveMenu:
 		return a.renderDeepDiveMenu()
+	case ScreenDeepDiveConfirm:
+		return a.renderDeepDiveConfirm()
 	case ScreenConfigGhostty:
 		return a.renderConfigGhostty()
 	case ScreenConfigTmux:
@@ -1268,6 +1544,8 @@ func (a *App) View() string {
 		return a.renderUpdate()
 	case ScreenHotkeys:
 		return a.renderHotkeysDualPane()
+	case ScreenFavorites:
+		return a.renderFavorites()
 	case ScreenUsers:
 		return a.renderUsersDualPane()
 	case ScreenBackups:
@@ -1286,6 +1564,16 @@ func (e execCommand) SetStdin(r io.Reader)  { e.Cmd.Stdin = r }
 func (e execCommand) SetStdout(w io.Writer) { e.Cmd.Stdout = w }
 func (e execCommand) SetStderr(w io.Writer) { e.Cmd.Stderr = w }
 
+// syncSudoKeepAlive runs the sudo keep-alive exactly while an install or
+// update is in progress
+func (a *App) syncSudoKeepAlive() {
+	if a.installRunning || a.manageInstalling || a.updateRunning {
+		a.sudoKeepAlive.Start()
+	} else {
+		a.sudoKeepAlive.Stop()
+	}
+}
+
 // sudoPromptCmd returns a command that prompts for sudo credentials
 func sudoPromptCmd() tea.ExecCommand {
 	// Use a script that shows a nice message then prompts for sudo
@@ -1356,24 +1644,36 @@ func (a *App) SetHotkeyFilter(tool string) {
 	a.hotkeyFilter = tool
 }
 
+// toolConfigScreens maps the tool names `dotfiles config <tool>` accepts to
+// their config screens
+var toolConfigScreens = map[string]Screen{
+	"ghostty":   ScreenConfigGhostty,
+	"tmux":      ScreenConfigTmux,
+	"zsh":       ScreenConfigZsh,
+	"neovim":    ScreenConfigNeovim,
+	"git":       ScreenConfigGit,
+	"yazi":      ScreenConfigYazi,
+	"fzf":       ScreenConfigFzf,
+	"apps":      ScreenConfigApps,
+	"utilities": ScreenConfigUtilities,
+}
+
 // GetToolConfigScreen returns the screen constant for a tool name
 func GetToolConfigScreen(tool string) (Screen, bool) {
-	screens := map[string]Screen{
-		"ghostty":   ScreenConfigGhostty,
-		"tmux":      ScreenConfigTmux,
-		"zsh":       ScreenConfigZsh,
-		"neovim":    ScreenConfigNeovim,
-		"git":       ScreenConfigGit,
-		"yazi":      ScreenConfigYazi,
-		"fzf":       ScreenConfigFzf,
-		"apps":      ScreenConfigApps,
-		"utilities": ScreenConfigUtilities,
-	}
-
-	screen, ok := screens[tool]
+	screen, ok := toolConfigScreens[tool]
 	return screen, ok
 }
 
+// ToolConfigNames returns the tool names GetToolConfigScreen accepts, sorted
+func ToolConfigNames() []string {
+	names := make([]string, 0, len(toolConfigScreens))
+	for name := range toolConfigScreens {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // MainMenuItem represents an item in the main menu
 type MainMenuItem struct {
 	Name        string
@@ -1415,6 +1715,12 @@ func GetMainMenuItems() []MainMenuItem {
 			Icon:        "󰌌",
 			Screen:      ScreenHotkeys,
 		},
+		{
+			Name:        "My Favorites",
+			Description: "Your favorite hotkeys from every tool",
+			Icon:        "󰓎",
+			Screen:      ScreenFavorites,
+		},
 		{
 			Name:        "Backups",
 			Description: "View and restore backups",
@@ -0,0 +1,209 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tekierz/dotfiles/internal/backup"
+	"github.com/tekierz/dotfiles/internal/diff"
+)
+
+// restoreDiffFiles returns the files in the pending restore that differ from disk
+func (a *App) restoreDiffFiles() []backup.RestoreFile {
+	var out []backup.RestoreFile
+	for _, f := range a.backupPlan {
+		if f.Status == backup.FileDiffers {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// restoreNewerFiles returns the files in the pending restore that changed on
+// disk after the backup was taken
+func (a *App) restoreNewerFiles() []backup.RestoreFile {
+	return backup.NewerFiles(a.backupPlan)
+}
+
+// toggleKeepNewer toggles keeping files that are newer on disk than the
+// backup: only the file whose diff is open in the diff view, otherwise all of
+// them (keeping all unless every one is already kept).
+func (a *App) toggleKeepNewer() {
+	if a.backupDiffView {
+		differing := a.restoreDiffFiles()
+		if a.backupDiffIndex >= len(differing) || !differing[a.backupDiffIndex].Newer {
+			return
+		}
+		dest := differing[a.backupDiffIndex].Dest
+		for i := range a.backupPlan {
+			if a.backupPlan[i].Dest == dest {
+				a.backupPlan[i].Keep = !a.backupPlan[i].Keep
+			}
+		}
+		return
+	}
+
+	keep := false
+	for _, f := range a.backupPlan {
+		if f.Newer && !f.Keep {
+			keep = true
+		}
+	}
+	for i := range a.backupPlan {
+		if a.backupPlan[i].Newer {
+			a.backupPlan[i].Keep = keep
+		}
+	}
+}
+
+// handleRestorePreviewKey handles diff navigation while a restore awaits
+// confirmation. It returns false for keys the confirm prompt should handle.
+func (a *App) handleRestorePreviewKey(key string) bool {
+	differing := a.restoreDiffFiles()
+
+	if key == "K" {
+		a.toggleKeepNewer()
+		return true
+	}
+
+	if !a.backupDiffView {
+		if key == "v" && len(differing) > 0 {
+			a.backupDiffView = true
+			a.backupDiffIndex = 0
+			a.backupDiffScroll = 0
+			return true
+		}
+		return false
+	}
+
+	switch key {
+	case "v", "esc":
+		a.backupDiffView = false
+	case "left", "h", "shift+tab":
+		if a.backupDiffIndex > 0 {
+			a.backupDiffIndex--
+			a.backupDiffScroll = 0
+		}
+	case "right", "l", "tab":
+		if a.backupDiffIndex < len(differing)-1 {
+			a.backupDiffIndex++
+			a.backupDiffScroll = 0
+		}
+	case "up", "k":
+		if a.backupDiffScroll > 0 {
+			a.backupDiffScroll--
+		}
+	case "down", "j":
+		a.backupDiffScroll++
+	case "pgup", "ctrl+u":
+		a.backupDiffScroll = maxInt(0, a.backupDiffScroll-10)
+	case "pgdown", "ctrl+d":
+		a.backupDiffScroll += 10
+	default:
+		return false
+	}
+	return true
+}
+
+// renderRestorePlan renders the pending restore's file comparison, or the
+// diff of the selected differing file when the diff view is open.
+func (a *App) renderRestorePlan(boxOuterW int) string {
+	innerW := maxInt(20, boxOuterW-4)
+	muted := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	header := lipgloss.NewStyle().Foreground(ColorMagenta).Bold(true)
+
+	var lines []string
+	if a.backupDiffView {
+		lines = a.renderRestoreDiff(innerW)
+	} else {
+		lines = append(lines, header.Render("RESTORE PREVIEW"), "")
+		for _, f := range a.backupPlan {
+			var status string
+			switch f.Status {
+			case backup.FileDiffers:
+				status = lipgloss.NewStyle().Foreground(ColorYellow).Bold(true).Render(fmt.Sprintf("%-10s", "differs"))
+			case backup.FileNew:
+				status = lipgloss.NewStyle().Foreground(ColorGreen).Render(fmt.Sprintf("%-10s", "new"))
+			default:
+				status = muted.Render(fmt.Sprintf("%-10s", "identical"))
+			}
+			line := status + " " + f.RelPath
+			if f.LinkTarget != "" {
+				line += muted.Render(" → " + f.LinkTarget)
+			}
+			if f.Newer {
+				note := "  ⚠ newer on disk"
+				if f.Keep {
+					note += ", keeping"
+				}
+				line += lipgloss.NewStyle().Foreground(ColorYellow).Render(note)
+			}
+			if f.Corrupt {
+				line += lipgloss.NewStyle().Foreground(ColorRed).Bold(true).Render("  ⚠ checksum mismatch")
+			}
+			lines = append(lines, truncateVisible(line, innerW))
+		}
+		if len(a.backupPlan) == 0 {
+			lines = append(lines, muted.Render("This backup contains no files to restore."))
+		}
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorMagenta).
+		Padding(0, 1).
+		Width(maxInt(1, boxOuterW-2)).
+		Render(strings.Join(lines, "\n"))
+}
+
+// renderRestoreDiff renders on-disk → backup diff lines for the selected file
+func (a *App) renderRestoreDiff(innerW int) []string {
+	differing := a.restoreDiffFiles()
+	if len(differing) == 0 {
+		return []string{"No differing files."}
+	}
+	if a.backupDiffIndex >= len(differing) {
+		a.backupDiffIndex = len(differing) - 1
+	}
+	f := differing[a.backupDiffIndex]
+
+	current, _ := os.ReadFile(f.Dest)
+	stored, _ := os.ReadFile(f.Src)
+	diffLines := diff.Lines(string(current), string(stored))
+	added, removed := diff.Stats(diffLines)
+
+	header := lipgloss.NewStyle().Foreground(ColorMagenta).Bold(true).
+		Render(fmt.Sprintf("DIFF %s (%d/%d)", f.RelPath, a.backupDiffIndex+1, len(differing)))
+	stats := lipgloss.NewStyle().Foreground(ColorTextMuted).
+		Render(fmt.Sprintf("  current → backup  +%d -%d", added, removed))
+	if f.Newer {
+		note := "  ⚠ newer on disk"
+		if f.Keep {
+			note += ", keeping"
+		}
+		stats += lipgloss.NewStyle().Foreground(ColorYellow).Render(note)
+	}
+
+	// Keep the diff within the screen; the rest of the backups view needs ~22 lines.
+	visible := maxInt(6, a.height-24)
+	maxScroll := maxInt(0, len(diffLines)-visible)
+	if a.backupDiffScroll > maxScroll {
+		a.backupDiffScroll = maxScroll
+	}
+
+	lines := []string{truncateVisible(header+stats, innerW), ""}
+	end := min(len(diffLines), a.backupDiffScroll+visible)
+	for _, l := range diffLines[a.backupDiffScroll:end] {
+		style := lipgloss.NewStyle().Foreground(ColorTextMuted)
+		switch l.Op {
+		case diff.Insert:
+			style = lipgloss.NewStyle().Foreground(ColorGreen)
+		case diff.Delete:
+			style = lipgloss.NewStyle().Foreground(ColorRed)
+		}
+		lines = append(lines, style.Render(truncateVisible(l.String(), innerW)))
+	}
+	return lines
+}
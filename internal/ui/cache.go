@@ -12,7 +12,7 @@ import (
 // checkUpdatesCmd starts an async update check
 func checkUpdatesCmd() tea.Cmd {
 	return func() tea.Msg {
-		updates, err := pkg.CheckDotfilesUpdates()
+		updates, err := tools.CheckUpdates()
 		return updateCheckDoneMsg{updates: updates, err: err}
 	}
 }
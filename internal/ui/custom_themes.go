@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"path/filepath"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/tools"
+)
+
+// customPaletteFields maps a custom theme's color keys to palette fields
+var customPaletteFields = []struct {
+	key string
+	set func(*ColorPalette, lipgloss.Color)
+}{
+	{"accent", func(p *ColorPalette, c lipgloss.Color) { p.Accent = c }},
+	{"accent_alt", func(p *ColorPalette, c lipgloss.Color) { p.AccentAlt = c }},
+	{"info", func(p *ColorPalette, c lipgloss.Color) { p.Info = c }},
+	{"success", func(p *ColorPalette, c lipgloss.Color) { p.Success = c }},
+	{"warning", func(p *ColorPalette, c lipgloss.Color) { p.Warning = c }},
+	{"error", func(p *ColorPalette, c lipgloss.Color) { p.Error = c }},
+	{"bg", func(p *ColorPalette, c lipgloss.Color) { p.Bg = c }},
+	{"surface", func(p *ColorPalette, c lipgloss.Color) { p.Surface = c }},
+	{"overlay", func(p *ColorPalette, c lipgloss.Color) { p.Overlay = c }},
+	{"border", func(p *ColorPalette, c lipgloss.Color) { p.Border = c }},
+	{"text", func(p *ColorPalette, c lipgloss.Color) { p.Text = c }},
+	{"text_muted", func(p *ColorPalette, c lipgloss.Color) { p.TextMuted = c }},
+	{"text_bright", func(p *ColorPalette, c lipgloss.Color) { p.TextBright = c }},
+}
+
+// customPalette builds the palette for a custom theme. Colors it leaves out
+// come from catppuccin (latte for light themes, mocha for dark ones).
+func customPalette(t *config.CustomTheme) ColorPalette {
+	p := ThemePalettes["catppuccin-mocha"]
+	if t.Light {
+		p = ThemePalettes["catppuccin-latte"]
+	}
+	for _, f := range customPaletteFields {
+		if hex := t.Colors[f.key]; hex != "" {
+			f.set(&p, lipgloss.Color(hex))
+		}
+	}
+	return p
+}
+
+// LoadCustomThemes registers the custom themes in config.ThemesDir with the
+// theme picker, SetTheme and the tool theme mappings. It can be called again
+// to pick up new or changed themes.
+func LoadCustomThemes() error {
+	custom, err := config.LoadCustomThemes()
+	for _, t := range custom {
+		p := customPalette(t)
+		ThemePalettes[t.Name] = p
+		tools.RegisterCustomTheme(t.Name, t.Light, string(p.Accent))
+
+		desc := "Custom theme"
+		if t.Source != "" {
+			desc = "Custom, from " + filepath.Base(t.Source)
+		}
+		registerTheme(t.Name, desc, string(p.Accent))
+	}
+	return err
+}
+
+// themeNames returns the names of the themes in the picker, custom ones last
+func themeNames() []string {
+	names := make([]string, len(themes))
+	for i, t := range themes {
+		names[i] = t.name
+	}
+	return names
+}
+
+// registerTheme adds a theme to the picker, or updates it if already listed
+func registerTheme(name, desc, color string) {
+	for i := range themes {
+		if themes[i].name == name {
+			themes[i].desc, themes[i].color = desc, color
+			return
+		}
+	}
+	themes = append(themes, struct {
+		name  string
+		desc  string
+		color string
+	}{name, desc, color})
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/testutil"
+)
+
+func TestCustomPaletteFallsBack(t *testing.T) {
+	p := customPalette(&config.CustomTheme{Light: true, Colors: map[string]string{"accent": "#c87533"}})
+	if p.Accent != lipgloss.Color("#c87533") {
+		t.Errorf("Accent = %s, want #c87533", p.Accent)
+	}
+	if latte := ThemePalettes["catppuccin-latte"]; p.Bg != latte.Bg {
+		t.Errorf("Bg = %s, want latte's %s for a light theme", p.Bg, latte.Bg)
+	}
+}
+
+func TestLoadCustomThemes(t *testing.T) {
+	testutil.TempConfigDir(t)
+	savedThemes := themes
+	defer func() {
+		themes = savedThemes
+		delete(ThemePalettes, "dunes")
+	}()
+
+	if err := config.SaveCustomTheme(&config.CustomTheme{Name: "dunes", Source: "/tmp/dunes.jpg", Colors: map[string]string{"accent": "#c87533"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadCustomThemes(); err != nil {
+		t.Fatalf("LoadCustomThemes failed: %v", err)
+	}
+	LoadCustomThemes() // reloading updates rather than duplicates
+
+	if _, ok := ThemeColors("dunes"); !ok {
+		t.Error("custom theme should have a palette")
+	}
+	names := themeNames()
+	if names[len(names)-1] != "dunes" || slices.Index(names, "dunes") != len(names)-1 {
+		t.Errorf("themeNames() = %v, want dunes listed once, last", names)
+	}
+	if got := themes[len(themes)-1].desc; got != "Custom, from dunes.jpg" {
+		t.Errorf("desc = %q", got)
+	}
+}
@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"sort"
+
 	"github.com/tekierz/dotfiles/internal/pkg"
 	"github.com/tekierz/dotfiles/internal/tools"
 )
@@ -39,9 +41,10 @@ type DeepDiveConfig struct {
 	TmuxSplitBinds   string
 	TmuxStatusBar    string
 	TmuxMouseMode    bool
-	TmuxHistoryLimit int // Scrollback buffer size
-	TmuxEscapeTime   int // Escape key delay in ms
-	TmuxBaseIndex    int // Starting index for windows/panes
+	TmuxHistoryLimit int  // Scrollback buffer size
+	TmuxEscapeTime   int  // Escape key delay in ms
+	TmuxBaseIndex    int  // Starting index for windows/panes
+	TmuxSession      bool // Write a script that opens dotfiles in a tmux session
 
 	// Tmux TPM (Plugin Manager) settings
 	TmuxTPMEnabled       bool
@@ -63,6 +66,7 @@ type DeepDiveConfig struct {
 	// Neovim settings
 	NeovimConfig     string
 	NeovimLSPs       []string
+	LSPRuntimes      map[string]bool // Runtimes (tools.LSPRuntimes IDs) to install for the LSPs
 	NeovimPlugins    []string
 	NeovimTabWidth   int    // Tab width (2, 4, 8)
 	NeovimWrap       bool   // Line wrapping
@@ -76,6 +80,10 @@ type DeepDiveConfig struct {
 	GitPullRebase       bool   // Rebase on pull
 	GitSignCommits      bool   // GPG sign commits
 	GitCredentialHelper string // Credential helper (cache, store, osxkeychain)
+	// Imported from an existing .gitconfig and written back on install
+	GitUserName  string
+	GitUserEmail string
+	GitExtra     []tools.GitConfigEntry
 
 	// Yazi settings
 	YaziKeymap      string
@@ -264,14 +272,147 @@ func NewDeepDiveConfig() *DeepDiveConfig {
 	}
 }
 
+// TmuxToolConfig converts the deep dive selections into a tmux generator config
+func (c *DeepDiveConfig) TmuxToolConfig() tools.TmuxConfig {
+	return tools.TmuxConfig{
+		Prefix:           c.TmuxPrefix,
+		SplitBinds:       c.TmuxSplitBinds,
+		StatusBar:        c.TmuxStatusBar,
+		MouseMode:        c.TmuxMouseMode,
+		Truecolor:        true,
+		Session:          c.TmuxSession,
+		TPMEnabled:       c.TmuxTPMEnabled,
+		PluginSensible:   c.TmuxPluginSensible,
+		PluginResurrect:  c.TmuxPluginResurrect,
+		PluginContinuum:  c.TmuxPluginContinuum,
+		PluginYank:       c.TmuxPluginYank,
+		ContinuumSaveMin: c.TmuxContinuumSaveMin,
+	}
+}
+
+// tmuxToolConfig is the tmux generator config for install and preview: the
+// deep dive selections rendered from the template chosen in Manage
+func (a *App) tmuxToolConfig() tools.TmuxConfig {
+	cfg := a.deepDiveConfig.TmuxToolConfig()
+	if a.manageConfig != nil {
+		cfg.Template = a.manageConfig.TmuxTemplate
+		cfg.Truecolor = a.manageConfig.TmuxTruecolor
+	}
+	return cfg
+}
+
+// gitToolConfig is the Git generator config for install and preview: the
+// deep dive selections rendered from the template and push setting chosen in
+// Manage
+func (a *App) gitToolConfig() tools.GitConfig {
+	cfg := a.deepDiveConfig.GitToolConfig()
+	if a.manageConfig != nil {
+		cfg.Template = a.manageConfig.GitTemplate
+		cfg.AutoSetupRemote = a.manageConfig.GitAutoSetupRemote
+	}
+	return cfg
+}
+
+// GhosttyToolConfig converts the deep dive selections into a Ghostty generator config
+func (c *DeepDiveConfig) GhosttyToolConfig() tools.GhosttyConfig {
+	return tools.GhosttyConfig{
+		FontSize:        c.GhosttyFontSize,
+		FontFamily:      c.GhosttyFontFamily,
+		Opacity:         c.GhosttyOpacity,
+		BlurRadius:      c.GhosttyBlurRadius,
+		TabBindings:     c.GhosttyTabBindings,
+		ScrollbackLines: c.GhosttyScrollbackLines,
+		CursorStyle:     c.GhosttyCursorStyle,
+	}
+}
+
+// GitToolConfig converts the deep dive selections into a Git generator config
+func (c *DeepDiveConfig) GitToolConfig() tools.GitConfig {
+	return tools.GitConfig{
+		DeltaSideBySide:  c.GitDeltaSideBySide,
+		DefaultBranch:    c.GitDefaultBranch,
+		Aliases:          c.GitAliases,
+		PullRebase:       c.GitPullRebase,
+		SignCommits:      c.GitSignCommits,
+		CredentialHelper: c.GitCredentialHelper,
+		UserName:         c.GitUserName,
+		UserEmail:        c.GitUserEmail,
+		Extra:            c.GitExtra,
+	}
+}
+
+// ImportGit pre-populates the Git settings from an existing .gitconfig
+func (c *DeepDiveConfig) ImportGit(imp tools.GitImport) {
+	cfg := c.GitToolConfig()
+	imp.ApplyTo(&cfg)
+	c.GitDeltaSideBySide = cfg.DeltaSideBySide
+	c.GitDefaultBranch = cfg.DefaultBranch
+	c.GitPullRebase = cfg.PullRebase
+	c.GitSignCommits = cfg.SignCommits
+	c.GitCredentialHelper = cfg.CredentialHelper
+	c.GitUserName = cfg.UserName
+	c.GitUserEmail = cfg.UserEmail
+	c.GitExtra = cfg.Extra
+}
+
+// selectionMaps returns the maps holding which tools and helper scripts to
+// install
+func (c *DeepDiveConfig) selectionMaps() []map[string]bool {
+	return []map[string]bool{c.CLITools, c.CLIUtilities, c.GUIApps, c.Utilities, c.MacApps}
+}
+
+// SelectedTools returns the sorted IDs of every enabled tool and helper
+// script, for saving as an install profile
+func (c *DeepDiveConfig) SelectedTools() []string {
+	var ids []string
+	for _, m := range c.selectionMaps() {
+		for id, enabled := range m {
+			if enabled {
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ApplySelection enables exactly the given tools and helper scripts,
+// disabling the rest. It returns the IDs no selection knows, e.g. a macOS
+// app in a profile saved on a Mac and applied on Linux.
+func (c *DeepDiveConfig) ApplySelection(ids []string) []string {
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+	known := make(map[string]bool)
+	for _, m := range c.selectionMaps() {
+		for id := range m {
+			m[id] = want[id]
+			known[id] = true
+		}
+	}
+
+	var unknown []string
+	for _, id := range ids {
+		if !known[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	return unknown
+}
+
 // DeepDiveMenuItem represents an item in the deep dive menu
 type DeepDiveMenuItem struct {
+	Key         string // Stable ID, used by the hidden_deep_dive_categories setting
 	Name        string
 	Description string
 	Screen      Screen
 	Icon        string
 	Category    string // Category header (empty = same category as previous)
 	Platform    string // Platform filter: "macos", "linux", or "" for all
+	// Categories are the tool categories the screen configures (for
+	// install --category)
+	Categories []tools.Category
 }
 
 // GetDeepDiveMenuItems returns the menu items for deep dive configuration
@@ -280,90 +421,116 @@ func GetDeepDiveMenuItems() []DeepDiveMenuItem {
 	return []DeepDiveMenuItem{
 		// Terminal & Shell - the foundation
 		{
+			Key:         "ghostty",
 			Name:        "Ghostty",
 			Description: "Terminal font, opacity, keybindings",
 			Screen:      ScreenConfigGhostty,
 			Icon:        "󰆍",
 			Category:    "TERMINAL & SHELL",
+			Categories:  []tools.Category{tools.CategoryTerminal},
 		},
 		{
+			Key:         "tmux",
 			Name:        "Tmux",
 			Description: "Prefix key, splits, mouse, TPM plugins",
 			Screen:      ScreenConfigTmux,
 			Icon:        "",
+			Categories:  []tools.Category{tools.CategoryTerminal},
 		},
 		{
+			Key:         "zsh",
 			Name:        "Zsh",
 			Description: "Prompt style, plugins, aliases",
 			Screen:      ScreenConfigZsh,
 			Icon:        "",
+			Categories:  []tools.Category{tools.CategoryShell},
 		},
 		// Development - coding essentials
 		{
+			Key:         "neovim",
 			Name:        "Neovim",
 			Description: "Config preset, LSP servers, plugins",
 			Screen:      ScreenConfigNeovim,
 			Icon:        "",
 			Category:    "DEVELOPMENT",
+			Categories:  []tools.Category{tools.CategoryEditor},
 		},
 		{
+			Key:         "git",
 			Name:        "Git",
 			Description: "Delta diff, default branch, aliases",
 			Screen:      ScreenConfigGit,
 			Icon:        "",
+			Categories:  []tools.Category{tools.CategoryGit},
 		},
 		{
+			Key:         "cli-tools",
 			Name:        "CLI Tools",
 			Description: "LazyGit, LazyDocker, btop, Glow",
 			Screen:      ScreenConfigCLITools,
 			Icon:        "",
+			Categories:  []tools.Category{tools.CategoryGit, tools.CategoryContainer, tools.CategoryUtility},
 		},
 		{
+			Key:         "claude-code",
 			Name:        "Claude Code",
 			Description: "AI coding assistant, MCP servers",
 			Screen:      ScreenConfigClaudeCode,
 			Icon:        "󰚩",
+			Categories:  []tools.Category{tools.CategoryUtility},
 		},
 		// Quality of Life tools
 		{
+			Key:         "yazi",
 			Name:        "Yazi",
 			Description: "File manager keymaps, preview settings",
 			Screen:      ScreenConfigYazi,
 			Icon:        "󰉋",
 			Category:    "QUALITY OF LIFE",
+			Categories:  []tools.Category{tools.CategoryFile},
 		},
 		{
+			Key:         "fzf",
 			Name:        "FZF",
 			Description: "Fuzzy finder preview, layout, height",
 			Screen:      ScreenConfigFzf,
 			Icon:        "󰍉",
+			Categories:  []tools.Category{tools.CategoryUtility},
 		},
 		{
+			Key:         "cli-utilities",
 			Name:        "CLI Utilities",
 			Description: "bat, eza, zoxide, ripgrep, fd, tailscale",
 			Screen:      ScreenConfigCLIUtilities,
 			Icon:        "󰘳",
+			Categories:  []tools.Category{tools.CategoryUtility, tools.CategoryGit},
 		},
 		// Optional Apps
 		{
+			Key:         "gui-apps",
 			Name:        "GUI Apps",
 			Description: "Zen Browser, Cursor, Sunshine, Moonlight",
 			Screen:      ScreenConfigGUIApps,
 			Icon:        "󰏇",
 			Category:    "OPTIONAL APPS",
+			Categories:  []tools.Category{tools.CategoryApp, tools.CategoryUtility},
 		},
 		{
+			Key:         "macos-apps",
 			Name:        "macOS Apps",
 			Description: "Rectangle, Raycast, Stats, more",
 			Screen:      ScreenConfigMacApps,
 			Icon:        "",
 			Platform:    "macos",
+			Categories:  []tools.Category{tools.CategoryApp},
 		},
 		{
+			Key:         "helper-scripts",
 			Name:        "Helper Scripts",
 			Description: "hk, caff, sshh utilities",
 			Screen:      ScreenConfigUtilities,
 			Icon:        "󰘚",
+			Categories:  []tools.Category{tools.CategoryShell, tools.CategoryUtility},
 		},
 	}
 }
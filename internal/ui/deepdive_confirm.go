@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tekierz/dotfiles/internal/pkg"
+	"github.com/tekierz/dotfiles/internal/tools"
+)
+
+// deepDiveSelection is one deep dive group's enabled tools, for the recap
+// shown before installation
+type deepDiveSelection struct {
+	title string
+	ids   []string // Sorted tool IDs
+}
+
+// deepDiveSelections lists what each deep dive selection map has enabled,
+// then the LSP runtimes added for the selected servers, skipping empty
+// groups. macOS apps only count on macOS, as in collectSelectedTools.
+func (a *App) deepDiveSelections() []deepDiveSelection {
+	cfg := a.deepDiveConfig
+	if cfg == nil {
+		return nil
+	}
+
+	groups := []struct {
+		title    string
+		selected map[string]bool
+	}{
+		{"CLI Tools", cfg.CLITools},
+		{"CLI Utilities", cfg.CLIUtilities},
+		{"GUI Apps", cfg.GUIApps},
+		{"Helper Scripts", cfg.Utilities},
+	}
+	if pkg.DetectPlatform() == pkg.PlatformMacOS {
+		groups = append(groups, struct {
+			title    string
+			selected map[string]bool
+		}{"macOS Apps", cfg.MacApps})
+	}
+
+	runtimes := make(map[string]bool)
+	for _, r := range cfg.selectedLSPRuntimes() {
+		runtimes[r.ID] = true
+	}
+	groups = append(groups, struct {
+		title    string
+		selected map[string]bool
+	}{"LSP Runtimes", runtimes})
+
+	var out []deepDiveSelection
+	for _, g := range groups {
+		var ids []string
+		for id, enabled := range g.selected {
+			if enabled {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		sort.Strings(ids)
+		out = append(out, deepDiveSelection{title: g.title, ids: ids})
+	}
+	return out
+}
+
+// handleDeepDiveConfirmKey handles the recap shown after "Continue to
+// Installation": enter proceeds, esc goes back to fix selections, a adds the
+// runtimes the selected LSP servers are missing
+func (a *App) handleDeepDiveConfirmKey(key string) {
+	switch key {
+	case "a":
+		a.addMissingLSPRuntimes()
+	case "enter", "y":
+		a.screen = ScreenThemePicker
+	case "esc", "backspace", "n":
+		a.screen = ScreenDeepDiveMenu
+	}
+}
+
+// renderDeepDiveConfirm renders the recap of every enabled tool, grouped by
+// deep dive section. Tools the install cache (loaded by the deep dive menu)
+// reports as installed are dimmed, since the installer skips them.
+func (a *App) renderDeepDiveConfirm() string {
+	title := renderConfigTitle("󰄬", "Review Selections", "Everything that will be set up")
+
+	reg := tools.GetRegistry()
+	boxW := a.deepDiveBoxWidth(64)
+	innerW := maxInt(20, boxW-6) // border + padding
+	labelW := 16
+
+	header := lipgloss.NewStyle().Foreground(ColorMagenta).Bold(true)
+	muted := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	text := lipgloss.NewStyle().Foreground(ColorText)
+
+	selections := a.deepDiveSelections()
+	total, pending := 0, 0
+	var lines []string
+	for _, sel := range selections {
+		var names []string
+		for _, id := range sel.ids {
+			name := id
+			if t, ok := reg.Get(id); ok {
+				name = t.Name()
+			} else if r, ok := tools.LSPRuntimeByID(id); ok {
+				name = r.Name
+			}
+			total++
+			if a.manageInstalledReady && a.manageInstalled[id] {
+				names = append(names, muted.Render(name+" ✓"))
+				continue
+			}
+			pending++
+			names = append(names, text.Render(name))
+		}
+
+		wrapped := lipgloss.NewStyle().Width(maxInt(10, innerW-labelW)).Render(strings.Join(names, ", "))
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top,
+			header.Width(labelW).Render(sel.title),
+			wrapped,
+		))
+	}
+	if len(selections) == 0 {
+		lines = append(lines, muted.Render("No optional tools selected"))
+	}
+
+	box := configBoxStyle.Width(boxW).Render(strings.Join(lines, "\n"))
+	summary := text.Render(fmt.Sprintf("%d selected • %d to install", total, pending))
+	if total > pending {
+		summary += muted.Render(" (✓ already installed)")
+	}
+	helpText := "enter continue • esc back to fix selections"
+	parts := []string{title, "", box, "", summary}
+	if recs := renderRecommendations(a.recommendedTools(), boxW); recs != "" {
+		parts = append(parts, recs)
+	}
+	if unresolved := a.unresolvedLSPRuntimes(); len(unresolved) > 0 {
+		warn := lipgloss.NewStyle().Foreground(ColorYellow)
+		for _, r := range unresolved {
+			parts = append(parts, warn.Render(fmt.Sprintf("⚠ %s needs %s, which isn't installed or selected",
+				strings.Join(r.NeededBy(a.deepDiveConfig.NeovimLSPs), ", "), r.Name)))
+		}
+		helpText = "enter continue • a add LSP runtimes • esc back to fix selections"
+	}
+	help := HelpStyle.Render(helpText)
+
+	return lipgloss.Place(
+		a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		lipgloss.JoinVertical(lipgloss.Center, append(parts, "", help)...),
+	)
+}
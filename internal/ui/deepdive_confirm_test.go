@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestContinueShowsSelectionRecap(t *testing.T) {
+	cfg := NewDeepDiveConfig()
+	cfg.CLITools = map[string]bool{"lazygit": true, "btop": false}
+	cfg.CLIUtilities = map[string]bool{"ripgrep": true, "bat": true}
+	cfg.GUIApps = map[string]bool{}
+	cfg.Utilities = map[string]bool{}
+	a := &App{screen: ScreenDeepDiveMenu, deepDiveConfig: cfg, width: 120, height: 40, manageInstalledReady: true}
+	a.deepDiveMenuIndex = len(a.deepDiveMenuItems()) // "Continue to Installation"
+
+	a.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if a.screen != ScreenDeepDiveConfirm {
+		t.Fatalf("screen = %v, want the selection recap", a.screen)
+	}
+
+	sel := a.deepDiveSelections()
+	if len(sel) != 2 || sel[0].title != "CLI Tools" || strings.Join(sel[1].ids, ",") != "bat,ripgrep" {
+		t.Errorf("selections = %+v, want CLI Tools [lazygit] and CLI Utilities [bat ripgrep]", sel)
+	}
+	if view := a.View(); !strings.Contains(view, "LazyGit") || !strings.Contains(view, "3 selected") {
+		t.Errorf("recap should list selected tools and a count:\n%s", view)
+	}
+
+	a.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if a.screen != ScreenDeepDiveMenu {
+		t.Errorf("esc: screen = %v, want back to the deep dive menu", a.screen)
+	}
+
+	a.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	a.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if a.screen != ScreenThemePicker {
+		t.Errorf("enter on recap: screen = %v, want theme picker", a.screen)
+	}
+}
+
+func TestSelectAllNotInstalled(t *testing.T) {
+	cfg := NewDeepDiveConfig()
+	cfg.CLIUtilities = map[string]bool{"bat": true}
+	a := &App{
+		screen:               ScreenConfigCLIUtilities,
+		deepDiveConfig:       cfg,
+		width:                120,
+		height:               40,
+		manageInstalled:      map[string]bool{"eza": true},
+		manageInstalledReady: true,
+	}
+	press := func() { a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")}) }
+
+	press()
+	for _, id := range []string{"bat", "zoxide", "ripgrep", "fd", "delta", "fswatch"} {
+		if !cfg.CLIUtilities[id] {
+			t.Errorf("A: %s not selected", id)
+		}
+	}
+	if cfg.CLIUtilities["eza"] {
+		t.Error("A selected eza, which is already installed")
+	}
+
+	press()
+	for id, on := range cfg.CLIUtilities {
+		if on {
+			t.Errorf("second A: %s still selected", id)
+		}
+	}
+}
+
+func TestRecommendationsFollowSelection(t *testing.T) {
+	cfg := NewDeepDiveConfig()
+	cfg.CLITools = map[string]bool{"lazygit": true}
+	cfg.CLIUtilities = map[string]bool{"delta": false, "bat": false}
+	cfg.GUIApps = map[string]bool{}
+	cfg.Utilities = map[string]bool{}
+	a := &App{
+		screen:               ScreenConfigCLIUtilities,
+		deepDiveConfig:       cfg,
+		width:                120,
+		height:               40,
+		manageInstalled:      map[string]bool{"neovim": true, "fd": true},
+		manageInstalledReady: true,
+	}
+
+	recs := a.recommendedTools()
+	ids := make([]string, len(recs))
+	for i, r := range recs {
+		ids[i] = r.ID
+	}
+	if strings.Join(ids, ",") != "delta,ripgrep" {
+		t.Errorf("recommendations = %v, want delta (lazygit) and ripgrep (neovim; fd is installed)", ids)
+	}
+	if view := a.View(); !strings.Contains(view, "★ with lazygit") {
+		t.Errorf("CLI utilities should mark delta as recommended:\n%s", view)
+	}
+
+	a.screen = ScreenDeepDiveConfirm
+	if view := a.View(); !strings.Contains(view, "Recommended with your selection") {
+		t.Errorf("recap should list recommendations:\n%s", view)
+	}
+	if cfg.CLIUtilities["delta"] {
+		t.Error("recommendations must not select anything")
+	}
+
+	cfg.CLIUtilities["delta"] = true
+	if recs := a.recommendedTools(); len(recs) != 1 || recs[0].ID != "ripgrep" {
+		t.Errorf("selecting delta should drop it from the recommendations, got %+v", recs)
+	}
+}
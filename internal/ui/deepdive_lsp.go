@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tekierz/dotfiles/internal/tools"
+)
+
+// missingLSPRuntimes returns the runtimes the selected LSP servers need that
+// aren't installed, whether or not they've been added to the install. PATH
+// is only searched the first time.
+func (a *App) missingLSPRuntimes() []tools.LSPRuntime {
+	if a.deepDiveConfig == nil {
+		return nil
+	}
+	if a.lspRuntimeInstalled == nil {
+		a.lspRuntimeInstalled = make(map[string]bool)
+		for _, r := range tools.LSPRuntimes {
+			a.lspRuntimeInstalled[r.ID] = r.Installed()
+		}
+	}
+
+	var missing []tools.LSPRuntime
+	for _, r := range tools.LSPRuntimes {
+		if !a.lspRuntimeInstalled[r.ID] && len(r.NeededBy(a.deepDiveConfig.NeovimLSPs)) > 0 {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+// unresolvedLSPRuntimes returns the missing runtimes not yet added to the
+// install
+func (a *App) unresolvedLSPRuntimes() []tools.LSPRuntime {
+	var out []tools.LSPRuntime
+	for _, r := range a.missingLSPRuntimes() {
+		if !a.deepDiveConfig.LSPRuntimes[r.ID] {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// addMissingLSPRuntimes adds every missing runtime to the install and
+// reports whether there were any
+func (a *App) addMissingLSPRuntimes() bool {
+	missing := a.unresolvedLSPRuntimes()
+	if len(missing) == 0 {
+		return false
+	}
+	if a.deepDiveConfig.LSPRuntimes == nil {
+		a.deepDiveConfig.LSPRuntimes = make(map[string]bool)
+	}
+	for _, r := range missing {
+		a.deepDiveConfig.LSPRuntimes[r.ID] = true
+	}
+	return true
+}
+
+// selectedLSPRuntimes returns the runtimes added to the install that a
+// selected LSP server still needs
+func (c *DeepDiveConfig) selectedLSPRuntimes() []tools.LSPRuntime {
+	var out []tools.LSPRuntime
+	for _, r := range tools.LSPRuntimes {
+		if c.LSPRuntimes[r.ID] && len(r.NeededBy(c.NeovimLSPs)) > 0 {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// renderLSPRuntimeWarnings lists each missing runtime: a warning with the
+// servers that need it, or a note that the install adds it
+func (a *App) renderLSPRuntimeWarnings() string {
+	warn := lipgloss.NewStyle().Foreground(ColorYellow)
+	ok := lipgloss.NewStyle().Foreground(ColorGreen)
+
+	var lines []string
+	for _, r := range a.missingLSPRuntimes() {
+		servers := strings.Join(r.NeededBy(a.deepDiveConfig.NeovimLSPs), ", ")
+		if a.deepDiveConfig.LSPRuntimes[r.ID] {
+			lines = append(lines, ok.Render(fmt.Sprintf("✓ %s will be installed for %s", r.Name, servers)))
+		} else {
+			lines = append(lines, warn.Render(fmt.Sprintf("⚠ %s needs %s, which isn't installed", servers, r.Name)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestMissingLSPRuntimes(t *testing.T) {
+	cfg := NewDeepDiveConfig()
+	cfg.NeovimLSPs = []string{"lua_ls", "tsserver", "gopls"}
+	a := &App{
+		screen:              ScreenConfigNeovim,
+		deepDiveConfig:      cfg,
+		width:               120,
+		height:              50,
+		lspRuntimeInstalled: map[string]bool{"node": true, "go": false, "rust": false},
+	}
+
+	missing := a.missingLSPRuntimes()
+	if len(missing) != 1 || missing[0].ID != "go" {
+		t.Fatalf("missing = %+v, want only go (rust_analyzer isn't selected)", missing)
+	}
+	if view := a.View(); !strings.Contains(view, "gopls needs Go") || !strings.Contains(view, "a add runtimes") {
+		t.Errorf("neovim screen should warn about go and offer to add it:\n%s", view)
+	}
+
+	a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if !cfg.LSPRuntimes["go"] || len(a.unresolvedLSPRuntimes()) != 0 {
+		t.Fatalf("a should add go to the install, LSPRuntimes = %v", cfg.LSPRuntimes)
+	}
+	if view := a.View(); !strings.Contains(view, "Go will be installed for gopls") {
+		t.Errorf("neovim screen should note go will be installed:\n%s", view)
+	}
+
+	sel := a.deepDiveSelections()
+	if last := sel[len(sel)-1]; last.title != "LSP Runtimes" || strings.Join(last.ids, ",") != "go" {
+		t.Errorf("selections = %+v, want LSP Runtimes [go] last", sel)
+	}
+
+	// Dropping gopls drops the runtime from the install
+	cfg.NeovimLSPs = []string{"lua_ls", "tsserver"}
+	if got := cfg.selectedLSPRuntimes(); len(got) != 0 {
+		t.Errorf("selectedLSPRuntimes = %+v, want none without gopls", got)
+	}
+}
+
+func TestConfirmOffersLSPRuntimes(t *testing.T) {
+	cfg := NewDeepDiveConfig()
+	cfg.NeovimLSPs = []string{"pyright"}
+	a := &App{
+		screen:               ScreenDeepDiveConfirm,
+		deepDiveConfig:       cfg,
+		width:                120,
+		height:               50,
+		manageInstalledReady: true,
+		lspRuntimeInstalled:  map[string]bool{},
+	}
+
+	if view := a.View(); !strings.Contains(view, "pyright needs Node.js") {
+		t.Errorf("recap should warn about node:\n%s", view)
+	}
+	a.handleDeepDiveConfirmKey("a")
+	if !cfg.LSPRuntimes["node"] || a.screen != ScreenDeepDiveConfirm {
+		t.Errorf("a should add node and stay on the recap, LSPRuntimes = %v", cfg.LSPRuntimes)
+	}
+	if view := a.View(); strings.Contains(view, "needs Node.js") || !strings.Contains(view, "Node.js") {
+		t.Errorf("recap should list Node.js instead of warning:\n%s", view)
+	}
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tekierz/dotfiles/internal/tools"
+)
+
+// deepDivePreviewText returns the file name and generated config for the
+// current deep dive screen. ok is false for screens without a preview.
+func (a *App) deepDivePreviewText() (file string, content string, ok bool) {
+	cfg := a.deepDiveConfig
+	switch a.screen {
+	case ScreenConfigTmux:
+		return "~/.tmux.conf", tools.GenerateTmuxConfig(a.tmuxToolConfig(), a.theme), true
+	case ScreenConfigGhostty:
+		return "~/.config/ghostty/config", tools.GenerateGhosttyConfig(cfg.GhosttyToolConfig(), a.theme), true
+	case ScreenConfigGit:
+		return "~/.gitconfig", tools.GenerateGitConfig(a.gitToolConfig(), a.theme), true
+	}
+	return "", "", false
+}
+
+// deepDivePreviewLines splits the current preview into display lines
+func (a *App) deepDivePreviewLines() []string {
+	_, content, ok := a.deepDivePreviewText()
+	if !ok {
+		return nil
+	}
+	return strings.Split(strings.TrimRight(content, "\n"), "\n")
+}
+
+// deepDivePreviewHeight returns how many preview lines fit on screen
+func (a *App) deepDivePreviewHeight() int {
+	// title (2) + spacing (2) + box border/padding (4) + help (1) + margin
+	h := a.height - 12
+	if h < 5 {
+		h = 5
+	}
+	return h
+}
+
+// handleDeepDivePreviewKey handles keys while the config preview is open
+func (a *App) handleDeepDivePreviewKey(key string) tea.Cmd {
+	maxScroll := maxInt(0, len(a.deepDivePreviewLines())-a.deepDivePreviewHeight())
+	switch key {
+	case "up", "k":
+		if a.deepDivePreviewScroll > 0 {
+			a.deepDivePreviewScroll--
+		}
+	case "down", "j":
+		if a.deepDivePreviewScroll < maxScroll {
+			a.deepDivePreviewScroll++
+		}
+	case "pgup":
+		a.deepDivePreviewScroll = maxInt(0, a.deepDivePreviewScroll-a.deepDivePreviewHeight())
+	case "pgdown":
+		a.deepDivePreviewScroll = min(maxScroll, a.deepDivePreviewScroll+a.deepDivePreviewHeight())
+	case "g", "home":
+		a.deepDivePreviewScroll = 0
+	case "G", "end":
+		a.deepDivePreviewScroll = maxScroll
+	case "Y":
+		_, content, _ := a.deepDivePreviewText()
+		return copyToClipboardCmd(content, "generated config")
+	case "p", "esc", "q", "enter":
+		a.deepDivePreview = false
+		a.deepDivePreviewScroll = 0
+	}
+	return nil
+}
+
+// renderDeepDivePreview renders a scrollable view of the generated config
+func (a *App) renderDeepDivePreview() string {
+	file, _, _ := a.deepDivePreviewText()
+	lines := a.deepDivePreviewLines()
+	title := renderConfigTitle("", "Preview", file)
+
+	height := a.deepDivePreviewHeight()
+	start := clampInt(a.deepDivePreviewScroll, 0, maxInt(0, len(lines)-height))
+	end := min(len(lines), start+height)
+
+	width := a.deepDiveBoxWidth(60)
+	innerW := maxInt(10, width-6)
+	lineStyle := lipgloss.NewStyle().Foreground(ColorText)
+	commentStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+
+	var content strings.Builder
+	for i := start; i < end; i++ {
+		line := truncatePlain(lines[i], innerW)
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			content.WriteString(commentStyle.Render(line))
+		} else {
+			content.WriteString(lineStyle.Render(line))
+		}
+		if i < end-1 {
+			content.WriteString("\n")
+		}
+	}
+
+	box := configBoxStyle.Width(width).Render(content.String())
+	position := fmt.Sprintf("lines %d-%d of %d", start+1, end, len(lines))
+	help := HelpStyle.Render("↑↓ scroll • pgup/pgdn page • Y copy • p/esc close • " + position)
+	if a.manageStatus != "" {
+		help = lipgloss.NewStyle().Foreground(ColorTextMuted).Render(a.manageStatus) + "\n" + help
+	}
+
+	return PlaceWithBackground(
+		a.width, a.height,
+		lipgloss.JoinVertical(lipgloss.Center, title, "", box, "", help),
+	)
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tekierz/dotfiles/internal/tools"
+)
+
+func TestDeepDivePreviewReflectsConfig(t *testing.T) {
+	a := &App{
+		screen:         ScreenConfigTmux,
+		deepDiveConfig: NewDeepDiveConfig(),
+		theme:          "dracula",
+	}
+
+	a.deepDiveConfig.TmuxPrefix = "ctrl-b"
+	_, content, ok := a.deepDivePreviewText()
+	if !ok {
+		t.Fatal("tmux screen should have a preview")
+	}
+	if !strings.Contains(content, "set -g prefix C-b") {
+		t.Errorf("preview should use in-progress prefix, got:\n%s", content)
+	}
+	if !strings.Contains(content, "# Theme: dracula") {
+		t.Error("preview should include the current theme")
+	}
+
+	a.screen = ScreenConfigGit
+	a.deepDiveConfig.GitDefaultBranch = "develop"
+	_, content, _ = a.deepDivePreviewText()
+	if !strings.Contains(content, "develop") {
+		t.Errorf("git preview should use in-progress default branch, got:\n%s", content)
+	}
+
+	// The template chosen in Manage shapes the generated file
+	a.manageConfig = NewManageConfig()
+	a.manageConfig.GitTemplate = "minimal"
+	_, content, _ = a.deepDivePreviewText()
+	if !strings.Contains(content, "# Template: minimal") || strings.Contains(content, "pager = delta") {
+		t.Errorf("git preview should use the minimal template, got:\n%s", content)
+	}
+
+	a.screen = ScreenConfigZsh
+	if _, _, ok := a.deepDivePreviewText(); ok {
+		t.Error("zsh screen should not have a preview")
+	}
+}
+
+func TestDeepDivePreviewToggle(t *testing.T) {
+	a := &App{
+		screen:         ScreenConfigGhostty,
+		deepDiveConfig: NewDeepDiveConfig(),
+		height:         10,
+	}
+
+	a.handleDeepDiveKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if !a.deepDivePreview {
+		t.Fatal("p should open the preview")
+	}
+
+	a.handleDeepDiveKey(tea.KeyMsg{Type: tea.KeyDown})
+	if a.deepDivePreviewScroll != 1 {
+		t.Errorf("deepDivePreviewScroll = %d, want 1", a.deepDivePreviewScroll)
+	}
+	if a.screen != ScreenConfigGhostty {
+		t.Error("scrolling the preview should not leave the config screen")
+	}
+
+	a.handleDeepDiveKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if a.deepDivePreview {
+		t.Error("esc should close the preview")
+	}
+	if a.screen != ScreenConfigGhostty {
+		t.Error("closing the preview should stay on the config screen")
+	}
+}
+
+func TestDeepDiveStatusCounts(t *testing.T) {
+	a := &App{manageInstalled: map[string]bool{}}
+	for _, id := range ScreenToolIDs[ScreenConfigTmux] {
+		a.manageInstalled[id] = true
+	}
+	cli := ScreenToolIDs[ScreenConfigCLITools]
+	if len(cli) < 2 {
+		t.Skip("CLI tools group needs at least two tools")
+	}
+	a.manageInstalled[cli[0]] = true
+
+	items := []DeepDiveMenuItem{
+		{Name: "Tmux", Screen: ScreenConfigTmux},
+		{Name: "CLI Tools", Screen: ScreenConfigCLITools},
+		{Name: "Zsh", Screen: ScreenConfigZsh},
+		{Name: "Unmapped", Screen: ScreenMainMenu},
+	}
+
+	counts, groups := a.deepDiveStatusCounts(items)
+	if groups != 3 {
+		t.Errorf("groups = %d, want 3 (unmapped items excluded)", groups)
+	}
+	if counts["installed"] != 1 || counts["partial"] != 1 || counts["pending"] != 1 {
+		t.Errorf("counts = %v, want 1 installed, 1 partial, 1 pending", counts)
+	}
+}
+
+func TestFilterDeepDiveByCategory(t *testing.T) {
+	items := GetDeepDiveMenuItems()
+	if got := filterDeepDiveByCategory(items, nil); len(got) != len(items) {
+		t.Errorf("no categories kept %d items, want all %d", len(got), len(items))
+	}
+
+	got := filterDeepDiveByCategory(items, []tools.Category{tools.CategoryShell})
+	var names []string
+	for _, item := range got {
+		names = append(names, item.Name)
+	}
+	if want := "Zsh,Helper Scripts"; strings.Join(names, ",") != want {
+		t.Errorf("shell items = %v, want %s", names, want)
+	}
+	// Headers move to the first kept item of each section
+	if got[0].Category != "TERMINAL & SHELL" || got[1].Category != "OPTIONAL APPS" {
+		t.Errorf("headers = %q, %q; want section headers carried over", got[0].Category, got[1].Category)
+	}
+}
+
+func TestHiddenDeepDiveCategories(t *testing.T) {
+	a := &App{screen: ScreenDeepDiveMenu, deepDiveConfig: NewDeepDiveConfig(), width: 120, height: 40, manageInstalledReady: true}
+	all := a.deepDiveMenuItems()
+	a.deepDiveMenuIndex = len(all) // "Continue" before hiding anything
+
+	*a.deepDiveShown("ghostty") = false
+	*a.deepDiveShown("tmux") = false
+	if got := a.hiddenDeepDiveCategories(); strings.Join(got, ",") != "ghostty,tmux" {
+		t.Errorf("hidden = %v, want ghostty,tmux", got)
+	}
+
+	items := a.deepDiveMenuItems()
+	if len(items) != len(all)-2 {
+		t.Fatalf("len(items) = %d, want %d", len(items), len(all)-2)
+	}
+	if items[0].Key != "zsh" || items[0].Category != "TERMINAL & SHELL" {
+		t.Errorf("first item = %s (header %q), want zsh with the section header", items[0].Key, items[0].Category)
+	}
+
+	// The stale index still selects Continue on the shorter list
+	a.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if a.screen != ScreenDeepDiveConfirm {
+		t.Errorf("screen = %v, want the selection recap", a.screen)
+	}
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tekierz/dotfiles/internal/tools"
+)
+
+// recommendedTools returns the tools recommended alongside what is installed
+// (per the install cache) or selected in the deep dive. Suggestions are only
+// highlighted, never selected.
+func (a *App) recommendedTools() []tools.Recommendation {
+	if a.deepDiveConfig == nil {
+		return nil
+	}
+	maps := a.deepDiveConfig.selectionMaps()
+	return tools.Recommend(func(id string) bool {
+		if a.manageInstalled[id] {
+			return true
+		}
+		for _, m := range maps {
+			if m[id] {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// recommendationSuffix marks a deep dive tool as recommended, naming the
+// tools that recommend it, or returns "" when it isn't
+func recommendationSuffix(recs []tools.Recommendation, id string) string {
+	for _, r := range recs {
+		if r.ID == id {
+			return lipgloss.NewStyle().Foreground(ColorMagenta).Render(" ★ with " + strings.Join(r.Because, ", "))
+		}
+	}
+	return ""
+}
+
+// renderRecommendations is the recap's "Recommended with your selection"
+// line, or "" when there is nothing to suggest
+func renderRecommendations(recs []tools.Recommendation, width int) string {
+	if len(recs) == 0 {
+		return ""
+	}
+	reg := tools.GetRegistry()
+	parts := make([]string, len(recs))
+	for i, r := range recs {
+		name := r.ID
+		if t, ok := reg.Get(r.ID); ok {
+			name = t.Name()
+		}
+		parts[i] = fmt.Sprintf("%s (with %s)", name, strings.Join(r.Because, ", "))
+	}
+	return lipgloss.NewStyle().Foreground(ColorMagenta).Width(width).Align(lipgloss.Center).
+		Render("★ Recommended with your selection: " + strings.Join(parts, ", "))
+}
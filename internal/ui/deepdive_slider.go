@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// sliderTrackWidth is the width deep dive sliders are drawn at
+const sliderTrackWidth = 24
+
+// deepDiveSlider binds a slider on a deep dive screen to its field and value.
+// Mouse input uses the same range and step as the arrow keys.
+type deepDiveSlider struct {
+	field    int
+	value    *int
+	min, max int
+	step     int
+}
+
+// deepDiveSliders lists the current screen's sliders in render order
+func (a *App) deepDiveSliders() []deepDiveSlider {
+	cfg := a.deepDiveConfig
+	if cfg == nil {
+		return nil
+	}
+	switch a.screen {
+	case ScreenConfigGhostty:
+		return []deepDiveSlider{
+			{field: 2, value: &cfg.GhosttyOpacity, min: 0, max: 100, step: 5},
+			{field: 3, value: &cfg.GhosttyBlurRadius, min: 0, max: 100, step: 5},
+		}
+	case ScreenConfigFzf:
+		return []deepDiveSlider{{field: 1, value: &cfg.FzfHeight, min: 20, max: 100, step: 10}}
+	}
+	return nil
+}
+
+// sliderTrack is the screen position of a slider's first track cell
+type sliderTrack struct {
+	x, y int
+}
+
+// sliderLineRe matches a slider as renderSliderControl draws it: the track
+// followed by its percentage
+var sliderLineRe = regexp.MustCompile(`[━─]{` + strconv.Itoa(sliderTrackWidth) + `} \d+%`)
+
+// findSliderTracks locates slider tracks in a rendered view, top to bottom
+func findSliderTracks(view string) []sliderTrack {
+	var tracks []sliderTrack
+	for y, line := range strings.Split(ansi.Strip(view), "\n") {
+		if loc := sliderLineRe.FindStringIndex(line); loc != nil {
+			tracks = append(tracks, sliderTrack{x: ansi.StringWidth(line[:loc[0]]), y: y})
+		}
+	}
+	return tracks
+}
+
+// valueAt converts a column on the slider's track to a value: proportional
+// to the position (the last cell is max), rounded to the nearest step and
+// clamped to the slider's range, so drags past either end pin to it
+func (s deepDiveSlider) valueAt(x int, track sliderTrack) int {
+	v := clampInt(x-track.x+1, 0, sliderTrackWidth) * s.max / sliderTrackWidth
+	if s.step > 0 {
+		v = (v + s.step/2) / s.step * s.step
+	}
+	return clampInt(v, s.min, s.max)
+}
+
+// handleSliderMouse sets a slider from a click on its track, and keeps
+// following the pointer while the button is held. It reports whether the
+// event was consumed.
+func (a *App) handleSliderMouse(m tea.MouseEvent) bool {
+	switch {
+	case m.Action == tea.MouseActionRelease:
+		dragging := a.sliderDragging
+		a.sliderDragging = false
+		return dragging
+	case m.Action == tea.MouseActionMotion && a.sliderDragging:
+	case m.Action == tea.MouseActionPress && m.Button == tea.MouseButtonLeft:
+		a.sliderDragging = false
+	default:
+		return false
+	}
+
+	sliders := a.deepDiveSliders()
+	if len(sliders) == 0 {
+		return false
+	}
+	// The sliders are located in the rendered screen, so this stays correct
+	// however the layout centers or resizes them
+	tracks := findSliderTracks(a.View())
+	if len(tracks) != len(sliders) {
+		return false
+	}
+
+	for i, track := range tracks {
+		s := sliders[i]
+		if a.sliderDragging {
+			// While dragging only the grabbed slider moves, wherever the pointer is
+			if s.field != a.configFieldIndex {
+				continue
+			}
+		} else if m.Y != track.y || m.X < track.x || m.X >= track.x+sliderTrackWidth {
+			continue
+		}
+		*s.value = s.valueAt(m.X, track)
+		a.configFieldIndex = s.field
+		a.sliderDragging = true
+		return true
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSliderClickAndDrag(t *testing.T) {
+	a := &App{
+		screen:         ScreenConfigGhostty,
+		deepDiveConfig: NewDeepDiveConfig(),
+		width:          120,
+		height:         60,
+	}
+
+	tracks := findSliderTracks(a.View())
+	if len(tracks) != 2 {
+		t.Fatalf("found %d slider tracks, want 2 (opacity, blur)", len(tracks))
+	}
+	blur := tracks[1]
+
+	// Click the middle of the blur track
+	a.handleConfigScreenMouse(tea.MouseMsg{X: blur.x + 11, Y: blur.y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	if got := a.deepDiveConfig.GhosttyBlurRadius; got != 50 {
+		t.Errorf("blur after click = %d, want 50", got)
+	}
+	if a.configFieldIndex != 3 {
+		t.Errorf("configFieldIndex = %d, want 3 (blur focused)", a.configFieldIndex)
+	}
+
+	// Drag past the right end, off the track's row
+	a.handleConfigScreenMouse(tea.MouseMsg{X: blur.x + 40, Y: blur.y + 2, Action: tea.MouseActionMotion, Button: tea.MouseButtonLeft})
+	if got := a.deepDiveConfig.GhosttyBlurRadius; got != 100 {
+		t.Errorf("blur after drag = %d, want 100", got)
+	}
+
+	// After release, motion no longer moves the slider
+	a.handleConfigScreenMouse(tea.MouseMsg{X: blur.x, Y: blur.y, Action: tea.MouseActionRelease})
+	a.handleConfigScreenMouse(tea.MouseMsg{X: blur.x, Y: blur.y, Action: tea.MouseActionMotion})
+	if got := a.deepDiveConfig.GhosttyBlurRadius; got != 100 {
+		t.Errorf("blur after release = %d, want 100", got)
+	}
+}
+
+func TestSliderValueAtRespectsRange(t *testing.T) {
+	s := deepDiveSlider{min: 20, max: 100, step: 10}
+	track := sliderTrack{x: 10}
+	tests := []struct {
+		x, want int
+	}{
+		{0, 20},  // left of the track clamps to min
+		{10, 20}, // first cell is below min
+		{21, 50}, // 12/24 of the way
+		{33, 100},
+		{50, 100},
+	}
+	for _, tt := range tests {
+		if got := s.valueAt(tt.x, track); got != tt.want {
+			t.Errorf("valueAt(%d) = %d, want %d", tt.x, got, tt.want)
+		}
+	}
+}
@@ -86,7 +86,7 @@ func (p *RealPackageManagerProvider) DetectPlatform() pkg.Platform {
 }
 
 func (p *RealPackageManagerProvider) CheckUpdates() ([]pkg.Package, error) {
-	return pkg.CheckDotfilesUpdates()
+	return tools.CheckUpdates()
 }
 
 // RealConfigProvider is the production implementation
@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tekierz/dotfiles/internal/hotkeys"
+)
+
+// favoriteHotkeys returns the active user's favorite hotkeys across every
+// category, in the Hotkeys screen's order. Favorites that no longer match a
+// hotkey (e.g. after a nav style switch) are left out.
+func (a *App) favoriteHotkeys() []hotkeyEntry {
+	userHotkeys := a.getCurrentUserHotkeys()
+	var entries []hotkeyEntry
+	for i, cat := range hotkeys.Categories(a.navStyle) {
+		for _, it := range cat.Items {
+			if userHotkeys.IsFavorite(cat.ID, it.Keys) {
+				entries = append(entries, hotkeyEntry{catIndex: i, cat: cat, item: it})
+			}
+		}
+	}
+	return entries
+}
+
+// favoritesListHeight is how many favorites fit on screen at once
+func (a *App) favoritesListHeight() int {
+	// title, subtitle, blank, box border(2), blank, status, help
+	return maxInt(3, a.height-10)
+}
+
+// handleFavoritesKey handles the My Favorites screen
+func (a *App) handleFavoritesKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	entries := a.favoriteHotkeys()
+	a.favoritesIndex = clampInt(a.favoritesIndex, 0, maxInt(0, len(entries)-1))
+
+	switch msg.String() {
+	case "up", "k":
+		if a.favoritesIndex > 0 {
+			a.favoritesIndex--
+		}
+	case "down", "j":
+		if a.favoritesIndex < len(entries)-1 {
+			a.favoritesIndex++
+		}
+	case "home", "g":
+		a.favoritesIndex = 0
+	case "end", "G":
+		a.favoritesIndex = maxInt(0, len(entries)-1)
+	case "f", "d":
+		if len(entries) > 0 {
+			e := entries[a.favoritesIndex]
+			a.toggleHotkeyFavorite(e.cat.ID, e.item.Keys)
+			a.hotkeysStatus = fmt.Sprintf("Removed %s from favorites (u to undo)", e.item.Keys)
+		}
+	case "u":
+		a.undoHotkeyFavorite()
+	case "enter":
+		if len(entries) > 0 {
+			a.openFavoriteInHotkeys(entries[a.favoritesIndex])
+		}
+	case "esc":
+		a.hotkeysStatus = ""
+		a.screen = ScreenMainMenu
+	}
+	return a, nil
+}
+
+// openFavoriteInHotkeys shows a favorite in the Hotkeys screen, which
+// returns here on esc
+func (a *App) openFavoriteInHotkeys(e hotkeyEntry) {
+	a.hotkeyFilter = ""
+	a.hotkeysFlat = false
+	a.hotkeysPane = hotkeysPaneItems
+	a.hotkeyCategory = e.catIndex
+	a.hotkeyCursor = 0
+	a.hotkeyItemScroll = 0
+	for i, d := range a.hotkeyDisplayItems(a.hotkeyCategories()) {
+		if d.item.Keys == e.item.Keys {
+			a.hotkeyCursor = i
+			break
+		}
+	}
+	a.hotkeysStatus = ""
+	a.hotkeysReturn = ScreenFavorites
+	a.screen = ScreenHotkeys
+}
+
+// renderFavorites renders the My Favorites screen: every favorite hotkey in
+// one list, labeled with the tool it belongs to
+func (a *App) renderFavorites() string {
+	entries := a.favoriteHotkeys()
+	a.favoritesIndex = clampInt(a.favoritesIndex, 0, maxInt(0, len(entries)-1))
+
+	boxOuterW := min(100, maxInt(40, a.width-8))
+	innerW := maxInt(20, boxOuterW-4) // border(2) + paddingX(2)
+
+	title := TitleStyle.Render("My Favorites")
+	subtitle := lipgloss.NewStyle().Foreground(ColorTextMuted).Italic(true).
+		Render(fmt.Sprintf("%d favorite hotkey(s) for %s", len(entries), a.getCurrentUsername()))
+
+	var body string
+	help := "esc menu • q quit"
+	if len(entries) == 0 {
+		body = lipgloss.NewStyle().Foreground(ColorTextMuted).
+			Render("No favorites yet.\n\nMark hotkeys with 'f' in the Hotkeys screen.")
+		if len(a.hotkeysUndo) > 0 {
+			help = "u undo • " + help
+		}
+	} else {
+		catW, keysW := 0, 0
+		for _, e := range entries {
+			catW = max(catW, lipgloss.Width(e.cat.Name))
+			keysW = max(keysW, lipgloss.Width(e.item.Keys))
+		}
+		catW = min(catW, 24)
+		keysW = min(keysW, 24)
+
+		listH := a.favoritesListHeight()
+		start := clampInt(a.favoritesIndex-listH/2, 0, maxInt(0, len(entries)-listH))
+		end := min(len(entries), start+listH)
+
+		var lines []string
+		for i := start; i < end; i++ {
+			e := entries[i]
+			cursor := "  "
+			catStyle := lipgloss.NewStyle().Foreground(ColorMagenta)
+			keysStyle := lipgloss.NewStyle().Foreground(ColorCyan)
+			descStyle := lipgloss.NewStyle().Foreground(ColorText)
+			if i == a.favoritesIndex {
+				cursor = lipgloss.NewStyle().Foreground(ColorCyan).Bold(true).Render("▸ ")
+				keysStyle = keysStyle.Bold(true)
+				descStyle = descStyle.Foreground(ColorTextBright)
+			}
+			cat := truncateVisible(e.cat.Icon+" "+e.cat.Name, catW+2)
+			keys := truncateVisible(e.item.Keys, keysW)
+			line := cursor +
+				catStyle.Render(cat+strings.Repeat(" ", maxInt(0, catW+2-lipgloss.Width(cat)))) + "  " +
+				keysStyle.Render(keys+strings.Repeat(" ", maxInt(0, keysW-lipgloss.Width(keys)))) + "  " +
+				descStyle.Render(e.item.Description)
+			lines = append(lines, truncateVisible(line, innerW))
+		}
+		if len(entries) > listH {
+			lines = append(lines, lipgloss.NewStyle().Foreground(ColorTextMuted).
+				Render(fmt.Sprintf("  %d-%d of %d", start+1, end, len(entries))))
+		}
+		body = strings.Join(lines, "\n")
+		help = "↑↓ navigate • enter open in Hotkeys • f unfavorite • u undo • " + help
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBorder).
+		Padding(0, 1).
+		Width(boxOuterW - 2).
+		Render(body)
+
+	sections := []string{title, subtitle, "", box, ""}
+	if a.hotkeysStatus != "" {
+		sections = append(sections, lipgloss.NewStyle().Foreground(ColorYellow).Render(a.hotkeysStatus))
+	}
+	sections = append(sections, HelpStyle.Render(truncateVisible(help, maxInt(20, a.width-4))))
+
+	return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left, sections...))
+}
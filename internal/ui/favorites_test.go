@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tekierz/dotfiles/internal/hotkeys"
+	"github.com/tekierz/dotfiles/internal/testutil"
+)
+
+func TestFavoritesScreen(t *testing.T) {
+	testutil.TempConfigDir(t)
+	a := &App{screen: ScreenFavorites, width: 120, height: 40}
+
+	cats := hotkeys.Categories(a.navStyle)
+	if len(cats) < 2 || len(cats[1].Items) < 2 {
+		t.Fatal("need at least 2 categories with items")
+	}
+	first, second := cats[0], cats[1]
+	// Favorited out of order; the screen lists them in category order
+	a.toggleHotkeyFavorite(second.ID, second.Items[1].Keys)
+	a.toggleHotkeyFavorite(first.ID, first.Items[0].Keys)
+	a.toggleHotkeyFavorite("no-such-tool", "ctrl+x")
+
+	entries := a.favoriteHotkeys()
+	if len(entries) != 2 || entries[0].cat.ID != first.ID || entries[1].cat.ID != second.ID {
+		t.Fatalf("favoriteHotkeys() = %+v, want %s then %s", entries, first.ID, second.ID)
+	}
+	view := a.View()
+	// Long category names are truncated, so look for their first word
+	if !strings.Contains(view, strings.Fields(first.Name)[0]) || !strings.Contains(view, strings.Fields(second.Name)[0]) {
+		t.Errorf("view should label favorites with their tool:\n%s", view)
+	}
+
+	// enter opens the favorite in the Hotkeys screen, and esc comes back
+	a.handleKey(tea.KeyMsg{Type: tea.KeyDown})
+	a.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if a.screen != ScreenHotkeys || a.hotkeyCategory != 1 || a.hotkeyCursor != 1 {
+		t.Errorf("enter: screen=%v category=%d cursor=%d, want Hotkeys at 1/1", a.screen, a.hotkeyCategory, a.hotkeyCursor)
+	}
+	a.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if a.screen != ScreenFavorites {
+		t.Fatalf("esc from Hotkeys: screen = %v, want ScreenFavorites", a.screen)
+	}
+
+	// f unfavorites the selected hotkey; u brings it back
+	a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if len(a.favoriteHotkeys()) != 1 || a.isHotkeyFavorite(second.ID, second.Items[1].Keys) {
+		t.Error("f should remove the selected favorite")
+	}
+	a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	if len(a.favoriteHotkeys()) != 2 {
+		t.Error("u should restore the removed favorite")
+	}
+}
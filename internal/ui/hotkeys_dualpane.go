@@ -165,11 +165,7 @@ func (a *App) hotkeyCategories() []hotkeys.Category {
 
 // getCurrentUsername returns the active user name from global config, or "default" if none set.
 func (a *App) getCurrentUsername() string {
-	cfg, err := config.LoadGlobalConfig()
-	if err != nil || cfg == nil || cfg.ActiveUser == "" {
-		return "default"
-	}
-	return cfg.ActiveUser
+	return config.ActiveUsername()
 }
 
 // getCurrentUserHotkeys returns the hotkeys config for the current user.
@@ -187,16 +183,153 @@ func (a *App) isHotkeyFavorite(categoryID, itemKey string) bool {
 	return userHotkeys.IsFavorite(categoryID, itemKey)
 }
 
+// hotkeyFavoriteToggle is a single favorite toggle, recorded for undo.
+type hotkeyFavoriteToggle struct {
+	categoryID string
+	itemKey    string
+}
+
+// maxHotkeysUndo bounds the favorite undo stack.
+const maxHotkeysUndo = 50
+
 // toggleHotkeyFavorite toggles the favorite status of the current hotkey item.
 func (a *App) toggleHotkeyFavorite(categoryID, itemKey string) {
+	a.toggleHotkeyFavorites([]hotkeyFavoriteToggle{{categoryID, itemKey}})
+}
+
+// toggleHotkeyFavorites toggles several favorites as one undoable operation.
+func (a *App) toggleHotkeyFavorites(toggles []hotkeyFavoriteToggle) {
+	if len(toggles) == 0 {
+		return
+	}
+	a.applyHotkeyFavoriteToggles(toggles)
+	a.hotkeysUndo = append(a.hotkeysUndo, toggles)
+	if len(a.hotkeysUndo) > maxHotkeysUndo {
+		a.hotkeysUndo = a.hotkeysUndo[len(a.hotkeysUndo)-maxHotkeysUndo:]
+	}
+	a.hotkeysStatus = ""
+}
+
+// undoHotkeyFavorite reverts the most recent favorite operation. Toggling is
+// its own inverse, so the recorded toggles are simply applied again.
+func (a *App) undoHotkeyFavorite() {
+	if len(a.hotkeysUndo) == 0 {
+		a.hotkeysStatus = "Nothing to undo"
+		return
+	}
+	last := a.hotkeysUndo[len(a.hotkeysUndo)-1]
+	a.hotkeysUndo = a.hotkeysUndo[:len(a.hotkeysUndo)-1]
+	a.applyHotkeyFavoriteToggles(last)
+	if len(last) == 1 {
+		a.hotkeysStatus = "Undid favorite toggle"
+	} else {
+		a.hotkeysStatus = fmt.Sprintf("Undid %d favorite toggles", len(last))
+	}
+}
+
+// applyHotkeyFavoriteToggles toggles each favorite and saves once.
+func (a *App) applyHotkeyFavoriteToggles(toggles []hotkeyFavoriteToggle) {
 	username := a.getCurrentUsername()
 	userHotkeys := a.getCurrentUserHotkeys()
-	userHotkeys.ToggleFavorite(categoryID, itemKey)
+	for _, t := range toggles {
+		userHotkeys.ToggleFavorite(t.categoryID, t.itemKey)
+	}
 	a.hotkeysFavorites.SetUserHotkeys(username, userHotkeys)
 	// Save to disk
 	_ = config.SaveHotkeysConfig(a.hotkeysFavorites)
 }
 
+// hotkeyEntry is a row of the items pane: an item and the category it
+// belongs to (which differs row to row in flat mode)
+type hotkeyEntry struct {
+	catIndex int
+	cat      hotkeys.Category
+	item     hotkeys.Item
+}
+
+// hotkeyDisplayItems returns the items pane rows: the selected category's
+// items, or every category's in flat mode, limited to favorites when the
+// favorites-only filter is on.
+func (a *App) hotkeyDisplayItems(cats []hotkeys.Category) []hotkeyEntry {
+	if len(cats) == 0 {
+		return nil
+	}
+	from := clampInt(a.hotkeyCategory, 0, len(cats)-1)
+	to := from + 1
+	if a.hotkeysFlat {
+		from, to = 0, len(cats)
+	}
+
+	var entries []hotkeyEntry
+	for i := from; i < to; i++ {
+		for _, it := range cats[i].Items {
+			if a.hotkeysFavoritesOnly && !a.isHotkeyFavorite(cats[i].ID, it.Keys) {
+				continue
+			}
+			entries = append(entries, hotkeyEntry{catIndex: i, cat: cats[i], item: it})
+		}
+	}
+	return entries
+}
+
+// firstHotkeyEntry returns the index of the first row in category catIndex,
+// or -1 if it has none
+func firstHotkeyEntry(entries []hotkeyEntry, catIndex int) int {
+	for i, e := range entries {
+		if e.catIndex == catIndex {
+			return i
+		}
+	}
+	return -1
+}
+
+// toggleHotkeysFlat switches between per-category and flat item lists,
+// keeping the cursor on the same item
+func (a *App) toggleHotkeysFlat(cats []hotkeys.Category) {
+	a.setHotkeysView(cats, !a.hotkeysFlat, a.hotkeysFavoritesOnly)
+}
+
+// toggleHotkeysAllFavorites switches to the favorites of every category in
+// one list (flat and favorites-only together), or back to the full list of
+// the cursor's category
+func (a *App) toggleHotkeysAllFavorites(cats []hotkeys.Category) {
+	on := !(a.hotkeysFlat && a.hotkeysFavoritesOnly)
+	a.setHotkeysView(cats, on, on)
+}
+
+// setHotkeysView sets the flat and favorites-only modes, keeping the cursor
+// on the same item if it's still listed, or else on its category's first
+// row, and scrolling the items pane to it
+func (a *App) setHotkeysView(cats []hotkeys.Category, flat, favoritesOnly bool) {
+	var current *hotkeyEntry
+	if entries := a.hotkeyDisplayItems(cats); a.hotkeyCursor >= 0 && a.hotkeyCursor < len(entries) {
+		current = &entries[a.hotkeyCursor]
+	}
+
+	a.hotkeysFlat = flat
+	a.hotkeysFavoritesOnly = favoritesOnly
+	a.hotkeyCursor = 0
+	a.hotkeyItemScroll = 0
+	if a.hotkeysFlat {
+		a.hotkeysPane = hotkeysPaneItems
+	}
+	if current == nil {
+		return
+	}
+	a.hotkeyCategory = current.catIndex
+	entries := a.hotkeyDisplayItems(cats)
+	a.hotkeyCursor = max(0, firstHotkeyEntry(entries, current.catIndex))
+	for i, e := range entries {
+		if e.catIndex == current.catIndex && e.item.Keys == current.item.Keys {
+			a.hotkeyCursor = i
+			break
+		}
+	}
+	if layout := a.hotkeysLayout(); a.hotkeyCursor >= layout.rightListH {
+		a.hotkeyItemScroll = min(a.hotkeyCursor-layout.rightListH+1, layout.maxItemScroll(len(entries)))
+	}
+}
+
 func (a *App) handleHotkeysKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	key := msg.String()
 
@@ -217,20 +350,10 @@ func (a *App) handleHotkeysKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// Clamp indices.
 	a.hotkeyCategory = clampInt(a.hotkeyCategory, 0, len(cats)-1)
-	cat := cats[a.hotkeyCategory]
-	allItems := cat.Items
 
-	// Get display items (filtered if favorites-only mode)
-	displayItems := allItems
-	if a.hotkeysFavoritesOnly {
-		var filtered []hotkeys.Item
-		for _, it := range allItems {
-			if a.isHotkeyFavorite(cat.ID, it.Keys) {
-				filtered = append(filtered, it)
-			}
-		}
-		displayItems = filtered
-	}
+	// Get display items (filtered if favorites-only mode, all categories
+	// in flat mode)
+	displayItems := a.hotkeyDisplayItems(cats)
 
 	if len(displayItems) == 0 {
 		a.hotkeyCursor = 0
@@ -260,6 +383,28 @@ func (a *App) handleHotkeysKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		a.hotkeyItemScroll = clampInt(a.hotkeyItemScroll, 0, maxScroll)
 	}
 
+	// In flat mode the categories pane follows the cursor's category
+	followCursor := func() {
+		if a.hotkeysFlat && a.hotkeyCursor < len(displayItems) {
+			a.hotkeyCategory = displayItems[a.hotkeyCursor].catIndex
+			ensureCatVisible()
+		}
+	}
+
+	// Moving between categories resets the item list, or in flat mode jumps
+	// to the category's first item
+	selectCategory := func() {
+		a.hotkeyCursor = 0
+		a.hotkeyItemScroll = 0
+		if a.hotkeysFlat {
+			if i := firstHotkeyEntry(displayItems, a.hotkeyCategory); i >= 0 {
+				a.hotkeyCursor = i
+				a.hotkeyItemScroll = i
+				ensureItemVisible()
+			}
+		}
+	}
+
 	// Handle tab navigation first (1-4 keys)
 	if handled, cmd := a.handleTabNavigationWithCmd(key); handled {
 		return a, cmd
@@ -269,17 +414,33 @@ func (a *App) handleHotkeysKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "esc":
 		a.hotkeyFilter = ""
 		a.hotkeysFavoritesOnly = false // Reset favorites filter on exit
+		a.hotkeysFlat = false
 		a.screen = a.hotkeysReturn
 		a.hotkeysReturn = ScreenMainMenu
 		return a, nil
 
-	case "tab":
+	}
+
+	// Rebindable actions (see keymap.go).
+	switch {
+	case a.keys.is(key, actionNextPane):
 		if a.hotkeysPane == hotkeysPaneCategories {
 			a.hotkeysPane = hotkeysPaneItems
 		} else {
 			a.hotkeysPane = hotkeysPaneCategories
 		}
 		return a, nil
+
+	case a.keys.is(key, actionExpandAll):
+		// Expand all categories into one list (or collapse back)
+		a.toggleHotkeysFlat(cats)
+		return a, nil
+	}
+
+	if key == "ctrl+f" {
+		// Every category's favorites in one list: a global cheatsheet
+		a.toggleHotkeysAllFavorites(cats)
+		return a, nil
 	}
 
 	// Categories pane navigation.
@@ -288,16 +449,14 @@ func (a *App) handleHotkeysKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case "up", "k":
 			if a.hotkeyCategory > 0 {
 				a.hotkeyCategory--
-				a.hotkeyCursor = 0
-				a.hotkeyItemScroll = 0
+				selectCategory()
 			}
 			ensureCatVisible()
 			return a, nil
 		case "down", "j":
 			if a.hotkeyCategory < len(cats)-1 {
 				a.hotkeyCategory++
-				a.hotkeyCursor = 0
-				a.hotkeyItemScroll = 0
+				selectCategory()
 			}
 			ensureCatVisible()
 			return a, nil
@@ -315,43 +474,46 @@ func (a *App) handleHotkeysKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	// Items pane navigation.
+	switch {
+	case a.keys.is(key, actionFavorite):
+		// Toggle favorite for current item (against its own category)
+		if len(displayItems) > 0 && a.hotkeyCursor >= 0 && a.hotkeyCursor < len(displayItems) {
+			entry := displayItems[a.hotkeyCursor]
+			a.toggleHotkeyFavorite(entry.cat.ID, entry.item.Keys)
+			// If in favorites-only mode and we just unfavorited, the row is
+			// gone: keep the cursor and scroll in range, and in flat mode on
+			// the category of the row that took its place
+			if a.hotkeysFavoritesOnly {
+				displayItems = a.hotkeyDisplayItems(cats)
+				a.hotkeyCursor = clampInt(a.hotkeyCursor, 0, maxInt(0, len(displayItems)-1))
+				ensureItemVisible()
+				followCursor()
+			}
+		}
+		return a, nil
+	}
+
 	switch key {
 	case "up", "k":
 		if a.hotkeyCursor > 0 {
 			a.hotkeyCursor--
 		}
 		ensureItemVisible()
+		followCursor()
 		return a, nil
 	case "down", "j":
 		if a.hotkeyCursor < len(displayItems)-1 {
 			a.hotkeyCursor++
 		}
 		ensureItemVisible()
+		followCursor()
 		return a, nil
 	case "left", "h":
 		a.hotkeysPane = hotkeysPaneCategories
 		return a, nil
-	case "f":
-		// Toggle favorite for current item
-		if len(displayItems) > 0 && a.hotkeyCursor >= 0 && a.hotkeyCursor < len(displayItems) {
-			item := displayItems[a.hotkeyCursor]
-			a.toggleHotkeyFavorite(cat.ID, item.Keys)
-			// If in favorites-only mode and we just unfavorited, adjust cursor
-			if a.hotkeysFavoritesOnly {
-				// Recalculate filtered list
-				var newFiltered []hotkeys.Item
-				for _, it := range allItems {
-					if a.isHotkeyFavorite(cat.ID, it.Keys) {
-						newFiltered = append(newFiltered, it)
-					}
-				}
-				if len(newFiltered) == 0 {
-					a.hotkeyCursor = 0
-				} else if a.hotkeyCursor >= len(newFiltered) {
-					a.hotkeyCursor = len(newFiltered) - 1
-				}
-			}
-		}
+	case "u":
+		// Undo the last favorite toggle (or bulk operation)
+		a.undoHotkeyFavorite()
 		return a, nil
 	case "F":
 		// Toggle favorites-only filter mode
@@ -367,7 +529,7 @@ func (a *App) handleHotkeysKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		a.hotkeysAliasCursor = 0
 		a.hotkeysAliasName = ""
 		if len(displayItems) > 0 && a.hotkeyCursor >= 0 && a.hotkeyCursor < len(displayItems) {
-			item := displayItems[a.hotkeyCursor]
+			item := displayItems[a.hotkeyCursor].item
 			a.hotkeysAliasCommand = item.Keys // Pre-fill command from selected hotkey
 		} else {
 			a.hotkeysAliasCommand = ""
@@ -570,20 +732,7 @@ func (a *App) handleHotkeysMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		if m.X < layout.rightX {
 			a.hotkeyCatScroll = clampInt(a.hotkeyCatScroll+delta, 0, layout.maxCatScroll(len(cats)))
 		} else {
-			cat := cats[clampInt(a.hotkeyCategory, 0, len(cats)-1)]
-			allItems := cat.Items
-
-			// Get display items (filtered if favorites-only mode)
-			displayItems := allItems
-			if a.hotkeysFavoritesOnly {
-				var filtered []hotkeys.Item
-				for _, it := range allItems {
-					if a.isHotkeyFavorite(cat.ID, it.Keys) {
-						filtered = append(filtered, it)
-					}
-				}
-				displayItems = filtered
-			}
+			displayItems := a.hotkeyDisplayItems(cats)
 			a.hotkeyItemScroll = clampInt(a.hotkeyItemScroll+delta, 0, layout.maxItemScroll(len(displayItems)))
 		}
 		return a, nil
@@ -602,32 +751,29 @@ func (a *App) handleHotkeysMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 			a.hotkeyCategory = idx
 			a.hotkeyCursor = 0
 			a.hotkeyItemScroll = 0
+			// In flat mode, jump to the category's first item
+			if a.hotkeysFlat {
+				if i := firstHotkeyEntry(a.hotkeyDisplayItems(cats), idx); i >= 0 {
+					a.hotkeyCursor = i
+					a.hotkeyItemScroll = i
+				}
+			}
 		}
 		return a, nil
 	}
 
 	// Click items.
 	if layout.inRightList(m.X, m.Y) {
-		cat := cats[clampInt(a.hotkeyCategory, 0, len(cats)-1)]
-		allItems := cat.Items
-
-		// Get display items (filtered if favorites-only mode)
-		displayItems := allItems
-		if a.hotkeysFavoritesOnly {
-			var filtered []hotkeys.Item
-			for _, it := range allItems {
-				if a.isHotkeyFavorite(cat.ID, it.Keys) {
-					filtered = append(filtered, it)
-				}
-			}
-			displayItems = filtered
-		}
+		displayItems := a.hotkeyDisplayItems(cats)
 
 		rel := m.Y - layout.rightListY
 		idx := a.hotkeyItemScroll + rel
 		if idx >= 0 && idx < len(displayItems) {
 			a.hotkeysPane = hotkeysPaneItems
 			a.hotkeyCursor = idx
+			if a.hotkeysFlat {
+				a.hotkeyCategory = displayItems[idx].catIndex
+			}
 		}
 		return a, nil
 	}
@@ -656,20 +802,8 @@ func (a *App) renderHotkeysDualPane() string {
 		}
 		a.hotkeyCatScroll = clampInt(a.hotkeyCatScroll, 0, maxCatScroll)
 
-		cat := cats[a.hotkeyCategory]
-		allItems := cat.Items
-
 		// Get display items (filtered if favorites-only mode)
-		displayItems := allItems
-		if a.hotkeysFavoritesOnly {
-			var filtered []hotkeys.Item
-			for _, it := range allItems {
-				if a.isHotkeyFavorite(cat.ID, it.Keys) {
-					filtered = append(filtered, it)
-				}
-			}
-			displayItems = filtered
-		}
+		displayItems := a.hotkeyDisplayItems(cats)
 
 		if len(displayItems) == 0 {
 			a.hotkeyCursor = 0
@@ -727,8 +861,9 @@ func (a *App) renderHotkeysHeader(width int) string {
 
 func (a *App) renderHotkeysFooter(width int, cats []hotkeys.Category) string {
 	// Split help text into two lines for better readability
-	helpLine1 := "Tab pane  ↑↓ move  ←→ switch  f favorite  F filter  a add alias"
-	helpLine2 := "Click select  Scroll  Esc back  q quit"
+	helpLine1 := fmt.Sprintf("%s pane  ↑↓ move  ←→ switch  %s favorite  u undo  F filter  ctrl+f all favorites  %s expand all  a add alias",
+		a.keys.label(actionNextPane), a.keys.label(actionFavorite), a.keys.label(actionExpandAll))
+	helpLine2 := "Click select  Scroll  Esc back  " + a.keys.label(actionQuit) + " quit"
 	hints := lipgloss.NewStyle().Foreground(ColorTextMuted).Render(
 		helpLine1 + "\n" + helpLine2,
 	)
@@ -737,6 +872,13 @@ func (a *App) renderHotkeysFooter(width int, cats []hotkeys.Category) string {
 	if len(cats) > 0 {
 		cat := cats[clampInt(a.hotkeyCategory, 0, len(cats)-1)]
 		statusText = fmt.Sprintf("%s %s — %d items", cat.Icon, cat.Name, len(cat.Items))
+		if a.hotkeysFlat {
+			total := 0
+			for _, c := range cats {
+				total += len(c.Items)
+			}
+			statusText = fmt.Sprintf("All categories — %d items", total)
+		}
 	}
 	if a.hotkeyFilter != "" {
 		statusText = statusText + lipgloss.NewStyle().Foreground(ColorTextMuted).Render("  (filtered)")
@@ -744,8 +886,9 @@ func (a *App) renderHotkeysFooter(width int, cats []hotkeys.Category) string {
 	if a.hotkeysFavoritesOnly {
 		statusText = statusText + lipgloss.NewStyle().Foreground(ColorYellow).Render("  [favorites only]")
 	}
-	if statusText == "" {
-		statusText = " "
+	statusText += lipgloss.NewStyle().Foreground(ColorCyan).Render("  [nav: " + a.navStyle + " • ctrl+n toggle]")
+	if a.hotkeysStatus != "" {
+		statusText += lipgloss.NewStyle().Foreground(ColorYellow).Render("  " + a.hotkeysStatus)
 	}
 	status := lipgloss.NewStyle().Foreground(ColorTextMuted).Render(truncateVisible(statusText, width))
 	return lipgloss.JoinVertical(lipgloss.Left, hints, status)
@@ -847,29 +990,15 @@ func (a *App) renderHotkeysItemsPanel(layout hotkeysLayout, cats []hotkeys.Categ
 	}
 
 	cat := cats[clampInt(a.hotkeyCategory, 0, len(cats)-1)]
-	items := cat.Items
 
 	// Filter to favorites only if mode is enabled
-	displayItems := items
-	itemIndices := make([]int, len(items)) // Map display index to original index
-	for i := range items {
-		itemIndices[i] = i
-	}
-	if a.hotkeysFavoritesOnly {
-		var filteredItems []hotkeys.Item
-		var filteredIndices []int
-		for i, it := range items {
-			if a.isHotkeyFavorite(cat.ID, it.Keys) {
-				filteredItems = append(filteredItems, it)
-				filteredIndices = append(filteredIndices, i)
-			}
-		}
-		displayItems = filteredItems
-		itemIndices = filteredIndices
-	}
+	displayItems := a.hotkeyDisplayItems(cats)
 
 	title := lipgloss.NewStyle().Foreground(ColorNeonPink).Bold(true).Render("ITEMS")
 	subText := fmt.Sprintf("%s %s", cat.Icon, cat.Name)
+	if a.hotkeysFlat {
+		subText = fmt.Sprintf("All %d categories", len(cats))
+	}
 	if a.hotkeysFavoritesOnly {
 		subText += fmt.Sprintf(" (%d favorites)", len(displayItems))
 	}
@@ -878,6 +1007,14 @@ func (a *App) renderHotkeysItemsPanel(layout hotkeysLayout, cats []hotkeys.Categ
 	// Use capped rightW for inner width calculation
 	innerW := maxInt(0, rightW-(layout.border*2)-(layout.padX*2))
 	keyW := min(22, maxInt(12, innerW/3))
+	// Flat mode shows each item's category as a column
+	catW := 0
+	if a.hotkeysFlat {
+		for _, c := range cats {
+			catW = max(catW, ansi.StringWidth(c.Name))
+		}
+		catW = min(catW, maxInt(8, innerW/5))
+	}
 
 	// Show alias input dialog if adding
 	if a.hotkeysAddingAlias {
@@ -888,18 +1025,22 @@ func (a *App) renderHotkeysItemsPanel(layout hotkeysLayout, cats []hotkeys.Categ
 
 	// Show message if no favorites in filter mode
 	if a.hotkeysFavoritesOnly && len(displayItems) == 0 {
-		msg := lipgloss.NewStyle().Foreground(ColorTextMuted).Render("No favorites in this category.\nPress 'F' to show all items.")
+		empty := "No favorites in this category."
+		if a.hotkeysFlat {
+			empty = "No favorites yet."
+		}
+		msg := lipgloss.NewStyle().Foreground(ColorTextMuted).Render(empty + "\nPress 'F' to show all items.")
 		content := lipgloss.JoinVertical(lipgloss.Left, title, sub, "", msg)
 		return panel.Render(content)
 	}
 
 	lines := make([]string, 0, layout.rightListH)
 	for i := a.hotkeyItemScroll; i < len(displayItems) && len(lines) < layout.rightListH; i++ {
-		it := displayItems[i]
+		it := displayItems[i].item
 		focused := i == a.hotkeyCursor
 
 		// Check if this item is a favorite
-		isFavorite := a.isHotkeyFavorite(cat.ID, it.Keys)
+		isFavorite := a.isHotkeyFavorite(displayItems[i].cat.ID, it.Keys)
 		starIndicator := "  "
 		if isFavorite {
 			starIndicator = lipgloss.NewStyle().Foreground(ColorYellow).Render("* ")
@@ -916,7 +1057,13 @@ func (a *App) renderHotkeysItemsPanel(layout hotkeysLayout, cats []hotkeys.Categ
 			lineStyle = lipgloss.NewStyle().Background(ColorOverlay)
 		}
 
-		line := fmt.Sprintf("%s%s%s %s", starIndicator, cursor, keyStyle.Render(it.Keys), descStyle.Render(it.Description))
+		catCol := ""
+		if catW > 0 {
+			catName := truncateVisible(displayItems[i].cat.Name, catW)
+			catCol = lipgloss.NewStyle().Foreground(ColorTextMuted).Width(catW).Render(catName) + " "
+		}
+
+		line := fmt.Sprintf("%s%s%s%s %s", starIndicator, cursor, catCol, keyStyle.Render(it.Keys), descStyle.Render(it.Description))
 		// Apply background highlight for focused line
 		if focused {
 			line = lineStyle.Width(innerW).Render(truncateVisible(line, innerW))
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/testutil"
+)
+
+func TestUndoHotkeyFavorite(t *testing.T) {
+	testutil.TempConfigDir(t)
+	a := &App{}
+
+	a.toggleHotkeyFavorite("tmux", "prefix |")
+	a.toggleHotkeyFavorites([]hotkeyFavoriteToggle{{"tmux", "prefix -"}, {"zsh", "ctrl+r"}})
+	if !a.isHotkeyFavorite("tmux", "prefix -") || !a.isHotkeyFavorite("zsh", "ctrl+r") {
+		t.Fatal("bulk toggle should favorite every item")
+	}
+
+	// Undo reverts the whole bulk operation.
+	a.undoHotkeyFavorite()
+	if a.isHotkeyFavorite("tmux", "prefix -") || a.isHotkeyFavorite("zsh", "ctrl+r") {
+		t.Error("undo should revert every toggle of the bulk operation")
+	}
+	if !a.isHotkeyFavorite("tmux", "prefix |") {
+		t.Error("undo should leave earlier operations alone")
+	}
+
+	// The undo is saved to disk.
+	saved, err := config.LoadHotkeysConfig()
+	if err != nil {
+		t.Fatalf("LoadHotkeysConfig() failed: %v", err)
+	}
+	if saved.GetUserHotkeys(a.getCurrentUsername()).IsFavorite("zsh", "ctrl+r") {
+		t.Error("undo was not saved")
+	}
+
+	a.undoHotkeyFavorite()
+	if a.isHotkeyFavorite("tmux", "prefix |") {
+		t.Error("second undo should revert the single toggle")
+	}
+
+	a.undoHotkeyFavorite()
+	if a.hotkeysStatus != "Nothing to undo" {
+		t.Errorf("hotkeysStatus = %q, want %q", a.hotkeysStatus, "Nothing to undo")
+	}
+}
+
+func TestHotkeysFlatMode(t *testing.T) {
+	testutil.TempConfigDir(t)
+	a := &App{screen: ScreenHotkeys, width: 120, height: 40, hotkeysPane: hotkeysPaneItems}
+	cats := a.hotkeyCategories()
+	if len(cats) < 2 {
+		t.Fatalf("need at least 2 categories, got %d", len(cats))
+	}
+
+	// Start on the second item of the second category
+	a.hotkeyCategory = 1
+	a.hotkeyCursor = 1
+	want := cats[1].Items[1]
+
+	a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	entries := a.hotkeyDisplayItems(cats)
+	total := 0
+	for _, c := range cats {
+		total += len(c.Items)
+	}
+	if len(entries) != total {
+		t.Fatalf("flat mode shows %d items, want all %d", len(entries), total)
+	}
+	if got := entries[a.hotkeyCursor]; got.catIndex != 1 || got.item.Keys != want.Keys {
+		t.Errorf("cursor on %s/%s, want the item selected before expanding", got.cat.ID, got.item.Keys)
+	}
+
+	// f favorites the item in its own category
+	a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if !a.isHotkeyFavorite(cats[1].ID, want.Keys) {
+		t.Errorf("f should favorite %s in %s", want.Keys, cats[1].ID)
+	}
+
+	// Moving into the next category moves the categories pane with it
+	a.hotkeyCursor = len(cats[0].Items) + len(cats[1].Items) - 1
+	a.handleKey(tea.KeyMsg{Type: tea.KeyDown})
+	if a.hotkeyCategory != 2 {
+		t.Errorf("hotkeyCategory = %d, want 2 after moving past category 1", a.hotkeyCategory)
+	}
+
+	// Collapsing returns to that category's list with the item kept
+	a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if a.hotkeysFlat || a.hotkeyCategory != 2 || a.hotkeyCursor != 0 {
+		t.Errorf("collapse: flat=%v category=%d cursor=%d, want category 2 cursor 0", a.hotkeysFlat, a.hotkeyCategory, a.hotkeyCursor)
+	}
+}
+
+func TestHotkeysAllFavorites(t *testing.T) {
+	testutil.TempConfigDir(t)
+	a := &App{screen: ScreenHotkeys, width: 120, height: 40, hotkeysPane: hotkeysPaneCategories}
+	cats := a.hotkeyCategories()
+	if len(cats) < 3 {
+		t.Fatalf("need at least 3 categories, got %d", len(cats))
+	}
+
+	// Favorites in the first and last categories
+	first, last := cats[0], cats[len(cats)-1]
+	a.toggleHotkeyFavorite(first.ID, first.Items[0].Keys)
+	a.toggleHotkeyFavorite(last.ID, last.Items[0].Keys)
+	a.toggleHotkeyFavorite(last.ID, last.Items[1].Keys)
+
+	// ctrl+f from the last category lists every favorite, cursor on that
+	// category's first one
+	a.hotkeyCategory = len(cats) - 1
+	a.handleKey(tea.KeyMsg{Type: tea.KeyCtrlF})
+	entries := a.hotkeyDisplayItems(cats)
+	if !a.hotkeysFlat || !a.hotkeysFavoritesOnly || len(entries) != 3 {
+		t.Fatalf("ctrl+f: flat=%v favoritesOnly=%v entries=%d, want all 3 favorites",
+			a.hotkeysFlat, a.hotkeysFavoritesOnly, len(entries))
+	}
+	if a.hotkeysPane != hotkeysPaneItems || a.hotkeyCursor != 1 {
+		t.Errorf("pane=%v cursor=%d, want the items pane on row 1", a.hotkeysPane, a.hotkeyCursor)
+	}
+	testutil.RequireContains(t, a.View(), "ctrl+f all favorites", "footer should list ctrl+f")
+
+	// Unfavoriting the last row keeps the cursor in range and on its new
+	// row's category
+	a.hotkeyCursor = 2
+	a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if a.isHotkeyFavorite(last.ID, last.Items[1].Keys) {
+		t.Fatal("f should unfavorite the row under the cursor")
+	}
+	if len(a.hotkeyDisplayItems(cats)) != 2 || a.hotkeyCursor != 1 || a.hotkeyCategory != len(cats)-1 {
+		t.Errorf("after unfavorite: cursor=%d category=%d, want cursor 1 in the last category", a.hotkeyCursor, a.hotkeyCategory)
+	}
+
+	// Moving up crosses into the first category
+	a.handleKey(tea.KeyMsg{Type: tea.KeyUp})
+	if a.hotkeyCategory != 0 {
+		t.Errorf("hotkeyCategory = %d, want 0 after moving to the first favorite", a.hotkeyCategory)
+	}
+
+	// ctrl+f again returns to that category's full list, item kept
+	a.handleKey(tea.KeyMsg{Type: tea.KeyCtrlF})
+	if a.hotkeysFlat || a.hotkeysFavoritesOnly || a.hotkeyCategory != 0 || a.hotkeyCursor != 0 {
+		t.Errorf("ctrl+f off: flat=%v favoritesOnly=%v category=%d cursor=%d, want category 0 cursor 0",
+			a.hotkeysFlat, a.hotkeysFavoritesOnly, a.hotkeyCategory, a.hotkeyCursor)
+	}
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// idleCheckInterval is how often the idle timeout is checked; it is
+	// also the countdown's resolution
+	idleCheckInterval = time.Second
+
+	// idleCountdown is how long before exiting the countdown is shown
+	idleCountdown = 30 * time.Second
+)
+
+// idleActive reports whether the idle timeout applies now. Running installs
+// (including ones started from Manage) and updates are never interrupted.
+func (a *App) idleActive() bool {
+	return a.idleTimeout > 0 && !a.installRunning && !a.manageInstalling && !a.updateRunning
+}
+
+// idleRemaining returns how long until the idle timeout exits the TUI
+func (a *App) idleRemaining(now time.Time) time.Duration {
+	return a.idleTimeout - now.Sub(a.lastInput)
+}
+
+// checkIdle quits once the idle timeout has passed without input, and
+// otherwise schedules the next check. Time spent in an install or update
+// doesn't count as idle.
+func (a *App) checkIdle(now time.Time) tea.Cmd {
+	if a.idleTimeout <= 0 {
+		return nil
+	}
+	if !a.idleActive() {
+		a.lastInput = now
+		return tickIdle()
+	}
+	if a.idleRemaining(now) <= 0 {
+		return tea.Quit
+	}
+	return tickIdle()
+}
+
+// withIdleBanner replaces the last line of view with the exit countdown
+// during the final idleCountdown before the idle timeout
+func (a *App) withIdleBanner(view string, now time.Time) string {
+	if !a.idleActive() || a.lastInput.IsZero() {
+		return view
+	}
+	remaining := a.idleRemaining(now)
+	if remaining > idleCountdown {
+		return view
+	}
+	secs := int((remaining + time.Second - 1) / time.Second)
+	banner := lipgloss.NewStyle().
+		Foreground(ColorYellow).
+		Bold(true).
+		Render(fmt.Sprintf("⏱ No input — exiting in %ds (press any key to stay)", max(secs, 0)))
+	banner = lipgloss.PlaceHorizontal(a.width, lipgloss.Center, banner)
+
+	if i := strings.LastIndex(view, "\n"); i >= 0 {
+		return view[:i+1] + banner
+	}
+	return banner
+}
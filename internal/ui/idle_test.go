@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCheckIdle(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	a := &App{idleTimeout: 5 * time.Minute, lastInput: start}
+
+	if cmd := a.checkIdle(start.Add(4 * time.Minute)); cmd == nil {
+		t.Fatal("checkIdle() stopped ticking before the timeout")
+	}
+	if view := a.withIdleBanner("top\nbottom", start.Add(4*time.Minute)); view != "top\nbottom" {
+		t.Errorf("banner shown too early: %q", view)
+	}
+	view := a.withIdleBanner("top\nbottom", start.Add(5*time.Minute-10*time.Second))
+	if !strings.HasPrefix(view, "top\n") || !strings.Contains(view, "exiting in 10s") {
+		t.Errorf("view = %q, want the countdown over the last line", view)
+	}
+
+	if _, ok := a.checkIdle(start.Add(5 * time.Minute))().(tea.QuitMsg); !ok {
+		t.Error("checkIdle() didn't quit after the timeout")
+	}
+}
+
+func TestCheckIdleDuringInstall(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	a := &App{idleTimeout: time.Minute, lastInput: start, installRunning: true}
+
+	later := start.Add(time.Hour)
+	if cmd := a.checkIdle(later); cmd == nil {
+		t.Fatal("checkIdle() stopped ticking during an install")
+	}
+	if !a.lastInput.Equal(later) {
+		t.Errorf("lastInput = %v, want the install to count as activity", a.lastInput)
+	}
+
+	a.installRunning = false
+	if view := a.withIdleBanner("x", later.Add(10*time.Second)); view != "x" {
+		t.Errorf("banner shown right after the install: %q", view)
+	}
+}
+
+func TestCheckIdleDuringManageInstall(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	a := &App{idleTimeout: time.Minute, lastInput: start, manageInstalling: true}
+
+	later := start.Add(time.Hour)
+	cmd := a.checkIdle(later)
+	if cmd == nil {
+		t.Fatal("checkIdle() stopped ticking during a Manage install")
+	}
+	if !a.lastInput.Equal(later) {
+		t.Errorf("lastInput = %v, want the Manage install to count as activity", a.lastInput)
+	}
+}
+
+func TestCheckIdleDisabled(t *testing.T) {
+	a := &App{}
+	if cmd := a.checkIdle(time.Now()); cmd != nil {
+		t.Error("checkIdle() scheduled a tick with the timeout off")
+	}
+}
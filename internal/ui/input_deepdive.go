@@ -11,11 +11,29 @@ import (
 func (a *App) handleDeepDiveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	key := msg.String()
 
+	// Generated config preview overlay (tmux/ghostty/git)
+	if a.deepDivePreview {
+		return a, a.handleDeepDivePreviewKey(key)
+	}
+	if key == "p" {
+		if _, _, ok := a.deepDivePreviewText(); ok {
+			a.deepDivePreview = true
+			a.deepDivePreviewScroll = 0
+			a.manageStatus = ""
+			return a, nil
+		}
+	}
+
 	switch a.screen {
+	case ScreenDeepDiveConfirm:
+		a.handleDeepDiveConfirmKey(key)
+
 	// Deep dive menu navigation
 	case ScreenDeepDiveMenu:
-		menuItems := GetFilteredDeepDiveMenuItems()
+		menuItems := a.deepDiveMenuItems()
 		maxIdx := len(menuItems) // +1 for "Continue" option
+		// Items may have been hidden since the index was set
+		a.deepDiveMenuIndex = min(a.deepDiveMenuIndex, maxIdx)
 		switch key {
 		case "up", "k":
 			if a.deepDiveMenuIndex > 0 {
@@ -27,8 +45,8 @@ func (a *App) handleDeepDiveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		case "enter":
 			if a.deepDiveMenuIndex == maxIdx {
-				// "Continue to Installation" selected
-				a.screen = ScreenThemePicker
+				// "Continue to Installation" selected: recap first
+				a.screen = ScreenDeepDiveConfirm
 			} else {
 				// Navigate to specific config screen
 				a.screen = menuItems[a.deepDiveMenuIndex].Screen
@@ -113,15 +131,15 @@ func (a *App) handleDeepDiveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 	// Tmux config
-	// Fields: 0=prefix, 1=splits, 2=status, 3=mouse, 4=history, 5=escape, 6=base, 7=TPM toggle
-	// If TPM enabled: 8=sensible, 9=resurrect, 10=continuum, 11=yank, 12=interval (if continuum)
+	// Fields: 0=prefix, 1=splits, 2=status, 3=mouse, 4=history, 5=escape, 6=base, 7=session, 8=TPM toggle
+	// If TPM enabled: 9=sensible, 10=resurrect, 11=continuum, 12=yank, 13=interval (if continuum)
 	case ScreenConfigTmux:
 		// Calculate max field based on TPM state
-		maxField := 7 // Fields 0-7 (basic settings + TPM toggle)
+		maxField := 8 // Fields 0-8 (basic settings + session + TPM toggle)
 		if a.deepDiveConfig.TmuxTPMEnabled {
-			maxField = 11 // + plugin toggles (8-11)
+			maxField = 12 // + plugin toggles (9-12)
 			if a.deepDiveConfig.TmuxPluginContinuum {
-				maxField = 12 // + continuum interval
+				maxField = 13 // + continuum interval
 			}
 		}
 
@@ -130,12 +148,12 @@ func (a *App) handleDeepDiveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if a.configFieldIndex > 0 {
 				a.configFieldIndex--
 				// Skip hidden fields when TPM is disabled
-				if !a.deepDiveConfig.TmuxTPMEnabled && a.configFieldIndex > 7 {
-					a.configFieldIndex = 7
+				if !a.deepDiveConfig.TmuxTPMEnabled && a.configFieldIndex > 8 {
+					a.configFieldIndex = 8
 				}
 				// Skip interval field when continuum is disabled
-				if a.deepDiveConfig.TmuxTPMEnabled && !a.deepDiveConfig.TmuxPluginContinuum && a.configFieldIndex == 12 {
-					a.configFieldIndex = 11
+				if a.deepDiveConfig.TmuxTPMEnabled && !a.deepDiveConfig.TmuxPluginContinuum && a.configFieldIndex == 13 {
+					a.configFieldIndex = 12
 				}
 			}
 		case "down", "j":
@@ -174,7 +192,7 @@ func (a *App) handleDeepDiveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				} else {
 					a.deepDiveConfig.TmuxBaseIndex = 0
 				}
-			case 12: // Continuum interval
+			case 13: // Continuum interval
 				if a.deepDiveConfig.TmuxTPMEnabled && a.deepDiveConfig.TmuxPluginContinuum {
 					if fwd {
 						if a.deepDiveConfig.TmuxContinuumSaveMin < 60 {
@@ -191,15 +209,17 @@ func (a *App) handleDeepDiveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			switch a.configFieldIndex {
 			case 3: // Mouse mode
 				a.deepDiveConfig.TmuxMouseMode = !a.deepDiveConfig.TmuxMouseMode
-			case 7: // TPM enabled
+			case 7: // Dotfiles session
+				a.deepDiveConfig.TmuxSession = !a.deepDiveConfig.TmuxSession
+			case 8: // TPM enabled
 				a.deepDiveConfig.TmuxTPMEnabled = !a.deepDiveConfig.TmuxTPMEnabled
-			case 8: // tmux-sensible
+			case 9: // tmux-sensible
 				a.deepDiveConfig.TmuxPluginSensible = !a.deepDiveConfig.TmuxPluginSensible
-			case 9: // tmux-resurrect
+			case 10: // tmux-resurrect
 				a.deepDiveConfig.TmuxPluginResurrect = !a.deepDiveConfig.TmuxPluginResurrect
-			case 10: // tmux-continuum
+			case 11: // tmux-continuum
 				a.deepDiveConfig.TmuxPluginContinuum = !a.deepDiveConfig.TmuxPluginContinuum
-			case 11: // tmux-yank
+			case 12: // tmux-yank
 				a.deepDiveConfig.TmuxPluginYank = !a.deepDiveConfig.TmuxPluginYank
 			}
 		case "esc", "enter":
@@ -293,6 +313,8 @@ func (a *App) handleDeepDiveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					togglePlugin(&a.deepDiveConfig.NeovimLSPs, lsps[lspIdx])
 				}
 			}
+		case "a": // Add missing LSP runtimes to the install
+			a.addMissingLSPRuntimes()
 		case "esc", "enter":
 			a.configFieldIndex = 0
 			a.screen = ScreenDeepDiveMenu
@@ -424,6 +446,8 @@ func (a *App) handleDeepDiveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if !a.manageInstalled[app] {
 				a.deepDiveConfig.MacApps[app] = !a.deepDiveConfig.MacApps[app]
 			}
+		case "A":
+			a.toggleAllNotInstalled(a.deepDiveConfig.MacApps, apps)
 		case "esc", "enter":
 			a.macAppIndex = 0
 			a.screen = ScreenDeepDiveMenu
@@ -447,6 +471,8 @@ func (a *App) handleDeepDiveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if !a.manageInstalled[util] {
 				a.deepDiveConfig.Utilities[util] = !a.deepDiveConfig.Utilities[util]
 			}
+		case "A":
+			a.toggleAllNotInstalled(a.deepDiveConfig.Utilities, utilities)
 		case "esc", "enter":
 			a.utilityIndex = 0
 			a.screen = ScreenDeepDiveMenu
@@ -470,6 +496,8 @@ func (a *App) handleDeepDiveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if !a.manageInstalled[tool] {
 				a.deepDiveConfig.CLITools[tool] = !a.deepDiveConfig.CLITools[tool]
 			}
+		case "A":
+			a.toggleAllNotInstalled(a.deepDiveConfig.CLITools, tools)
 		case "esc", "enter":
 			a.cliToolIndex = 0
 			a.screen = ScreenDeepDiveMenu
@@ -493,6 +521,8 @@ func (a *App) handleDeepDiveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if !a.manageInstalled[app] {
 				a.deepDiveConfig.GUIApps[app] = !a.deepDiveConfig.GUIApps[app]
 			}
+		case "A":
+			a.toggleAllNotInstalled(a.deepDiveConfig.GUIApps, apps)
 		case "esc", "enter":
 			a.guiAppIndex = 0
 			a.screen = ScreenDeepDiveMenu
@@ -516,6 +546,8 @@ func (a *App) handleDeepDiveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if !a.manageInstalled[util] {
 				a.deepDiveConfig.CLIUtilities[util] = !a.deepDiveConfig.CLIUtilities[util]
 			}
+		case "A":
+			a.toggleAllNotInstalled(a.deepDiveConfig.CLIUtilities, utilities)
 		case "esc", "enter":
 			a.cliUtilityIndex = 0
 			a.screen = ScreenDeepDiveMenu
@@ -2,8 +2,10 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tekierz/dotfiles/internal/backup"
 	"github.com/tekierz/dotfiles/internal/pkg"
 )
 
@@ -60,8 +62,12 @@ func (a *App) handleManagementKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			a.updateChecking = true
 			return a, checkUpdatesCmd()
 		}
-		// Don't allow actions while update is running
+		// Don't allow actions while update is running, except pausing an
+		// update all
 		if a.updateRunning {
+			if key == "p" {
+				a.pauseUpdateQueue()
+			}
 			return a, nil
 		}
 		// Handle tab navigation first
@@ -75,27 +81,56 @@ func (a *App) handleManagementKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		case "down", "j":
 			a.updateIndex++
+		case "n": // Jump to the next package that can be selected (not pinned)
+			if len(a.updateResults) > 0 {
+				from := clampInt(a.updateIndex, 0, len(a.updateResults)-1)
+				next := nextIndex(len(a.updateResults), from, func(i int) bool {
+					return !a.updateResults[i].Pinned
+				})
+				if next < 0 {
+					a.updateStatus = "All outdated packages are pinned"
+				} else {
+					a.updateIndex = next
+				}
+			}
 		case " ": // Toggle selection for batch update
+			if a.updatePaused() {
+				a.updateStatus = updatePausedStatus
+				return a, nil
+			}
 			if len(a.updateResults) > 0 && a.updateIndex < len(a.updateResults) {
-				if a.updateSelected[a.updateIndex] {
-					delete(a.updateSelected, a.updateIndex)
+				name := a.updateResults[a.updateIndex].Name
+				if a.updateResults[a.updateIndex].Pinned {
+					a.updateStatus = fmt.Sprintf("%s is pinned (dotfiles update unpin %s)", name, name)
+				} else if a.updateSelected[name] {
+					delete(a.updateSelected, name)
 				} else {
-					a.updateSelected[a.updateIndex] = true
+					a.updateSelected[name] = true
 				}
 			}
 		case "enter": // Update selected or current package
+			if a.updatePaused() {
+				// Another update would refresh the list under the paused queue
+				a.updateStatus = updatePausedStatus
+				return a, nil
+			}
 			if len(a.updateResults) > 0 && !a.updateChecking && !a.updateRunning {
 				var packagesToUpdate []pkg.Package
 				if len(a.updateSelected) > 0 {
 					// Update selected packages
-					for idx := range a.updateSelected {
-						if idx < len(a.updateResults) {
-							packagesToUpdate = append(packagesToUpdate, a.updateResults[idx])
+					for _, p := range a.updateResults {
+						if a.updateSelected[p.Name] {
+							packagesToUpdate = append(packagesToUpdate, p)
 						}
 					}
 				} else if a.updateIndex < len(a.updateResults) {
 					// Update current package
-					packagesToUpdate = append(packagesToUpdate, a.updateResults[a.updateIndex])
+					current := a.updateResults[a.updateIndex]
+					if current.Pinned {
+						a.updateStatus = fmt.Sprintf("%s is pinned (dotfiles update unpin %s)", current.Name, current.Name)
+						return a, nil
+					}
+					packagesToUpdate = append(packagesToUpdate, current)
 				}
 				if len(packagesToUpdate) > 0 {
 					a.clearInstallLogs()
@@ -103,19 +138,40 @@ func (a *App) handleManagementKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					return a, checkSudoAndUpdateCmd(packagesToUpdate, false)
 				}
 			}
-		case "a": // Update all packages
+		case "a": // Update all packages, or resume a paused update all
+			if a.updatePaused() {
+				a.updateStatus = fmt.Sprintf("Resuming %d package(s)...", len(a.updateQueue))
+				return a, checkSudoAndUpdateCmd(nil, true)
+			}
 			if len(a.updateResults) > 0 && !a.updateChecking && !a.updateRunning {
+				// Packages are updated one by one (so the run can be paused),
+				// which also leaves pinned ones alone
+				unpinned := pkg.Unpinned(a.updateResults)
+				if len(unpinned) == 0 {
+					a.updateStatus = "All outdated packages are pinned"
+					return a, nil
+				}
 				a.clearInstallLogs()
-				a.updateStatus = "Updating all packages..."
-				return a, checkSudoAndUpdateCmd(nil, true)
+				a.updateStatus = fmt.Sprintf("Updating %d package(s)...", len(unpinned))
+				return a, checkSudoAndUpdateCmd(unpinned, true)
+			}
+		case "s": // Cycle sort order (persisted)
+			if len(a.updateResults) > 0 {
+				a.updateSort = nextUpdateSort(a.updateSort)
+				a.applyUpdateSort()
+				a.updateStatus = "Sorted by " + updateSortLabel(a.updateSort)
+				return a, saveUpdateSortCmd(a.updateSort)
 			}
 		case "r": // Refresh updates
 			a.updateCheckDone = false
 			a.updateChecking = true
 			a.updateResults = nil
+			a.updateOriginal = nil
 			a.updateError = nil
 			a.updateStatus = ""
-			a.updateSelected = make(map[int]bool)
+			a.updateSelected = make(map[string]bool)
+			a.updateQueue = nil // Drops a paused update all
+			a.updateQueueResults = nil
 			a.clearInstallLogs()
 			return a, checkUpdatesCmd()
 		case "c", "C": // Clear logs
@@ -215,15 +271,42 @@ func (a *App) handleManagementKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if a.backupRunning {
 			return a, nil
 		}
+		// Label prompt for a new backup
+		if a.backupNaming {
+			switch key {
+			case "enter":
+				a.backupNaming = false
+				a.backupRunning = true
+				a.backupStatus = "Creating backup..."
+				return a, createBackupCmd(strings.TrimSpace(a.backupNameInput))
+			case "esc":
+				a.backupNaming = false
+				a.backupStatus = ""
+			case "backspace":
+				if r := []rune(a.backupNameInput); len(r) > 0 {
+					a.backupNameInput = string(r[:len(r)-1])
+				}
+			default:
+				if msg.Type == tea.KeyRunes && !msg.Alt {
+					a.backupNameInput += string(msg.Runes)
+				} else if msg.Type == tea.KeySpace {
+					a.backupNameInput += " "
+				}
+			}
+			return a, nil
+		}
 		// Handle confirmation mode
 		if a.backupConfirmMode {
+			if a.backupConfirmType == "restore" && a.handleRestorePreviewKey(key) {
+				return a, nil
+			}
 			switch key {
 			case "y", "Y":
 				if len(a.backups) > 0 && a.backupIndex < len(a.backups) {
 					a.backupRunning = true
 					backup := a.backups[a.backupIndex]
 					if a.backupConfirmType == "restore" {
-						return a, restoreBackupCmd(backup)
+						return a, restoreBackupCmd(backup.Name, a.backupPlan)
 					} else if a.backupConfirmType == "delete" {
 						return a, deleteBackupCmd(backup)
 					}
@@ -231,6 +314,8 @@ func (a *App) handleManagementKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				a.backupConfirmMode = false
 			case "n", "N", "esc":
 				a.backupConfirmMode = false
+				a.backupPlan = nil
+				a.backupDiffView = false
 				a.backupStatus = ""
 			}
 			return a, nil
@@ -250,9 +335,9 @@ func (a *App) handleManagementKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		case "enter": // Restore selected backup
 			if len(a.backups) > 0 && a.backupIndex < len(a.backups) {
-				a.backupConfirmMode = true
-				a.backupConfirmType = "restore"
-				a.backupStatus = fmt.Sprintf("Restore backup '%s'? (y/n)", a.backups[a.backupIndex].Name)
+				a.backupRunning = true
+				a.backupStatus = "Comparing backup with current files..."
+				return a, planRestoreCmd(a.backups[a.backupIndex])
 			}
 		case "d", "D": // Delete selected backup
 			if len(a.backups) > 0 && a.backupIndex < len(a.backups) {
@@ -260,10 +345,15 @@ func (a *App) handleManagementKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				a.backupConfirmType = "delete"
 				a.backupStatus = fmt.Sprintf("Delete backup '%s'? (y/n)", a.backups[a.backupIndex].Name)
 			}
-		case "n", "N": // Create new backup
-			a.backupRunning = true
-			a.backupStatus = "Creating backup..."
-			return a, createBackupCmd()
+		case "o", "O": // Open the selected backup (or the backups folder)
+			path := backup.Dir()
+			if len(a.backups) > 0 && a.backupIndex < len(a.backups) {
+				path = a.backups[a.backupIndex].Path
+			}
+			return a, openBackupCmd(path)
+		case "n", "N": // Create new backup (prompts for an optional label)
+			a.backupNaming = true
+			a.backupNameInput = ""
 		case "r", "R": // Refresh backup list
 			a.backupsLoaded = false
 			a.backupsLoading = true
@@ -107,16 +107,12 @@ func (a *App) handleThemePickerMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	switch m.Button {
 	case tea.MouseButtonWheelUp:
 		if a.themeIndex > 0 {
-			a.themeIndex--
-			a.theme = themes[a.themeIndex].name
-			SetTheme(a.theme)
+			return a, a.scrollThemePreview(a.themeIndex - 1)
 		}
 		return a, nil
 	case tea.MouseButtonWheelDown:
 		if a.themeIndex < len(themes)-1 {
-			a.themeIndex++
-			a.theme = themes[a.themeIndex].name
-			SetTheme(a.theme)
+			return a, a.scrollThemePreview(a.themeIndex + 1)
 		}
 		return a, nil
 	}
@@ -138,9 +134,7 @@ func (a *App) handleThemePickerMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		if m.Y >= listStartY && m.Y < listStartY+len(themes) && m.X >= startX {
 			themeIdx := m.Y - listStartY
 			if themeIdx >= 0 && themeIdx < len(themes) {
-				a.themeIndex = themeIdx
-				a.theme = themes[themeIdx].name
-				SetTheme(a.theme)
+				a.previewTheme(themeIdx)
 				return a, nil
 			}
 		}
@@ -194,10 +188,9 @@ func (a *App) handleDeepDiveMenuMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		return a, nil
 	}
 	if m.Button == tea.MouseButtonWheelDown {
-		items := GetDeepDiveMenuItems()
-		if a.deepDiveMenuIndex < len(items)-1 {
-			a.deepDiveMenuIndex++
-		}
+		// len(items) is the "Continue" option
+		items := a.deepDiveMenuItems()
+		a.deepDiveMenuIndex = min(a.deepDiveMenuIndex+1, len(items))
 		return a, nil
 	}
 
@@ -206,7 +199,7 @@ func (a *App) handleDeepDiveMenuMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	}
 
 	// Menu items are in a centered container
-	items := GetDeepDiveMenuItems()
+	items := a.deepDiveMenuItems()
 	contentHeight := len(items) + 10 // items + headers + padding
 	startY := (a.height - contentHeight) / 2
 	listStartY := startY + 4 // After title and instructions
@@ -234,6 +227,11 @@ func (a *App) handleDeepDiveMenuMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 func (a *App) handleConfigScreenMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	m := tea.MouseEvent(msg)
 
+	// Clicks and drags on a slider track set its value
+	if a.handleSliderMouse(m) {
+		return a, nil
+	}
+
 	// Handle scroll wheel for field navigation
 	if m.Button == tea.MouseButtonWheelUp {
 		if a.configFieldIndex > 0 {
@@ -1,6 +1,12 @@
 package ui
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/tools"
+)
 
 // handleWizardKey handles key events for wizard screens:
 // ScreenAnimation, ScreenWelcome, ScreenThemePicker, ScreenNavPicker,
@@ -46,19 +52,20 @@ func (a *App) handleWizardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		switch key {
 		case "up", "k":
 			if a.themeIndex > 0 {
-				a.themeIndex--
-				a.theme = themes[a.themeIndex].name
-				SetTheme(a.theme) // Apply theme immediately for live preview
+				return a, a.scrollThemePreview(a.themeIndex - 1) // Live preview, throttled
 			}
 		case "down", "j":
 			if a.themeIndex < len(themes)-1 {
-				a.themeIndex++
-				a.theme = themes[a.themeIndex].name
-				SetTheme(a.theme) // Apply theme immediately for live preview
+				return a, a.scrollThemePreview(a.themeIndex + 1) // Live preview, throttled
 			}
 		case "enter":
+			// Apply the final selection even if its preview is still pending
+			a.previewTheme(a.themeIndex)
+			a.appliedTheme = a.themeIndex
 			a.screen = ScreenNavPicker
 		case "esc":
+			// Leaving without confirming reverts the preview
+			a.previewTheme(a.appliedTheme)
 			a.screen = ScreenWelcome
 		}
 
@@ -90,30 +97,117 @@ func (a *App) handleWizardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case "enter":
 			// Only advance if installation is complete
 			if !a.installRunning {
-				a.screen = ScreenSummary
+				a.enterSummary()
 			}
 		}
 
 	case ScreenSummary:
+		if a.summaryNaming {
+			return a, a.handleSummaryNamingKey(msg)
+		}
 		switch key {
+		case "p":
+			a.summaryNaming = true
+			a.summaryNameInput = ""
+			a.summaryStatus = ""
 		case "enter", "q":
 			return a, tea.Quit
+		case "c":
+			if a.summaryZshPath != "" {
+				a.summaryStatus = ""
+				return a, tea.ExecProcess(tools.ChshCommand(a.summaryZshPath), func(err error) tea.Msg {
+					return chshDoneMsg{err: err}
+				})
+			}
+		case "b":
+			if a.summaryBrewPath != "" {
+				brew, shell := a.summaryBrewPath, a.summaryShell
+				return a, func() tea.Msg {
+					return brewShellenvDoneMsg{err: tools.AddBrewShellenv(shell, brew)}
+				}
+			}
+		case "l":
+			if a.summaryLocalBin == tools.LocalBinMissing {
+				shell := a.summaryShell
+				return a, func() tea.Msg {
+					return localBinPathDoneMsg{err: tools.AddLocalBinToPath(shell)}
+				}
+			}
 		}
 
 	case ScreenError:
 		switch key {
 		case "r":
 			// Retry - go back to progress
-			a.screen = ScreenProgress
+			if a.canRetry() {
+				a.screen = ScreenProgress
+			}
 		case "s":
 			// Skip - continue to summary
-			a.screen = ScreenSummary
+			a.enterSummary()
 		case "q":
 			return a, tea.Quit
 		case "esc":
 			a.screen = ScreenFileTree
+		case "up", "k":
+			a.scrollError(1)
+		case "down", "j":
+			a.scrollError(-1)
+		case "pgup", "ctrl+u":
+			a.scrollError(10)
+		case "pgdown", "ctrl+d":
+			a.scrollError(-10)
 		}
 	}
 
 	return a, nil
 }
+
+// handleSummaryNamingKey handles typing the name of an install profile to
+// save the current selection as
+func (a *App) handleSummaryNamingKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		name := strings.TrimSpace(a.summaryNameInput)
+		if err := config.ValidateProfileName(name); err != nil {
+			a.summaryStatus = err.Error()
+			return nil
+		}
+		a.summaryNaming = false
+		a.summaryStatus = "Saving…"
+		return saveInstallProfileCmd(name, a.deepDiveConfig.SelectedTools())
+	case "esc":
+		a.summaryNaming = false
+		a.summaryStatus = ""
+	case "backspace":
+		if r := []rune(a.summaryNameInput); len(r) > 0 {
+			a.summaryNameInput = string(r[:len(r)-1])
+		}
+	default:
+		if msg.Type == tea.KeyRunes && !msg.Alt {
+			a.summaryNameInput += string(msg.Runes)
+		}
+	}
+	return nil
+}
+
+// enterSummary shows the summary screen, checking whether zsh still needs to
+// be made the login shell and Homebrew and ~/.local/bin still need to be put
+// on PATH in the resolved shell's rc file
+func (a *App) enterSummary() {
+	a.screen = ScreenSummary
+	shellSetting := ""
+	if g, err := config.LoadGlobalConfig(); err == nil {
+		shellSetting = g.Shell
+	}
+	a.summaryShell = tools.ResolveShell(shellSetting)
+	// The rc file was written for the resolved shell, so zsh only needs to
+	// become the login shell when that's the one configured
+	a.summaryZshPath = ""
+	if a.summaryShell == tools.ShellZsh {
+		a.summaryZshPath = tools.ZshNotDefault()
+	}
+	a.summaryBrewPath = tools.BrewNotOnPath(a.summaryShell)
+	a.summaryLocalBin = tools.CheckLocalBin(a.summaryShell)
+	a.summaryStatus = ""
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/testutil"
+)
+
+func TestApplySelection(t *testing.T) {
+	cfg := NewDeepDiveConfig()
+	cfg.CLITools = map[string]bool{"lazygit": false, "btop": true}
+	cfg.CLIUtilities = map[string]bool{"bat": false}
+	cfg.GUIApps = map[string]bool{}
+	cfg.Utilities = map[string]bool{"hk": true}
+	cfg.MacApps = map[string]bool{}
+
+	unknown := cfg.ApplySelection([]string{"lazygit", "bat", "rectangle"})
+	if got := strings.Join(cfg.SelectedTools(), ","); got != "bat,lazygit" {
+		t.Errorf("SelectedTools() = %q, want %q", got, "bat,lazygit")
+	}
+	if len(unknown) != 1 || unknown[0] != "rectangle" {
+		t.Errorf("unknown = %v, want [rectangle]", unknown)
+	}
+}
+
+func TestSummarySavesInstallProfile(t *testing.T) {
+	testutil.TempConfigDir(t)
+
+	cfg := NewDeepDiveConfig()
+	cfg.ApplySelection([]string{"bat"})
+	a := &App{screen: ScreenSummary, deepDiveConfig: cfg, width: 100, height: 40}
+
+	a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if !a.summaryNaming {
+		t.Fatal("p should prompt for a profile name")
+	}
+	// q is typed into the name, not treated as quit
+	if _, cmd := a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}); cmd != nil {
+		t.Fatal("q should be typed while naming")
+	}
+	a.handleKey(tea.KeyMsg{Type: tea.KeyBackspace})
+	a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("work")})
+
+	_, cmd := a.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should save the profile")
+	}
+	a.Update(cmd())
+	if !strings.Contains(a.summaryStatus, `"work"`) {
+		t.Errorf("summaryStatus = %q, want confirmation", a.summaryStatus)
+	}
+
+	profile, err := config.LoadInstallProfile("work")
+	if err != nil {
+		t.Fatalf("profile not saved: %v", err)
+	}
+	if strings.Join(profile.Tools, ",") != strings.Join(cfg.SelectedTools(), ",") {
+		t.Errorf("Tools = %v, want %v", profile.Tools, cfg.SelectedTools())
+	}
+}
@@ -11,10 +11,70 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/tekierz/dotfiles/internal/config"
 	"github.com/tekierz/dotfiles/internal/pkg"
+	"github.com/tekierz/dotfiles/internal/runner"
 	"github.com/tekierz/dotfiles/internal/scripts"
 	"github.com/tekierz/dotfiles/internal/tools"
 )
 
+// InstallResult tallies an install run for scripted use: tools installed,
+// tools or configuration steps that failed, and tools skipped (already
+// installed, unknown, or without packages for this platform)
+type InstallResult struct {
+	Installed int
+	Failed    int
+	Skipped   int
+	// SkippedTools says which tools were skipped and why, for the summary
+	SkippedTools []SkippedTool
+}
+
+// SkippedTool is a selected tool the install run didn't install
+type SkippedTool struct {
+	ID     string
+	Reason string // e.g. "already installed", "no macOS package"
+}
+
+// skip records toolID as skipped for reason
+func (r *InstallResult) skip(toolID, reason string) {
+	r.Skipped++
+	r.SkippedTools = append(r.SkippedTools, SkippedTool{ID: toolID, Reason: reason})
+}
+
+// SkippedSummary formats the skipped tools as "foo (already installed), bar
+// (no macOS package)", or "" if nothing was skipped
+func (r InstallResult) SkippedSummary() string {
+	parts := make([]string, 0, len(r.SkippedTools))
+	for _, s := range r.SkippedTools {
+		parts = append(parts, fmt.Sprintf("%s (%s)", s.ID, s.Reason))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// platformLabel names platform for people, e.g. "macOS"
+func platformLabel(platform pkg.Platform) string {
+	switch platform {
+	case pkg.PlatformMacOS:
+		return "macOS"
+	case pkg.PlatformArch:
+		return "Arch"
+	case pkg.PlatformDebian:
+		return "Debian"
+	case pkg.PlatformPi:
+		return "Raspberry Pi"
+	}
+	return string(platform)
+}
+
+// String formats the result as a machine-parseable summary line
+func (r InstallResult) String() string {
+	return fmt.Sprintf("INSTALLED=%d FAILED=%d SKIPPED=%d", r.Installed, r.Failed, r.Skipped)
+}
+
+// InstallResult returns the outcome of the last install run, and false if no
+// install has finished
+func (a *App) InstallResult() (InstallResult, bool) {
+	return a.installResult, a.installComplete
+}
+
 // startInstallation begins the installation process using the Go-based package manager
 func (a *App) startInstallation() tea.Cmd {
 	if a.installRunning {
@@ -27,6 +87,9 @@ func (a *App) startInstallation() tea.Cmd {
 
 	// Save theme and nav style before installation
 	a.saveInstallerConfig()
+	if err := a.saveSnapshotSettings(); err != nil {
+		a.installOutput = append(a.installOutput, fmt.Sprintf("⚠ Couldn't save the snapshot's settings: %v", err))
+	}
 
 	// Collect all selected tools from deep dive config
 	selectedTools := a.collectSelectedTools()
@@ -34,11 +97,11 @@ func (a *App) startInstallation() tea.Cmd {
 	return func() tea.Msg {
 		if len(selectedTools) == 0 {
 			a.installOutput = append(a.installOutput, "No tools selected for installation")
-			return installDoneMsg{err: nil}
+			return installDoneMsg{}
 		}
 
 		// Auto-backup before making changes (if enabled)
-		if err := autoBackupIfEnabled(); err != nil {
+		if err := autoBackupIfEnabled("auto: pre-install"); err != nil {
 			a.installOutput = append(a.installOutput, fmt.Sprintf("⚠ Auto-backup failed: %v", err))
 		} else {
 			globalCfg, _ := config.LoadGlobalConfig()
@@ -50,7 +113,7 @@ func (a *App) startInstallation() tea.Cmd {
 		// Detect package manager
 		mgr := pkg.DetectManager()
 		if mgr == nil {
-			return installDoneMsg{err: fmt.Errorf("no package manager detected")}
+			return installDoneMsg{err: pkg.ErrNoManager, result: InstallResult{Failed: 1}}
 		}
 
 		platform := pkg.DetectPlatform()
@@ -59,6 +122,12 @@ func (a *App) startInstallation() tea.Cmd {
 		a.installOutput = append(a.installOutput, fmt.Sprintf("Installing %d tools using %s...", len(selectedTools), mgr.Name()))
 
 		var lastErr error
+		var result InstallResult
+		var written []string // Tools whose configs this run wrote, for runPostApply
+		fail := func(err error) {
+			lastErr = err
+			result.Failed++
+		}
 		successCount := 0
 		for _, toolID := range selectedTools {
 			a.installStep++
@@ -67,6 +136,7 @@ func (a *App) startInstallation() tea.Cmd {
 			t, ok := reg.Get(toolID)
 			if !ok {
 				a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ Unknown tool: %s", toolID))
+				result.skip(toolID, "unknown tool")
 				continue
 			}
 
@@ -74,16 +144,20 @@ func (a *App) startInstallation() tea.Cmd {
 			if t.IsInstalled() {
 				a.installOutput = append(a.installOutput, fmt.Sprintf("  ✓ %s already installed", toolID))
 				successCount++
+				result.skip(toolID, "already installed")
 				continue
 			}
 
 			// Get packages for this platform
-			pkgs := t.Packages()[platform]
-			if len(pkgs) == 0 {
-				pkgs = t.Packages()["all"]
+			pkgs, err := tools.InstallPackages(t, mgr, platform, "")
+			if err != nil {
+				a.installOutput = append(a.installOutput, fmt.Sprintf("  ✗ %v", err))
+				fail(err)
+				continue
 			}
 			if len(pkgs) == 0 {
 				a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ No packages for %s on this platform", toolID))
+				result.skip(toolID, fmt.Sprintf("no %s package", platformLabel(platform)))
 				continue
 			}
 
@@ -92,12 +166,14 @@ func (a *App) startInstallation() tea.Cmd {
 			cmd, err := mgr.InstallStreaming(ctx, pkgs...)
 			if err != nil {
 				a.installOutput = append(a.installOutput, fmt.Sprintf("  ✗ Failed to start install: %v", err))
-				lastErr = err
+				fail(err)
 				continue
 			}
 
-			// Collect output
+			// Collect output (the full log is kept to classify failures)
+			var toolLog []string
 			for line := range cmd.Output {
+				toolLog = append(toolLog, line)
 				a.installOutput = append(a.installOutput, "  "+line)
 				// Keep last 20 lines for display (using copy to avoid memory leak)
 				const maxOutputLines = 20
@@ -107,12 +183,14 @@ func (a *App) startInstallation() tea.Cmd {
 				}
 			}
 
-			if err := cmd.Wait(); err != nil {
+			if err := pkg.Classify(cmd.Wait(), strings.Join(toolLog, "\n")); err != nil {
 				a.installOutput = append(a.installOutput, fmt.Sprintf("  ✗ Failed to install %s: %v", toolID, err))
-				lastErr = err
+				fail(err)
 			} else {
 				a.installOutput = append(a.installOutput, fmt.Sprintf("  ✓ %s installed successfully", toolID))
+				config.RecordInstall(toolID, t.Version())
 				successCount++
+				result.Installed++
 			}
 		}
 
@@ -127,7 +205,7 @@ func (a *App) startInstallation() tea.Cmd {
 		a.installOutput = append(a.installOutput, "\n▶ Installing dotfiles utilities...")
 		if err := installUtilities(a.deepDiveConfig.Utilities); err != nil {
 			a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ Failed to install utilities: %v", err))
-			lastErr = err
+			fail(err)
 		} else {
 			a.installOutput = append(a.installOutput, "  ✓ Utilities installed to ~/.local/bin")
 		}
@@ -135,23 +213,18 @@ func (a *App) startInstallation() tea.Cmd {
 		// Configure tmux with TPM plugins
 		a.installStep++
 		a.installOutput = append(a.installOutput, "\n▶ Configuring tmux...")
-		tmuxCfg := tools.TmuxConfig{
-			Prefix:           a.deepDiveConfig.TmuxPrefix,
-			SplitBinds:       a.deepDiveConfig.TmuxSplitBinds,
-			StatusBar:        a.deepDiveConfig.TmuxStatusBar,
-			MouseMode:        a.deepDiveConfig.TmuxMouseMode,
-			TPMEnabled:       a.deepDiveConfig.TmuxTPMEnabled,
-			PluginSensible:   a.deepDiveConfig.TmuxPluginSensible,
-			PluginResurrect:  a.deepDiveConfig.TmuxPluginResurrect,
-			PluginContinuum:  a.deepDiveConfig.TmuxPluginContinuum,
-			PluginYank:       a.deepDiveConfig.TmuxPluginYank,
-			ContinuumSaveMin: a.deepDiveConfig.TmuxContinuumSaveMin,
-		}
+		tmuxCfg := a.tmuxToolConfig()
+		tmuxUpToDate := tools.TmuxConfigUpToDate(tmuxCfg, a.theme)
 		if err := tools.SetupTPM(tmuxCfg, a.theme); err != nil {
 			a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ Failed to configure tmux: %v", err))
-			lastErr = err
+			fail(err)
 		} else {
-			a.installOutput = append(a.installOutput, "  ✓ Tmux configured with ~/.tmux.conf")
+			if tmuxUpToDate {
+				a.installOutput = append(a.installOutput, "  ✓ ~/.tmux.conf already up to date")
+			} else {
+				a.installOutput = append(a.installOutput, "  ✓ Tmux configured with ~/.tmux.conf")
+				written = append(written, "tmux")
+			}
 			if tmuxCfg.TPMEnabled {
 				if tools.IsTPMInstalled() {
 					a.installOutput = append(a.installOutput, "  ✓ TPM plugins ready (run prefix+I in tmux to install)")
@@ -160,6 +233,14 @@ func (a *App) startInstallation() tea.Cmd {
 				}
 			}
 		}
+		if tmuxCfg.Session {
+			if err := tools.WriteTmuxSession(); err != nil {
+				a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ Failed to write tmux session script: %v", err))
+				fail(err)
+			} else {
+				a.installOutput = append(a.installOutput, fmt.Sprintf("  ✓ Run %s to open dotfiles in tmux", tools.TmuxSessionPath()))
+			}
+		}
 
 		// Apply Claude Code MCP configuration if claude-code was selected
 		if a.deepDiveConfig.CLITools["claude-code"] || a.deepDiveConfig.Utilities["claude-code"] {
@@ -169,7 +250,7 @@ func (a *App) startInstallation() tea.Cmd {
 			// Use user's MCP selections from deep dive config
 			if err := claudeTool.ApplyConfigWithMCPs(a.deepDiveConfig.ClaudeCodeMCPs); err != nil {
 				a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ Failed to configure Claude MCP: %v", err))
-				lastErr = err
+				fail(err)
 			} else {
 				// Count enabled MCPs for status message
 				enabledCount := 0
@@ -179,31 +260,30 @@ func (a *App) startInstallation() tea.Cmd {
 					}
 				}
 				a.installOutput = append(a.installOutput, fmt.Sprintf("  ✓ Claude Code configured with %d MCP server(s)", enabledCount))
+				written = append(written, "claude-code")
 			}
 		}
 
 		// Configure Ghostty
 		a.installStep++
 		a.installOutput = append(a.installOutput, "\n▶ Configuring Ghostty...")
-		ghosttyCfg := tools.GhosttyConfig{
-			FontSize:        a.deepDiveConfig.GhosttyFontSize,
-			FontFamily:      a.deepDiveConfig.GhosttyFontFamily,
-			Opacity:         a.deepDiveConfig.GhosttyOpacity,
-			BlurRadius:      a.deepDiveConfig.GhosttyBlurRadius,
-			TabBindings:     a.deepDiveConfig.GhosttyTabBindings,
-			ScrollbackLines: a.deepDiveConfig.GhosttyScrollbackLines,
-			CursorStyle:     a.deepDiveConfig.GhosttyCursorStyle,
-		}
+		ghosttyCfg := a.deepDiveConfig.GhosttyToolConfig()
 		if err := tools.WriteGhosttyConfig(ghosttyCfg, a.theme); err != nil {
 			a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ Failed to configure Ghostty: %v", err))
-			lastErr = err
+			fail(err)
 		} else {
 			a.installOutput = append(a.installOutput, "  ✓ Ghostty configured")
+			written = append(written, "ghostty")
 		}
 
-		// Configure Zsh
+		// Configure the shell rc file (zsh, bash or fish)
 		a.installStep++
-		a.installOutput = append(a.installOutput, "\n▶ Configuring Zsh...")
+		shellSetting := ""
+		if g, err := config.LoadGlobalConfig(); err == nil {
+			shellSetting = g.Shell
+		}
+		shell := tools.ResolveShell(shellSetting)
+		a.installOutput = append(a.installOutput, fmt.Sprintf("\n▶ Configuring %s...", shell))
 		zshCfg := tools.ZshConfig{
 			PromptStyle:     a.deepDiveConfig.ZshPromptStyle,
 			Plugins:         a.deepDiveConfig.ZshPlugins,
@@ -213,11 +293,41 @@ func (a *App) startInstallation() tea.Cmd {
 			SyntaxHighlight: a.deepDiveConfig.ZshSyntaxHighlight,
 			Autosuggestions: a.deepDiveConfig.ZshAutosuggestions,
 		}
-		if err := tools.WriteZshConfig(zshCfg, a.theme); err != nil {
-			a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ Failed to configure Zsh: %v", err))
-			lastErr = err
+		if err := tools.WriteShellConfig(shell, zshCfg, a.theme); err != nil {
+			a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ Failed to configure %s: %v", shell, err))
+			fail(err)
 		} else {
-			a.installOutput = append(a.installOutput, "  ✓ Zsh configured with ~/.zshrc")
+			a.installOutput = append(a.installOutput, fmt.Sprintf("  ✓ %s configured with %s", shell, shell.DisplayPath()))
+			if shell == tools.ShellZsh {
+				written = append(written, "zsh")
+			}
+		}
+
+		// Install the runtimes the selected LSP servers need, so Mason can
+		// install the servers on first launch
+		if runtimes := a.deepDiveConfig.selectedLSPRuntimes(); len(runtimes) > 0 {
+			a.installStep++
+			a.installOutput = append(a.installOutput, "\n▶ Installing LSP runtimes...")
+			for _, r := range runtimes {
+				if r.Installed() {
+					a.installOutput = append(a.installOutput, fmt.Sprintf("  ✓ %s already installed", r.Name))
+					result.skip(r.ID, "already installed")
+					continue
+				}
+				pkgs := r.Packages[platform]
+				if len(pkgs) == 0 {
+					a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ No packages for %s on this platform", r.Name))
+					result.skip(r.ID, fmt.Sprintf("no %s package", platformLabel(platform)))
+					continue
+				}
+				if err := mgr.Install(pkgs...); err != nil {
+					a.installOutput = append(a.installOutput, fmt.Sprintf("  ✗ Failed to install %s: %v", r.Name, err))
+					fail(err)
+					continue
+				}
+				a.installOutput = append(a.installOutput, fmt.Sprintf("  ✓ %s installed", r.Name))
+				result.Installed++
+			}
 		}
 
 		// Configure Neovim
@@ -234,27 +344,24 @@ func (a *App) startInstallation() tea.Cmd {
 		}
 		if err := tools.WriteNeovimConfig(neovimCfg, a.theme); err != nil {
 			a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ Failed to configure Neovim: %v", err))
-			lastErr = err
+			fail(err)
 		} else {
 			a.installOutput = append(a.installOutput, fmt.Sprintf("  ✓ Neovim configured (%s)", neovimCfg.ConfigPreset))
+			written = append(written, "neovim")
 		}
 
 		// Configure Git
 		a.installStep++
 		a.installOutput = append(a.installOutput, "\n▶ Configuring Git...")
-		gitCfg := tools.GitConfig{
-			DeltaSideBySide:  a.deepDiveConfig.GitDeltaSideBySide,
-			DefaultBranch:    a.deepDiveConfig.GitDefaultBranch,
-			Aliases:          a.deepDiveConfig.GitAliases,
-			PullRebase:       a.deepDiveConfig.GitPullRebase,
-			SignCommits:      a.deepDiveConfig.GitSignCommits,
-			CredentialHelper: a.deepDiveConfig.GitCredentialHelper,
-		}
-		if err := tools.WriteGitConfig(gitCfg, a.theme); err != nil {
+		gitCfg := a.gitToolConfig()
+		if tools.GitConfigUpToDate(gitCfg, a.theme) {
+			a.installOutput = append(a.installOutput, "  ✓ ~/.gitconfig already up to date")
+		} else if err := tools.WriteGitConfig(gitCfg, a.theme); err != nil {
 			a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ Failed to configure Git: %v", err))
-			lastErr = err
+			fail(err)
 		} else {
 			a.installOutput = append(a.installOutput, "  ✓ Git configured with ~/.gitconfig")
+			written = append(written, "git")
 		}
 
 		// Configure Yazi
@@ -267,9 +374,10 @@ func (a *App) startInstallation() tea.Cmd {
 		}
 		if err := tools.WriteYaziConfig(yaziCfg, a.theme); err != nil {
 			a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ Failed to configure Yazi: %v", err))
-			lastErr = err
+			fail(err)
 		} else {
 			a.installOutput = append(a.installOutput, "  ✓ Yazi configured")
+			written = append(written, "yazi")
 		}
 
 		// Configure FZF
@@ -282,9 +390,10 @@ func (a *App) startInstallation() tea.Cmd {
 		}
 		if err := tools.WriteFzfConfig(fzfCfg, a.theme); err != nil {
 			a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ Failed to configure FZF: %v", err))
-			lastErr = err
+			fail(err)
 		} else {
 			a.installOutput = append(a.installOutput, "  ✓ FZF configured")
+			written = append(written, "fzf")
 		}
 
 		// Configure LazyGit
@@ -297,9 +406,10 @@ func (a *App) startInstallation() tea.Cmd {
 		}
 		if err := tools.WriteLazyGitConfig(lazygitCfg, a.theme); err != nil {
 			a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ Failed to configure LazyGit: %v", err))
-			lastErr = err
+			fail(err)
 		} else {
 			a.installOutput = append(a.installOutput, "  ✓ LazyGit configured")
+			written = append(written, "lazygit")
 		}
 
 		// Configure Btop
@@ -313,9 +423,10 @@ func (a *App) startInstallation() tea.Cmd {
 		}
 		if err := tools.WriteBtopConfig(btopCfg, a.theme); err != nil {
 			a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ Failed to configure Btop: %v", err))
-			lastErr = err
+			fail(err)
 		} else {
 			a.installOutput = append(a.installOutput, "  ✓ Btop configured")
+			written = append(written, "btop")
 		}
 
 		// Configure Glow
@@ -328,9 +439,20 @@ func (a *App) startInstallation() tea.Cmd {
 		}
 		if err := tools.WriteGlowConfig(glowCfg, a.theme); err != nil {
 			a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ Failed to configure Glow: %v", err))
-			lastErr = err
+			fail(err)
 		} else {
 			a.installOutput = append(a.installOutput, "  ✓ Glow configured")
+			written = append(written, "glow")
+		}
+
+		a.runPostApply(written)
+		a.mirrorConfigsToRepo()
+
+		// User-defined follow-up commands, only after a clean install
+		if lastErr == nil {
+			if err := a.runPostInstallHooks(); err != nil {
+				fail(err)
+			}
 		}
 
 		// Build context from last few output lines for error display
@@ -343,10 +465,114 @@ func (a *App) startInstallation() tea.Cmd {
 			context = strings.Join(a.installOutput[start:], "\n")
 		}
 
-		return installDoneMsg{err: lastErr, context: context}
+		return installDoneMsg{err: lastErr, context: context, result: result}
+	}
+}
+
+// runPostApply runs the PostApply commands of the installed tools in
+// written, whose configs this run just wrote, so the new configs take effect
+// without a restart (running tmux sessions reload). Tools whose configs were
+// left alone are skipped. Failures are warnings: the configs are already in
+// place.
+func (a *App) runPostApply(written []string) {
+	reg := tools.GetRegistry()
+	var pending []tools.Tool
+	for _, id := range written {
+		if t, ok := reg.Get(id); ok && len(t.PostApply()) > 0 && t.IsInstalled() {
+			pending = append(pending, t)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	a.installStep++
+	a.installOutput = append(a.installOutput, "\n▶ Applying config changes...")
+	for _, t := range pending {
+		errs := tools.RunPostApply(context.Background(), t)
+		for _, err := range errs {
+			a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ %v", err))
+		}
+		if len(errs) == 0 {
+			a.installOutput = append(a.installOutput, fmt.Sprintf("  ✓ %s updated", t.Name()))
+		}
 	}
 }
 
+// mirrorConfigsToRepo commits the generated config files into
+// GlobalConfig.DotfilesRepo, if one is set. Problems (no repo, uncommitted
+// changes there) are reported but don't fail the install.
+func (a *App) mirrorConfigsToRepo() {
+	cfg, err := config.LoadGlobalConfig()
+	if err != nil || cfg.DotfilesRepo == "" {
+		return
+	}
+
+	a.installStep++
+	a.installOutput = append(a.installOutput, fmt.Sprintf("\n▶ Committing config files to %s...", cfg.DotfilesRepo))
+	result, err := tools.MirrorToRepo(context.Background(), cfg.DotfilesRepo, "install")
+	switch {
+	case err != nil:
+		a.installOutput = append(a.installOutput, fmt.Sprintf("  ⚠ Not committed: %v", err))
+	case result.Commit == "":
+		a.installOutput = append(a.installOutput, "  ✓ Repo already up to date")
+	default:
+		a.installOutput = append(a.installOutput, fmt.Sprintf("  ✓ Committed %d file(s) as %s", len(result.Files), result.Commit))
+	}
+}
+
+// runPostInstallHooks runs GlobalConfig.PostInstallHooks sequentially with
+// output streamed to the install log. All hooks are syntax-checked first;
+// execution stops at the first failure unless PostInstallContinueOnError is set.
+func (a *App) runPostInstallHooks() error {
+	cfg, err := config.LoadGlobalConfig()
+	if err != nil || len(cfg.PostInstallHooks) == 0 {
+		return nil
+	}
+
+	a.installStep++
+	a.installOutput = append(a.installOutput, fmt.Sprintf("\n▶ Running %d post-install hook(s)...", len(cfg.PostInstallHooks)))
+
+	for _, hook := range cfg.PostInstallHooks {
+		if err := runner.ValidateShell(hook); err != nil {
+			a.installOutput = append(a.installOutput, fmt.Sprintf("  ✗ Invalid hook %q: %v", hook, err))
+			return fmt.Errorf("invalid post-install hook %q: %w", hook, err)
+		}
+	}
+
+	var firstErr error
+	for _, hook := range cfg.PostInstallHooks {
+		a.installOutput = append(a.installOutput, "  $ "+hook)
+
+		cmd, err := runner.RunShellStreaming(context.Background(), hook)
+		if err == nil {
+			for line := range cmd.Output {
+				a.installOutput = append(a.installOutput, "    "+line)
+				const maxOutputLines = 20
+				if len(a.installOutput) > maxOutputLines {
+					copy(a.installOutput, a.installOutput[len(a.installOutput)-maxOutputLines:])
+					a.installOutput = a.installOutput[:maxOutputLines]
+				}
+			}
+			err = cmd.Wait()
+		}
+
+		if err != nil {
+			a.installOutput = append(a.installOutput, fmt.Sprintf("  ✗ Hook failed: %v", err))
+			if firstErr == nil {
+				firstErr = fmt.Errorf("post-install hook %q failed: %w", hook, err)
+			}
+			if !cfg.PostInstallContinueOnError {
+				return firstErr
+			}
+			continue
+		}
+		a.installOutput = append(a.installOutput, "  ✓ Hook completed")
+	}
+
+	return firstErr
+}
+
 // installUtilities copies the dotfiles binary and shell utilities to ~/.local/bin
 func installUtilities(utilities map[string]bool) error {
 	home := os.Getenv("HOME")
@@ -516,14 +742,13 @@ func (a *App) streamingInstallToolCmd(toolID string) tea.Cmd {
 
 		mgr := pkg.DetectManager()
 		if mgr == nil {
-			return manageInstallWithLogsMsg{toolID: toolID, err: fmt.Errorf("no package manager detected")}
+			return manageInstallWithLogsMsg{toolID: toolID, err: pkg.ErrNoManager}
 		}
 
 		// Get packages for this platform
-		platform := pkg.DetectPlatform()
-		pkgs := t.Packages()[platform]
-		if len(pkgs) == 0 {
-			pkgs = t.Packages()["all"]
+		pkgs, err := tools.InstallPackages(t, mgr, pkg.DetectPlatform(), "")
+		if err != nil {
+			return manageInstallWithLogsMsg{toolID: toolID, err: err}
 		}
 		if len(pkgs) == 0 {
 			return manageInstallWithLogsMsg{toolID: toolID, err: fmt.Errorf("no packages defined for %s", toolID)}
@@ -543,7 +768,10 @@ func (a *App) streamingInstallToolCmd(toolID string) tea.Cmd {
 		}
 
 		// Wait for completion
-		err = cmd.Wait()
+		err = pkg.Classify(cmd.Wait(), strings.Join(logs, "\n"))
+		if err == nil {
+			config.RecordInstall(toolID, t.Version())
+		}
 		return manageInstallWithLogsMsg{toolID: toolID, logs: logs, err: err}
 	}
 }
@@ -553,7 +781,7 @@ func (a *App) streamingUpdateCmd(packages []pkg.Package) tea.Cmd {
 	return func() tea.Msg {
 		mgr := pkg.DetectManager()
 		if mgr == nil {
-			return updateWithLogsMsg{err: fmt.Errorf("no package manager detected")}
+			return updateWithLogsMsg{err: pkg.ErrNoManager}
 		}
 
 		var pkgNames []string
@@ -573,7 +801,7 @@ func (a *App) streamingUpdateCmd(packages []pkg.Package) tea.Cmd {
 			logs = append(logs, line)
 		}
 
-		err = cmd.Wait()
+		err = pkg.Classify(cmd.Wait(), strings.Join(logs, "\n"))
 
 		// Build results
 		var results []pkg.UpdateResult
@@ -588,31 +816,6 @@ func (a *App) streamingUpdateCmd(packages []pkg.Package) tea.Cmd {
 	}
 }
 
-// streamingUpdateAllCmd returns a command that updates all packages with output collection
-func (a *App) streamingUpdateAllCmd() tea.Cmd {
-	return func() tea.Msg {
-		mgr := pkg.DetectManager()
-		if mgr == nil {
-			return updateWithLogsMsg{err: fmt.Errorf("no package manager detected")}
-		}
-
-		ctx := context.Background()
-		cmd, err := mgr.UpdateAllStreaming(ctx)
-		if err != nil {
-			return updateWithLogsMsg{err: err}
-		}
-
-		// Collect all output
-		var logs []string
-		for line := range cmd.Output {
-			logs = append(logs, line)
-		}
-
-		err = cmd.Wait()
-		return updateWithLogsMsg{logs: logs, err: err}
-	}
-}
-
 // saveInstallerConfig saves theme and nav style during installer flow
 func (a *App) saveInstallerConfig() {
 	g, err := config.LoadGlobalConfig()
@@ -626,3 +829,28 @@ func (a *App) saveInstallerConfig() {
 	// Save synchronously since we're about to start installation
 	_ = config.SaveGlobalConfig(g)
 }
+
+// saveSnapshotSettings saves what an install --from snapshot carries that
+// the wizard doesn't: the Manage settings and the active user's keyboard
+// style. It runs once the install starts, so quitting the wizard leaves the
+// existing settings alone.
+func (a *App) saveSnapshotSettings() error {
+	snap := a.pendingSnapshot
+	if snap == nil {
+		return nil
+	}
+	a.pendingSnapshot = nil
+
+	if len(snap.Manage) > 0 {
+		if err := config.SaveToolConfig("manage", a.manageConfig); err != nil {
+			return err
+		}
+	}
+	if config.IsValidKeyboardStyle(snap.KeyboardStyle) {
+		if user, err := config.GetActiveUser(); err == nil && user != nil && user.KeyboardStyle != snap.KeyboardStyle {
+			user.KeyboardStyle = snap.KeyboardStyle
+			return config.SaveUserProfile(user)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/pkg"
+	"github.com/tekierz/dotfiles/internal/testutil"
+	"github.com/tekierz/dotfiles/internal/tools"
+)
+
+func TestInstallResult(t *testing.T) {
+	a := &App{}
+	if _, ok := a.InstallResult(); ok {
+		t.Error("InstallResult() reported a result before any install")
+	}
+
+	a.Update(installDoneMsg{result: InstallResult{Installed: 8, Failed: 1, Skipped: 2}, err: errors.New("boom")})
+	got, ok := a.InstallResult()
+	if !ok {
+		t.Fatal("InstallResult() reported no result after an install")
+	}
+	if want := "INSTALLED=8 FAILED=1 SKIPPED=2"; got.String() != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+
+	// An error without a tallied failure still counts as failed
+	a.Update(installDoneMsg{err: errors.New("no package manager")})
+	if got, _ := a.InstallResult(); got.Failed != 1 {
+		t.Errorf("Failed = %d, want 1", got.Failed)
+	}
+}
+
+func TestInstallResultSkippedSummary(t *testing.T) {
+	var r InstallResult
+	if got := r.SkippedSummary(); got != "" {
+		t.Errorf("SkippedSummary() = %q, want empty", got)
+	}
+
+	r.skip("bat", "already installed")
+	r.skip("ghostty", "no "+platformLabel(pkg.PlatformDebian)+" package")
+	if r.Skipped != 2 {
+		t.Errorf("Skipped = %d, want 2", r.Skipped)
+	}
+	if want := "bat (already installed), ghostty (no Debian package)"; r.SkippedSummary() != want {
+		t.Errorf("SkippedSummary() = %q, want %q", r.SkippedSummary(), want)
+	}
+
+	a := &App{screen: ScreenSummary, width: 100, height: 40, installResult: r}
+	testutil.RequireContains(t, a.renderSummary(), "Skipped: bat (already installed)", "summary should list skipped tools")
+}
+
+func TestErrorScreenScroll(t *testing.T) {
+	a := &App{width: 80, height: 30}
+	context := strings.Repeat("build step failed\n", 60)
+	a.Update(installDoneMsg{err: errors.New("boom"), context: context})
+	if a.screen != ScreenError {
+		t.Fatalf("screen = %v, want ScreenError", a.screen)
+	}
+
+	_, h := a.errorPanelSize()
+	top := CalculateMaxLogScroll(len(a.errorLines()), h-4)
+	if top == 0 {
+		t.Fatal("expected the error to overflow its panel")
+	}
+	if a.errorScroll != top {
+		t.Errorf("errorScroll = %d, want %d (start of the error)", a.errorScroll, top)
+	}
+
+	a.handleKey(tea.KeyMsg{Type: tea.KeyPgDown})
+	if a.errorScroll != top-10 {
+		t.Errorf("after pgdown errorScroll = %d, want %d", a.errorScroll, top-10)
+	}
+	for range 20 {
+		a.handleKey(tea.KeyMsg{Type: tea.KeyPgDown})
+	}
+	if a.errorScroll != 0 {
+		t.Errorf("errorScroll = %d, want 0 at the end", a.errorScroll)
+	}
+	a.handleKey(tea.KeyMsg{Type: tea.KeyPgUp})
+	if a.errorScroll != 10 {
+		t.Errorf("after pgup errorScroll = %d, want 10", a.errorScroll)
+	}
+}
+
+func TestCheckSudoAndUpdateCmd(t *testing.T) {
+	defer pkg.SetManager(nil)()
+	if msg, ok := checkSudoAndUpdateCmd(nil, true)().(updateRunDoneMsg); !ok || !errors.Is(msg.err, pkg.ErrNoManager) {
+		t.Errorf("with no manager got %#v, want ErrNoManager", msg)
+	}
+
+	mock := pkg.NewMockPackageManager()
+	defer pkg.SetManager(mock)()
+	if msg, ok := checkSudoAndUpdateCmd(nil, true)().(updateStartMsg); !ok || !msg.all {
+		t.Errorf("without sudo got %#v, want updateStartMsg{all: true}", msg)
+	}
+}
+
+func TestSummaryAddsLocalBinToPath(t *testing.T) {
+	home := filepath.Dir(filepath.Dir(testutil.TempConfigDir(t)))
+	t.Setenv("PATH", "/usr/bin:/bin")
+	if err := os.MkdirAll(filepath.Join(home, ".local", "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	g := config.DefaultGlobalConfig()
+	g.Shell = "bash"
+	if err := config.SaveGlobalConfig(g); err != nil {
+		t.Fatal(err)
+	}
+
+	a := &App{width: 120, height: 40}
+	a.enterSummary()
+	if a.summaryLocalBin != tools.LocalBinMissing || a.summaryShell != tools.ShellBash {
+		t.Fatalf("summary: local bin = %v for %s, want missing for bash", a.summaryLocalBin, a.summaryShell)
+	}
+	// The rc file went to bash, so zsh isn't offered as the login shell
+	if a.summaryZshPath != "" {
+		t.Errorf("summary offers chsh to %s for a bash setup", a.summaryZshPath)
+	}
+	if view := a.View(); !strings.Contains(view, "press L") {
+		t.Errorf("summary should offer to fix PATH:\n%s", view)
+	}
+
+	_, cmd := a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if cmd == nil {
+		t.Fatal("l should add ~/.local/bin to PATH")
+	}
+	a.Update(cmd())
+	data, _ := os.ReadFile(filepath.Join(home, ".bashrc"))
+	if !strings.Contains(string(data), tools.LocalBinPathLine(tools.ShellBash)) {
+		t.Errorf(".bashrc = %q, want the PATH line", data)
+	}
+	if !strings.Contains(a.summaryStatus, "source ~/.bashrc") {
+		t.Errorf("status = %q, want a note to source ~/.bashrc", a.summaryStatus)
+	}
+}
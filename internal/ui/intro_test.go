@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/testutil"
+)
+
+func TestSkipIntroSetting(t *testing.T) {
+	testutil.TempConfigDir(t)
+
+	g := config.DefaultGlobalConfig()
+	g.SkipIntro = true
+	g.SkipDurdrawCheck = true
+	if err := config.SaveGlobalConfig(g); err != nil {
+		t.Fatalf("SaveGlobalConfig() failed: %v", err)
+	}
+
+	a := NewApp(false)
+	if a.screen != ScreenWelcome || !a.animationDone {
+		t.Errorf("skip_intro: screen = %v, want ScreenWelcome", a.screen)
+	}
+	a.SetStartScreen(ScreenManage)
+	if a.screen != ScreenManage {
+		t.Errorf("SetStartScreen(ScreenManage) with skip_intro: screen = %v, want ScreenManage", a.screen)
+	}
+	if !a.skipDurdrawCheck {
+		t.Error("skip_durdraw_check should be loaded")
+	}
+}
+
+func TestCompactIntro(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+	}{
+		{"narrow", 16, 30},
+		{"short", 80, 6},
+		{"tiny", 10, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &App{screen: ScreenAnimation, width: tt.width, height: tt.height, animFrame: 5}
+			view := a.renderAnimation()
+			lines := strings.Split(view, "\n")
+			if len(lines) > tt.height {
+				t.Errorf("intro is %d lines, terminal has %d", len(lines), tt.height)
+			}
+			for _, line := range lines {
+				if w := lipgloss.Width(line); w > tt.width {
+					t.Errorf("intro line is %d wide, terminal has %d", w, tt.width)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// keyAction names a rebindable action. Actions are the keys of the
+// "keybindings" setting in global.json, e.g. {"save": "ctrl+s", "quit": "ctrl+q"}.
+type keyAction string
+
+const (
+	actionQuit       keyAction = "quit"
+	actionToggleNav  keyAction = "toggle-nav"
+	actionCycleTheme keyAction = "cycle-theme"
+	actionBack       keyAction = "back"
+	actionForward    keyAction = "forward"
+	actionNextPane   keyAction = "next-pane"
+	actionSave       keyAction = "save"
+	actionInstall    keyAction = "install"
+	actionFavorite   keyAction = "favorite"
+	actionExpandAll  keyAction = "expand-all"
+
+	actionExportScreen keyAction = "export-screen"
+)
+
+// keyScope is where an action's keys are live. Global actions are checked
+// before any screen handler, so their keys conflict with every other action.
+type keyScope string
+
+const (
+	scopeGlobal  keyScope = "global"
+	scopeManage  keyScope = "manage"
+	scopeHotkeys keyScope = "hotkeys"
+
+	// Screens with no rebindable actions of their own. They're listed so a
+	// global action can't take a key one of them handles.
+	scopeMainMenu  keyScope = "main menu"
+	scopeUpdate    keyScope = "update"
+	scopeBackups   keyScope = "backups"
+	scopeUsers     keyScope = "users"
+	scopeFavorites keyScope = "favorites"
+	scopeWizard    keyScope = "wizard"
+	scopeSummary   keyScope = "summary"
+	scopeError     keyScope = "error"
+	scopeDeepDive  keyScope = "deep dive"
+)
+
+// defaultKeybinding is an action's built-in keys
+type defaultKeybinding struct {
+	action keyAction
+	scopes []keyScope
+	keys   []string
+}
+
+// defaultKeybindings lists every rebindable action with its default keys
+var defaultKeybindings = []defaultKeybinding{
+	{actionQuit, []keyScope{scopeGlobal}, []string{"q"}},
+	{actionToggleNav, []keyScope{scopeGlobal}, []string{"ctrl+n"}},
+	{actionCycleTheme, []keyScope{scopeGlobal}, []string{"ctrl+t"}},
+	{actionBack, []keyScope{scopeGlobal}, []string{"alt+left", "ctrl+o"}},
+	{actionForward, []keyScope{scopeGlobal}, []string{"alt+right"}},
+	{actionExportScreen, []keyScope{scopeGlobal}, []string{"ctrl+e"}},
+	{actionNextPane, []keyScope{scopeManage, scopeHotkeys}, []string{"tab"}},
+	{actionSave, []keyScope{scopeManage}, []string{"s", "ctrl+s"}},
+	{actionInstall, []keyScope{scopeManage}, []string{"i"}},
+	{actionFavorite, []keyScope{scopeHotkeys}, []string{"f"}},
+	{actionExpandAll, []keyScope{scopeHotkeys}, []string{"e"}},
+}
+
+// reservedKeys can't be rebound: they always quit, cancel, confirm or move
+var reservedKeys = []string{"ctrl+c", "esc", "enter", "up", "down", "left", "right"}
+
+// screenKeys are the fixed keys each screen handles itself (tab switching,
+// vim-style movement and the single-key commands, including those of its
+// prompts and overlays). An action live on that screen can't take one, since
+// one of the two would never fire; global actions are live on every screen.
+var screenKeys = map[keyScope][]string{
+	scopeManage: {"1", "2", "3", "4", "j", "k", "h", "l", " ", "/", "?", "d", "u", "y", "Y", "p", "o",
+		"n", "m", "J", "c", "C", "pgup", "pgdown", "ctrl+u", "ctrl+d"},
+	scopeHotkeys:  {"1", "2", "3", "4", "j", "k", "h", "l", "u", "F", "a", "ctrl+f"},
+	scopeMainMenu: {"j", "k"},
+	scopeUpdate: {"1", "2", "3", "4", "j", "k", "n", " ", "a", "s", "r", "p", "c", "C",
+		"pgup", "pgdown", "ctrl+u", "ctrl+d"},
+	scopeBackups: {"1", "2", "3", "4", "j", "k", "h", "l", "d", "D", "o", "O", "n", "N", "r", "R",
+		"y", "Y", "K", "v", "tab", "shift+tab", "pgup", "pgdown", "ctrl+u", "ctrl+d"},
+	scopeUsers: {"1", "2", "3", "4", "j", "k", "h", "l", "n", "N", "a", "d", "x", "s", "r", "q",
+		"y", "Y", "tab", "shift+tab"},
+	scopeFavorites: {"j", "k", "g", "G", "home", "end", "f", "d", "u"},
+	scopeWizard:    {"j", "k", "h", "l", "tab"},
+	scopeSummary:   {"p", "q", "c", "b", "l"},
+	scopeError:     {"j", "k", "r", "s", "q", "pgup", "pgdown", "ctrl+u", "ctrl+d"},
+	scopeDeepDive: {"j", "k", "h", "l", " ", "a", "A", "p", "y", "n", "backspace",
+		"g", "G", "home", "end", "Y", "q", "pgup", "pgdown"},
+}
+
+// keymap maps actions to the keys that trigger them
+type keymap map[keyAction][]string
+
+// defaultKeymap returns the built-in bindings
+func defaultKeymap() keymap {
+	k := make(keymap, len(defaultKeybindings))
+	for _, b := range defaultKeybindings {
+		k[b.action] = b.keys
+	}
+	return k
+}
+
+// newKeymap applies user overrides (action → comma-separated keys) to the
+// defaults. Unknown actions, reserved keys, keys a screen handles itself and
+// overrides that would make two actions share a key are dropped (keeping the
+// default) and reported.
+func newKeymap(overrides map[string]string) (keymap, []error) {
+	k := defaultKeymap()
+	var errs []error
+
+	actions := make([]string, 0, len(overrides))
+	for action := range overrides {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	overridden := make(map[keyAction]bool)
+	for _, name := range actions {
+		action := keyAction(name)
+		if _, ok := k[action]; !ok {
+			errs = append(errs, fmt.Errorf("unknown action %q (valid: %s)", name, strings.Join(keyActionNames(), ", ")))
+			continue
+		}
+		keys := parseKeyList(overrides[name])
+		if len(keys) == 0 {
+			errs = append(errs, fmt.Errorf("%s: no keys given", name))
+			continue
+		}
+		if i := slices.IndexFunc(keys, func(key string) bool { return slices.Contains(reservedKeys, key) }); i >= 0 {
+			errs = append(errs, fmt.Errorf("%s: %q is reserved", name, keys[i]))
+			continue
+		}
+		if scope, key, ok := screenKeyConflict(action, keys); ok {
+			errs = append(errs, fmt.Errorf("%s: %q is already used by the %s screen", name, key, scope))
+			continue
+		}
+		k[action] = keys
+		overridden[action] = true
+	}
+
+	// Reverting a conflicting override can expose another conflict with
+	// its default keys, so repeat until the map is clean. Defaults never
+	// conflict with each other, so this ends.
+	for {
+		a, b, key, ok := k.conflict()
+		if !ok {
+			break
+		}
+		errs = append(errs, fmt.Errorf("%s and %s are both bound to %q", a, b, key))
+		for _, action := range []keyAction{a, b} {
+			if overridden[action] {
+				k[action] = defaultKeymap()[action]
+				delete(overridden, action)
+			}
+		}
+	}
+	return k, errs
+}
+
+// conflict returns the first pair of actions sharing a key in an overlapping
+// scope
+func (k keymap) conflict() (keyAction, keyAction, string, bool) {
+	for i, a := range defaultKeybindings {
+		for _, b := range defaultKeybindings[i+1:] {
+			if !scopesOverlap(a.scopes, b.scopes) {
+				continue
+			}
+			for _, key := range k[a.action] {
+				if slices.Contains(k[b.action], key) {
+					return a.action, b.action, key, true
+				}
+			}
+		}
+	}
+	return "", "", "", false
+}
+
+// screenKeyConflict returns the first of keys that a screen where action is
+// live handles itself, and that screen's scope. The action's default keys
+// never conflict: a screen may handle q itself for when quit is rebound.
+func screenKeyConflict(action keyAction, keys []string) (keyScope, string, bool) {
+	i := slices.IndexFunc(defaultKeybindings, func(b defaultKeybinding) bool { return b.action == action })
+	if i < 0 {
+		return "", "", false
+	}
+	for _, scope := range slices.Sorted(maps.Keys(screenKeys)) {
+		if !scopesOverlap(defaultKeybindings[i].scopes, []keyScope{scope}) {
+			continue
+		}
+		for _, key := range keys {
+			if slices.Contains(screenKeys[scope], key) && !slices.Contains(defaultKeybindings[i].keys, key) {
+				return scope, key, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// scopesOverlap reports whether actions in scopes a and b can see the same key
+func scopesOverlap(a, b []keyScope) bool {
+	if slices.Contains(a, scopeGlobal) || slices.Contains(b, scopeGlobal) {
+		return true
+	}
+	return slices.ContainsFunc(a, func(s keyScope) bool { return slices.Contains(b, s) })
+}
+
+// is reports whether key triggers action. A nil keymap uses the defaults.
+func (k keymap) is(key string, action keyAction) bool {
+	return slices.Contains(k.keys(action), key)
+}
+
+// label returns the first key bound to action, for help text
+func (k keymap) label(action keyAction) string {
+	if keys := k.keys(action); len(keys) > 0 {
+		return keys[0]
+	}
+	return ""
+}
+
+// keys returns the keys bound to action
+func (k keymap) keys(action keyAction) []string {
+	if k == nil {
+		for _, b := range defaultKeybindings {
+			if b.action == action {
+				return b.keys
+			}
+		}
+	}
+	return k[action]
+}
+
+// parseKeyList splits "s, ctrl+s" into its keys
+func parseKeyList(s string) []string {
+	var keys []string
+	for _, key := range strings.Split(s, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// keyActionNames lists the rebindable actions in definition order
+func keyActionNames() []string {
+	names := make([]string, len(defaultKeybindings))
+	for i, b := range defaultKeybindings {
+		names[i] = string(b.action)
+	}
+	return names
+}
+
+// ValidateKeybindings reports problems with the "keybindings" setting; the
+// affected actions keep their default keys
+func ValidateKeybindings(bindings map[string]string) []error {
+	_, errs := newKeymap(bindings)
+	return errs
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNewKeymap(t *testing.T) {
+	k, errs := newKeymap(map[string]string{
+		"save":     "ctrl+s, w",
+		"quit":     "ctrl+q",
+		"favorite": "esc",
+		"launch":   "x",
+	})
+	if len(errs) != 2 {
+		t.Fatalf("errs = %v, want the reserved key and the unknown action", errs)
+	}
+	if !k.is("w", actionSave) || k.is("s", actionSave) {
+		t.Errorf("save = %v, want ctrl+s and w only", k[actionSave])
+	}
+	if !k.is("ctrl+q", actionQuit) || k.is("q", actionQuit) {
+		t.Errorf("quit = %v, want ctrl+q", k[actionQuit])
+	}
+	if !k.is("f", actionFavorite) {
+		t.Errorf("favorite = %v, want the default f", k[actionFavorite])
+	}
+}
+
+func TestNewKeymapConflicts(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		wantErr   string
+		check     func(k keymap) bool
+	}{
+		{
+			name:      "global key shadows a screen action",
+			overrides: map[string]string{"quit": "ctrl+s"},
+			wantErr:   "quit and save are both bound to \"ctrl+s\"",
+			check:     func(k keymap) bool { return k.is("q", actionQuit) && k.is("s", actionSave) },
+		},
+		{
+			name:      "same screen",
+			overrides: map[string]string{"install": "s"},
+			wantErr:   "save and install",
+			check:     func(k keymap) bool { return k.is("i", actionInstall) },
+		},
+		{
+			name:      "different screens may share a key",
+			overrides: map[string]string{"favorite": "i"},
+			check:     func(k keymap) bool { return k.is("i", actionFavorite) && k.is("i", actionInstall) },
+		},
+		{
+			name:      "screen key",
+			overrides: map[string]string{"save": "j"},
+			wantErr:   "save: \"j\" is already used by the manage screen",
+			check:     func(k keymap) bool { return k.is("s", actionSave) && !k.is("j", actionSave) },
+		},
+		{
+			name:      "global action takes a screen key",
+			overrides: map[string]string{"cycle-theme": "ctrl+f"},
+			wantErr:   "cycle-theme: \"ctrl+f\" is already used by the hotkeys screen",
+			check:     func(k keymap) bool { return k.is("ctrl+t", actionCycleTheme) },
+		},
+		{
+			name:      "global action takes a key of a screen without actions",
+			overrides: map[string]string{"back": "r"},
+			wantErr:   "back: \"r\" is already used by the backups screen",
+			check:     func(k keymap) bool { return k.is("alt+left", actionBack) && !k.is("r", actionBack) },
+		},
+		{
+			name:      "a default key isn't a screen conflict",
+			overrides: map[string]string{"quit": "q,ctrl+q"},
+			check:     func(k keymap) bool { return k.is("q", actionQuit) && k.is("ctrl+q", actionQuit) },
+		},
+		{
+			name:      "screen keys are per screen",
+			overrides: map[string]string{"favorite": "y"},
+			check:     func(k keymap) bool { return k.is("y", actionFavorite) },
+		},
+		{
+			name:      "swapping keys within a screen",
+			overrides: map[string]string{"save": "i", "install": "s"},
+			check:     func(k keymap) bool { return k.is("i", actionSave) && k.is("s", actionInstall) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, errs := newKeymap(tt.overrides)
+			var got string
+			if len(errs) > 0 {
+				got = errs[0].Error()
+			}
+			if tt.wantErr == "" && len(errs) > 0 || !strings.Contains(got, tt.wantErr) {
+				t.Errorf("errs = %v, want %q", errs, tt.wantErr)
+			}
+			if !tt.check(k) {
+				t.Errorf("keymap = %v", k)
+			}
+		})
+	}
+}
+
+func TestGlobalKeysTypeWhileEnteringText(t *testing.T) {
+	tests := []struct {
+		name  string
+		app   *App
+		typed func(a *App) string
+	}{
+		{
+			name:  "manage search",
+			app:   &App{screen: ScreenManage, manageSearching: true},
+			typed: func(a *App) string { return a.manageSearchQuery },
+		},
+		{
+			name:  "backup label",
+			app:   &App{screen: ScreenBackups, backupsLoaded: true, backupNaming: true},
+			typed: func(a *App) string { return a.backupNameInput },
+		},
+		{
+			name:  "install profile name",
+			app:   &App{screen: ScreenSummary, summaryNaming: true},
+			typed: func(a *App) string { return a.summaryNameInput },
+		},
+		{
+			name:  "hotkeys alias",
+			app:   &App{screen: ScreenHotkeys, hotkeysAddingAlias: true},
+			typed: func(a *App) string { return a.hotkeysAliasName },
+		},
+		{
+			name:  "new user name",
+			app:   &App{screen: ScreenUsers, usersCreating: true},
+			typed: func(a *App) string { return a.usersNewName },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, cmd := tt.app.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+			if cmd != nil {
+				if _, ok := cmd().(tea.QuitMsg); ok {
+					t.Fatal("q should be typed, not quit")
+				}
+			}
+			if got := tt.typed(tt.app); got != "q" {
+				t.Errorf("typed text = %q, want q", got)
+			}
+		})
+	}
+}
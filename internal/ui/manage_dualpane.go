@@ -1,7 +1,9 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
 	"unicode/utf8"
@@ -9,6 +11,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/tekierz/dotfiles/internal/clipboard"
 	"github.com/tekierz/dotfiles/internal/config"
 	"github.com/tekierz/dotfiles/internal/pkg"
 	"github.com/tekierz/dotfiles/internal/runner"
@@ -70,6 +73,13 @@ type manageField struct {
 	min  int
 	max  int
 	step int
+
+	// Optional check for text fields, run as the user types; a non-nil
+	// error blocks the commit and is shown under the editor.
+	validate func(string) error
+
+	// Left out of share codes (personal details such as the git author)
+	private bool
 }
 
 // manageItem is a tool entry in the left pane.
@@ -81,6 +91,7 @@ type manageItem struct {
 	category     tools.Category
 	installed    bool
 	configurable bool
+	manager      string // Package manager that installs it ("" if it can't)
 }
 
 // manageSavedMsg is emitted after a save attempt.
@@ -92,12 +103,47 @@ type manageInstallDoneMsg struct {
 	err    error
 }
 
+// manageCopiedMsg is emitted after copying something to the clipboard.
+type manageCopiedMsg struct {
+	what string // e.g. "path"
+	err  error
+}
+
+// manageToggleCmd applies toggles with side effects: restarting the UI tick
+// when "animations" is re-enabled or pause-on-battery is turned off while
+// paused, and applying or clearing the system accent.
+func (a *App) manageToggleCmd(key string, wasEnabled bool) tea.Cmd {
+	switch key {
+	case "animations":
+		if a.animationsEnabled && !wasEnabled && !a.animationsPaused {
+			return tickUI()
+		}
+	case "pause_on_battery":
+		if !a.pauseAnimationsOnBattery && a.animationsPaused {
+			a.animationsPaused = false
+			if a.animationsEnabled {
+				return tickUI()
+			}
+		}
+	case "follow_accent":
+		if !a.followSystemAccent {
+			SetAccentOverride("")
+			return nil
+		}
+		return systemAccentCmd(true)
+	}
+	return nil
+}
+
 func (a *App) saveManageConfigCmd() tea.Cmd {
 	// Capture by value (pointer is stable) and run file I/O in a command.
 	cfg := a.manageConfig
 	theme := a.theme
 	nav := a.navStyle
 	animationsEnabled := a.animationsEnabled
+	pauseOnBattery := a.pauseAnimationsOnBattery
+	followAccent := a.followSystemAccent
+	hiddenDeepDive := a.hiddenDeepDiveCategories()
 	return func() tea.Msg {
 		if err := config.SaveToolConfig("manage", cfg); err != nil {
 			return manageSavedMsg{err: err}
@@ -111,6 +157,9 @@ func (a *App) saveManageConfigCmd() tea.Cmd {
 		g.Theme = theme
 		g.NavStyle = nav
 		g.DisableAnimations = !animationsEnabled
+		g.PauseAnimationsOnBattery = pauseOnBattery
+		g.FollowSystemAccent = followAccent
+		g.HiddenDeepDiveCategories = hiddenDeepDive
 
 		if err := config.SaveGlobalConfig(g); err != nil {
 			return manageSavedMsg{err: err}
@@ -130,19 +179,89 @@ func (a *App) installToolCmd(toolID string) tea.Cmd {
 
 		mgr := pkg.DetectManager()
 		if mgr == nil {
-			return manageInstallDoneMsg{toolID: toolID, err: fmt.Errorf("no package manager detected")}
+			return manageInstallDoneMsg{toolID: toolID, err: pkg.ErrNoManager}
 		}
 
 		return manageInstallDoneMsg{toolID: toolID, err: t.Install(mgr)}
 	}
 }
 
+// copyToClipboardCmd copies text to the system clipboard off the UI thread.
+func copyToClipboardCmd(text, what string) tea.Cmd {
+	return func() tea.Msg {
+		return manageCopiedMsg{what: what, err: clipboard.Copy(text)}
+	}
+}
+
+// manageInstallCommandLine returns the command that installs a tool's
+// packages on this platform, e.g. "brew install tmux"
+func manageInstallCommandLine(toolID string) (string, error) {
+	t, ok := tools.GetRegistry().Get(toolID)
+	if !ok {
+		return "", errors.New("no packages to install for this item")
+	}
+	pkgs := t.Packages()[pkg.DetectPlatform()]
+	if len(pkgs) == 0 {
+		pkgs = t.Packages()["all"]
+	}
+	if len(pkgs) == 0 {
+		return "", fmt.Errorf("no packages for %s on this platform", t.Name())
+	}
+	mgr := pkg.DetectManager()
+	if mgr == nil {
+		return "", pkg.ErrNoManager
+	}
+	return pkg.InstallCommandLine(mgr.Name(), pkgs...), nil
+}
+
+// manageCheckingStatus is shown while install status is still loading, in
+// place of anything that depends on it
+const manageCheckingStatus = "Checking install status…"
+
+// manageInstallItem starts the streaming install for a not-installed item,
+// focusing the settings pane so the install log is visible
+func (a *App) manageInstallItem(item manageItem) tea.Cmd {
+	if item.id == "global" {
+		a.manageStatus = "Select a tool/app to install"
+		return nil
+	}
+	if a.manageInstalling {
+		return nil
+	}
+	if a.installCacheLoading {
+		a.manageStatus = manageCheckingStatus
+		return nil
+	}
+	if item.installed {
+		a.manageStatus = "Already installed"
+		return nil
+	}
+
+	// Clear logs and start install flow (will check sudo first)
+	a.clearInstallLogs()
+	a.manageStatus = ""
+	a.manageInstalling = true
+	a.manageInstallID = item.id
+	a.managePane = managePaneSettings
+	return a.checkSudoAndInstallCmd(item.id)
+}
+
+// saveSkipUpdateCheckCmd persists whether a tool is excluded from update checks
+func saveSkipUpdateCheckCmd(toolID string, skip bool) tea.Cmd {
+	return func() tea.Msg {
+		if err := config.SetSkipUpdateCheck(toolID, skip); err != nil {
+			return manageSavedMsg{err: err}
+		}
+		return nil
+	}
+}
+
 // checkSudoAndInstallCmd checks if sudo is needed and either prompts or starts install
 func (a *App) checkSudoAndInstallCmd(toolID string) tea.Cmd {
 	return func() tea.Msg {
 		mgr := pkg.DetectManager()
 		if mgr == nil {
-			return manageInstallDoneMsg{toolID: toolID, err: fmt.Errorf("no package manager detected")}
+			return manageInstallDoneMsg{toolID: toolID, err: pkg.ErrNoManager}
 		}
 
 		// Check if sudo is needed and not cached
@@ -166,6 +285,10 @@ func (a *App) handleManageKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return a, nil
 
 		case "enter":
+			if err := a.manageEditError(); err != nil {
+				a.manageStatus = "✗ " + err.Error()
+				return a, nil
+			}
 			a.manageCommitEditing()
 			a.manageStatus = "Updated ✓"
 			return a, nil
@@ -294,21 +417,23 @@ func (a *App) handleManageKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		a.manageStartEditing(f)
 	}
 
+	if a.manageSearching {
+		a.handleManageSearchKey(msg, items, layout)
+		return a, nil
+	}
+
+	if a.manageRawJSON && a.handleManageRawKey(key, layout) {
+		return a, nil
+	}
+
 	// Handle tab navigation first (1-4 keys)
 	if handled, cmd := a.handleTabNavigationWithCmd(key); handled {
 		return a, cmd
 	}
 
-	switch key {
-	// Global navigation.
-	case "esc":
-		a.manageStatus = ""
-		a.manageCancelEditing()
-		a.managePane = managePaneTools
-		a.screen = ScreenMainMenu
-		return a, nil
-
-	case "tab":
+	// Rebindable actions (see keymap.go).
+	switch {
+	case a.keys.is(key, actionNextPane):
 		if a.managePane == managePaneTools {
 			a.managePane = managePaneSettings
 		} else {
@@ -317,34 +442,28 @@ func (a *App) handleManageKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, nil
 
 	// Save (persist to config).
-	case "s", "ctrl+s":
+	case a.keys.is(key, actionSave):
 		a.manageStatus = "Saving…"
 		return a, a.saveManageConfigCmd()
 
-	case "i":
-		// Install selected tool/app (settings pane only).
-		if a.managePane != managePaneSettings {
-			return a, nil
-		}
-		item := items[a.manageIndex]
-		if item.id == "global" {
-			a.manageStatus = "Select a tool/app to install"
-			return a, nil
-		}
-		if a.manageInstalling {
-			return a, nil
-		}
-		if item.installed {
-			a.manageStatus = "Already installed"
-			return a, nil
-		}
+	case a.keys.is(key, actionInstall):
+		// Install selected tool/app from either pane.
+		return a, a.manageInstallItem(items[a.manageIndex])
+	}
 
-		// Clear logs and start install flow (will check sudo first)
-		a.clearInstallLogs()
+	switch key {
+	// Global navigation.
+	case "esc":
 		a.manageStatus = ""
-		a.manageInstalling = true
-		a.manageInstallID = item.id
-		return a, a.checkSudoAndInstallCmd(item.id)
+		a.manageCancelEditing()
+		if layout.compact && a.managePane == managePaneSettings {
+			// One pane at a time: back out to the tools list first
+			a.managePane = managePaneTools
+			return a, nil
+		}
+		a.managePane = managePaneTools
+		a.screen = ScreenMainMenu
+		return a, nil
 
 	case "?":
 		// Jump to hotkeys/cheatsheet for the selected tool.
@@ -362,6 +481,107 @@ func (a *App) handleManageKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		a.screen = ScreenHotkeys
 		return a, nil
 
+	case "y":
+		// Copy the selected tool's config file path.
+		path := manageConfigPath(items[a.manageIndex].id)
+		if path == "" {
+			a.manageStatus = "No config file for this tool"
+			return a, nil
+		}
+		return a, copyToClipboardCmd(path, "path")
+
+	case "Y":
+		// Copy the whole generated config, e.g. for a dotfiles repo.
+		content := a.manageGeneratedConfig(items[a.manageIndex].id)
+		if content == "" {
+			a.manageStatus = "No generated config for this tool"
+			return a, nil
+		}
+		return a, copyToClipboardCmd(content, "generated config")
+
+	case "p":
+		// Copy the package-manager command that installs the selected tool.
+		line, err := manageInstallCommandLine(items[a.manageIndex].id)
+		if err != nil {
+			a.manageStatus = err.Error()
+			return a, nil
+		}
+		a.manageStatus = line
+		return a, copyToClipboardCmd(line, "install command: "+line)
+
+	case "o":
+		// Open the selected tool's homepage/docs in the browser.
+		item := items[a.manageIndex]
+		url := manageToolURL(item.id)
+		if url == "" {
+			a.manageStatus = fmt.Sprintf("No docs link for %s", item.name)
+			return a, nil
+		}
+		a.manageStatus = "Opening " + url
+		return a, openToolDocsCmd(url)
+
+	case "d":
+		// Toggle the full description/packages view for the selected tool.
+		a.manageShowDetails = !a.manageShowDetails
+		return a, nil
+
+	case "/":
+		// Search every tool's settings at once.
+		a.manageSearching = true
+		a.manageSearchQuery = ""
+		a.manageSearchCursor = 0
+		a.manageRawJSON = false
+		return a, nil
+
+	case "u":
+		// Include/exclude the selected tool from update checks.
+		item := items[a.manageIndex]
+		if item.id == "global" {
+			return a, nil
+		}
+		if a.manageSkipUpdates == nil {
+			a.manageSkipUpdates = make(map[string]bool)
+		}
+		skip := !a.manageSkipUpdates[item.id]
+		a.manageSkipUpdates[item.id] = skip
+		if skip {
+			a.manageStatus = fmt.Sprintf("Update checks off for %s", item.name)
+		} else {
+			a.manageStatus = fmt.Sprintf("Update checks on for %s", item.name)
+		}
+		return a, saveSkipUpdateCheckCmd(item.id, skip)
+
+	case "J":
+		// Show what is actually persisted in manage.json for the selected item.
+		a.toggleManageRawJSON(items[a.manageIndex].id)
+		return a, nil
+
+	case "m":
+		// List only the tools the detected package manager can install.
+		a.toggleManageManagerOnly(items[a.manageIndex].id)
+		return a, nil
+
+	case "n":
+		// Jump to the next tool that isn't installed yet.
+		if a.installCacheLoading {
+			a.manageStatus = manageCheckingStatus
+			return a, nil
+		}
+		next := nextIndex(len(items), a.manageIndex, func(i int) bool {
+			return items[i].id != "global" && !items[i].installed
+		})
+		if next < 0 {
+			a.manageStatus = "Everything is installed"
+			return a, nil
+		}
+		a.manageIndex = next
+		a.configFieldIndex = 0
+		a.manageFieldsScroll = 0
+		a.managePane = managePaneTools
+		a.manageEnsureToolsVisible(layout, len(items))
+		a.manageStatus = ""
+		return a, nil
+
 	case "c", "C":
 		// Clear install logs (only when not installing)
 		if !a.manageInstalling && len(a.installLogs) > 0 {
@@ -414,7 +634,15 @@ func (a *App) handleManageKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			a.manageEnsureToolsVisible(layout, len(items))
 			return a, nil
 
-		case "right", "l", "enter":
+		case "enter":
+			// Enter installs a not-installed tool directly; otherwise open its settings.
+			if item := items[a.manageIndex]; item.id != "global" && !item.installed && !a.installCacheLoading {
+				return a, a.manageInstallItem(item)
+			}
+			a.managePane = managePaneSettings
+			return a, nil
+
+		case "right", "l":
 			a.managePane = managePaneSettings
 			return a, nil
 		}
@@ -453,10 +681,7 @@ func (a *App) handleManageKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			case manageFieldToggle:
 				wasEnabled := a.animationsEnabled
 				toggleField()
-				if f.key == "animations" && a.animationsEnabled && !wasEnabled {
-					// Restart the UI tick when enabling animations.
-					return a, tickUI()
-				}
+				return a, a.manageToggleCmd(f.key, wasEnabled)
 			case manageFieldOption:
 				adjustField(1)
 			case manageFieldNumber:
@@ -472,9 +697,7 @@ func (a *App) handleManageKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			case manageFieldToggle:
 				wasEnabled := a.animationsEnabled
 				toggleField()
-				if f.key == "animations" && a.animationsEnabled && !wasEnabled {
-					return a, tickUI()
-				}
+				return a, a.manageToggleCmd(f.key, wasEnabled)
 			case manageFieldText:
 				startEditingField()
 			case manageFieldOption:
@@ -527,7 +750,7 @@ func (a *App) handleManageMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 
-		if m.X < layout.rightX { // left side (tools)
+		if layout.onLeft(m.X) { // left side (tools)
 			a.manageToolsScroll = clampInt(a.manageToolsScroll+delta, 0, layout.maxToolsScroll(len(items)))
 		} else { // right side (fields)
 			fields := a.manageFieldsFor(items[a.manageIndex].id)
@@ -551,9 +774,7 @@ func (a *App) handleManageMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 				a.manageIndex = idx
 				a.configFieldIndex = 0
 				a.manageFieldsScroll = 0
-				a.manageEditing = false
-				a.manageEditField = nil
-				a.manageEditValue = ""
+				a.manageCancelEditing()
 				a.manageStatus = ""
 			}
 			a.manageEnsureToolsVisible(layout, len(items))
@@ -589,10 +810,7 @@ func (a *App) handleManageMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 			if f.b != nil {
 				wasEnabled := a.animationsEnabled
 				*f.b = !*f.b
-				if f.key == "animations" && a.animationsEnabled && !wasEnabled {
-					// Restart UI tick if animations were turned back on via mouse.
-					return a, tickUI()
-				}
+				return a, a.manageToggleCmd(f.key, wasEnabled)
 			}
 		case manageFieldOption:
 			// Click on left half cycles backward, right half cycles forward.
@@ -638,6 +856,12 @@ type manageLayout struct {
 
 	gap int
 
+	// compact shows one pane at a time, full width (see manageCompactWidth);
+	// the hidden pane takes no clicks
+	compact     bool
+	leftHidden  bool
+	rightHidden bool
+
 	leftX int
 	leftW int
 
@@ -670,19 +894,31 @@ func (l manageLayout) maxFieldsScroll(fieldsLen int) int {
 }
 
 func (l manageLayout) inLeftList(x, y int) bool {
-	if x < l.leftX || x >= l.leftX+l.leftW {
+	if l.leftHidden || x < l.leftX || x >= l.leftX+l.leftW {
 		return false
 	}
 	return y >= l.leftListY && y < l.leftListY+l.leftListH
 }
 
 func (l manageLayout) inRightList(x, y int) bool {
-	if x < l.rightX || x >= l.rightX+l.rightW {
+	if l.rightHidden || x < l.rightX || x >= l.rightX+l.rightW {
 		return false
 	}
 	return y >= l.rightListY && y < l.rightListY+l.rightListH
 }
 
+// onLeft reports whether x falls on the tools pane
+func (l manageLayout) onLeft(x int) bool {
+	if l.compact {
+		return !l.leftHidden
+	}
+	return x < l.rightX
+}
+
+// manageCompactWidth is the terminal width below which Manage shows the tools
+// and settings panes one at a time (tab switches) instead of side by side
+const manageCompactWidth = 72
+
 func (a *App) manageLayout() manageLayout {
 	// Header/footer heights are kept fixed for consistent mouse mapping.
 	const headerH = 3
@@ -703,6 +939,17 @@ func (a *App) manageLayout() manageLayout {
 		leftW = maxInt(22, a.width-minRight-gap)
 	}
 	rightW := maxInt(0, a.width-leftW-gap)
+	rightX := leftW + gap
+
+	// Narrow terminals: the focused pane gets the whole width. Search and
+	// raw JSON live in the settings pane, so they keep it shown.
+	compact := a.width < manageCompactWidth
+	leftHidden, rightHidden := false, false
+	if compact {
+		gap, leftW, rightW, rightX = 0, a.width, a.width, 0
+		showRight := a.managePane == managePaneSettings || a.manageSearching || a.manageRawJSON
+		leftHidden, rightHidden = showRight, !showRight
+	}
 
 	// Panel styling constants (must match render functions).
 	border := 1
@@ -748,9 +995,13 @@ func (a *App) manageLayout() manageLayout {
 
 		gap: gap,
 
+		compact:     compact,
+		leftHidden:  leftHidden,
+		rightHidden: rightHidden,
+
 		leftX:  0,
 		leftW:  leftW,
-		rightX: leftW + gap,
+		rightX: rightX,
 		rightW: rightW,
 
 		border: border,
@@ -811,6 +1062,10 @@ func (a *App) manageItems() []manageItem {
 	reg := tools.GetRegistry()
 	all := reg.All()
 	platform := pkg.DetectPlatform()
+	mgrName := ""
+	if mgr := pkg.DetectManager(); mgr != nil {
+		mgrName = mgr.Name()
+	}
 
 	// Prefer a stable, human-friendly ordering (category → name).
 	categoryOrder := map[tools.Category]int{
@@ -869,6 +1124,16 @@ func (a *App) manageItems() []manageItem {
 	}
 
 	for _, t := range all {
+		// Tools installed some other way (flatpak, app bundle, by hand) have
+		// no packages the detected manager could install
+		manager := ""
+		if mgrName != "" && toolHasPackagesForPlatform(t, platform) {
+			manager = mgrName
+		}
+		if a.manageManagerOnly && manager == "" {
+			continue
+		}
+
 		icon := t.Icon()
 		if icon == "" {
 			icon = fallbackToolIcon(t.ID(), t.Category())
@@ -882,12 +1147,138 @@ func (a *App) manageItems() []manageItem {
 			category:     t.Category(),
 			installed:    a.manageInstalled[t.ID()],
 			configurable: t.HasConfig(),
+			manager:      manager,
 		})
 	}
 
 	return items
 }
 
+// manageConfigPath resolves the primary config file for a manage item
+// ("" when the tool has no config file).
+func manageConfigPath(itemID string) string {
+	if itemID == "global" {
+		return filepath.Join(config.ConfigDir(), "global.json")
+	}
+	t, ok := tools.GetRegistry().Get(itemID)
+	if !ok {
+		return ""
+	}
+	paths := t.ConfigPaths()
+	if len(paths) == 0 {
+		return ""
+	}
+	return paths[0]
+}
+
+// manageToolURL returns the homepage/docs for a manage item ("" if unknown).
+// Global links to dotfiles' own docs.
+func manageToolURL(itemID string) string {
+	if itemID == "global" {
+		return "https://github.com/tekierz/dotfiles#readme"
+	}
+	if t, ok := tools.GetRegistry().Get(itemID); ok {
+		return t.URL()
+	}
+	return ""
+}
+
+// manageGeneratedConfig returns the config dotfiles would write for a tool
+// with the current theme, or "" if the tool has no generator. tmux and Git
+// are rendered from the same settings an install writes them from.
+func (a *App) manageGeneratedConfig(itemID string) string {
+	switch itemID {
+	case "tmux":
+		return tools.GenerateTmuxConfig(a.tmuxToolConfig(), a.theme)
+	case "git":
+		return tools.GenerateGitConfig(a.gitToolConfig(), a.theme)
+	}
+	t, ok := tools.GetRegistry().Get(itemID)
+	if !ok {
+		return ""
+	}
+	return t.GenerateConfig(a.theme)
+}
+
+// manageDetailLines renders the wrapped, untruncated description, package list
+// and config paths for an item (shown instead of the fields when toggled with d).
+func manageDetailLines(item manageItem, width int) []string {
+	if width <= 0 {
+		return nil
+	}
+	label := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	text := lipgloss.NewStyle().Foreground(ColorText).Width(width)
+
+	var blocks []string
+	desc := item.description
+	if desc == "" {
+		desc = "No description."
+	}
+	blocks = append(blocks, label.Render("Description"), text.Render(desc))
+
+	if item.id != "global" {
+		via := item.manager
+		if via == "" {
+			via = "Not available from " + manageManagerName() + "; installed some other way"
+		}
+		blocks = append(blocks, "", label.Render("Installs via"), text.Render(via))
+	}
+
+	if t, ok := tools.GetRegistry().Get(item.id); ok {
+		platform := pkg.DetectPlatform()
+		pkgs := t.Packages()[platform]
+		if len(pkgs) == 0 {
+			pkgs = t.Packages()["all"]
+		}
+		if len(pkgs) > 0 {
+			blocks = append(blocks, "", label.Render("Packages"), text.Render(strings.Join(pkgs, ", ")))
+		}
+		if url := t.URL(); url != "" {
+			blocks = append(blocks, "", label.Render("Docs"), text.Render(url))
+		}
+		if paths := t.ConfigPaths(); len(paths) > 0 {
+			blocks = append(blocks, "", label.Render("Config"), text.Render(strings.Join(paths, "\n")))
+		}
+	}
+
+	blocks = append(blocks, "", label.Render("d: back to settings"))
+	return strings.Split(strings.Join(blocks, "\n"), "\n")
+}
+
+// manageManagerName names the detected package manager for Manage's
+// "installs via" hints
+func manageManagerName() string {
+	if mgr := pkg.DetectManager(); mgr != nil {
+		return mgr.Name()
+	}
+	return "the package manager"
+}
+
+// toggleManageManagerOnly switches between listing every tool and only those
+// the detected package manager can install, keeping selectedID selected if
+// it is still listed
+func (a *App) toggleManageManagerOnly(selectedID string) {
+	a.manageManagerOnly = !a.manageManagerOnly
+	if a.manageManagerOnly {
+		a.manageStatus = fmt.Sprintf("Showing tools %s can install (m: show all)", manageManagerName())
+	} else {
+		a.manageStatus = "Showing all tools"
+	}
+
+	items := a.manageItems()
+	a.manageIndex = 0
+	for i, it := range items {
+		if it.id == selectedID {
+			a.manageIndex = i
+			break
+		}
+	}
+	a.configFieldIndex = 0
+	a.manageFieldsScroll = 0
+	a.manageToolsScroll = 0
+	a.manageEnsureToolsVisible(a.manageLayout(), len(items))
+}
+
 func toolHasPackagesForPlatform(t tools.Tool, platform pkg.Platform) bool {
 	pkgs := t.Packages()[platform]
 	if len(pkgs) == 0 {
@@ -933,14 +1324,14 @@ func (a *App) manageFieldsFor(itemID string) []manageField {
 
 	switch itemID {
 	case "global":
-		return []manageField{
+		fields := []manageField{
 			{
 				key:         "theme",
 				label:       "Theme",
 				description: "Controls generated tool configs (installer) and visual accents",
 				kind:        manageFieldOption,
 				str:         &a.theme,
-				options:     config.AvailableThemes,
+				options:     themeNames(),
 			},
 			{
 				key:         "nav",
@@ -957,7 +1348,32 @@ func (a *App) manageFieldsFor(itemID string) []manageField {
 				kind:        manageFieldToggle,
 				b:           &a.animationsEnabled,
 			},
+			{
+				key:         "pause_on_battery",
+				label:       "Pause on Battery",
+				description: "Stop animations while running on battery power (re-checked every 30s)",
+				kind:        manageFieldToggle,
+				b:           &a.pauseAnimationsOnBattery,
+			},
+			{
+				key:         "follow_accent",
+				label:       "System Accent",
+				description: "Use the macOS accent color instead of the theme's accent (falls back to the theme)",
+				kind:        manageFieldToggle,
+				b:           &a.followSystemAccent,
+			},
 		}
+		// One toggle per deep dive category available on this platform
+		for _, item := range GetFilteredDeepDiveMenuItems(nil) {
+			fields = append(fields, manageField{
+				key:         "deepdive_" + item.Key,
+				label:       "Deep Dive: " + item.Name,
+				description: "List " + item.Name + " in the installer's deep dive menu",
+				kind:        manageFieldToggle,
+				b:           a.deepDiveShown(item.Key),
+			})
+		}
+		return fields
 
 	case "ghostty":
 		return []manageField{
@@ -981,6 +1397,7 @@ func (a *App) manageFieldsFor(itemID string) []manageField {
 			{key: "history", label: "History Limit", description: "Scrollback lines per pane", kind: manageFieldNumber, n: &cfg.TmuxHistoryLimit, min: 1000, max: 200000, step: 1000, unit: " lines"},
 			{key: "escape", label: "Escape Time", description: "Escape timing for key chords", kind: manageFieldNumber, n: &cfg.TmuxEscapeTime, min: 0, max: 1000, step: 5, unit: "ms"},
 			{key: "resize", label: "Aggressive Resize", description: "Aggressively resize panes on window changes", kind: manageFieldToggle, b: &cfg.TmuxAggressiveResize},
+			{key: "truecolor", label: "Truecolor", description: "Pass 24-bit color through so themes don't look washed out", kind: manageFieldToggle, b: &cfg.TmuxTruecolor},
 			// TPM (Plugin Manager) settings
 			{key: "tpm_enabled", label: "TPM Enabled", description: "Enable Tmux Plugin Manager", kind: manageFieldToggle, b: &cfg.TmuxTPMEnabled},
 			{key: "plugin_sensible", label: "tmux-sensible", description: "Sensible default settings", kind: manageFieldToggle, b: &cfg.TmuxPluginSensible},
@@ -989,6 +1406,7 @@ func (a *App) manageFieldsFor(itemID string) []manageField {
 			{key: "plugin_yank", label: "tmux-yank", description: "Enhanced clipboard support", kind: manageFieldToggle, b: &cfg.TmuxPluginYank},
 			{key: "continuum_save", label: "Auto-save Interval", description: "Minutes between auto-saves", kind: manageFieldNumber, n: &cfg.TmuxContinuumSaveMin, min: 5, max: 60, step: 5, unit: " min"},
 			{key: "continuum_restore", label: "Auto-restore", description: "Restore sessions on tmux start", kind: manageFieldToggle, b: &cfg.TmuxContinuumRestore},
+			{key: "template", label: "Template", description: "Starting point the settings below apply to (full, minimal or vim)", kind: manageFieldOption, str: &cfg.TmuxTemplate, options: tools.TemplateNames(tools.TmuxTemplates)},
 		}
 
 	case "zsh":
@@ -1023,6 +1441,9 @@ func (a *App) manageFieldsFor(itemID string) []manageField {
 			{key: "merge", label: "Merge Tool", description: "Default merge tool", kind: manageFieldOption, str: &cfg.GitMergeTool, options: []string{"vimdiff", "nvimdiff", "meld"}},
 			{key: "creds", label: "Credential Helper", description: "Credential helper backend", kind: manageFieldOption, str: &cfg.GitCredentialHelper, options: []string{"store", "cache", "osxkeychain"}},
 			{key: "sign", label: "Sign Commits", description: "Require signed commits", kind: manageFieldToggle, b: &cfg.GitSignCommits},
+			{key: "name", label: "User Name", description: "Commit author name (user.name)", kind: manageFieldText, str: &cfg.GitUserName, private: true},
+			{key: "email", label: "User Email", description: "Commit author email (user.email)", kind: manageFieldText, str: &cfg.GitUserEmail, validate: validateEmail, private: true},
+			{key: "template", label: "Template", description: "Starting point the settings below apply to (personal, work or minimal)", kind: manageFieldOption, str: &cfg.GitTemplate, options: tools.TemplateNames(tools.GitTemplates)},
 		}
 
 	case "yazi":
@@ -1036,7 +1457,7 @@ func (a *App) manageFieldsFor(itemID string) []manageField {
 
 	case "fzf":
 		return []manageField{
-			{key: "opts", label: "Default Opts", description: "Extra CLI options passed to fzf", kind: manageFieldText, str: &cfg.FzfDefaultOpts},
+			{key: "opts", label: "Default Opts", description: "Extra CLI options passed to fzf", kind: manageFieldText, str: &cfg.FzfDefaultOpts, validate: noShellMeta("fzf options")},
 			{key: "height", label: "Height", description: "Height percentage for fzf UI", kind: manageFieldNumber, n: &cfg.FzfHeight, min: 20, max: 100, step: 5, unit: "%"},
 			{key: "layout", label: "Layout", description: "Layout mode", kind: manageFieldOption, str: &cfg.FzfLayout, options: []string{"reverse", "default", "reverse-list"}},
 			{key: "border", label: "Border Style", description: "Border style for fzf window", kind: manageFieldOption, str: &cfg.FzfBorderStyle, options: []string{"rounded", "sharp", "bold", "none"}},
@@ -1060,17 +1481,17 @@ func (a *App) manageFieldsFor(itemID string) []manageField {
 
 	case "btop":
 		return []manageField{
-			{key: "theme", label: "Theme", description: "btop theme name", kind: manageFieldOption, str: &cfg.BtopTheme, options: []string{"auto", "dracula", "gruvbox", "nord", "tokyo-night"}},
+			{key: "theme", label: "Theme", description: "btop theme (auto follows the global theme)", kind: manageFieldOption, str: &cfg.BtopTheme, options: []string{"auto", "dracula", "gruvbox", "nord", "tokyo-night"}},
 			{key: "rate", label: "Update Rate", description: "Refresh interval", kind: manageFieldNumber, n: &cfg.BtopUpdateMs, min: 250, max: 10000, step: 250, unit: "ms"},
 			{key: "temp", label: "Show Temp", description: "Show CPU temperature", kind: manageFieldToggle, b: &cfg.BtopShowTemp},
 			{key: "scale", label: "Temp Scale", description: "Celsius/Fahrenheit", kind: manageFieldOption, str: &cfg.BtopTempScale, options: []string{"celsius", "fahrenheit"}},
 			{key: "graph", label: "Graph Symbol", description: "Graph rendering symbol set", kind: manageFieldOption, str: &cfg.BtopGraphSymbol, options: []string{"braille", "block", "tty"}},
-			{key: "boxes", label: "Shown Boxes", description: "Which panels to show", kind: manageFieldText, str: &cfg.BtopShownBoxes},
+			{key: "boxes", label: "Shown Boxes", description: "Which panels to show", kind: manageFieldText, str: &cfg.BtopShownBoxes, validate: noShellMeta("shown boxes")},
 		}
 
 	case "glow":
 		return []manageField{
-			{key: "style", label: "Style", description: "Style theme for Glow", kind: manageFieldOption, str: &cfg.GlowStyle, options: []string{"auto", "dark", "light", "notty"}},
+			{key: "style", label: "Style", description: "Style theme for Glow (auto follows the global theme)", kind: manageFieldOption, str: &cfg.GlowStyle, options: []string{"auto", "dark", "light", "notty"}},
 			{key: "pager", label: "Pager", description: "Pager program", kind: manageFieldOption, str: &cfg.GlowPager, options: []string{"less", "more", "none"}},
 			{key: "width", label: "Width", description: "Max render width", kind: manageFieldNumber, n: &cfg.GlowWidth, min: 40, max: 240, step: 5, unit: " chars"},
 			{key: "mouse", label: "Mouse", description: "Enable mouse support in Glow", kind: manageFieldToggle, b: &cfg.GlowMouse},
@@ -1096,20 +1517,6 @@ func (a *App) renderManageDualPane() string {
 		return "Loading..."
 	}
 
-	// Show loading state if cache is being populated
-	if a.installCacheLoading {
-		spinner := AnimatedSpinnerDots(a.uiFrame)
-		loadingStyle := lipgloss.NewStyle().
-			Foreground(ColorCyan).
-			Bold(true)
-		loadingText := loadingStyle.Render(fmt.Sprintf("%s Loading installation status...", spinner))
-
-		return PlaceWithBackground(
-			a.width, a.height,
-			loadingText,
-		)
-	}
-
 	layout := a.manageLayout()
 	items := a.manageItems()
 
@@ -1131,15 +1538,23 @@ func (a *App) renderManageDualPane() string {
 	header := a.renderManageHeader(layout.w)
 	footer := a.renderManageFooter(layout.w, items, fields)
 
-	left := a.renderManageToolsPanel(layout, items)
-	right := a.renderManageSettingsPanel(layout, items, fields)
+	var body string
+	switch {
+	case layout.leftHidden:
+		body = a.renderManageSettingsPanel(layout, items, fields)
+	case layout.rightHidden:
+		body = a.renderManageToolsPanel(layout, items)
+	default:
+		left := a.renderManageToolsPanel(layout, items)
+		right := a.renderManageSettingsPanel(layout, items, fields)
 
-	// Style the gap between panels (no explicit background to respect terminal transparency)
-	gapStyle := lipgloss.NewStyle().
-		Height(layout.bodyH)
-	gap := gapStyle.Render(strings.Repeat(" ", layout.gap))
+		// Style the gap between panels (no explicit background to respect terminal transparency)
+		gapStyle := lipgloss.NewStyle().
+			Height(layout.bodyH)
+		gap := gapStyle.Render(strings.Repeat(" ", layout.gap))
 
-	body := lipgloss.JoinHorizontal(lipgloss.Top, left, gap, right)
+		body = lipgloss.JoinHorizontal(lipgloss.Top, left, gap, right)
+	}
 	view := lipgloss.JoinVertical(lipgloss.Left, header, body, footer)
 
 	// No explicit background to respect terminal transparency
@@ -1150,6 +1565,9 @@ func (a *App) renderManageHeader(width int) string {
 	tabs := RenderTabBar(ScreenManage, width)
 
 	subText := "Dual-pane config editor • Click, scroll, and tweak everything"
+	if width < manageCompactWidth {
+		subText = fmt.Sprintf("Config editor • %s switches between tools and settings", a.keys.label(actionNextPane))
+	}
 	if a.animationsEnabled {
 		subText = AnimatedSpinnerDots(a.uiFrame/2) + " " + subText
 	}
@@ -1163,9 +1581,15 @@ func (a *App) renderManageHeader(width int) string {
 
 func (a *App) renderManageFooter(width int, items []manageItem, fields []manageField) string {
 	// Hint line: short and consistent.
-	hints := lipgloss.NewStyle().Foreground(ColorTextMuted).Render(
-		"Tab switch pane • ↑↓ move • ←→ adjust • Space toggle • Enter edit • I install • ? hotkeys • S save • Esc back • q quit",
-	)
+	// Install actions are left out until install status has loaded
+	installHints := fmt.Sprintf("Enter edit/install • %s install • n next not installed", a.keys.label(actionInstall))
+	if a.installCacheLoading {
+		installHints = "Enter edit"
+	}
+	hints := lipgloss.NewStyle().Foreground(ColorTextMuted).Render(fmt.Sprintf(
+		"%s switch pane • ↑↓ move • ←→ adjust • Space toggle • %s • m %s only (⊘ = not available) • o docs • u update checks • / search • d details • J raw json • p copy install cmd • y copy path • Y copy config • ? hotkeys • %s save • Esc back • %s quit",
+		a.keys.label(actionNextPane), installHints, manageManagerName(), a.keys.label(actionSave), a.keys.label(actionQuit),
+	))
 
 	// Status line: either save feedback, or focused field description.
 	statusText := a.manageStatus
@@ -1224,7 +1648,14 @@ func (a *App) renderManageToolsPanel(layout manageLayout, items []manageItem) st
 			installedCount++
 		}
 	}
-	sub := lipgloss.NewStyle().Foreground(ColorTextMuted).Render(fmt.Sprintf("%d installed • %d tools", installedCount, toolCount))
+	subText := fmt.Sprintf("%d installed • %d tools", installedCount, toolCount)
+	if a.installCacheLoading {
+		subText = fmt.Sprintf("%s %s • %d tools", AnimatedSpinner(a.uiFrame), manageCheckingStatus, toolCount)
+	}
+	if a.manageManagerOnly {
+		subText += " • " + manageManagerName() + " only"
+	}
+	sub := lipgloss.NewStyle().Foreground(ColorTextMuted).Render(subText)
 
 	innerW := maxInt(0, layout.leftW-(layout.border*2)-(layout.padX*2))
 	tagStyle := lipgloss.NewStyle().Foreground(ColorText).Background(ColorOverlay).Padding(0, 1)
@@ -1236,7 +1667,7 @@ func (a *App) renderManageToolsPanel(layout manageLayout, items []manageItem) st
 
 		cursor := "  "
 		nameStyle := lipgloss.NewStyle().Foreground(ColorText)
-		if it.id != "global" && !it.installed {
+		if it.id != "global" && !it.installed && !a.installCacheLoading {
 			nameStyle = lipgloss.NewStyle().Foreground(ColorTextMuted)
 		}
 		if focused {
@@ -1247,6 +1678,8 @@ func (a *App) renderManageToolsPanel(layout manageLayout, items []manageItem) st
 		status := StatusDot("pending")
 		if it.id == "global" {
 			status = lipgloss.NewStyle().Foreground(ColorCyan).Render("●")
+		} else if a.installCacheLoading {
+			status = lipgloss.NewStyle().Foreground(ColorTextMuted).Render("◌")
 		} else if it.installed {
 			status = StatusDot("success")
 		}
@@ -1264,6 +1697,10 @@ func (a *App) renderManageToolsPanel(layout manageLayout, items []manageItem) st
 		tag := tagStyle.Render(cat)
 
 		left := fmt.Sprintf("%s%s %s%s", cursor, status, icon, nameStyle.Render(it.name))
+		// Not installable with the detected package manager.
+		if it.id != "global" && it.manager == "" {
+			left += lipgloss.NewStyle().Foreground(ColorTextMuted).Render(" ⊘")
+		}
 		// Small visual hint that settings exist.
 		if it.id != "global" && it.configurable {
 			left += lipgloss.NewStyle().Foreground(ColorTextMuted).Render("  ")
@@ -1311,6 +1748,13 @@ func (a *App) renderManageSettingsPanel(layout manageLayout, items []manageItem,
 		borderColor = ColorCyan
 	}
 
+	if a.manageSearching {
+		return a.renderManageSearchPanel(layout, items)
+	}
+	if a.manageRawJSON {
+		return a.renderManageRawPanel(layout)
+	}
+
 	// If installing, show log panel instead of settings
 	if a.manageInstalling || len(a.installLogs) > 0 {
 		return a.renderManageLogPanel(layout, items)
@@ -1334,11 +1778,16 @@ func (a *App) renderManageSettingsPanel(layout manageLayout, items []manageItem,
 	title := lipgloss.NewStyle().Foreground(ColorNeonPink).Bold(true).Render("SETTINGS")
 	statusBadge := ""
 	if item.id != "global" {
-		if item.installed {
+		if a.installCacheLoading {
+			statusBadge = " " + RenderBadge("CHECKING…", ColorText, ColorMuted)
+		} else if item.installed {
 			statusBadge = " " + RenderBadge("INSTALLED", ColorBg, ColorGreen)
 		} else {
 			statusBadge = " " + RenderBadge("NOT INSTALLED", ColorText, ColorMuted)
 		}
+		if a.manageSkipUpdates[item.id] {
+			statusBadge += " " + RenderBadge("NO UPDATE CHECK", ColorText, ColorMuted)
+		}
 	}
 	metaName := item.name
 	if item.icon != "" {
@@ -1357,9 +1806,19 @@ func (a *App) renderManageSettingsPanel(layout manageLayout, items []manageItem,
 		// Reserve the first line for the editor, but keep overall height stable.
 		fieldCapacity--
 	}
+	editErr := a.manageEditError()
+	if editErr != nil && fieldCapacity > 0 {
+		// And one more for the validation hint under it.
+		fieldCapacity--
+	}
 
 	var fieldLines []string
-	if len(fields) == 0 {
+	if a.manageShowDetails {
+		fieldLines = manageDetailLines(item, innerW)
+		if len(fieldLines) > fieldCapacity {
+			fieldLines = fieldLines[:fieldCapacity]
+		}
+	} else if len(fields) == 0 {
 		// No explicit fields for this tool. Show a helpful placeholder plus an
 		// install hint.
 		msgStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
@@ -1374,7 +1833,9 @@ func (a *App) renderManageSettingsPanel(layout manageLayout, items []manageItem,
 				fieldLines = append(fieldLines, msgStyle.Render("No configurable settings for this tool."))
 			}
 
-			if !item.installed {
+			if a.installCacheLoading {
+				fieldLines = append(fieldLines, msgStyle.Render(manageCheckingStatus))
+			} else if !item.installed {
 				fieldLines = append(fieldLines, strong.Render("Press I to install"))
 			} else {
 				fieldLines = append(fieldLines, msgStyle.Render("Installed — press S to save global prefs"))
@@ -1406,14 +1867,21 @@ func (a *App) renderManageSettingsPanel(layout manageLayout, items []manageItem,
 
 	var fieldsBlock string
 	if a.manageEditing && a.manageEditField != nil && visibleFieldLines > 0 {
-		fieldsBlock = strings.Join(append([]string{a.renderManageInlineEditor(innerW)}, fieldLines...), "\n")
+		editorLines := []string{a.renderManageInlineEditor(innerW)}
+		if editErr != nil && visibleFieldLines > 1 {
+			hint := lipgloss.NewStyle().Foreground(ColorRed).Render("✗ " + editErr.Error())
+			editorLines = append(editorLines, truncateVisible(hint, innerW))
+		}
+		fieldsBlock = strings.Join(append(editorLines, fieldLines...), "\n")
 	} else {
 		fieldsBlock = strings.Join(fieldLines, "\n")
 	}
 
 	// Exactly 3 header lines before the fields area (matches manageLayout.rightHeaderLines).
 	actionLine := ""
-	if item.id != "global" && !item.installed {
+	if item.id != "global" && a.installCacheLoading {
+		actionLine = lipgloss.NewStyle().Foreground(ColorTextMuted).Render(manageCheckingStatus)
+	} else if item.id != "global" && !item.installed {
 		actionLine = lipgloss.NewStyle().Foreground(ColorYellow).Render("I: install this tool/app")
 	} else if item.id != "global" && len(fields) == 0 {
 		actionLine = lipgloss.NewStyle().Foreground(ColorTextMuted).Render("No editable fields in manager yet")
@@ -1534,6 +2002,7 @@ func (a *App) manageStartEditing(field manageField) {
 	a.manageEditing = true
 	a.manageEditField = field.str
 	a.manageEditFieldKey = field.label
+	a.manageEditValidate = field.validate
 	a.manageEditValue = *field.str
 	a.manageEditCursor = utf8.RuneCountInString(a.manageEditValue)
 }
@@ -1546,12 +2015,23 @@ func (a *App) manageCommitEditing() {
 	a.manageEditing = false
 	a.manageEditField = nil
 	a.manageEditFieldKey = ""
+	a.manageEditValidate = nil
+}
+
+// manageEditError validates the value being edited, or returns nil when the
+// field has no validator
+func (a *App) manageEditError() error {
+	if !a.manageEditing || a.manageEditValidate == nil {
+		return nil
+	}
+	return a.manageEditValidate(a.manageEditValue)
 }
 
 func (a *App) manageCancelEditing() {
 	a.manageEditing = false
 	a.manageEditField = nil
 	a.manageEditFieldKey = ""
+	a.manageEditValidate = nil
 	a.manageEditValue = ""
 	a.manageEditCursor = 0
 }
@@ -0,0 +1,412 @@
+package ui
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/pkg"
+	"github.com/tekierz/dotfiles/internal/testutil"
+)
+
+func TestManageConfigPath(t *testing.T) {
+	configDir := testutil.TempConfigDir(t)
+
+	if got, want := manageConfigPath("global"), filepath.Join(configDir, "global.json"); got != want {
+		t.Errorf("manageConfigPath(global) = %q, want %q", got, want)
+	}
+
+	if got := manageConfigPath("ghostty"); !strings.HasSuffix(got, filepath.Join(".config", "ghostty", "config")) {
+		t.Errorf("manageConfigPath(ghostty) = %q, want ghostty config path", got)
+	}
+
+	if got := manageConfigPath("does-not-exist"); got != "" {
+		t.Errorf("manageConfigPath(unknown) = %q, want empty", got)
+	}
+}
+
+func TestManageOpenDocs(t *testing.T) {
+	t.Setenv("DISPLAY", "")
+	t.Setenv("WAYLAND_DISPLAY", "")
+	a := &App{screen: ScreenManage, width: 120, height: 24, manageInstalled: map[string]bool{}}
+	items := a.manageItems()
+	a.manageIndex = len(items) - 1
+	url := manageToolURL(items[a.manageIndex].id)
+	if url == "" {
+		t.Fatalf("%s has no docs URL", items[a.manageIndex].id)
+	}
+
+	_, cmd := a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if cmd == nil {
+		t.Fatal("o should open the tool's docs")
+	}
+	if runtime.GOOS == "darwin" {
+		return // open(1) doesn't need a display
+	}
+	a.Update(cmd())
+	testutil.RequireContains(t, a.manageStatus, url, "headless should show the docs URL")
+
+	if got := manageToolURL("does-not-exist"); got != "" {
+		t.Errorf("manageToolURL(unknown) = %q, want empty", got)
+	}
+}
+
+func TestManageGeneratedConfig(t *testing.T) {
+	testutil.TempConfigDir(t)
+	a := &App{theme: "nord", deepDiveConfig: NewDeepDiveConfig(), manageConfig: NewManageConfig()}
+
+	testutil.RequireContains(t, a.manageGeneratedConfig("tmux"), "Theme: nord", "tmux config should be generated for the theme")
+
+	// tmux and Git follow the Manage settings, as an install would write them
+	a.manageConfig.TmuxTemplate = "minimal"
+	testutil.RequireContains(t, a.manageGeneratedConfig("tmux"), "# Template: minimal", "tmux config should use the Manage template")
+	a.manageConfig.GitTemplate = "work"
+	a.manageConfig.GitAutoSetupRemote = false
+	git := a.manageGeneratedConfig("git")
+	testutil.RequireContains(t, git, "# Template: work", "git config should use the Manage template")
+	if strings.Contains(git, "autoSetupRemote") {
+		t.Errorf("git config sets autoSetupRemote with the toggle off:\n%s", git)
+	}
+
+	if got := a.manageGeneratedConfig("global"); got != "" {
+		t.Errorf("manageGeneratedConfig(global) = %q, want empty", got)
+	}
+}
+
+func TestManageDetailLinesWrapsDescription(t *testing.T) {
+	item := manageItem{
+		id:          "ghostty",
+		name:        "Ghostty",
+		description: "A very long description that would normally be truncated in the narrow settings meta line",
+	}
+
+	lines := manageDetailLines(item, 20)
+	for _, line := range lines {
+		if w := lipgloss.Width(line); w > 20 {
+			t.Errorf("line %q has width %d, want <= 20", line, w)
+		}
+	}
+
+	joined := strings.Join(lines, " ")
+	testutil.RequireContains(t, joined, "truncated", "full description should be shown")
+	testutil.RequireContains(t, joined, "Config", "config paths should be shown")
+}
+
+func TestManageInstallItem(t *testing.T) {
+	a := &App{managePane: managePaneTools}
+
+	if cmd := a.manageInstallItem(manageItem{id: "bat", installed: true}); cmd != nil {
+		t.Error("installed tool should not start an install")
+	}
+	if a.manageStatus != "Already installed" {
+		t.Errorf("manageStatus = %q, want %q", a.manageStatus, "Already installed")
+	}
+
+	if cmd := a.manageInstallItem(manageItem{id: "bat"}); cmd == nil {
+		t.Fatal("not-installed tool should start an install")
+	}
+	if !a.manageInstalling || a.manageInstallID != "bat" {
+		t.Errorf("manageInstalling = %v, manageInstallID = %q; want install of bat", a.manageInstalling, a.manageInstallID)
+	}
+	if a.managePane != managePaneSettings {
+		t.Error("install should focus the settings pane to show logs")
+	}
+
+	if cmd := a.manageInstallItem(manageItem{id: "eza"}); cmd != nil {
+		t.Error("a second install should not start while one is running")
+	}
+}
+
+func TestSetAccentOverride(t *testing.T) {
+	SetTheme("catppuccin-mocha")
+	t.Cleanup(func() {
+		SetAccentOverride("")
+		SetTheme("catppuccin-mocha")
+	})
+
+	SetAccentOverride("#007AFF")
+	if ColorCyan != "#007AFF" {
+		t.Errorf("ColorCyan = %q, want system accent", ColorCyan)
+	}
+	if ColorBg != CurrentPalette.Bg {
+		t.Errorf("ColorBg = %q, theme background should be kept", ColorBg)
+	}
+
+	// Switching themes keeps following the system accent
+	SetTheme("dracula")
+	if ColorCyan != "#007AFF" {
+		t.Errorf("ColorCyan after SetTheme = %q, want system accent", ColorCyan)
+	}
+
+	SetAccentOverride("")
+	if ColorCyan != CurrentPalette.Accent {
+		t.Errorf("ColorCyan = %q, want theme accent %q after clearing", ColorCyan, CurrentPalette.Accent)
+	}
+}
+
+func TestCtrlNTogglesNavStyle(t *testing.T) {
+	testutil.TempConfigDir(t)
+	a := &App{screen: ScreenHotkeys, navStyle: "emacs", hotkeyCategory: 3, hotkeyCursor: 5}
+
+	_, cmd := a.handleKey(tea.KeyMsg{Type: tea.KeyCtrlN})
+	if a.navStyle != "vim" {
+		t.Fatalf("navStyle = %q, want vim", a.navStyle)
+	}
+	if a.hotkeyCategory != 0 || a.hotkeyCursor != 0 {
+		t.Error("hotkeys cursor should reset when the category set changes")
+	}
+	// The toggle works from any screen, so its feedback can't live in Manage
+	testutil.RequireContains(t, a.withStatusBanner("top\nbottom"), "Navigation: vim", "the new nav style should show on the Hotkeys screen")
+	if cmd == nil {
+		t.Fatal("toggle should persist the nav style")
+	}
+	cmd()
+
+	g, err := config.LoadGlobalConfig()
+	if err != nil {
+		t.Fatalf("LoadGlobalConfig() failed: %v", err)
+	}
+	if g.NavStyle != "vim" {
+		t.Errorf("persisted NavStyle = %q, want vim", g.NavStyle)
+	}
+
+	a.toggleNavStyle()
+	if a.navStyle != "emacs" {
+		t.Errorf("navStyle = %q, want emacs after second toggle", a.navStyle)
+	}
+}
+
+func TestToggleNavStyleKeepsCorruptConfig(t *testing.T) {
+	dir := testutil.TempConfigDir(t)
+	path := testutil.CreateTempFile(t, dir, "global.json", `{"theme": "nord",`)
+	a := &App{screen: ScreenMainMenu, navStyle: "emacs"}
+
+	msg := a.toggleNavStyle()()
+	if got := testutil.MustReadFile(t, path); got != `{"theme": "nord",` {
+		t.Errorf("global.json = %q, want the corrupt file left alone", got)
+	}
+	a.Update(msg)
+	testutil.RequireContains(t, a.bannerStatus, "Navigation not saved", "the failed save should be reported")
+}
+
+func TestCtrlTCyclesTheme(t *testing.T) {
+	defer SetTheme("catppuccin-mocha")
+	last := len(themes) - 1
+	a := &App{screen: ScreenMainMenu, themeIndex: last - 1, theme: themes[last-1].name}
+
+	a.handleKey(tea.KeyMsg{Type: tea.KeyCtrlT})
+	if a.theme != themes[last].name {
+		t.Fatalf("theme = %q, want %q", a.theme, themes[last].name)
+	}
+	if CurrentPalette != ThemePalettes[a.theme] {
+		t.Error("ctrl+t should apply the theme immediately")
+	}
+	testutil.RequireContains(t, a.withStatusBanner("menu"), "Theme: "+a.theme, "the main menu should show the new theme")
+
+	a.handleKey(tea.KeyMsg{Type: tea.KeyCtrlT})
+	if a.themeIndex != 0 || a.theme != themes[0].name {
+		t.Errorf("theme = %q (index %d), want wrap to %q", a.theme, a.themeIndex, themes[0].name)
+	}
+}
+
+func TestThemePickerEscRevertsPreview(t *testing.T) {
+	defer SetTheme("catppuccin-mocha")
+	a := &App{screen: ScreenThemePicker, theme: themes[0].name}
+	a.syncThemeIndex()
+
+	a.handleKey(tea.KeyMsg{Type: tea.KeyDown})
+	if a.theme != themes[1].name || CurrentPalette != ThemePalettes[themes[1].name] {
+		t.Fatalf("theme = %q, want live preview of %q", a.theme, themes[1].name)
+	}
+
+	a.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if a.theme != themes[0].name || CurrentPalette != ThemePalettes[themes[0].name] {
+		t.Errorf("after esc theme = %q, want revert to %q", a.theme, themes[0].name)
+	}
+
+	a.screen = ScreenThemePicker
+	a.handleKey(tea.KeyMsg{Type: tea.KeyDown})
+	a.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if a.appliedTheme != 1 {
+		t.Errorf("appliedTheme = %d after enter, want 1", a.appliedTheme)
+	}
+	if got := renderThemePreview(a.theme, 36); !strings.Contains(got, "MANAGE") {
+		t.Errorf("preview should render a Manage panel, got %q", got)
+	}
+}
+
+func TestThemePickerThrottlesPreview(t *testing.T) {
+	defer SetTheme("catppuccin-mocha")
+	a := &App{screen: ScreenThemePicker, theme: themes[0].name}
+	a.syncThemeIndex()
+
+	// The first move previews at once; quick repeats only move the cursor
+	if _, cmd := a.handleKey(tea.KeyMsg{Type: tea.KeyDown}); cmd != nil {
+		t.Error("first move should preview immediately")
+	}
+	_, first := a.handleKey(tea.KeyMsg{Type: tea.KeyDown})
+	_, last := a.handleKey(tea.KeyMsg{Type: tea.KeyDown})
+	if a.themeIndex != 3 || first == nil || last == nil {
+		t.Fatalf("themeIndex = %d, want 3 with deferred previews", a.themeIndex)
+	}
+	if CurrentPalette != ThemePalettes[themes[1].name] {
+		t.Error("quick repeats should not rebuild styles")
+	}
+
+	// A superseded preview is ignored; the latest applies the cursor's theme
+	a.Update(first())
+	if CurrentPalette != ThemePalettes[themes[1].name] {
+		t.Error("superseded preview should be ignored")
+	}
+	a.Update(last())
+	if CurrentPalette != ThemePalettes[themes[3].name] {
+		t.Errorf("palette after settling should be %s", themes[3].name)
+	}
+
+	// enter applies the selection even with a preview still pending
+	a.handleKey(tea.KeyMsg{Type: tea.KeyUp})
+	a.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if a.appliedTheme != 2 || CurrentPalette != ThemePalettes[themes[2].name] {
+		t.Errorf("enter: appliedTheme = %d, want 2 applied", a.appliedTheme)
+	}
+}
+
+func TestManageWhileCheckingInstallStatus(t *testing.T) {
+	a := &App{screen: ScreenManage, width: 120, height: 30, installCacheLoading: true}
+	items := a.manageItems()
+	a.manageIndex = 1
+
+	view := a.renderManageDualPane()
+	testutil.RequireContains(t, view, "TOOLS", "the tools panel should render while loading")
+	testutil.RequireContains(t, view, manageCheckingStatus, "the tools panel should say install status is loading")
+
+	if cmd := a.manageInstallItem(items[1]); cmd != nil || a.manageInstalling {
+		t.Error("install should wait for install status")
+	}
+	a.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if a.manageInstalling || a.managePane != managePaneSettings {
+		t.Error("enter should open settings, not install, while loading")
+	}
+
+	a.Update(installCacheDoneMsg{installed: map[string]bool{}})
+	if strings.Contains(a.renderManageDualPane(), manageCheckingStatus) {
+		t.Error("loading indicator should clear once install status arrives")
+	}
+}
+
+func TestManageJumpToNextNotInstalled(t *testing.T) {
+	a := &App{screen: ScreenManage, width: 120, height: 24, manageInstalled: map[string]bool{}}
+	items := a.manageItems()
+	if len(items) < 4 {
+		t.Fatal("need at least 3 tools")
+	}
+	for _, it := range items {
+		a.manageInstalled[it.id] = true
+	}
+	first, last := items[1].id, items[len(items)-1].id
+	a.manageInstalled[first] = false
+	a.manageInstalled[last] = false
+	press := func() { a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}) }
+
+	press()
+	if a.manageIndex != 1 {
+		t.Errorf("n: manageIndex = %d, want 1 (%s)", a.manageIndex, first)
+	}
+	press()
+	if a.manageIndex != len(items)-1 {
+		t.Errorf("second n: manageIndex = %d, want %d (%s)", a.manageIndex, len(items)-1, last)
+	}
+	if a.manageToolsScroll == 0 {
+		t.Error("jumping to the last tool should scroll it into view")
+	}
+	press()
+	if a.manageIndex != 1 {
+		t.Errorf("third n: manageIndex = %d, want wrap to 1", a.manageIndex)
+	}
+
+	a.manageInstalled[first] = true
+	a.manageInstalled[last] = true
+	press()
+	if a.manageIndex != 1 || a.manageStatus != "Everything is installed" {
+		t.Errorf("with nothing to install: index %d, status %q", a.manageIndex, a.manageStatus)
+	}
+}
+
+func TestManageFilterByManager(t *testing.T) {
+	defer pkg.SetManager(pkg.NewMockPackageManager())()
+
+	a := &App{screen: ScreenManage, width: 120, height: 24, manageInstalled: map[string]bool{}}
+	// Installed tools stay listed even when the manager can't install them
+	for _, it := range a.manageItems() {
+		a.manageInstalled[it.id] = true
+	}
+	all := a.manageItems()
+	selected := -1
+	for i, it := range all {
+		if it.manager == "mock" {
+			selected = i
+		}
+	}
+	if selected < 0 {
+		t.Skip("no tool has packages for this platform")
+	}
+	a.manageIndex = selected
+	press := func() { a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")}) }
+
+	press()
+	filtered := a.manageItems()
+	for _, it := range filtered[1:] {
+		if it.manager != "mock" {
+			t.Errorf("filtered list includes %s, which mock can't install", it.id)
+		}
+	}
+	if filtered[0].id != "global" {
+		t.Error("Global should stay listed")
+	}
+	if filtered[a.manageIndex].id != all[selected].id {
+		t.Errorf("selection moved to %s, want %s kept", filtered[a.manageIndex].id, all[selected].id)
+	}
+
+	press()
+	if got := len(a.manageItems()); got != len(all) || a.manageStatus != "Showing all tools" {
+		t.Errorf("second m: %d items, status %q; want all %d", got, a.manageStatus, len(all))
+	}
+}
+
+func TestManageCompactLayout(t *testing.T) {
+	a := &App{screen: ScreenManage, width: 60, height: 30, manageInstalled: map[string]bool{}, manageInstalledReady: true}
+
+	layout := a.manageLayout()
+	if !layout.compact || layout.leftW != 60 || layout.rightHidden != true {
+		t.Fatalf("layout at 60 columns = %+v, want compact with only the tools pane", layout)
+	}
+	if !layout.inLeftList(50, layout.leftListY) || layout.inRightList(50, layout.rightListY) {
+		t.Error("only the tools pane should take clicks")
+	}
+	if view := a.View(); !strings.Contains(view, "TOOLS") || strings.Contains(view, "SETTINGS") {
+		t.Errorf("compact view should show only the tools pane:\n%s", view)
+	}
+
+	a.handleKey(tea.KeyMsg{Type: tea.KeyTab})
+	layout = a.manageLayout()
+	if !layout.leftHidden || !layout.inRightList(50, layout.rightListY) {
+		t.Errorf("tab should switch to the settings pane, layout = %+v", layout)
+	}
+	if view := a.View(); strings.Contains(view, "TOOLS") || !strings.Contains(view, "SETTINGS") {
+		t.Errorf("compact view should show only the settings pane:\n%s", view)
+	}
+
+	a.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if a.screen != ScreenManage || a.managePane != managePaneTools {
+		t.Errorf("esc in compact settings: screen %v pane %v, want back to the tools pane", a.screen, a.managePane)
+	}
+
+	a.width = 120
+	if layout := a.manageLayout(); layout.compact || layout.leftHidden || layout.rightHidden {
+		t.Errorf("wide terminals should keep both panes, layout = %+v", layout)
+	}
+}
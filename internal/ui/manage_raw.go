@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/tekierz/dotfiles/internal/config"
+)
+
+// manageRawExtraPrefixes lists field name prefixes that belong to a tool
+// without starting with its ID. GhosstyCursorStyle is misspelled, but it is
+// the key existing manage.json files were saved with.
+var manageRawExtraPrefixes = map[string][]string{
+	"ghostty": {"ghossty"},
+}
+
+// manageRawJSONLines formats the persisted manage.json for display. For a tool
+// item only the fields belonging to that tool (matched by name prefix, e.g.
+// "Tmux*" for tmux) are shown; the global item shows the whole file.
+func manageRawJSONLines(data []byte, toolID string) ([]string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse manage.json: %w", err)
+	}
+
+	if toolID != "" && toolID != "global" {
+		prefixes := append([]string{strings.ToLower(strings.ReplaceAll(toolID, "-", ""))}, manageRawExtraPrefixes[toolID]...)
+		for k := range fields {
+			if !slices.ContainsFunc(prefixes, func(p string) bool { return strings.HasPrefix(strings.ToLower(k), p) }) {
+				delete(fields, k)
+			}
+		}
+		if len(fields) == 0 {
+			return []string{fmt.Sprintf("No persisted fields for %s.", toolID)}, nil
+		}
+	}
+
+	out, err := json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to format manage.json: %w", err)
+	}
+	return strings.Split(string(bytes.TrimSpace(out)), "\n"), nil
+}
+
+// toggleManageRawJSON opens or closes the read-only view of what is persisted
+// in manage.json for the selected item.
+func (a *App) toggleManageRawJSON(itemID string) {
+	if a.manageRawJSON {
+		a.manageRawJSON = false
+		a.manageRawLines = nil
+		return
+	}
+
+	a.manageRawJSON = true
+	a.manageRawScroll = 0
+	a.manageRawTitle = "MANAGE.JSON"
+	if itemID != "global" {
+		a.manageRawTitle += ": " + strings.ToUpper(itemID)
+	}
+
+	path := filepath.Join(config.ToolsDir(), "manage.json")
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		a.manageRawLines = []string{"Nothing persisted yet — press S to save.", "", path}
+		return
+	}
+	if err == nil {
+		a.manageRawLines, err = manageRawJSONLines(data, itemID)
+	}
+	if err != nil {
+		a.manageRawLines = []string{err.Error()}
+	}
+}
+
+// handleManageRawKey scrolls or closes the raw JSON view. It returns false for
+// keys the rest of the Manage screen should handle.
+func (a *App) handleManageRawKey(key string, layout manageLayout) bool {
+	maxScroll := CalculateMaxLogScroll(len(a.manageRawLines), layout.bodyH-4)
+
+	switch key {
+	case "J", "esc":
+		a.manageRawJSON = false
+		a.manageRawLines = nil
+	case "up", "k":
+		a.manageRawScroll = maxInt(0, a.manageRawScroll-1)
+	case "down", "j":
+		a.manageRawScroll = min(maxScroll, a.manageRawScroll+1)
+	case "pgup", "ctrl+u":
+		a.manageRawScroll = maxInt(0, a.manageRawScroll-10)
+	case "pgdown", "ctrl+d":
+		a.manageRawScroll = min(maxScroll, a.manageRawScroll+10)
+	case "home", "g":
+		a.manageRawScroll = 0
+	case "end", "G":
+		a.manageRawScroll = maxScroll
+	default:
+		return false
+	}
+	return true
+}
+
+// renderManageRawPanel renders the raw JSON view in place of the settings pane
+func (a *App) renderManageRawPanel(layout manageLayout) string {
+	// RenderLogPanel scrolls from the bottom; the raw view reads top-down.
+	maxScroll := CalculateMaxLogScroll(len(a.manageRawLines), layout.bodyH-4)
+	scroll := maxScroll - clampInt(a.manageRawScroll, 0, maxScroll)
+
+	title := a.manageRawTitle + "  (read-only • ↑↓ scroll • J/Esc close)"
+	return RenderLogPanel(a.manageRawLines, layout.rightW, layout.bodyH, scroll, title, a.managePane == managePaneSettings)
+}
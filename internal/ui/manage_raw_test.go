@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestManageRawJSONLines(t *testing.T) {
+	data := []byte(`{"TmuxPrefix":"C-a","TmuxMouseMode":true,"ZshAutoCD":true,"LazyGitPaging":"delta","GhosttyFontSize":14,"GhosstyCursorStyle":"bar"}`)
+
+	tests := []struct {
+		toolID string
+		want   []string
+		absent []string
+	}{
+		{"global", []string{"TmuxPrefix", "ZshAutoCD", "LazyGitPaging"}, nil},
+		{"tmux", []string{"TmuxPrefix", "TmuxMouseMode"}, []string{"ZshAutoCD"}},
+		{"lazygit", []string{"LazyGitPaging"}, []string{"TmuxPrefix"}},
+		{"ghostty", []string{"GhosttyFontSize", "GhosstyCursorStyle"}, []string{"TmuxPrefix"}},
+		{"yazi", []string{"No persisted fields for yazi."}, []string{"{"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.toolID, func(t *testing.T) {
+			lines, err := manageRawJSONLines(data, tt.toolID)
+			if err != nil {
+				t.Fatalf("manageRawJSONLines() error = %v", err)
+			}
+			got := strings.Join(lines, "\n")
+			for _, s := range tt.want {
+				if !strings.Contains(got, s) {
+					t.Errorf("manageRawJSONLines(%q) missing %q in:\n%s", tt.toolID, s, got)
+				}
+			}
+			for _, s := range tt.absent {
+				if strings.Contains(got, s) {
+					t.Errorf("manageRawJSONLines(%q) should not contain %q", tt.toolID, s)
+				}
+			}
+		})
+	}
+
+	if _, err := manageRawJSONLines([]byte("{not json"), "global"); err == nil {
+		t.Error("manageRawJSONLines() expected error for invalid JSON")
+	}
+}
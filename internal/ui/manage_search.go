@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// manageSearchResult is one field in the flat, cross-tool settings index
+type manageSearchResult struct {
+	itemIndex  int
+	fieldIndex int
+	toolName   string
+	field      manageField
+}
+
+// label renders the result as "Tool › Field"
+func (r manageSearchResult) label() string {
+	return r.toolName + " › " + r.field.label
+}
+
+// manageSearchIndex lists every field of every item, in Manage order
+func (a *App) manageSearchIndex(items []manageItem) []manageSearchResult {
+	var results []manageSearchResult
+	for i, it := range items {
+		for j, f := range a.manageFieldsFor(it.id) {
+			results = append(results, manageSearchResult{
+				itemIndex:  i,
+				fieldIndex: j,
+				toolName:   it.name,
+				field:      f,
+			})
+		}
+	}
+	return results
+}
+
+// filterManageSearch keeps results matching every word of the query against
+// the tool name, field label and key (case-insensitive), e.g. "tmux history"
+func filterManageSearch(results []manageSearchResult, query string) []manageSearchResult {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return results
+	}
+
+	var out []manageSearchResult
+	for _, r := range results {
+		haystack := strings.ToLower(r.toolName + " " + r.field.label + " " + r.field.key)
+		matched := true
+		for _, w := range words {
+			if !strings.Contains(haystack, w) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// handleManageSearchKey handles input while the settings search is open
+func (a *App) handleManageSearchKey(msg tea.KeyMsg, items []manageItem, layout manageLayout) {
+	results := filterManageSearch(a.manageSearchIndex(items), a.manageSearchQuery)
+
+	switch msg.String() {
+	case "esc":
+		a.manageSearching = false
+	case "enter":
+		if len(results) == 0 {
+			return
+		}
+		r := results[clampInt(a.manageSearchCursor, 0, len(results)-1)]
+		a.manageSearching = false
+		a.manageIndex = r.itemIndex
+		a.configFieldIndex = r.fieldIndex
+		a.managePane = managePaneSettings
+		a.manageEnsureToolsVisible(layout, len(items))
+		a.manageEnsureFieldsVisible(layout, len(a.manageFieldsFor(items[r.itemIndex].id)))
+	case "up", "ctrl+p":
+		if a.manageSearchCursor > 0 {
+			a.manageSearchCursor--
+		}
+	case "down", "ctrl+n":
+		if a.manageSearchCursor < len(results)-1 {
+			a.manageSearchCursor++
+		}
+	case "backspace":
+		if r := []rune(a.manageSearchQuery); len(r) > 0 {
+			a.manageSearchQuery = string(r[:len(r)-1])
+			a.manageSearchCursor = 0
+		}
+	default:
+		if msg.Type == tea.KeyRunes && len(msg.Runes) > 0 && !msg.Alt {
+			a.manageSearchQuery += string(msg.Runes)
+			a.manageSearchCursor = 0
+		} else if msg.Type == tea.KeySpace {
+			a.manageSearchQuery += " "
+		}
+	}
+}
+
+// renderManageSearchPanel renders the search box and matching fields in place
+// of the settings pane
+func (a *App) renderManageSearchPanel(layout manageLayout, items []manageItem) string {
+	panel := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorCyan).
+		Padding(1, 1).
+		Width(maxInt(1, layout.rightW-2)).
+		Height(maxInt(1, layout.bodyH-2))
+
+	innerW := maxInt(0, layout.rightW-(layout.border*2)-(layout.padX*2))
+	muted := lipgloss.NewStyle().Foreground(ColorTextMuted)
+
+	results := filterManageSearch(a.manageSearchIndex(items), a.manageSearchQuery)
+	a.manageSearchCursor = clampInt(a.manageSearchCursor, 0, maxInt(0, len(results)-1))
+
+	title := lipgloss.NewStyle().Foreground(ColorNeonPink).Bold(true).Render("SEARCH SETTINGS")
+	prompt := lipgloss.NewStyle().Foreground(ColorCyan).Bold(true).Render("/ ") +
+		lipgloss.NewStyle().Foreground(ColorTextBright).Render(a.manageSearchQuery) +
+		lipgloss.NewStyle().Foreground(ColorCyan).Render("▌")
+	count := muted.Render(fmt.Sprintf("%d matches • Enter jump • Esc close", len(results)))
+
+	lines := []string{title, truncateVisible(prompt, innerW), truncateVisible(count, innerW), ""}
+
+	visible := maxInt(1, layout.bodyH-4-len(lines))
+	start := 0
+	if a.manageSearchCursor >= visible {
+		start = a.manageSearchCursor - visible + 1
+	}
+	for i := start; i < len(results) && i < start+visible; i++ {
+		r := results[i]
+		cursor := "  "
+		style := lipgloss.NewStyle().Foreground(ColorText)
+		if i == a.manageSearchCursor {
+			cursor = lipgloss.NewStyle().Foreground(ColorCyan).Bold(true).Render("▸ ")
+			style = lipgloss.NewStyle().Foreground(ColorCyan).Bold(true)
+		}
+		lines = append(lines, truncateVisible(cursor+style.Render(r.label()), innerW))
+	}
+	if len(results) == 0 {
+		lines = append(lines, muted.Render("No matching settings"))
+	}
+
+	return panel.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFilterManageSearch(t *testing.T) {
+	a := &App{manageConfig: NewManageConfig()}
+	items := []manageItem{{id: "global", name: "Global"}, {id: "tmux", name: "Tmux"}, {id: "zsh", name: "Zsh"}}
+	index := a.manageSearchIndex(items)
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"tmux history limit", "Tmux › History Limit"},
+		{"HISTORY size", "Zsh › History Size"},
+		{"zsh hist_size", "Zsh › History Size"},
+	}
+
+	for _, tt := range tests {
+		got := filterManageSearch(index, tt.query)
+		if len(got) != 1 || got[0].label() != tt.want {
+			labels := make([]string, len(got))
+			for i, r := range got {
+				labels[i] = r.label()
+			}
+			t.Errorf("filterManageSearch(%q) = %v, want [%s]", tt.query, labels, tt.want)
+		}
+	}
+
+	if got := filterManageSearch(index, ""); len(got) != len(index) {
+		t.Errorf("empty query returned %d results, want all %d", len(got), len(index))
+	}
+	if got := filterManageSearch(index, "no such setting"); len(got) != 0 {
+		t.Errorf("filterManageSearch(no match) = %d results, want 0", len(got))
+	}
+}
+
+func TestManageSearchEnterJumpsToField(t *testing.T) {
+	a := &App{manageConfig: NewManageConfig(), width: 120, height: 40}
+	items := []manageItem{{id: "global", name: "Global"}, {id: "tmux", name: "Tmux"}}
+	a.manageSearching = true
+	a.manageSearchQuery = "history limit"
+
+	a.handleManageSearchKey(tea.KeyMsg{Type: tea.KeyEnter}, items, a.manageLayout())
+
+	if a.manageSearching {
+		t.Error("search should close after jumping")
+	}
+	if a.manageIndex != 1 || a.managePane != managePaneSettings {
+		t.Errorf("manageIndex = %d, pane = %v; want tmux settings", a.manageIndex, a.managePane)
+	}
+	if f := a.manageFieldsFor("tmux")[a.configFieldIndex]; f.key != "history" {
+		t.Errorf("focused field = %q, want history", f.key)
+	}
+}
+
+func TestManageSearchTypingQDoesNotQuit(t *testing.T) {
+	a := &App{screen: ScreenManage, manageConfig: NewManageConfig(), manageSearching: true, width: 120, height: 40}
+
+	_, cmd := a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if cmd != nil {
+		t.Error("typing q in the search box should not quit")
+	}
+}
@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tekierz/dotfiles/internal/suggest"
+)
+
+// set parses raw according to the field's kind and stores it: options must be
+// one of the field's options, numbers are clamped to min/max and toggles
+// accept on/off and yes/no as well as anything strconv.ParseBool does.
+func (f manageField) set(raw string) error {
+	switch f.kind {
+	case manageFieldToggle:
+		if f.b == nil {
+			break
+		}
+		v, err := parseToggle(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %q is not a boolean (use true or false)", f.key, raw)
+		}
+		*f.b = v
+		return nil
+
+	case manageFieldNumber:
+		if f.n == nil {
+			break
+		}
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %q is not a number", f.key, raw)
+		}
+		*f.n = clampInt(v, f.min, f.max)
+		return nil
+
+	case manageFieldOption:
+		if f.str == nil {
+			break
+		}
+		if !slices.Contains(f.options, raw) {
+			return fmt.Errorf("%s: %q is not one of %s", f.key, raw, strings.Join(f.options, ", "))
+		}
+		*f.str = raw
+		return nil
+
+	case manageFieldText:
+		if f.str == nil {
+			break
+		}
+		if f.validate != nil {
+			if err := f.validate(raw); err != nil {
+				return fmt.Errorf("%s: %w", f.key, err)
+			}
+		}
+		*f.str = raw
+		return nil
+	}
+	return fmt.Errorf("%s cannot be set", f.key)
+}
+
+// validateEmail accepts a bare address like ada@example.com, or an empty
+// value to leave it unset
+func validateEmail(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	addr, err := mail.ParseAddress(raw)
+	if err != nil || addr.Address != raw {
+		return errors.New("invalid email")
+	}
+	return nil
+}
+
+// shellMetaChars are rejected in values written into shell startup files,
+// where they could end a quoted string or run a command
+const shellMetaChars = "\"$`\\;|&<>"
+
+// noShellMeta returns a validator rejecting shell metacharacters in what
+func noShellMeta(what string) func(string) error {
+	return func(raw string) error {
+		if i := strings.IndexAny(raw, shellMetaChars); i >= 0 {
+			return fmt.Errorf("no shell metacharacters in %s (found %q)", what, raw[i])
+		}
+		return nil
+	}
+}
+
+// parseToggle parses a boolean, also accepting on/off and yes/no
+func parseToggle(raw string) (bool, error) {
+	switch strings.ToLower(raw) {
+	case "on", "yes":
+		return true, nil
+	case "off", "no":
+		return false, nil
+	}
+	return strconv.ParseBool(raw)
+}
+
+// value returns the field's current value as it would be typed on the command line
+func (f manageField) value() string {
+	switch {
+	case f.kind == manageFieldToggle && f.b != nil:
+		return strconv.FormatBool(*f.b)
+	case f.kind == manageFieldNumber && f.n != nil:
+		return strconv.Itoa(*f.n)
+	case f.str != nil:
+		return *f.str
+	}
+	return ""
+}
+
+// noManageFieldsError reports that tool has no Manage settings, suggesting
+// the tool that was likely meant if it looks like a typo
+func (a *App) noManageFieldsError(tool string) error {
+	var ids []string
+	for _, it := range a.manageItems() {
+		if len(a.manageFieldsFor(it.id)) > 0 {
+			ids = append(ids, it.id)
+		}
+	}
+	if hint := suggest.DidYouMean(tool, ids); hint != "" {
+		return fmt.Errorf("%s has no configurable settings (%s)", tool, hint)
+	}
+	return fmt.Errorf("%s has no configurable settings", tool)
+}
+
+// SetManageFields applies key=value assignments to a tool's Manage settings,
+// validated against the same field definitions the TUI uses, and saves them.
+// Nothing is saved if any assignment is invalid, or if the saved settings
+// couldn't be loaded, since saving would replace them with the defaults. It
+// returns the resulting "key=value" pairs.
+func (a *App) SetManageFields(tool string, assignments []string) ([]string, error) {
+	if a.manageLoadErr != nil {
+		return nil, a.manageLoadErr
+	}
+	fields := a.manageFieldsFor(tool)
+	if len(fields) == 0 {
+		return nil, a.noManageFieldsError(tool)
+	}
+	if len(assignments) == 0 {
+		return nil, fmt.Errorf("no settings given (expected key=value)")
+	}
+
+	byKey := make(map[string]manageField, len(fields))
+	var keys []string
+	for _, f := range fields {
+		byKey[f.key] = f
+		keys = append(keys, f.key)
+	}
+
+	var applied []string
+	for _, assignment := range assignments {
+		key, raw, ok := strings.Cut(assignment, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid setting %q (expected key=value)", assignment)
+		}
+		f, ok := byKey[strings.TrimSpace(key)]
+		if !ok {
+			return nil, fmt.Errorf("unknown setting %q for %s (valid: %s)", key, tool, strings.Join(keys, ", "))
+		}
+		if err := f.set(strings.TrimSpace(raw)); err != nil {
+			return nil, err
+		}
+		applied = append(applied, f.key+"="+f.value())
+	}
+
+	if msg, ok := a.saveManageConfigCmd()().(manageSavedMsg); ok && msg.err != nil {
+		return nil, msg.err
+	}
+	return applied, nil
+}
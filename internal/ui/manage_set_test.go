@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/testutil"
+)
+
+func TestSetManageFields(t *testing.T) {
+	testutil.TempConfigDir(t)
+	app := NewApp(true)
+
+	applied, err := app.SetManageFields("tmux", []string{"prefix=C-a", "mouse=off", "history=999999"})
+	if err != nil {
+		t.Fatalf("SetManageFields() error: %v", err)
+	}
+	want := []string{"prefix=C-a", "mouse=false", "history=200000"}
+	if strings.Join(applied, " ") != strings.Join(want, " ") {
+		t.Errorf("SetManageFields() = %v, want %v", applied, want)
+	}
+
+	saved, err := config.LoadToolConfig("manage", NewManageConfig)
+	if err != nil {
+		t.Fatalf("LoadToolConfig() error: %v", err)
+	}
+	if saved.TmuxPrefix != "C-a" || saved.TmuxMouseMode || saved.TmuxHistoryLimit != 200000 {
+		t.Errorf("saved tmux = %q/%v/%d, want C-a/false/200000", saved.TmuxPrefix, saved.TmuxMouseMode, saved.TmuxHistoryLimit)
+	}
+}
+
+func TestSetManageFieldsErrors(t *testing.T) {
+	testutil.TempConfigDir(t)
+	app := NewApp(true)
+
+	tests := []struct {
+		tool        string
+		assignments []string
+		wantErr     string
+	}{
+		{"tmux", []string{"prefx=C-a"}, "valid: prefix,"},
+		{"tmux", []string{"prefix=C-z"}, "not one of C-a, C-b, C-Space"},
+		{"tmux", []string{"mouse=maybe"}, "not a boolean"},
+		{"tmux", []string{"history=lots"}, "not a number"},
+		{"tmux", []string{"prefix"}, "expected key=value"},
+		{"git", []string{"email=ada at example"}, "invalid email"},
+		{"fzf", []string{"opts=--height 40%; rm -rf ~"}, "no shell metacharacters"},
+		{"tmux", nil, "no settings given"},
+		{"does-not-exist", []string{"a=b"}, "no configurable settings"},
+		{"tmxu", []string{"a=b"}, `did you mean "tmux"?`},
+	}
+
+	for _, tt := range tests {
+		_, err := app.SetManageFields(tt.tool, tt.assignments)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("SetManageFields(%s, %v) error = %v, want %q", tt.tool, tt.assignments, err, tt.wantErr)
+		}
+	}
+
+	// An invalid assignment must not save the valid ones before it.
+	app.SetManageFields("tmux", []string{"prefix=C-Space", "mouse=maybe"})
+	if saved, _ := config.LoadToolConfig("manage", NewManageConfig); saved.TmuxPrefix == "C-Space" {
+		t.Error("SetManageFields() saved settings despite an invalid assignment")
+	}
+}
+
+func TestSetManageFieldsCorruptConfig(t *testing.T) {
+	dir := testutil.TempConfigDir(t)
+	path := testutil.CreateTempFile(t, filepath.Join(dir, "tools"), "manage.json", "{not json")
+
+	app := NewApp(true)
+	_, err := app.SetManageFields("tmux", []string{"prefix=C-a"})
+	if !errors.As(err, new(*config.CorruptError)) {
+		t.Errorf("SetManageFields() error = %v, want a *config.CorruptError", err)
+	}
+	if got := testutil.MustReadFile(t, path); got != "{not json" {
+		t.Errorf("manage.json = %q, want the corrupt file left alone", got)
+	}
+}
+
+func TestManageEditorValidation(t *testing.T) {
+	testutil.TempConfigDir(t)
+	a := NewApp(true)
+
+	var email manageField
+	for _, f := range a.manageFieldsFor("git") {
+		if f.key == "email" {
+			email = f
+		}
+	}
+	*email.str = ""
+	a.manageStartEditing(email)
+
+	typeText := func(s string) {
+		a.handleManageKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+	}
+	typeText("ada")
+	if err := a.manageEditError(); err == nil || err.Error() != "invalid email" {
+		t.Errorf("manageEditError() = %v, want invalid email", err)
+	}
+
+	// Enter is blocked while the value is invalid
+	a.handleManageKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if !a.manageEditing || *email.str != "" {
+		t.Fatalf("invalid value committed: editing=%v value=%q", a.manageEditing, *email.str)
+	}
+
+	typeText("@example.com")
+	a.handleManageKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if a.manageEditing || *email.str != "ada@example.com" {
+		t.Errorf("after fixing: editing=%v value=%q, want committed ada@example.com", a.manageEditing, *email.str)
+	}
+}
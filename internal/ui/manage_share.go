@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// shareCodeVersion is bumped if the share code format changes
+const shareCodeVersion = 1
+
+// maxShareCodeSize bounds a decompressed share code, so a pasted code can't
+// expand without limit
+const maxShareCodeSize = 64 << 10
+
+// shareCode is what a share code encodes: one tool's Manage settings, keyed
+// like "dotfiles config <tool> set"
+type shareCode struct {
+	Version  int               `json:"v"`
+	Tool     string            `json:"tool"`
+	Settings map[string]string `json:"settings"`
+}
+
+// ShareChange is a setting a share code would change
+type ShareChange struct {
+	Key  string
+	From string
+	To   string
+}
+
+// ShareManageFields encodes a tool's current Manage settings as a short code
+// (deflated JSON in URL-safe base64) that ImportShareCode applies elsewhere.
+// Private fields such as the git author are left out.
+func (a *App) ShareManageFields(tool string) (string, error) {
+	fields := a.manageFieldsFor(tool)
+	if len(fields) == 0 {
+		return "", a.noManageFieldsError(tool)
+	}
+
+	sc := shareCode{Version: shareCodeVersion, Tool: tool, Settings: make(map[string]string)}
+	for _, f := range fields {
+		if f.private {
+			continue
+		}
+		sc.Settings[f.key] = f.value()
+	}
+
+	data, err := json.Marshal(sc)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(data); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// decodeShareCode reverses ShareManageFields
+func decodeShareCode(code string) (shareCode, error) {
+	var sc shareCode
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(code))
+	if err != nil {
+		return sc, fmt.Errorf("invalid share code: %w", err)
+	}
+	data, err := io.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(raw)), maxShareCodeSize+1))
+	if err != nil {
+		return sc, fmt.Errorf("invalid share code: %w", err)
+	}
+	if len(data) > maxShareCodeSize {
+		return sc, fmt.Errorf("invalid share code: too large")
+	}
+	if err := json.Unmarshal(data, &sc); err != nil {
+		return sc, fmt.Errorf("invalid share code: %w", err)
+	}
+	if sc.Version != shareCodeVersion {
+		return sc, fmt.Errorf("share code version %d is not supported (expected %d)", sc.Version, shareCodeVersion)
+	}
+	if sc.Tool == "" || len(sc.Settings) == 0 {
+		return sc, fmt.Errorf("share code has no settings")
+	}
+	return sc, nil
+}
+
+// PreviewShareCode decodes a share code and validates every setting against
+// the tool's Manage fields, without changing anything. It returns the tool
+// and the settings that differ from the current ones, sorted by key.
+func (a *App) PreviewShareCode(code string) (string, []ShareChange, error) {
+	sc, err := decodeShareCode(code)
+	if err != nil {
+		return "", nil, err
+	}
+	fields := a.manageFieldsFor(sc.Tool)
+	if len(fields) == 0 {
+		return "", nil, fmt.Errorf("share code is for unknown tool %q", sc.Tool)
+	}
+
+	byKey := make(map[string]manageField, len(fields))
+	for _, f := range fields {
+		if !f.private {
+			byKey[f.key] = f
+		}
+	}
+
+	var changes []ShareChange
+	for key, raw := range sc.Settings {
+		f, ok := byKey[key]
+		if !ok {
+			return "", nil, fmt.Errorf("share code has unknown setting %q for %s", key, sc.Tool)
+		}
+		to, err := f.check(raw)
+		if err != nil {
+			return "", nil, err
+		}
+		if from := f.value(); from != to {
+			changes = append(changes, ShareChange{Key: key, From: from, To: to})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return sc.Tool, changes, nil
+}
+
+// ImportShareCode applies a share code's settings and saves them, like
+// SetManageFields. Nothing is saved if any setting is invalid.
+func (a *App) ImportShareCode(code string) (string, []ShareChange, error) {
+	tool, changes, err := a.PreviewShareCode(code)
+	if err != nil || len(changes) == 0 {
+		return tool, changes, err
+	}
+	assignments := make([]string, len(changes))
+	for i, c := range changes {
+		assignments[i] = c.Key + "=" + c.To
+	}
+	if _, err := a.SetManageFields(tool, assignments); err != nil {
+		return "", nil, err
+	}
+	return tool, changes, nil
+}
+
+// check validates raw as set would, without storing it, and returns the
+// value set would store (numbers clamped, toggles normalized)
+func (f manageField) check(raw string) (string, error) {
+	var s string
+	var b bool
+	var n int
+	if f.str != nil {
+		f.str = &s
+	}
+	if f.b != nil {
+		f.b = &b
+	}
+	if f.n != nil {
+		f.n = &n
+	}
+	if err := f.set(raw); err != nil {
+		return "", err
+	}
+	return f.value(), nil
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/testutil"
+)
+
+func TestShareCodeRoundTrip(t *testing.T) {
+	testutil.TempConfigDir(t)
+	src := NewApp(true)
+	if _, err := src.SetManageFields("tmux", []string{"prefix=C-Space", "mouse=off"}); err != nil {
+		t.Fatalf("SetManageFields() error: %v", err)
+	}
+	code, err := src.ShareManageFields("tmux")
+	if err != nil {
+		t.Fatalf("ShareManageFields() error: %v", err)
+	}
+
+	testutil.TempConfigDir(t)
+	dst := NewApp(true)
+	tool, changes, err := dst.PreviewShareCode(code)
+	if err != nil {
+		t.Fatalf("PreviewShareCode() error: %v", err)
+	}
+	if tool != "tmux" || len(changes) != 2 {
+		t.Fatalf("PreviewShareCode() = %s %v, want tmux with 2 changes", tool, changes)
+	}
+	if changes[0] != (ShareChange{Key: "mouse", From: "true", To: "false"}) {
+		t.Errorf("changes[0] = %+v, want mouse true → false", changes[0])
+	}
+	if dst.manageConfig.TmuxPrefix == "C-Space" {
+		t.Error("PreviewShareCode() changed settings")
+	}
+
+	if _, _, err := dst.ImportShareCode(code); err != nil {
+		t.Fatalf("ImportShareCode() error: %v", err)
+	}
+	saved, err := config.LoadToolConfig("manage", NewManageConfig)
+	if err != nil {
+		t.Fatalf("LoadToolConfig() error: %v", err)
+	}
+	if saved.TmuxPrefix != "C-Space" || saved.TmuxMouseMode {
+		t.Errorf("saved tmux = %q/%v, want C-Space/false", saved.TmuxPrefix, saved.TmuxMouseMode)
+	}
+}
+
+func TestShareCodeLeavesOutPrivateFields(t *testing.T) {
+	testutil.TempConfigDir(t)
+	a := NewApp(true)
+	a.manageConfig.GitUserEmail = "ada@example.com"
+
+	code, err := a.ShareManageFields("git")
+	if err != nil {
+		t.Fatalf("ShareManageFields() error: %v", err)
+	}
+	sc, err := decodeShareCode(code)
+	if err != nil {
+		t.Fatalf("decodeShareCode() error: %v", err)
+	}
+	if _, ok := sc.Settings["email"]; ok {
+		t.Error("share code includes the git email")
+	}
+}
+
+func TestPreviewShareCodeErrors(t *testing.T) {
+	testutil.TempConfigDir(t)
+	a := NewApp(true)
+
+	encode := func(json string) string {
+		var buf bytes.Buffer
+		w, _ := flate.NewWriter(&buf, flate.BestCompression)
+		w.Write([]byte(json))
+		w.Close()
+		return base64.RawURLEncoding.EncodeToString(buf.Bytes())
+	}
+
+	tests := []struct {
+		name    string
+		code    string
+		wantErr string
+	}{
+		{"not base64", "!!!", "invalid share code"},
+		{"not deflate", base64.RawURLEncoding.EncodeToString([]byte("hello")), "invalid share code"},
+		{"wrong version", encode(`{"v":2,"tool":"tmux","settings":{"mouse":"true"}}`), "version 2"},
+		{"unknown tool", encode(`{"v":1,"tool":"emacs","settings":{"a":"b"}}`), "unknown tool"},
+		{"unknown key", encode(`{"v":1,"tool":"tmux","settings":{"prefx":"C-a"}}`), "unknown setting"},
+		{"private key", encode(`{"v":1,"tool":"git","settings":{"email":"x@example.com"}}`), "unknown setting"},
+		{"invalid value", encode(`{"v":1,"tool":"tmux","settings":{"prefix":"C-z"}}`), "not one of"},
+		{"empty", encode(`{"v":1,"tool":"tmux","settings":{}}`), "no settings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := a.PreviewShareCode(tt.code)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("PreviewShareCode() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tekierz/dotfiles/internal/accent"
+	"github.com/tekierz/dotfiles/internal/backup"
 	"github.com/tekierz/dotfiles/internal/pkg"
+	"github.com/tekierz/dotfiles/internal/power"
 	"github.com/tekierz/dotfiles/internal/runner"
 )
 
@@ -14,6 +17,53 @@ type tickMsg time.Time
 // uiTickMsg is a global tick for small UI animations (spinners/widgets).
 type uiTickMsg time.Time
 
+// powerCheckMsg triggers a periodic battery state re-check
+type powerCheckMsg struct{}
+
+// idleTickMsg triggers a check of the idle timeout (see idle.go)
+type idleTickMsg time.Time
+
+// powerStateMsg reports whether the machine is running on battery
+type powerStateMsg struct {
+	onBattery bool
+}
+
+// systemAccentMsg carries the system accent color ("" when unavailable)
+type systemAccentMsg struct {
+	color string
+}
+
+// chshDoneMsg is emitted after running chsh to make zsh the login shell
+type chshDoneMsg struct {
+	err error
+}
+
+// brewShellenvDoneMsg is emitted after adding Homebrew's shellenv line to the shell's rc file
+type brewShellenvDoneMsg struct {
+	err error
+}
+
+// localBinPathDoneMsg is emitted after adding ~/.local/bin to PATH in the shell's rc file
+type localBinPathDoneMsg struct {
+	err error
+}
+
+// updateSortSavedMsg reports that the Update sort mode couldn't be saved
+type updateSortSavedMsg struct {
+	err error
+}
+
+// navStyleSavedMsg reports that the toggled nav style couldn't be saved
+type navStyleSavedMsg struct {
+	err error
+}
+
+// installProfileSavedMsg is emitted after saving the selection as an install profile
+type installProfileSavedMsg struct {
+	name string
+	err  error
+}
+
 // durdrawAvailableMsg indicates if durdraw is available
 type durdrawAvailableMsg bool
 
@@ -29,6 +79,7 @@ type installOutputMsg struct {
 type installDoneMsg struct {
 	err     error
 	context string // last few lines of output for error context
+	result  InstallResult
 }
 
 // installStartMsg triggers installation start
@@ -87,13 +138,7 @@ type updateStartMsg struct {
 }
 
 // BackupEntry represents a backup in the list
-type BackupEntry struct {
-	Name      string
-	Timestamp time.Time
-	FileCount int
-	Size      int64 // bytes
-	Path      string
-}
+type BackupEntry = backup.Entry
 
 // backupsLoadedMsg indicates the async backup list loading completed
 type backupsLoadedMsg struct {
@@ -101,10 +146,19 @@ type backupsLoadedMsg struct {
 	err     error
 }
 
+// backupPlanMsg carries the file comparison shown before a restore
+type backupPlanMsg struct {
+	name   string
+	reason string // Why the backup was taken, if automatic
+	files  []backup.RestoreFile
+	err    error
+}
+
 // backupRestoreDoneMsg indicates a restore operation completed
 type backupRestoreDoneMsg struct {
 	name  string
 	count int
+	kept  int // Newer files left as they were
 	err   error
 }
 
@@ -114,6 +168,24 @@ type backupDeleteDoneMsg struct {
 	err  error
 }
 
+// backupOpenedMsg reports the result of opening a backup in a file manager
+type backupOpenedMsg struct {
+	path string
+	err  error
+}
+
+// toolDocsOpenedMsg reports the result of opening a tool's docs in a browser
+type toolDocsOpenedMsg struct {
+	url string
+	err error
+}
+
+// screenExportedMsg reports where the current screen was exported to
+type screenExportedMsg struct {
+	path string
+	err  error
+}
+
 // backupCreateDoneMsg indicates a new backup was created
 type backupCreateDoneMsg struct {
 	name string
@@ -127,6 +199,14 @@ type manageInstallWithLogsMsg struct {
 	err    error
 }
 
+// updateStepMsg reports one package of an update all queue finishing
+type updateStepMsg struct {
+	pkg      pkg.Package
+	logs     []string
+	err      error
+	canceled bool // Stopped by a pause before it finished; still queued
+}
+
 // updateWithLogsMsg carries update result with collected logs
 type updateWithLogsMsg struct {
 	logs    []string
@@ -134,6 +214,12 @@ type updateWithLogsMsg struct {
 	err     error
 }
 
+// themePreviewMsg applies a deferred theme picker preview; seq identifies
+// the scroll that scheduled it
+type themePreviewMsg struct {
+	seq int
+}
+
 // tickAnimation returns a command that sends tickMsg on each animation frame
 func tickAnimation() tea.Cmd {
 	return tea.Tick(introAnimationTick, func(t time.Time) tea.Msg {
@@ -148,6 +234,46 @@ func tickUI() tea.Cmd {
 	})
 }
 
+// powerCheckInterval is how often battery state is re-checked
+const powerCheckInterval = 30 * time.Second
+
+// tickPowerCheck schedules the next battery state check
+func tickPowerCheck() tea.Cmd {
+	return tea.Tick(powerCheckInterval, func(time.Time) tea.Msg {
+		return powerCheckMsg{}
+	})
+}
+
+// tickIdle schedules the next idle timeout check
+func tickIdle() tea.Cmd {
+	return tea.Tick(idleCheckInterval, func(t time.Time) tea.Msg {
+		return idleTickMsg(t)
+	})
+}
+
+// checkPowerCmd detects battery state. Detection is skipped (reporting AC)
+// unless pausing animations on battery is enabled.
+func checkPowerCmd(enabled bool) tea.Cmd {
+	return func() tea.Msg {
+		if !enabled {
+			return powerStateMsg{}
+		}
+		onBattery, _ := power.OnBattery()
+		return powerStateMsg{onBattery: onBattery}
+	}
+}
+
+// systemAccentCmd reads the system accent color when following it is enabled
+func systemAccentCmd(enabled bool) tea.Cmd {
+	return func() tea.Msg {
+		if !enabled {
+			return systemAccentMsg{}
+		}
+		color, _ := accent.System()
+		return systemAccentMsg{color: color}
+	}
+}
+
 // checkDurdraw returns a command that checks if durdraw is available
 func checkDurdraw() tea.Cmd {
 	return func() tea.Msg {
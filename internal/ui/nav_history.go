@@ -0,0 +1,169 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxNavHistory bounds how many screens back navigation remembers
+const maxNavHistory = 50
+
+// navEntry is a visited screen with the selection to restore on returning
+type navEntry struct {
+	screen     Screen
+	fieldIndex int // configFieldIndex, which the config screens share
+	listIndex  int // The screen's own list cursor, see navPosition
+	scroll     int // The screen's own list scroll offset, see navPosition
+}
+
+// navHistory holds the screens reached by key or mouse, like a browser's
+// back and forward lists. Each screen's own esc target still applies; esc
+// only pops the history when it lands where back would have.
+type navHistory struct {
+	back    []navEntry
+	forward []navEntry
+}
+
+// currentNavEntry snapshots the current screen and its selection
+func (a *App) currentNavEntry() navEntry {
+	e := navEntry{screen: a.screen, fieldIndex: a.configFieldIndex}
+	index, scroll := a.navPosition(a.screen)
+	if index != nil {
+		e.listIndex = *index
+	}
+	if scroll != nil {
+		e.scroll = *scroll
+	}
+	return e
+}
+
+// navPosition returns the list cursor and scroll offset that screen keeps
+// apart from configFieldIndex, or nil for those it doesn't have
+func (a *App) navPosition(screen Screen) (index, scroll *int) {
+	switch screen {
+	case ScreenMainMenu:
+		return &a.mainMenuIndex, nil
+	case ScreenDeepDiveMenu:
+		return &a.deepDiveMenuIndex, nil
+	case ScreenConfigMacApps:
+		return &a.macAppIndex, nil
+	case ScreenConfigUtilities:
+		return &a.utilityIndex, nil
+	case ScreenConfigCLITools:
+		return &a.cliToolIndex, nil
+	case ScreenConfigGUIApps:
+		return &a.guiAppIndex, nil
+	case ScreenConfigCLIUtilities:
+		return &a.cliUtilityIndex, nil
+	case ScreenManage:
+		return &a.manageIndex, &a.manageToolsScroll
+	case ScreenUpdate:
+		return &a.updateIndex, nil
+	case ScreenHotkeys:
+		return &a.hotkeyCursor, &a.hotkeyItemScroll
+	case ScreenFavorites:
+		return &a.favoritesIndex, nil
+	case ScreenBackups:
+		return &a.backupIndex, nil
+	case ScreenUsers:
+		return &a.usersIndex, nil
+	}
+	return nil, nil
+}
+
+// navHistoryExcluded reports whether a screen stays out of the history. The
+// intro, running install and error screens can't be returned to, so
+// reaching one starts a fresh history.
+func navHistoryExcluded(s Screen) bool {
+	return s == ScreenAnimation || s == ScreenProgress || s == ScreenError
+}
+
+// recordNavigation updates the history after a key or mouse event moved
+// from prev to the current screen
+func (a *App) recordNavigation(prev navEntry, esc bool) {
+	if a.screen == prev.screen {
+		return
+	}
+	if navHistoryExcluded(a.screen) || navHistoryExcluded(prev.screen) {
+		a.nav = navHistory{}
+		return
+	}
+	if n := len(a.nav.back); esc && n > 0 && a.nav.back[n-1].screen == a.screen {
+		a.nav.back = a.nav.back[:n-1]
+		a.nav.forward = append(a.nav.forward, prev)
+		return
+	}
+	a.nav.back = append(a.nav.back, prev)
+	if len(a.nav.back) > maxNavHistory {
+		a.nav.back = a.nav.back[len(a.nav.back)-maxNavHistory:]
+	}
+	a.nav.forward = nil
+}
+
+// canNavigateHistory reports whether the back/forward keys apply now
+func (a *App) canNavigateHistory() bool {
+	return !a.textInputActive() && !a.installRunning && !a.updateRunning && !navHistoryExcluded(a.screen)
+}
+
+// navigateBack returns to the previous screen in the history
+func (a *App) navigateBack() tea.Cmd {
+	n := len(a.nav.back)
+	if n == 0 {
+		return nil
+	}
+	target := a.nav.back[n-1]
+	a.nav.back = a.nav.back[:n-1]
+	a.nav.forward = append(a.nav.forward, a.currentNavEntry())
+	return a.restoreNavEntry(target)
+}
+
+// navigateForward undoes the last navigateBack
+func (a *App) navigateForward() tea.Cmd {
+	n := len(a.nav.forward)
+	if n == 0 {
+		return nil
+	}
+	target := a.nav.forward[n-1]
+	a.nav.forward = a.nav.forward[:n-1]
+	a.nav.back = append(a.nav.back, a.currentNavEntry())
+	return a.restoreNavEntry(target)
+}
+
+// restoreNavEntry shows a screen from the history with its selection,
+// starting any loading the screen does on entry
+func (a *App) restoreNavEntry(e navEntry) tea.Cmd {
+	a.screen = e.screen
+	a.configFieldIndex = e.fieldIndex
+	index, scroll := a.navPosition(e.screen)
+	if index != nil {
+		*index = e.listIndex
+	}
+	if scroll != nil {
+		*scroll = e.scroll
+	}
+	return a.screenEnterCmd(e.screen)
+}
+
+// screenEnterCmd starts the async loading a management screen needs the
+// first time it is shown
+func (a *App) screenEnterCmd(screen Screen) tea.Cmd {
+	switch screen {
+	case ScreenManage:
+		return a.startInstallCacheLoad()
+	case ScreenUpdate:
+		if !a.updateChecking && !a.updateCheckDone {
+			a.updateChecking = true
+			return checkUpdatesCmd()
+		}
+	case ScreenUsers:
+		if !a.usersLoaded {
+			a.usersLoaded = true
+			return loadUsersCmd()
+		}
+	case ScreenBackups:
+		if !a.backupsLoading && !a.backupsLoaded {
+			a.backupsLoading = true
+			return loadBackupsCmd()
+		}
+	}
+	return nil
+}
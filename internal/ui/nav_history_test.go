@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNavHistoryBackForward(t *testing.T) {
+	a := &App{screen: ScreenMainMenu, mainMenuIndex: 1, usersLoaded: true, backupsLoaded: true, updateCheckDone: true}
+	a.Update(tea.KeyMsg{Type: tea.KeyEnter}) // main menu → Manage
+	first := a.screen
+	a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	second := a.screen
+	if first == second || second == ScreenMainMenu {
+		t.Fatalf("expected two different screens, got %v then %v", first, second)
+	}
+
+	a.Update(tea.KeyMsg{Type: tea.KeyLeft, Alt: true})
+	if a.screen != first {
+		t.Fatalf("after back screen = %v, want %v", a.screen, first)
+	}
+	a.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if a.screen != ScreenMainMenu {
+		t.Fatalf("after second back screen = %v, want ScreenMainMenu", a.screen)
+	}
+	a.Update(tea.KeyMsg{Type: tea.KeyRight, Alt: true})
+	if a.screen != first {
+		t.Errorf("after forward screen = %v, want %v", a.screen, first)
+	}
+}
+
+func TestNavHistoryEscPops(t *testing.T) {
+	a := &App{screen: ScreenManageZsh}
+	a.nav.back = []navEntry{{screen: ScreenMainMenu}}
+	prev := a.currentNavEntry()
+	a.screen = ScreenMainMenu
+	a.recordNavigation(prev, true)
+	if len(a.nav.back) != 0 {
+		t.Errorf("back = %v, want esc to pop the matching entry", a.nav.back)
+	}
+	if len(a.nav.forward) != 1 || a.nav.forward[0].screen != ScreenManageZsh {
+		t.Errorf("forward = %v, want the screen esc left", a.nav.forward)
+	}
+
+	// A new navigation drops the forward list
+	prev = a.currentNavEntry()
+	a.screen = ScreenBackups
+	a.recordNavigation(prev, false)
+	if len(a.nav.forward) != 0 || len(a.nav.back) != 1 {
+		t.Errorf("back = %v, forward = %v after a new navigation", a.nav.back, a.nav.forward)
+	}
+}
+
+func TestNavHistoryRestoresSelection(t *testing.T) {
+	a := &App{screen: ScreenConfigZsh, configFieldIndex: 4}
+	prev := a.currentNavEntry()
+	a.screen, a.configFieldIndex = ScreenDeepDiveMenu, 0
+	a.recordNavigation(prev, false)
+
+	a.navigateBack()
+	if a.screen != ScreenConfigZsh || a.configFieldIndex != 4 {
+		t.Errorf("restored %v field %d, want ScreenConfigZsh field 4", a.screen, a.configFieldIndex)
+	}
+}
+
+func TestNavHistoryRestoresListPosition(t *testing.T) {
+	a := &App{screen: ScreenManage, manageIndex: 7, manageToolsScroll: 3, manageInstalledReady: true}
+	prev := a.currentNavEntry()
+	a.screen, a.manageIndex, a.manageToolsScroll = ScreenMainMenu, 0, 0
+	a.recordNavigation(prev, false)
+
+	a.navigateBack()
+	if a.screen != ScreenManage || a.manageIndex != 7 || a.manageToolsScroll != 3 {
+		t.Errorf("restored %v index %d scroll %d, want ScreenManage index 7 scroll 3", a.screen, a.manageIndex, a.manageToolsScroll)
+	}
+}
+
+func TestNavHistoryResetByInstall(t *testing.T) {
+	a := &App{screen: ScreenFileTree}
+	a.nav.back = []navEntry{{screen: ScreenWelcome}}
+	prev := a.currentNavEntry()
+	a.screen = ScreenProgress
+	a.recordNavigation(prev, false)
+	if len(a.nav.back) != 0 {
+		t.Errorf("back = %v, want the history cleared on entering Progress", a.nav.back)
+	}
+}
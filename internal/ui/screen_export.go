@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/tekierz/dotfiles/internal/config"
+)
+
+// ScreensDir returns the directory screen exports are written to
+func ScreensDir() string {
+	return filepath.Join(config.ConfigDir(), "screens")
+}
+
+// plainScreen strips a rendered view to plain text: no ANSI styling and no
+// trailing blanks on each line or at the end
+func plainScreen(view string) string {
+	lines := strings.Split(ansi.Strip(view), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n"
+}
+
+// exportScreenCmd writes the current screen as plain text to a timestamped
+// file in ScreensDir, for bug reports and docs
+func (a *App) exportScreenCmd() tea.Cmd {
+	text := plainScreen(a.view())
+	name := fmt.Sprintf("screen-%s.txt", time.Now().Format("20060102-150405"))
+	return func() tea.Msg {
+		dir := ScreensDir()
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return screenExportedMsg{err: err}
+		}
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(text), 0600); err != nil {
+			return screenExportedMsg{err: err}
+		}
+		return screenExportedMsg{path: path}
+	}
+}
+
+// withStatusBanner replaces the last line of view with bannerStatus (e.g.
+// the result of the last screen export), until the next key press
+func (a *App) withStatusBanner(view string) string {
+	if a.bannerStatus == "" {
+		return view
+	}
+	banner := lipgloss.NewStyle().Foreground(ColorCyan).Bold(true).Render(a.bannerStatus)
+	banner = lipgloss.PlaceHorizontal(a.width, lipgloss.Center, banner)
+
+	if i := strings.LastIndex(view, "\n"); i >= 0 {
+		return view[:i+1] + banner
+	}
+	return banner
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tekierz/dotfiles/internal/testutil"
+)
+
+func TestPlainScreen(t *testing.T) {
+	got := plainScreen("\x1b[1;36mTitle\x1b[0m   \nbody  \n\n\n")
+	if got != "Title\nbody\n" {
+		t.Errorf("plainScreen = %q, want %q", got, "Title\nbody\n")
+	}
+}
+
+func TestExportScreen(t *testing.T) {
+	testutil.TempConfigDir(t)
+	a := &App{screen: ScreenManage, width: 100, height: 30, manageInstalled: map[string]bool{}, manageInstalledReady: true}
+
+	_, cmd := a.handleKey(tea.KeyMsg{Type: tea.KeyCtrlE})
+	if cmd == nil {
+		t.Fatal("ctrl+e should export the screen")
+	}
+	msg, ok := cmd().(screenExportedMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("export = %+v", msg)
+	}
+	a.Update(msg)
+
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text := string(data); strings.Contains(text, "\x1b[") || !strings.Contains(text, "TOOLS") {
+		t.Errorf("export should be the screen as plain text:\n%s", text)
+	}
+	testutil.RequireContains(t, a.View(), "Screen saved to "+msg.path, "status line after export")
+
+	a.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if strings.Contains(a.View(), "Screen saved to") {
+		t.Error("the export status should clear on the next key")
+	}
+}
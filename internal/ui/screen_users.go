@@ -347,7 +347,7 @@ func (a *App) getUserFields() []userField {
 			description: "Color theme for all tools",
 			kind:        userFieldOption,
 			value:       item.theme,
-			options:     config.AvailableThemes,
+			options:     themeNames(),
 		},
 		{
 			key:         "nav",
@@ -7,6 +7,13 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/tekierz/dotfiles/internal/pkg"
+	"github.com/tekierz/dotfiles/internal/tools"
+)
+
+// The intro card needs this much room; smaller terminals get the compact intro
+const (
+	minIntroWidth  = 24
+	minIntroHeight = 10
 )
 
 // renderAnimation renders the intro animation screen
@@ -14,6 +21,9 @@ func (a *App) renderAnimation() string {
 	if a.width == 0 || a.height == 0 {
 		return "Loading..."
 	}
+	if a.width < minIntroWidth || a.height < minIntroHeight {
+		return a.renderCompactIntro()
+	}
 
 	// Compute a stable "card" size that fits on the screen.
 	// We keep the content area fixed-size throughout the animation to avoid
@@ -198,6 +208,18 @@ func (a *App) renderAnimation() string {
 	return PlaceWithBackground(a.width, a.height, card)
 }
 
+// renderCompactIntro is the intro for terminals too small for the card: the
+// title and progress, without the rain or border
+func (a *App) renderCompactIntro() string {
+	progress := float64(a.animFrame) / float64(introAnimationFrames)
+	if progress > 1 {
+		progress = 1
+	}
+	title := lipgloss.NewStyle().Bold(true).Render(GradientText("DOTFILES", GradientCyber))
+	pct := lipgloss.NewStyle().Foreground(ColorTextMuted).Render(fmt.Sprintf("%3d%%", int(progress*100)))
+	return PlaceWithBackground(a.width, a.height, lipgloss.JoinVertical(lipgloss.Center, title, pct))
+}
+
 // renderWelcome renders the welcome/main menu screen
 func (a *App) renderWelcome() string {
 	// ASCII Logo with gradient
@@ -337,33 +359,13 @@ func (a *App) renderThemePicker() string {
 	content := themeList.String()
 
 	if showPreview {
-		// Preview box with color swatches
-		selectedTheme := themes[a.themeIndex]
-		previewColor := lipgloss.Color(selectedTheme.color)
-
-		preview := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(previewColor).
-			Width(maxInt(1, previewOuterW-2)). // border adds 2
-			Padding(1).
-			Render(lipgloss.JoinVertical(
-				lipgloss.Left,
-				lipgloss.NewStyle().Foreground(previewColor).Bold(true).Render(selectedTheme.name),
-				"",
-				lipgloss.NewStyle().Foreground(previewColor).Render("████████████████████"),
-				"",
-				lipgloss.NewStyle().Foreground(ColorText).Render("  normal text"),
-				lipgloss.NewStyle().Foreground(previewColor).Render("  highlighted text"),
-				lipgloss.NewStyle().Foreground(ColorTextMuted).Render("  muted text"),
-				"",
-				lipgloss.NewStyle().Foreground(ColorGreen).Render("  ✓ success"),
-				lipgloss.NewStyle().Foreground(ColorRed).Render("  ✗ error"),
-			))
-
+		// The picker has already applied the highlighted theme, so a
+		// miniature Manage panel shows it on a representative screen
+		preview := renderThemePreview(themes[a.themeIndex].name, previewOuterW)
 		content = lipgloss.JoinHorizontal(lipgloss.Top, content, "  ", preview)
 	}
 
-	help := HelpStyle.Render("[↑↓/jk] Navigate    [ENTER] Select    [ESC] Back")
+	help := HelpStyle.Render("[↑↓/jk] Navigate    [ENTER] Select    [ESC] Cancel")
 
 	return PlaceWithBackground(
 		a.width, a.height,
@@ -378,6 +380,54 @@ func (a *App) renderThemePicker() string {
 	)
 }
 
+// renderThemePreview renders a fake Manage panel (tool list plus a few
+// settings) in the current colors, for the theme picker's preview pane
+func renderThemePreview(name string, outerW int) string {
+	innerW := maxInt(1, outerW-4) // border + padding
+	text := lipgloss.NewStyle().Foreground(ColorText)
+	muted := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	accent := lipgloss.NewStyle().Foreground(ColorCyan).Bold(true)
+
+	tool := func(label string, selected bool) string {
+		if selected {
+			return accent.Render("▸ " + label)
+		}
+		return text.Render("  " + label)
+	}
+	field := func(label, value string) string {
+		pad := maxInt(1, innerW-lipgloss.Width(label)-lipgloss.Width(value)-2)
+		return text.Render("  "+label+strings.Repeat(" ", pad)) + lipgloss.NewStyle().Foreground(ColorNeonBlue).Render(value)
+	}
+
+	lines := []string{
+		lipgloss.NewStyle().Foreground(ColorNeonPink).Bold(true).Render("MANAGE") + muted.Render(" · "+name),
+		"",
+		tool("Ghostty", true),
+		tool("Tmux", false),
+		tool("Neovim", false),
+		muted.Render(strings.Repeat("─", innerW)),
+		field("Font size", "14"),
+		field("Opacity", "█████░ 85%"),
+		field("Cursor", "block"),
+		field("Blur", "[✓]"),
+		"",
+		lipgloss.NewStyle().Foreground(ColorGreen).Render("✓ Saved") + "  " +
+			lipgloss.NewStyle().Foreground(ColorYellow).Render("! 1 update") + "  " +
+			lipgloss.NewStyle().Foreground(ColorRed).Render("✗ error"),
+		muted.Render("S save • Esc back"),
+	}
+	for i, l := range lines {
+		lines[i] = truncateVisible(l, innerW)
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorCyan).
+		Width(maxInt(1, outerW-2)). // border adds 2
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
+
 // renderNavPicker renders the navigation style selection screen
 func (a *App) renderNavPicker() string {
 	title := TitleStyle.Render("Select Navigation Style")
@@ -765,56 +815,139 @@ func (a *App) renderSummary() string {
 	))
 	summary = lipgloss.NewStyle().MaxWidth(maxInt(20, a.width-6)).Render(summary)
 
-	help := HelpStyle.Render("[ENTER] Exit")
+	sections := []string{title, summary}
+	if skipped := a.installResult.SkippedSummary(); skipped != "" {
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(ColorTextMuted).
+			Width(maxInt(20, a.width-10)).
+			Render("Skipped: "+skipped), "")
+	}
+	helpText := "[ENTER] Exit"
+	if a.summaryZshPath != "" {
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(ColorYellow).
+			MaxWidth(maxInt(20, a.width-6)).
+			Render("⚠ zsh is not your login shell — press C to run chsh -s "+a.summaryZshPath), "")
+		helpText = "[C] Make zsh default  [ENTER] Exit"
+	}
+	if a.summaryBrewPath != "" {
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(ColorYellow).
+			MaxWidth(maxInt(20, a.width-6)).
+			Render("⚠ Homebrew is not on your PATH — press B to add "+tools.BrewShellenvLine(a.summaryShell, a.summaryBrewPath)+" to "+a.summaryShell.DisplayPath()), "")
+		helpText = "[B] Add brew to PATH  " + helpText
+	}
+	switch a.summaryLocalBin {
+	case tools.LocalBinMissing:
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(ColorYellow).
+			MaxWidth(maxInt(20, a.width-6)).
+			Render("⚠ ~/.local/bin is not on your PATH, so dotfiles and hk won't be found — press L to add it to "+a.summaryShell.DisplayPath()), "")
+		helpText = "[L] Add ~/.local/bin to PATH  " + helpText
+	case tools.LocalBinNeedsRestart:
+		sections = append(sections, lipgloss.NewStyle().
+			Foreground(ColorTextMuted).
+			MaxWidth(maxInt(20, a.width-6)).
+			Render("~/.local/bin is on PATH in new shells — restart your shell or run: source "+a.summaryShell.DisplayPath()), "")
+	}
+	helpText = "[P] Save as profile  " + helpText
+	if a.summaryNaming {
+		sections = append(sections, lipgloss.NewStyle().Foreground(ColorMagenta).Bold(true).Render("Install profile name: ")+
+			lipgloss.NewStyle().Foreground(ColorTextBright).Render(a.summaryNameInput)+
+			lipgloss.NewStyle().Foreground(ColorCyan).Render("▌"), "")
+		helpText = "[ENTER] Save  [ESC] Cancel"
+	}
+	if a.summaryStatus != "" {
+		sections = append(sections, lipgloss.NewStyle().Foreground(ColorTextMuted).Render(a.summaryStatus), "")
+	}
+	sections = append(sections, HelpStyle.Render(helpText))
 
 	return PlaceWithBackground(
 		a.width, a.height,
-		ContainerStyle.Render(lipgloss.JoinVertical(
-			lipgloss.Center,
-			title,
-			summary,
-			help,
-		)),
+		ContainerStyle.Render(lipgloss.JoinVertical(lipgloss.Center, sections...)),
 	)
 }
 
-// renderError renders the error recovery screen
+// canRetry reports whether retrying could help, e.g. not when no package
+// manager exists
+func (a *App) canRetry() bool {
+	return a.lastError == nil || pkg.IsRetryable(a.lastError)
+}
+
+// showError switches to the Error screen with err scrolled to its start
+func (a *App) showError(err error) {
+	a.lastError = err
+	a.screen = ScreenError
+	_, h := a.errorPanelSize()
+	a.errorScroll = CalculateMaxLogScroll(len(a.errorLines()), h-4)
+}
+
+// errorPanelSize returns the size of the Error screen's details panel,
+// leaving room for the title, hint, buttons and the container around them
+func (a *App) errorPanelSize() (int, int) {
+	w := maxInt(24, a.width-10)
+	h := maxInt(7, a.height-16)
+	if lines := len(a.errorLines()); lines+4 < h {
+		h = lines + 4 // shrink to fit short errors
+	}
+	return w, h
+}
+
+// errorLines returns the error message wrapped to the details panel
+func (a *App) errorLines() []string {
+	msg := "Unknown error"
+	if a.lastError != nil {
+		msg = a.lastError.Error()
+	}
+	wrapped := lipgloss.NewStyle().Width(maxInt(24, a.width-10) - 4).Render(msg)
+	return strings.Split(wrapped, "\n")
+}
+
+// scrollError scrolls the error details up (delta > 0) or down
+func (a *App) scrollError(delta int) {
+	_, h := a.errorPanelSize()
+	maxScroll := CalculateMaxLogScroll(len(a.errorLines()), h-4)
+	a.errorScroll = max(0, min(maxScroll, a.errorScroll+delta))
+}
+
+// renderError renders the error recovery screen. The error, which often
+// carries the tail of the install output, is shown in a scrollable log panel.
 func (a *App) renderError() string {
 	title := lipgloss.NewStyle().
 		Foreground(ColorRed).
 		Bold(true).
 		Render("✗ Error Occurred")
 
-	errMsg := "Unknown error"
-	if a.lastError != nil {
-		errMsg = a.lastError.Error()
+	lines := a.errorLines()
+	w, h := a.errorPanelSize()
+	errorBox := RenderLogPanel(lines, w, h, a.errorScroll, "Details", true)
+	if info := LogPanelScrollInfo(lines, h-4, a.errorScroll); info != "" {
+		errorBox = lipgloss.JoinVertical(lipgloss.Left, errorBox,
+			HelpStyle.Render(info+" • pgup/pgdn scroll"))
 	}
 
-	errorBox := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(ColorRed).
-		Padding(1).
-		MaxWidth(maxInt(20, a.width-10)).
-		Render(errMsg)
-
-	options := lipgloss.JoinHorizontal(
-		lipgloss.Top,
-		ButtonStyle.Render(" [R] Retry "),
-		"  ",
+	var buttons []string
+	if a.canRetry() {
+		buttons = append(buttons, ButtonStyle.Render(" [R] Retry "), "  ")
+	}
+	buttons = append(buttons,
 		ButtonStyle.Render(" [S] Skip "),
 		"  ",
 		ButtonStyle.Render(" [Q] Quit "),
 	)
+	options := lipgloss.JoinHorizontal(lipgloss.Top, buttons...)
+
+	sections := []string{title, "", errorBox}
+	if hint := pkg.Hint(a.lastError); hint != "" {
+		sections = append(sections, "", lipgloss.NewStyle().
+			Foreground(ColorYellow).
+			MaxWidth(maxInt(20, a.width-10)).
+			Render("→ "+hint))
+	}
+	sections = append(sections, "", options)
 
 	return PlaceWithBackground(
 		a.width, a.height,
-		ContainerStyle.Render(lipgloss.JoinVertical(
-			lipgloss.Center,
-			title,
-			"",
-			errorBox,
-			"",
-			options,
-		)),
+		ContainerStyle.Render(lipgloss.JoinVertical(lipgloss.Center, sections...)),
 	)
 }
@@ -2,6 +2,7 @@ package screens
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/tekierz/dotfiles/internal/pkg"
 	"github.com/tekierz/dotfiles/internal/ui"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -39,7 +40,9 @@ func (s *ErrorScreen) Update(msg tea.Msg) (ui.ScreenHandler, tea.Cmd) {
 		switch msg.String() {
 		case "r":
 			// Retry - navigate to progress screen
-			return s, ui.NavigateTo(ui.ScreenProgress)
+			if s.canRetry() {
+				return s, ui.NavigateTo(ui.ScreenProgress)
+			}
 		case "s":
 			// Skip - continue to summary
 			return s, ui.NavigateTo(ui.ScreenSummary)
@@ -52,6 +55,12 @@ func (s *ErrorScreen) Update(msg tea.Msg) (ui.ScreenHandler, tea.Cmd) {
 	return s, nil
 }
 
+// canRetry reports whether retrying could help, e.g. not when no package
+// manager exists.
+func (s *ErrorScreen) canRetry() bool {
+	return s.err == nil || pkg.IsRetryable(s.err)
+}
+
 // View renders the error screen.
 func (s *ErrorScreen) View(width, height int) string {
 	title := lipgloss.NewStyle().
@@ -75,14 +84,25 @@ func (s *ErrorScreen) View(width, height int) string {
 		Foreground(ui.ColorTextBright).
 		Padding(0, 1)
 
-	options := lipgloss.JoinHorizontal(
-		lipgloss.Top,
-		buttonStyle.Render(" [R] Retry "),
-		"  ",
+	var buttons []string
+	if s.canRetry() {
+		buttons = append(buttons, buttonStyle.Render(" [R] Retry "), "  ")
+	}
+	buttons = append(buttons,
 		buttonStyle.Render(" [S] Skip "),
 		"  ",
 		buttonStyle.Render(" [Q] Quit "),
 	)
+	options := lipgloss.JoinHorizontal(lipgloss.Top, buttons...)
+
+	sections := []string{title, "", errorBox}
+	if hint := pkg.Hint(s.err); hint != "" {
+		sections = append(sections, "", lipgloss.NewStyle().
+			Foreground(ui.ColorYellow).
+			MaxWidth(max(20, width-10)).
+			Render("→ "+hint))
+	}
+	sections = append(sections, "", options)
 
 	containerStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -92,13 +112,6 @@ func (s *ErrorScreen) View(width, height int) string {
 	return lipgloss.Place(
 		width, height,
 		lipgloss.Center, lipgloss.Center,
-		containerStyle.Render(lipgloss.JoinVertical(
-			lipgloss.Center,
-			title,
-			"",
-			errorBox,
-			"",
-			options,
-		)),
+		containerStyle.Render(lipgloss.JoinVertical(lipgloss.Center, sections...)),
 	)
 }
@@ -2,10 +2,12 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/tekierz/dotfiles/internal/pkg"
+	"github.com/tekierz/dotfiles/internal/tools"
 )
 
 // Focused field styles
@@ -37,33 +39,105 @@ var (
 				Padding(0, 1)
 )
 
-// GetFilteredDeepDiveMenuItems returns deep dive menu items filtered for the current platform
-func GetFilteredDeepDiveMenuItems() []DeepDiveMenuItem {
+// GetFilteredDeepDiveMenuItems returns deep dive menu items filtered for the
+// current platform, minus any whose Key is in hidden. A hidden item's section
+// header moves to the next kept item.
+func GetFilteredDeepDiveMenuItems(hidden []string) []DeepDiveMenuItem {
 	platform := pkg.DetectPlatform()
 	allItems := GetDeepDiveMenuItems()
 
 	var filtered []DeepDiveMenuItem
+	header := ""
 	for _, item := range allItems {
-		// If item has no platform restriction, include it
-		if item.Platform == "" {
-			filtered = append(filtered, item)
+		if !deepDiveItemOnPlatform(item, platform) {
 			continue
 		}
-		// If item is for macos, only include on macOS
-		if item.Platform == "macos" && platform == pkg.PlatformMacOS {
-			filtered = append(filtered, item)
-			continue
+		if item.Category != "" {
+			header = item.Category
 		}
-		// If item is for linux, only include on Linux (arch or debian)
-		if item.Platform == "linux" && (platform == pkg.PlatformArch || platform == pkg.PlatformDebian) {
-			filtered = append(filtered, item)
+		if slices.Contains(hidden, item.Key) {
 			continue
 		}
+		item.Category = header
+		header = ""
+		filtered = append(filtered, item)
 	}
 
 	return filtered
 }
 
+// deepDiveItemOnPlatform reports whether item applies to platform
+func deepDiveItemOnPlatform(item DeepDiveMenuItem, platform pkg.Platform) bool {
+	switch item.Platform {
+	case "":
+		// No platform restriction
+		return true
+	case "macos":
+		return platform == pkg.PlatformMacOS
+	case "linux":
+		// Linux means arch or debian
+		return platform == pkg.PlatformArch || platform == pkg.PlatformDebian
+	}
+	return false
+}
+
+// filterDeepDiveByCategory keeps items that configure any of cats (all items
+// when cats is empty). A section's header moves to its first kept item.
+func filterDeepDiveByCategory(items []DeepDiveMenuItem, cats []tools.Category) []DeepDiveMenuItem {
+	if len(cats) == 0 {
+		return items
+	}
+
+	var filtered []DeepDiveMenuItem
+	header := ""
+	for _, item := range items {
+		if item.Category != "" {
+			header = item.Category
+		}
+		if !slices.ContainsFunc(item.Categories, func(c tools.Category) bool { return slices.Contains(cats, c) }) {
+			continue
+		}
+		item.Category = header
+		header = ""
+		filtered = append(filtered, item)
+	}
+	return filtered
+}
+
+// deepDiveMenuItems returns the deep dive menu as shown: filtered for the
+// platform, the hidden categories setting and any install --category scope
+func (a *App) deepDiveMenuItems() []DeepDiveMenuItem {
+	return filterDeepDiveByCategory(GetFilteredDeepDiveMenuItems(a.hiddenDeepDiveCategories()), a.deepDiveCategories)
+}
+
+// deepDiveShown returns the Global setting toggle for whether the deep dive
+// item with key is listed, creating it (shown) on first use
+func (a *App) deepDiveShown(key string) *bool {
+	if a.deepDiveVisible == nil {
+		a.deepDiveVisible = make(map[string]*bool)
+	}
+	shown, ok := a.deepDiveVisible[key]
+	if !ok {
+		shown = new(bool)
+		*shown = true
+		a.deepDiveVisible[key] = shown
+	}
+	return shown
+}
+
+// hiddenDeepDiveCategories returns the keys of deep dive items turned off in
+// the Global settings, sorted
+func (a *App) hiddenDeepDiveCategories() []string {
+	var hidden []string
+	for key, shown := range a.deepDiveVisible {
+		if !*shown {
+			hidden = append(hidden, key)
+		}
+	}
+	slices.Sort(hidden)
+	return hidden
+}
+
 // renderDeepDiveMenu renders the deep dive tool selection menu
 func (a *App) renderDeepDiveMenu() string {
 	// Show loading state if cache is being populated
@@ -96,7 +170,7 @@ func (a *App) renderDeepDiveMenu() string {
 		Italic(true).
 		Render("Customize each tool before installation")
 
-	items := GetFilteredDeepDiveMenuItems()
+	items := a.deepDiveMenuItems()
 	var menuList strings.Builder
 
 	// Category header style
@@ -155,7 +229,8 @@ func (a *App) renderDeepDiveMenu() string {
 
 	// Continue option
 	continueIdx := len(items)
-	continueSelected := a.deepDiveMenuIndex == continueIdx
+	// >= so a stale index past a shortened list lands on Continue
+	continueSelected := a.deepDiveMenuIndex >= continueIdx
 	continueCursor := "  "
 	continueStyle := unfocusedStyle
 	if continueSelected {
@@ -168,13 +243,15 @@ func (a *App) renderDeepDiveMenu() string {
 	// Wrap menu in a box
 	menuBox := configBoxStyle.Width(a.deepDiveBoxWidth(64)).Render(menuList.String())
 
-	// Status legend
+	// Status legend with per-status counts, plus an overall summary
+	counts, groups := a.deepDiveStatusCounts(items)
 	legendStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
-	installedDot := lipgloss.NewStyle().Foreground(ColorNeonBlue).Render("●")
-	partialDot := lipgloss.NewStyle().Foreground(ColorYellow).Render("●")
-	pendingDot := lipgloss.NewStyle().Foreground(ColorTextMuted).Render("○")
-	legend := legendStyle.Render(fmt.Sprintf("%s installed  %s partial  %s not installed",
-		installedDot, partialDot, pendingDot))
+	legend := legendStyle.Render(fmt.Sprintf("%s installed (%d)  %s partial (%d)  %s not installed (%d)",
+		StatusDot("installed"), counts["installed"],
+		StatusDot("partial"), counts["partial"],
+		StatusDot("pending"), counts["pending"]))
+	summary := lipgloss.NewStyle().Foreground(ColorText).Render(
+		fmt.Sprintf("%d of %d tool groups installed", counts["installed"], groups))
 
 	help := HelpStyle.Render("↑↓/jk navigate • enter select • esc back")
 
@@ -185,6 +262,7 @@ func (a *App) renderDeepDiveMenu() string {
 		"",
 		menuBox,
 		"",
+		summary,
 		legend,
 		"",
 		help,
@@ -223,14 +301,14 @@ func (a *App) renderConfigGhostty() string {
 	// Opacity
 	opacityFocused := a.configFieldIndex == fieldIdx
 	content.WriteString(renderFieldLabel("Background Opacity", opacityFocused))
-	content.WriteString(renderSliderControl(cfg.GhosttyOpacity, 100, 24, opacityFocused))
+	content.WriteString(renderSliderControl(cfg.GhosttyOpacity, 100, sliderTrackWidth, opacityFocused))
 	content.WriteString("\n\n")
 	fieldIdx++
 
 	// Blur radius
 	blurFocused := a.configFieldIndex == fieldIdx
 	content.WriteString(renderFieldLabel("Blur Radius", blurFocused))
-	content.WriteString(renderSliderControl(cfg.GhosttyBlurRadius, 100, 24, blurFocused))
+	content.WriteString(renderSliderControl(cfg.GhosttyBlurRadius, 100, sliderTrackWidth, blurFocused))
 	content.WriteString("\n\n")
 	fieldIdx++
 
@@ -269,7 +347,7 @@ func (a *App) renderConfigGhostty() string {
 	))
 
 	box := configBoxStyle.Width(a.deepDiveBoxWidth(55)).Render(content.String())
-	help := HelpStyle.Render("↑↓ navigate • ←→ adjust • enter/esc save & back")
+	help := HelpStyle.Render("↑↓ navigate • ←→ adjust • p preview • enter/esc save & back")
 
 	return PlaceWithBackground(
 		a.width, a.height,
@@ -361,6 +439,16 @@ func (a *App) renderConfigTmux() string {
 		fmt.Sprintf("%d", cfg.TmuxBaseIndex),
 		baseFocused,
 	))
+	content.WriteString("\n\n")
+	fieldIdx++
+
+	// Dotfiles session script
+	sessionFocused := a.configFieldIndex == fieldIdx
+	content.WriteString(renderFieldLabel("Dotfiles Session", sessionFocused))
+	content.WriteString(renderToggle(cfg.TmuxSession, sessionFocused))
+	if sessionFocused {
+		content.WriteString(lipgloss.NewStyle().Foreground(ColorTextMuted).Render("  Script opening dotfiles in its own tmux window"))
+	}
 	fieldIdx++
 
 	// TPM section
@@ -424,7 +512,7 @@ func (a *App) renderConfigTmux() string {
 	}
 
 	box := configBoxStyle.Width(a.deepDiveBoxWidth(55)).Render(content.String())
-	help := HelpStyle.Render("↑↓ navigate • ←→ select • space toggle • enter/esc back")
+	help := HelpStyle.Render("↑↓ navigate • ←→ select • space toggle • p preview • enter/esc back")
 
 	return PlaceWithBackground(
 		a.width, a.height,
@@ -627,9 +715,17 @@ func (a *App) renderConfigNeovim() string {
 		content.WriteString("\n")
 		fieldIdx++
 	}
+	if warnings := a.renderLSPRuntimeWarnings(); warnings != "" {
+		content.WriteString("\n")
+		content.WriteString(warnings)
+	}
 
 	box := configBoxStyle.Width(a.deepDiveBoxWidth(55)).Render(content.String())
-	help := HelpStyle.Render("↑↓ navigate • space/enter select • esc back")
+	helpText := "↑↓ navigate • space/enter select • esc back"
+	if len(a.unresolvedLSPRuntimes()) > 0 {
+		helpText = "↑↓ navigate • space/enter select • a add runtimes • esc back"
+	}
+	help := HelpStyle.Render(helpText)
 
 	return PlaceWithBackground(
 		a.width, a.height,
@@ -688,6 +784,22 @@ func (a *App) renderConfigGit() string {
 	content.WriteString("\n\n")
 	fieldIdx++
 
+	// Settings carried over from an existing .gitconfig
+	if cfg.GitUserName != "" || cfg.GitUserEmail != "" || len(cfg.GitExtra) > 0 {
+		muted := lipgloss.NewStyle().Foreground(ColorTextMuted)
+		content.WriteString(sectionHeaderStyle.Render("Kept From ~/.gitconfig"))
+		content.WriteString("\n")
+		if cfg.GitUserName != "" || cfg.GitUserEmail != "" {
+			content.WriteString(muted.Render(fmt.Sprintf("  user: %s <%s>", cfg.GitUserName, cfg.GitUserEmail)))
+			content.WriteString("\n")
+		}
+		if n := len(cfg.GitExtra); n > 0 {
+			content.WriteString(muted.Render(fmt.Sprintf("  %d other setting(s) preserved", n)))
+			content.WriteString("\n")
+		}
+		content.WriteString("\n")
+	}
+
 	// Aliases preview
 	content.WriteString(sectionHeaderStyle.Render("Included Aliases"))
 	content.WriteString("\n")
@@ -703,7 +815,7 @@ func (a *App) renderConfigGit() string {
 	}
 
 	box := configBoxStyle.Width(a.deepDiveBoxWidth(55)).Render(content.String())
-	help := HelpStyle.Render("↑↓ navigate • ←→ select • space toggle • esc back")
+	help := HelpStyle.Render("↑↓ navigate • ←→ select • space toggle • p preview • esc back")
 
 	return PlaceWithBackground(
 		a.width, a.height,
@@ -771,7 +883,7 @@ func (a *App) renderConfigFzf() string {
 	// Height slider
 	heightFocused := a.configFieldIndex == 1
 	content.WriteString(renderFieldLabel("Window Height", heightFocused))
-	content.WriteString(renderSliderControl(cfg.FzfHeight, 100, 24, heightFocused))
+	content.WriteString(renderSliderControl(cfg.FzfHeight, 100, sliderTrackWidth, heightFocused))
 	content.WriteString("\n\n")
 
 	// Layout
@@ -860,7 +972,7 @@ func (a *App) renderConfigMacApps() string {
 	}
 
 	box := configBoxStyle.Width(a.deepDiveBoxWidth(65)).Render(content.String())
-	help := HelpStyle.Render("↑↓ navigate • space toggle • enter/esc save & back • yellow = installed")
+	help := HelpStyle.Render("↑↓ navigate • space toggle • A all • enter/esc save & back • yellow = installed")
 
 	return lipgloss.Place(
 		a.width, a.height,
@@ -1169,7 +1281,7 @@ func (a *App) renderConfigUtilities() string {
 	}
 
 	box := configBoxStyle.Width(a.deepDiveBoxWidth(60)).Render(content.String())
-	help := HelpStyle.Render("↑↓ navigate • space toggle • enter/esc save & back • yellow = installed")
+	help := HelpStyle.Render("↑↓ navigate • space toggle • A all • enter/esc save & back • yellow = installed")
 
 	return lipgloss.Place(
 		a.width, a.height,
@@ -1200,6 +1312,7 @@ func (a *App) renderConfigCLITools() string {
 		{"claude-code", "Claude Code", "AI-powered coding assistant (npm)"},
 	}
 
+	recs := a.recommendedTools()
 	for i, tool := range tools {
 		focused := a.cliToolIndex == i
 		enabled := cfg.CLITools[tool.id]
@@ -1228,6 +1341,8 @@ func (a *App) renderConfigCLITools() string {
 		suffix := ""
 		if installed {
 			suffix = lipgloss.NewStyle().Foreground(ColorTextMuted).Italic(true).Render(" (installed)")
+		} else if !enabled {
+			suffix = recommendationSuffix(recs, tool.id)
 		}
 
 		content.WriteString(fmt.Sprintf("%s%s %s%s %s\n",
@@ -1240,7 +1355,7 @@ func (a *App) renderConfigCLITools() string {
 	}
 
 	box := configBoxStyle.Width(a.deepDiveBoxWidth(70)).Render(content.String())
-	help := HelpStyle.Render("↑↓ navigate • space toggle • enter/esc save & back • yellow = installed")
+	help := HelpStyle.Render("↑↓ navigate • space toggle • A all • enter/esc save & back • yellow = installed • ★ = recommended")
 
 	return lipgloss.Place(
 		a.width, a.height,
@@ -1273,6 +1388,7 @@ func (a *App) renderConfigCLIUtilities() string {
 		{"fswatch", "fswatch", "File system watcher"},
 	}
 
+	recs := a.recommendedTools()
 	for i, util := range utilities {
 		focused := a.cliUtilityIndex == i
 		enabled := cfg.CLIUtilities[util.id]
@@ -1300,6 +1416,8 @@ func (a *App) renderConfigCLIUtilities() string {
 		suffix := ""
 		if installed {
 			suffix = lipgloss.NewStyle().Foreground(ColorTextMuted).Italic(true).Render(" (installed)")
+		} else if !enabled {
+			suffix = recommendationSuffix(recs, util.id)
 		}
 
 		content.WriteString(fmt.Sprintf("%s%s %s%s %s\n",
@@ -1312,7 +1430,7 @@ func (a *App) renderConfigCLIUtilities() string {
 	}
 
 	box := configBoxStyle.Width(a.deepDiveBoxWidth(65)).Render(content.String())
-	help := HelpStyle.Render("↑↓ navigate • space toggle • enter/esc save & back • yellow = installed")
+	help := HelpStyle.Render("↑↓ navigate • space toggle • A all • enter/esc save & back • yellow = installed • ★ = recommended")
 
 	return lipgloss.Place(
 		a.width, a.height,
@@ -1383,7 +1501,7 @@ func (a *App) renderConfigGUIApps() string {
 	}
 
 	box := configBoxStyle.Width(a.deepDiveBoxWidth(70)).Render(content.String())
-	help := HelpStyle.Render("↑↓ navigate • space toggle • enter/esc save & back • yellow = installed")
+	help := HelpStyle.Render("↑↓ navigate • space toggle • A all • enter/esc save & back • yellow = installed")
 
 	return lipgloss.Place(
 		a.width, a.height,
@@ -5,11 +5,16 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/tekierz/dotfiles/internal/config"
 	"github.com/tekierz/dotfiles/internal/tools"
 )
 
 // ManageConfig holds detailed management configuration for all tools
 type ManageConfig struct {
+	// SchemaVersion is the file format version; older files are migrated
+	// on load (see config.SchemaVersion)
+	SchemaVersion int
+
 	// Ghostty detailed settings
 	GhosttyFontFamily        string
 	GhosttyFontSize          int
@@ -21,6 +26,7 @@ type ManageConfig struct {
 	GhosttyConfirmClose      bool
 
 	// Tmux detailed settings
+	TmuxTemplate         string // tools.TmuxTemplates entry the generator starts from
 	TmuxPrefix           string
 	TmuxBaseIndex        int
 	TmuxMouseMode        bool
@@ -29,6 +35,7 @@ type ManageConfig struct {
 	TmuxHistoryLimit     int
 	TmuxEscapeTime       int
 	TmuxAggressiveResize bool
+	TmuxTruecolor        bool // tmux-256color with 24-bit color overrides
 
 	// Tmux TPM settings
 	TmuxTPMEnabled       bool
@@ -59,6 +66,7 @@ type ManageConfig struct {
 	NeovimUndoFile    bool
 
 	// Git detailed settings
+	GitTemplate         string // tools.GitTemplates entry the generator starts from
 	GitDefaultBranch    string
 	GitAutoSetupRemote  bool
 	GitPullRebase       bool
@@ -66,6 +74,9 @@ type ManageConfig struct {
 	GitMergeTool        string
 	GitCredentialHelper string
 	GitSignCommits      bool
+	// Identity, pre-filled from an existing .gitconfig
+	GitUserName  string
+	GitUserEmail string
 
 	// Yazi detailed settings
 	YaziShowHidden  bool
@@ -119,6 +130,8 @@ type ManageConfig struct {
 // NewManageConfig creates a new management config with defaults
 func NewManageConfig() *ManageConfig {
 	return &ManageConfig{
+		SchemaVersion: config.SchemaVersion,
+
 		// Ghostty
 		GhosttyFontFamily:        "JetBrainsMono Nerd Font",
 		GhosttyFontSize:          14,
@@ -130,6 +143,7 @@ func NewManageConfig() *ManageConfig {
 		GhosttyConfirmClose:      true,
 
 		// Tmux
+		TmuxTemplate:         tools.TmuxTemplates[0].Name,
 		TmuxPrefix:           "C-a",
 		TmuxBaseIndex:        1,
 		TmuxMouseMode:        true,
@@ -138,6 +152,7 @@ func NewManageConfig() *ManageConfig {
 		TmuxHistoryLimit:     50000,
 		TmuxEscapeTime:       10,
 		TmuxAggressiveResize: true,
+		TmuxTruecolor:        true,
 
 		// Tmux TPM
 		TmuxTPMEnabled:       true,
@@ -168,6 +183,7 @@ func NewManageConfig() *ManageConfig {
 		NeovimUndoFile:    true,
 
 		// Git
+		GitTemplate:         tools.GitTemplates[0].Name,
 		GitDefaultBranch:    "main",
 		GitAutoSetupRemote:  true,
 		GitPullRebase:       true,
@@ -226,6 +242,33 @@ func NewManageConfig() *ManageConfig {
 	}
 }
 
+// ImportGit pre-populates the Git settings from an existing .gitconfig. The
+// Manage credential picker has no "none", so helpers it can't show are left
+// at the default.
+func (c *ManageConfig) ImportGit(imp tools.GitImport) {
+	if imp.DefaultBranch != "" {
+		c.GitDefaultBranch = imp.DefaultBranch
+	}
+	if imp.PullRebase != nil {
+		c.GitPullRebase = *imp.PullRebase
+	}
+	if imp.SignCommits != nil {
+		c.GitSignCommits = *imp.SignCommits
+	}
+	if imp.AutoSetupRemote != nil {
+		c.GitAutoSetupRemote = *imp.AutoSetupRemote
+	}
+	if imp.CredentialHelper != "" && imp.CredentialHelper != "none" {
+		c.GitCredentialHelper = imp.CredentialHelper
+	}
+	if imp.UserName != "" {
+		c.GitUserName = imp.UserName
+	}
+	if imp.UserEmail != "" {
+		c.GitUserEmail = imp.UserEmail
+	}
+}
+
 // manageTool represents a tool in the manage list
 type manageTool struct {
 	id     string
@@ -48,7 +48,7 @@ func (a *App) renderMainMenu() string {
 
 	menu := strings.Join(menuLines, "\n")
 
-	help := HelpStyle.Render(truncateVisible("↑↓ navigate • enter select • q quit", maxLineW))
+	help := HelpStyle.Render(truncateVisible("↑↓ navigate • enter select • ctrl+t theme ("+a.theme+") • q quit", maxLineW))
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
 		title,
@@ -136,7 +136,7 @@ func (a *App) renderUpdate() string {
 
 	// Build subtitle with selection count
 	selectedCount := len(a.updateSelected)
-	subtitleText := fmt.Sprintf("Found %d outdated package(s)", len(updates))
+	subtitleText := fmt.Sprintf("Found %d outdated package(s) • sorted by %s", len(updates), updateSortLabel(a.updateSort))
 	if selectedCount > 0 {
 		subtitleText += fmt.Sprintf(" • %d selected", selectedCount)
 	}
@@ -169,7 +169,10 @@ func (a *App) renderUpdate() string {
 		newStyle := lipgloss.NewStyle().Foreground(ColorGreen)
 		checkStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
 
-		if a.updateSelected[i] {
+		if p.Pinned {
+			checkbox = "🔒"
+			style = style.Foreground(ColorTextMuted)
+		} else if a.updateSelected[p.Name] {
 			checkbox = "●"
 			checkStyle = lipgloss.NewStyle().Foreground(ColorCyan)
 		}
@@ -197,7 +200,11 @@ func (a *App) renderUpdate() string {
 		Width(maxInt(1, boxOuterW-2)). // border adds 2
 		Render(packageList)
 
-	help := HelpStyle.Render("↑↓ navigate • space select • enter update • a update all • r refresh • esc menu")
+	updateAll := "a update all"
+	if a.updatePaused() {
+		updateAll = fmt.Sprintf("a resume (%d left)", len(a.updateQueue))
+	}
+	help := HelpStyle.Render("↑↓ navigate • n next • space select • enter update • " + updateAll + " • s sort • r refresh • esc menu")
 
 	// Build content with optional status line
 	var contentParts []string
@@ -223,6 +230,8 @@ func (a *App) renderUpdateWithLogs(tabBar, title string) string {
 			spinner = "..."
 		}
 		statusTitle = fmt.Sprintf("UPDATING %s", spinner)
+	} else if a.updatePaused() {
+		statusTitle = "UPDATE PAUSED"
 	} else {
 		statusTitle = "UPDATE LOG"
 	}
@@ -301,9 +310,14 @@ func (a *App) renderUpdateWithLogs(tabBar, title string) string {
 
 	// Help text
 	var help string
-	if a.updateRunning {
+	switch {
+	case a.updateRunning && len(a.updateQueue) > 0:
+		help = HelpStyle.Render("p pause • updating... please wait")
+	case a.updateRunning:
 		help = HelpStyle.Render("updating... please wait")
-	} else {
+	case a.updatePaused():
+		help = HelpStyle.Render("a resume • c clear logs • pgup/pgdn scroll • r refresh (drops the rest) • esc menu")
+	default:
 		help = HelpStyle.Render("c clear logs • pgup/pgdn scroll • r refresh • esc menu")
 	}
 
@@ -449,6 +463,11 @@ func (a *App) renderBackups() string {
 		}
 		statusLine = statusStyle.Render(a.backupStatus)
 	}
+	if a.backupNaming {
+		statusLine = lipgloss.NewStyle().Foreground(ColorMagenta).Bold(true).Render("Backup label (optional): ") +
+			lipgloss.NewStyle().Foreground(ColorTextBright).Render(a.backupNameInput) +
+			lipgloss.NewStyle().Foreground(ColorCyan).Render("▌")
+	}
 
 	// Check if no backups
 	if len(a.backups) == 0 {
@@ -456,8 +475,10 @@ func (a *App) renderBackups() string {
 		var helpText string
 		if a.backupRunning {
 			helpText = "creating backup... please wait"
+		} else if a.backupNaming {
+			helpText = "enter create • esc cancel"
 		} else {
-			helpText = "n new backup • r refresh • 1-4 switch tabs • esc menu • q quit"
+			helpText = "n new backup • o open folder • r refresh • 1-4 switch tabs • esc menu • q quit"
 		}
 		help := HelpStyle.Render(helpText)
 
@@ -506,12 +527,18 @@ func (a *App) renderBackups() string {
 		dateStr := b.Timestamp.Format("Jan 02 15:04")
 
 		// Format size
-		sizeStr := formatBytes(b.Size)
+		sizeStr := FormatBytes(b.Size)
 
-		// Truncate name if needed
+		// Prefer the label, then the reason for automatic backups (the date
+		// has its own column); truncate if needed
 		displayName := b.Name
-		if len(displayName) > 24 {
-			displayName = displayName[:21] + "..."
+		if b.Label != "" {
+			displayName = b.Label
+		} else if b.Reason != "" {
+			displayName = b.Reason
+		}
+		if len([]rune(displayName)) > 24 {
+			displayName = string([]rune(displayName)[:21]) + "..."
 		}
 
 		line := fmt.Sprintf("%s%-24s %s %s %s",
@@ -542,13 +569,23 @@ func (a *App) renderBackups() string {
 	var detailsBox string
 	if len(a.backups) > 0 && a.backupIndex < len(a.backups) {
 		selected := a.backups[a.backupIndex]
+		label := selected.Label
+		if label == "" {
+			label = "—"
+		}
+		reason := selected.Reason
+		if reason == "" {
+			reason = "manual"
+		}
 		detailLines := []string{
 			lipgloss.NewStyle().Foreground(ColorMagenta).Bold(true).Render("DETAILS"),
 			"",
 			fmt.Sprintf("%s %s", lipgloss.NewStyle().Foreground(ColorTextMuted).Render("Name:"), lipgloss.NewStyle().Foreground(ColorText).Render(selected.Name)),
+			fmt.Sprintf("%s %s", lipgloss.NewStyle().Foreground(ColorTextMuted).Render("Label:"), lipgloss.NewStyle().Foreground(ColorText).Render(label)),
+			fmt.Sprintf("%s %s", lipgloss.NewStyle().Foreground(ColorTextMuted).Render("Reason:"), lipgloss.NewStyle().Foreground(ColorText).Render(reason)),
 			fmt.Sprintf("%s %s", lipgloss.NewStyle().Foreground(ColorTextMuted).Render("Date:"), lipgloss.NewStyle().Foreground(ColorText).Render(selected.Timestamp.Format("2006-01-02 15:04:05"))),
 			fmt.Sprintf("%s %d", lipgloss.NewStyle().Foreground(ColorTextMuted).Render("Files:"), selected.FileCount),
-			fmt.Sprintf("%s %s", lipgloss.NewStyle().Foreground(ColorTextMuted).Render("Size:"), formatBytes(selected.Size)),
+			fmt.Sprintf("%s %s", lipgloss.NewStyle().Foreground(ColorTextMuted).Render("Size:"), FormatBytes(selected.Size)),
 			fmt.Sprintf("%s %s", lipgloss.NewStyle().Foreground(ColorTextMuted).Render("Path:"), lipgloss.NewStyle().Foreground(ColorTextMuted).Render(truncateVisible(selected.Path, 40))),
 		}
 		detailsContent := strings.Join(detailLines, "\n")
@@ -560,14 +597,27 @@ func (a *App) renderBackups() string {
 			Render(detailsContent)
 	}
 
+	// A pending restore replaces the details with the file comparison (or a diff)
+	if a.backupConfirmMode && a.backupConfirmType == "restore" {
+		detailsBox = a.renderRestorePlan(boxOuterW)
+	}
+
 	// Help text
 	var helpText string
 	if a.backupRunning {
 		helpText = "please wait..."
+	} else if a.backupNaming {
+		helpText = "enter create • esc cancel"
+	} else if a.backupDiffView {
+		helpText = "←→ file • ↑↓ scroll • K keep this file • v/esc close diff • y restore • n cancel"
+	} else if a.backupConfirmMode && a.backupConfirmType == "restore" && len(a.restoreNewerFiles()) > 0 {
+		helpText = "y confirm • K keep newer files • v view diffs • n cancel"
+	} else if a.backupConfirmMode && a.backupConfirmType == "restore" && len(a.restoreDiffFiles()) > 0 {
+		helpText = "y confirm • v view diffs • n cancel"
 	} else if a.backupConfirmMode {
 		helpText = "y confirm • n cancel"
 	} else {
-		helpText = "up/down navigate • enter restore • d delete • n new backup • r refresh • esc menu"
+		helpText = "up/down navigate • enter restore • d delete • n new backup • o open • r refresh • esc menu"
 	}
 	help := HelpStyle.Render(helpText)
 
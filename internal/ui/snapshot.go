@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/pkg"
+)
+
+// Snapshot records the current machine for "dotfiles snapshot": the
+// installed tools the installer can select, installed helper scripts, the
+// theme and navigation style, and the Manage settings.
+func (a *App) Snapshot() *config.Snapshot {
+	a.ensureInstallCache()
+
+	snap := &config.Snapshot{
+		CreatedAt: time.Now().Format(time.RFC3339),
+		Platform:  string(pkg.DetectPlatform()),
+		Theme:     a.theme,
+		NavStyle:  a.navStyle,
+		Tools:     []string{},
+	}
+	snap.Hostname, _ = os.Hostname()
+	if user, err := config.GetActiveUserForHost(); err == nil && user != nil {
+		snap.KeyboardStyle = user.KeyboardStyle
+	}
+
+	c := a.deepDiveConfig
+	for _, m := range c.selectionMaps() {
+		for id := range m {
+			if !a.manageInstalled[id] {
+				continue
+			}
+			if _, ok := c.Utilities[id]; ok {
+				snap.Utilities = append(snap.Utilities, id)
+			} else {
+				snap.Tools = append(snap.Tools, id)
+			}
+		}
+	}
+	sort.Strings(snap.Tools)
+	sort.Strings(snap.Utilities)
+
+	if data, err := json.Marshal(a.manageConfig); err == nil {
+		snap.Manage = data
+	}
+	return snap
+}
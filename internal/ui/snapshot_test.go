@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/testutil"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	dir := testutil.TempConfigDir(t)
+
+	cfg := NewDeepDiveConfig()
+	cfg.CLITools = map[string]bool{"lazygit": false, "btop": false}
+	cfg.CLIUtilities = map[string]bool{"bat": true}
+	cfg.GUIApps = map[string]bool{}
+	cfg.Utilities = map[string]bool{"hk": false, "caff": false}
+	cfg.MacApps = map[string]bool{}
+	manage := NewManageConfig()
+	manage.TmuxPrefix = "C-b"
+	a := &App{
+		theme:                "nord",
+		navStyle:             "vim",
+		deepDiveConfig:       cfg,
+		manageConfig:         manage,
+		manageInstalledReady: true,
+		manageInstalled:      map[string]bool{"lazygit": true, "hk": true, "bat": false, "neovim": true},
+	}
+
+	snap := a.Snapshot()
+	if got := strings.Join(snap.Tools, ","); got != "lazygit" {
+		t.Errorf("Tools = %q, want only installed selectable tools", got)
+	}
+	if got := strings.Join(snap.Utilities, ","); got != "hk" {
+		t.Errorf("Utilities = %q, want hk", got)
+	}
+
+	path := filepath.Join(dir, "machine.json")
+	if err := config.SaveSnapshot(path, snap); err != nil {
+		t.Fatalf("SaveSnapshot() failed: %v", err)
+	}
+	loaded, err := config.LoadSnapshot(path)
+	if err != nil {
+		t.Fatalf("LoadSnapshot() failed: %v", err)
+	}
+
+	// Installing from the snapshot selects exactly what was installed
+	b := &App{theme: "catppuccin-mocha", navStyle: "emacs", deepDiveConfig: NewDeepDiveConfig()}
+	WithSnapshot(loaded)(b)
+	if got := strings.Join(b.deepDiveConfig.SelectedTools(), ","); got != "hk,lazygit" {
+		t.Errorf("selected = %q, want hk,lazygit", got)
+	}
+	if b.theme != "nord" || b.navStyle != "vim" || !b.deepDive {
+		t.Errorf("theme=%q nav=%q deepDive=%v, want nord, vim, deep dive", b.theme, b.navStyle, b.deepDive)
+	}
+	if !strings.Contains(string(loaded.Manage), `"C-b"`) {
+		t.Errorf("Manage = %s, want the tmux prefix recorded", loaded.Manage)
+	}
+
+	// The Manage settings are only saved once the install starts
+	if b.manageConfig == nil || b.manageConfig.TmuxPrefix != "C-b" {
+		t.Errorf("manageConfig = %+v, want the snapshot's tmux prefix", b.manageConfig)
+	}
+	if config.ToolConfigExists("manage") {
+		t.Fatal("WithSnapshot saved the Manage settings before the install started")
+	}
+	if err := b.saveSnapshotSettings(); err != nil {
+		t.Fatalf("saveSnapshotSettings() failed: %v", err)
+	}
+	saved, err := config.LoadToolConfig("manage", NewManageConfig)
+	if err != nil || saved.TmuxPrefix != "C-b" {
+		t.Errorf("saved manage = %+v, %v; want the snapshot's tmux prefix", saved, err)
+	}
+}
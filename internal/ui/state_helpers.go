@@ -56,28 +56,7 @@ func (a *App) handleTabNavigationWithCmd(key string) (bool, tea.Cmd) {
 	a.screen = targetScreen
 
 	// Start async operations when switching to certain screens
-	if targetScreen == ScreenUpdate && !a.updateChecking && !a.updateCheckDone {
-		a.updateChecking = true
-		return true, checkUpdatesCmd()
-	}
-
-	if targetScreen == ScreenManage {
-		if cmd := a.startInstallCacheLoad(); cmd != nil {
-			return true, cmd
-		}
-	}
-
-	if targetScreen == ScreenUsers && !a.usersLoaded {
-		a.usersLoaded = true
-		return true, loadUsersCmd()
-	}
-
-	if targetScreen == ScreenBackups && !a.backupsLoading && !a.backupsLoaded {
-		a.backupsLoading = true
-		return true, loadBackupsCmd()
-	}
-
-	return true, nil
+	return true, a.screenEnterCmd(targetScreen)
 }
 
 // handleTabNavigation handles number key shortcuts for tab navigation
@@ -133,6 +112,20 @@ func (a *App) getDeepDiveItemStatus(item DeepDiveMenuItem) string {
 	return "pending" // None installed (grey)
 }
 
+// deepDiveStatusCounts tallies getDeepDiveItemStatus over the menu items that
+// map to tools; groups is how many such items there are
+func (a *App) deepDiveStatusCounts(items []DeepDiveMenuItem) (counts map[string]int, groups int) {
+	counts = make(map[string]int)
+	for _, item := range items {
+		if len(ScreenToolIDs[item.Screen]) == 0 {
+			continue
+		}
+		groups++
+		counts[a.getDeepDiveItemStatus(item)]++
+	}
+	return counts, groups
+}
+
 // getConfigScreenMaxFields returns the number of fields for the current config screen
 func (a *App) getConfigScreenMaxFields() int {
 	switch a.screen {
@@ -140,9 +133,9 @@ func (a *App) getConfigScreenMaxFields() int {
 		return 7
 	case ScreenConfigTmux:
 		if a.deepDiveConfig.TmuxTPMEnabled {
-			return 13
+			return 14
 		}
-		return 8
+		return 9
 	case ScreenConfigZsh:
 		return 13
 	case ScreenConfigNeovim:
@@ -168,6 +161,35 @@ func (a *App) getConfigScreenMaxFields() int {
 	}
 }
 
+// toggleAllNotInstalled selects every item in ids that isn't installed yet,
+// or deselects them all if they already are. Installed items are left alone,
+// as the space toggle does.
+func (a *App) toggleAllNotInstalled(selection map[string]bool, ids []string) {
+	allSelected := true
+	for _, id := range ids {
+		if !a.manageInstalled[id] && !selection[id] {
+			allSelected = false
+			break
+		}
+	}
+	for _, id := range ids {
+		if !a.manageInstalled[id] {
+			selection[id] = !allSelected
+		}
+	}
+}
+
+// nextIndex returns the first index after from (wrapping around, ending at
+// from itself) for which match is true, or -1 if none matches
+func nextIndex(n, from int, match func(i int) bool) int {
+	for step := 1; step <= n; step++ {
+		if i := (from + step) % n; match(i) {
+			return i
+		}
+	}
+	return -1
+}
+
 // togglePlugin adds or removes a plugin from the list
 func togglePlugin(plugins *[]string, plugin string) {
 	for i, p := range *plugins {
@@ -278,6 +278,16 @@ var ThemePalettes = map[string]ColorPalette{
 // CurrentPalette holds the active theme's colors
 var CurrentPalette = ThemePalettes["neon-seapunk"]
 
+// accentOverride replaces the palette accent when following the system accent
+var accentOverride lipgloss.Color
+
+// SetAccentOverride sets (or, with "", clears) a color that replaces the
+// current theme's accent, keeping the rest of the palette
+func SetAccentOverride(color string) {
+	accentOverride = lipgloss.Color(color)
+	updateDynamicColors()
+}
+
 // SetTheme updates the current palette based on theme name
 func SetTheme(theme string) {
 	if p, ok := ThemePalettes[theme]; ok {
@@ -288,26 +298,13 @@ func SetTheme(theme string) {
 
 // updateDynamicColors updates the legacy color variables from CurrentPalette
 func updateDynamicColors() {
-	ColorCyan = CurrentPalette.Accent
-	ColorNeonBlue = CurrentPalette.Info
-	ColorMagenta = CurrentPalette.AccentAlt
-	ColorNeonPink = CurrentPalette.AccentAlt
-	ColorNeonPurple = CurrentPalette.AccentAlt
-	ColorGreen = CurrentPalette.Success
-	ColorYellow = CurrentPalette.Warning
-	ColorRed = CurrentPalette.Error
-	ColorBg = CurrentPalette.Bg
-	ColorSurface = CurrentPalette.Surface
-	ColorOverlay = CurrentPalette.Overlay
-	ColorMuted = CurrentPalette.Border // Use border color as muted background
-	ColorBorder = CurrentPalette.Border
-	ColorText = CurrentPalette.Text
-	ColorTextMuted = CurrentPalette.TextMuted
-	ColorTextBright = CurrentPalette.TextBright
+	for i, c := range resolveColors(CurrentPalette) {
+		*colorVars[i].dst = c.Hex
+	}
 
 	// Update gradients based on current palette
 	GradientCyber = []lipgloss.Color{
-		CurrentPalette.Accent,
+		ColorCyan,
 		CurrentPalette.Info,
 		CurrentPalette.AccentAlt,
 	}
@@ -0,0 +1,103 @@
+package ui
+
+import "github.com/charmbracelet/lipgloss"
+
+// NamedColor is one resolved color of a theme
+type NamedColor struct {
+	Name string         // Variable or palette field name, e.g. "ColorCyan"
+	From string         // Palette field the variable takes its value from
+	Hex  lipgloss.Color // Resolved value
+}
+
+// colorVars lists the Color* variables SetTheme assigns, in assignment
+// order, and the palette field each takes its value from
+var colorVars = []struct {
+	name string
+	from string
+	dst  *lipgloss.Color
+}{
+	{"ColorCyan", "Accent", &ColorCyan},
+	{"ColorNeonBlue", "Info", &ColorNeonBlue},
+	{"ColorMagenta", "AccentAlt", &ColorMagenta},
+	{"ColorNeonPink", "AccentAlt", &ColorNeonPink},
+	{"ColorNeonPurple", "AccentAlt", &ColorNeonPurple},
+	{"ColorGreen", "Success", &ColorGreen},
+	{"ColorYellow", "Warning", &ColorYellow},
+	{"ColorRed", "Error", &ColorRed},
+	{"ColorBg", "Bg", &ColorBg},
+	{"ColorSurface", "Surface", &ColorSurface},
+	{"ColorOverlay", "Overlay", &ColorOverlay},
+	{"ColorMuted", "Border", &ColorMuted},
+	{"ColorBorder", "Border", &ColorBorder},
+	{"ColorText", "Text", &ColorText},
+	{"ColorTextMuted", "TextMuted", &ColorTextMuted},
+	{"ColorTextBright", "TextBright", &ColorTextBright},
+}
+
+// resolveColors returns the colorVars values for p, with accentOverride in
+// place of the accent. updateDynamicColors applies them and ThemeColors
+// reports them, so the two can't disagree.
+func resolveColors(p ColorPalette) []NamedColor {
+	colors := make([]NamedColor, len(colorVars))
+	for i, v := range colorVars {
+		hex := paletteField(p, v.from)
+		if v.from == "Accent" && accentOverride != "" {
+			hex = accentOverride
+		}
+		colors[i] = NamedColor{Name: v.name, From: v.from, Hex: hex}
+	}
+	return colors
+}
+
+// paletteField returns the palette field called name
+func paletteField(p ColorPalette, name string) lipgloss.Color {
+	for _, f := range paletteFields {
+		if f.name == name {
+			return f.get(p)
+		}
+	}
+	return ""
+}
+
+// paletteFields lists a ColorPalette's fields in declaration order
+var paletteFields = []struct {
+	name string
+	get  func(ColorPalette) lipgloss.Color
+}{
+	{"Accent", func(p ColorPalette) lipgloss.Color { return p.Accent }},
+	{"AccentAlt", func(p ColorPalette) lipgloss.Color { return p.AccentAlt }},
+	{"Info", func(p ColorPalette) lipgloss.Color { return p.Info }},
+	{"Success", func(p ColorPalette) lipgloss.Color { return p.Success }},
+	{"Warning", func(p ColorPalette) lipgloss.Color { return p.Warning }},
+	{"Error", func(p ColorPalette) lipgloss.Color { return p.Error }},
+	{"Bg", func(p ColorPalette) lipgloss.Color { return p.Bg }},
+	{"Surface", func(p ColorPalette) lipgloss.Color { return p.Surface }},
+	{"Overlay", func(p ColorPalette) lipgloss.Color { return p.Overlay }},
+	{"Border", func(p ColorPalette) lipgloss.Color { return p.Border }},
+	{"Text", func(p ColorPalette) lipgloss.Color { return p.Text }},
+	{"TextMuted", func(p ColorPalette) lipgloss.Color { return p.TextMuted }},
+	{"TextBright", func(p ColorPalette) lipgloss.Color { return p.TextBright }},
+}
+
+// ThemeColors returns the Color* variables SetTheme(theme) would apply, in
+// the order they're assigned, without changing the current theme
+func ThemeColors(theme string) ([]NamedColor, bool) {
+	p, ok := ThemePalettes[theme]
+	if !ok {
+		return nil, false
+	}
+	return resolveColors(p), true
+}
+
+// ThemePalette returns a theme's palette fields in declaration order
+func ThemePalette(theme string) ([]NamedColor, bool) {
+	p, ok := ThemePalettes[theme]
+	if !ok {
+		return nil, false
+	}
+	colors := make([]NamedColor, len(paletteFields))
+	for i, f := range paletteFields {
+		colors[i] = NamedColor{Name: f.name, Hex: f.get(p)}
+	}
+	return colors, true
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestThemeColorsMatchSetTheme(t *testing.T) {
+	saved := CurrentPalette
+	defer func() {
+		CurrentPalette = saved
+		updateDynamicColors()
+	}()
+
+	for theme := range ThemePalettes {
+		colors, ok := ThemeColors(theme)
+		if !ok {
+			t.Fatalf("ThemeColors(%q) not found", theme)
+		}
+		SetTheme(theme)
+		applied := map[string]lipgloss.Color{
+			"ColorCyan": ColorCyan, "ColorNeonBlue": ColorNeonBlue, "ColorMagenta": ColorMagenta,
+			"ColorNeonPink": ColorNeonPink, "ColorNeonPurple": ColorNeonPurple, "ColorGreen": ColorGreen,
+			"ColorYellow": ColorYellow, "ColorRed": ColorRed, "ColorBg": ColorBg,
+			"ColorSurface": ColorSurface, "ColorOverlay": ColorOverlay, "ColorMuted": ColorMuted,
+			"ColorBorder": ColorBorder, "ColorText": ColorText, "ColorTextMuted": ColorTextMuted,
+			"ColorTextBright": ColorTextBright,
+		}
+		if len(colors) != len(applied) {
+			t.Errorf("%s: ThemeColors has %d colors, SetTheme assigns %d", theme, len(colors), len(applied))
+		}
+		for _, c := range colors {
+			if applied[c.Name] != c.Hex {
+				t.Errorf("%s: %s = %q, SetTheme applies %q", theme, c.Name, c.Hex, applied[c.Name])
+			}
+		}
+	}
+
+	if _, ok := ThemeColors("no-such-theme"); ok {
+		t.Error("ThemeColors() found an unknown theme")
+	}
+}
+
+func TestThemeColorsFollowAccentOverride(t *testing.T) {
+	saved := CurrentPalette
+	defer func() {
+		CurrentPalette = saved
+		SetAccentOverride("")
+	}()
+
+	SetAccentOverride("#123456")
+	SetTheme("nord")
+	colors, _ := ThemeColors("nord")
+	if colors[0].Name != "ColorCyan" || colors[0].Hex != ColorCyan || ColorCyan != "#123456" {
+		t.Errorf("ThemeColors %s = %q, SetTheme applies %q; want the override", colors[0].Name, colors[0].Hex, ColorCyan)
+	}
+}
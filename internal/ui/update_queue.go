@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tekierz/dotfiles/internal/pkg"
+)
+
+// Update all works through a queue, one package at a time, instead of the
+// manager's single upgrade-everything call, so it can be paused and resumed
+// from the package it stopped at:
+//
+//	p      pause once the package being updated finishes
+//	p p    stop that package now too (it stays queued and is retried)
+//	a      resume the remaining packages
+//
+// updateQueue[0] is the package in flight while updateRunning is set; a
+// paused queue is a non-empty updateQueue with updateRunning unset.
+
+// updatePausedStatus is shown when a key that would start another update is
+// pressed while an update all is paused
+const updatePausedStatus = "Update all is paused • a resume or r to drop it"
+
+// updatePaused reports whether an update all was paused with packages left
+func (a *App) updatePaused() bool {
+	return !a.updateRunning && len(a.updateQueue) > 0
+}
+
+// startUpdateQueue begins updating pkgs one at a time, or resumes the
+// paused queue when pkgs is nil
+func (a *App) startUpdateQueue(pkgs []pkg.Package) tea.Cmd {
+	if pkgs != nil {
+		a.updateQueue = pkgs
+		a.updateQueueTotal = len(pkgs)
+		a.updateQueueResults = nil
+	}
+	a.updatePausing = false
+	return a.nextUpdateStep()
+}
+
+// nextUpdateStep starts updating the head of the queue, or finishes the
+// update all once the queue is empty
+func (a *App) nextUpdateStep() tea.Cmd {
+	if len(a.updateQueue) == 0 {
+		return a.finishUpdateQueue()
+	}
+	p := a.updateQueue[0]
+	ctx, cancel := context.WithCancel(context.Background())
+	a.updateCancel = cancel
+	a.updateRunning = true
+	a.updateStatus = fmt.Sprintf("Updating %s (%d/%d)...", p.Name, len(a.updateQueueResults)+1, a.updateQueueTotal)
+	return updatePackageCmd(ctx, p)
+}
+
+// updatePackageCmd updates a single package, collecting its output
+func updatePackageCmd(ctx context.Context, p pkg.Package) tea.Cmd {
+	return func() tea.Msg {
+		mgr := pkg.DetectManager()
+		if mgr == nil {
+			return updateStepMsg{pkg: p, err: pkg.ErrNoManager}
+		}
+
+		cmd, err := mgr.UpdateStreaming(ctx, p.Name)
+		if err != nil {
+			return updateStepMsg{pkg: p, err: err, canceled: ctx.Err() != nil}
+		}
+		var logs []string
+		for line := range cmd.Output {
+			logs = append(logs, line)
+		}
+		err = pkg.Classify(cmd.Wait(), strings.Join(logs, "\n"))
+		return updateStepMsg{pkg: p, logs: logs, err: err, canceled: ctx.Err() != nil}
+	}
+}
+
+// handleUpdateStep records a finished package and moves on to the next,
+// unless a pause was requested
+func (a *App) handleUpdateStep(msg updateStepMsg) tea.Cmd {
+	if a.updateCancel != nil {
+		a.updateCancel()
+		a.updateCancel = nil
+	}
+	a.appendInstallLog(fmt.Sprintf("▶ %s", msg.pkg.Name))
+	for _, line := range msg.logs {
+		a.appendInstallLog(line)
+	}
+
+	// A package stopped by the pause stays at the head of the queue
+	if !msg.canceled {
+		a.updateQueueResults = append(a.updateQueueResults, pkg.UpdateResult{
+			Package: msg.pkg,
+			Success: msg.err == nil,
+			Error:   msg.err,
+		})
+		if msg.err != nil {
+			a.appendInstallLog(fmt.Sprintf("✗ %s: %v", msg.pkg.Name, msg.err))
+		}
+		if len(a.updateQueue) > 0 {
+			a.updateQueue = a.updateQueue[1:]
+		}
+	}
+
+	if (a.updatePausing || msg.canceled) && len(a.updateQueue) > 0 {
+		a.updateRunning = false
+		a.updatePausing = false
+		a.installLogAutoScroll = false
+		a.updateStatus = fmt.Sprintf("Paused after %d/%d • %d package(s) left • a resume",
+			len(a.updateQueueResults), a.updateQueueTotal, len(a.updateQueue))
+		return nil
+	}
+	return a.nextUpdateStep()
+}
+
+// pauseUpdateQueue asks the running update all to stop after the current
+// package; asked again, it cancels that package too
+func (a *App) pauseUpdateQueue() {
+	if !a.updateRunning || len(a.updateQueue) == 0 {
+		return
+	}
+	if !a.updatePausing {
+		a.updatePausing = true
+		a.updateStatus = fmt.Sprintf("Pausing after %s • p again to stop it now", a.updateQueue[0].Name)
+		return
+	}
+	if a.updateCancel != nil {
+		a.updateCancel()
+		a.updateStatus = fmt.Sprintf("Stopping %s...", a.updateQueue[0].Name)
+	}
+}
+
+// finishUpdateQueue reports the results of a completed update all and
+// refreshes the package list
+func (a *App) finishUpdateQueue() tea.Cmd {
+	a.updateRunning = false
+	a.updatePausing = false
+	a.installLogAutoScroll = false
+	a.updateStatus = updateResultsStatus(a.updateQueueResults)
+	a.updateQueue = nil
+	a.updateQueueResults = nil
+	a.updateSelected = make(map[string]bool)
+	a.updateCheckDone = false
+	a.updateChecking = true
+	return checkUpdatesCmd()
+}
+
+// updateResultsStatus summarizes an update run for the status line
+func updateResultsStatus(results []pkg.UpdateResult) string {
+	successes, failures := 0, 0
+	for _, r := range results {
+		if r.Success {
+			successes++
+		} else {
+			failures++
+		}
+	}
+	switch {
+	case failures > 0:
+		return fmt.Sprintf("Updated %d, failed %d", successes, failures)
+	case successes > 0:
+		return fmt.Sprintf("Updated %d package(s) ✓", successes)
+	default:
+		return "Update complete ✓"
+	}
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tekierz/dotfiles/internal/pkg"
+	"github.com/tekierz/dotfiles/internal/testutil"
+)
+
+func TestUpdateAllPauseResume(t *testing.T) {
+	testutil.TempConfigDir(t)
+	defer pkg.SetManager(pkg.NewMockPackageManager())()
+
+	bat, git, zsh := pkg.Package{Name: "bat"}, pkg.Package{Name: "git"}, pkg.Package{Name: "zsh"}
+	a := &App{
+		screen:          ScreenUpdate,
+		width:           120,
+		height:          40,
+		updateCheckDone: true,
+		updateResults:   []pkg.Package{bat, {Name: "fzf", Pinned: true}, git, zsh},
+		updateSelected:  map[string]bool{},
+	}
+	key := func(k string) tea.Cmd {
+		_, cmd := a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		return cmd
+	}
+	// start runs the sudo check a key returned and delivers its updateStartMsg
+	start := func(cmd tea.Cmd) {
+		t.Helper()
+		if cmd == nil {
+			t.Fatal("expected an update command")
+		}
+		msg, ok := cmd().(updateStartMsg)
+		if !ok || !msg.all {
+			t.Fatalf("got %#v, want updateStartMsg{all: true}", msg)
+		}
+		a.Update(msg)
+	}
+
+	// a queues the unpinned packages and starts on the first
+	start(key("a"))
+	if !a.updateRunning || len(a.updateQueue) != 3 || a.updateQueue[0].Name != "bat" {
+		t.Fatalf("running=%v queue=%v, want bat, git, zsh with bat in flight", a.updateRunning, a.updateQueue)
+	}
+
+	// p pauses once bat finishes
+	key("p")
+	a.Update(updateStepMsg{pkg: bat, logs: []string{"bat upgraded"}})
+	if !a.updatePaused() || len(a.updateQueue) != 2 || len(a.updateQueueResults) != 1 {
+		t.Fatalf("after pause: paused=%v queue=%v results=%d, want git and zsh left", a.updatePaused(), a.updateQueue, len(a.updateQueueResults))
+	}
+	view := a.View()
+	testutil.RequireContains(t, view, "UPDATE PAUSED", "log panel should show the pause")
+	testutil.RequireContains(t, view, "a resume", "help should offer resuming")
+
+	// Updating other packages would leave the paused queue stale
+	a.updateIndex = 2
+	if _, cmd := a.handleKey(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || a.updateStatus != updatePausedStatus {
+		t.Fatalf("enter while paused: status=%q, want it blocked", a.updateStatus)
+	}
+	if key(" "); len(a.updateSelected) != 0 || len(a.updateQueue) != 2 {
+		t.Fatalf("space while paused: selected=%v queue=%v, want nothing changed", a.updateSelected, a.updateQueue)
+	}
+
+	// a resumes from git, keeping the log
+	start(key("a"))
+	if !a.updateRunning || a.updateQueue[0].Name != "git" || !strings.Contains(a.updateStatus, "(2/3)") {
+		t.Fatalf("resume: running=%v queue=%v status=%q, want git as 2/3", a.updateRunning, a.updateQueue, a.updateStatus)
+	}
+	if len(a.installLogs) == 0 {
+		t.Error("resuming should keep the log of the packages already updated")
+	}
+
+	// p p stops git now; it stays queued
+	key("p")
+	key("p")
+	a.Update(updateStepMsg{pkg: git, canceled: true})
+	if !a.updatePaused() || a.updateQueue[0].Name != "git" || len(a.updateQueueResults) != 1 {
+		t.Fatalf("after stop: queue=%v results=%d, want git still queued", a.updateQueue, len(a.updateQueueResults))
+	}
+
+	// Resumed, the remaining packages run to the end
+	start(key("a"))
+	a.Update(updateStepMsg{pkg: git})
+	if a.updateQueue[0].Name != "zsh" || !a.updateRunning {
+		t.Fatalf("queue=%v, want zsh in flight after git", a.updateQueue)
+	}
+	a.Update(updateStepMsg{pkg: zsh, err: pkg.ErrNoManager})
+	if a.updateRunning || len(a.updateQueue) != 0 || a.updateStatus != "Updated 2, failed 1" || !a.updateChecking {
+		t.Errorf("done: running=%v queue=%v status=%q checking=%v, want the summary and a refresh",
+			a.updateRunning, a.updateQueue, a.updateStatus, a.updateChecking)
+	}
+}
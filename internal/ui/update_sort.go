@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tekierz/dotfiles/internal/config"
+	"github.com/tekierz/dotfiles/internal/pkg"
+)
+
+// Update list sort modes (persisted as GlobalConfig.UpdateSort).
+// The empty string keeps the order reported by the package manager.
+const (
+	updateSortOriginal = ""
+	updateSortName     = "name"
+)
+
+// nextUpdateSort cycles original → name → original
+func nextUpdateSort(current string) string {
+	if current == updateSortOriginal {
+		return updateSortName
+	}
+	return updateSortOriginal
+}
+
+// updateSortLabel returns a human label for a sort mode
+func updateSortLabel(mode string) string {
+	switch mode {
+	case updateSortName:
+		return "name"
+	}
+	return "default order"
+}
+
+// sortUpdates returns a copy of pkgs ordered by mode. Unknown modes keep
+// the original order.
+func sortUpdates(pkgs []pkg.Package, mode string) []pkg.Package {
+	if pkgs == nil {
+		return nil
+	}
+	sorted := append([]pkg.Package(nil), pkgs...)
+	if mode == updateSortName {
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return strings.ToLower(sorted[i].Name) < strings.ToLower(sorted[j].Name)
+		})
+	}
+	return sorted
+}
+
+// applyUpdateSort re-sorts the update list, keeping the cursor on the same package.
+// Selections are keyed by package name so they survive re-sorting.
+func (a *App) applyUpdateSort() {
+	current := ""
+	if a.updateIndex >= 0 && a.updateIndex < len(a.updateResults) {
+		current = a.updateResults[a.updateIndex].Name
+	}
+
+	a.updateResults = sortUpdates(a.updateOriginal, a.updateSort)
+
+	for i, p := range a.updateResults {
+		if p.Name == current {
+			a.updateIndex = i
+			return
+		}
+	}
+}
+
+// saveUpdateSortCmd persists the Update screen sort mode. A global.json
+// that can't be loaded is left alone, not replaced with defaults.
+func saveUpdateSortCmd(mode string) tea.Cmd {
+	return func() tea.Msg {
+		g, err := config.LoadGlobalConfig()
+		if err == nil {
+			g.UpdateSort = mode
+			err = config.SaveGlobalConfig(g)
+		}
+		if err != nil {
+			return updateSortSavedMsg{err: err}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tekierz/dotfiles/internal/pkg"
+	"github.com/tekierz/dotfiles/internal/testutil"
+)
+
+func TestSortUpdates(t *testing.T) {
+	pkgs := []pkg.Package{
+		{Name: "neovim"},
+		{Name: "bat"},
+		{Name: "Eza"},
+	}
+
+	tests := []struct {
+		mode string
+		want []string
+	}{
+		{updateSortOriginal, []string{"neovim", "bat", "Eza"}},
+		{updateSortName, []string{"bat", "Eza", "neovim"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(updateSortLabel(tt.mode), func(t *testing.T) {
+			got := sortUpdates(pkgs, tt.mode)
+			for i, name := range tt.want {
+				if got[i].Name != name {
+					t.Errorf("sortUpdates(%q)[%d] = %q, want %q", tt.mode, i, got[i].Name, name)
+				}
+			}
+		})
+	}
+
+	if pkgs[0].Name != "neovim" {
+		t.Error("sortUpdates should not modify its input")
+	}
+}
+
+func TestNextUpdateSort(t *testing.T) {
+	tests := []struct {
+		current string
+		want    string
+	}{
+		{updateSortOriginal, updateSortName},
+		{updateSortName, updateSortOriginal},
+		{"size", updateSortOriginal}, // No longer offered; an old saved value resets
+	}
+
+	for _, tt := range tests {
+		if got := nextUpdateSort(tt.current); got != tt.want {
+			t.Errorf("nextUpdateSort(%q) = %q, want %q", tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestApplyUpdateSortKeepsCursorAndSelection(t *testing.T) {
+	a := &App{
+		updateOriginal: []pkg.Package{{Name: "zsh"}, {Name: "bat"}, {Name: "git"}},
+		updateSelected: map[string]bool{"git": true},
+	}
+	a.updateResults = sortUpdates(a.updateOriginal, updateSortOriginal)
+	a.updateIndex = 0 // zsh
+
+	a.updateSort = updateSortName
+	a.applyUpdateSort()
+
+	if got := a.updateResults[a.updateIndex].Name; got != "zsh" {
+		t.Errorf("cursor on %q after sort, want %q", got, "zsh")
+	}
+	if !a.updateSelected["git"] {
+		t.Error("selection should survive re-sorting")
+	}
+}
+
+func TestUpdateSortKeepsCorruptConfig(t *testing.T) {
+	dir := testutil.TempConfigDir(t)
+	corrupt := `{"theme": "nord",`
+	path := testutil.CreateTempFile(t, dir, "global.json", corrupt)
+	a := &App{
+		screen:          ScreenUpdate,
+		updateCheckDone: true,
+		updateOriginal:  []pkg.Package{{Name: "zsh"}, {Name: "bat"}},
+		updateResults:   []pkg.Package{{Name: "zsh"}, {Name: "bat"}},
+		updateSelected:  map[string]bool{},
+	}
+
+	_, cmd := a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if cmd == nil {
+		t.Fatal("s should save the sort mode")
+	}
+	a.Update(cmd())
+	if got := testutil.MustReadFile(t, path); got != corrupt {
+		t.Errorf("global.json = %q, want it left byte-identical", got)
+	}
+	testutil.RequireContains(t, a.updateStatus, "Sort not saved", "the failed save should be reported")
+}
+
+func TestUpdateJumpToNextSelectable(t *testing.T) {
+	a := &App{
+		screen:          ScreenUpdate,
+		updateCheckDone: true,
+		updateResults:   []pkg.Package{{Name: "bat"}, {Name: "git", Pinned: true}, {Name: "zsh"}},
+		updateSelected:  map[string]bool{},
+	}
+	press := func() { a.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}) }
+
+	press()
+	if a.updateIndex != 2 {
+		t.Errorf("n from bat: index = %d, want 2 (skipping pinned git)", a.updateIndex)
+	}
+	press()
+	if a.updateIndex != 0 {
+		t.Errorf("n from zsh: index = %d, want wrap to 0", a.updateIndex)
+	}
+}
@@ -0,0 +1,287 @@
+// Package wallpaper derives a color theme from an image's dominant colors.
+package wallpaper
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // register decoders for image.Decode
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"os"
+	"sort"
+)
+
+const (
+	maxSamples = 128 // samples along the image's longer side
+	clusters   = 8   // dominant colors to extract
+	iterations = 12  // k-means rounds
+)
+
+// Scheme is a theme derived from an image
+type Scheme struct {
+	Light bool // the image is mostly light, so the theme has a light background
+	// Colors maps palette fields (accent, accent_alt, info, success, warning,
+	// error, bg, surface, overlay, border, text, text_muted, text_bright) to
+	// #rrggbb values
+	Colors map[string]string
+}
+
+// Swatch is one dominant color and the share of the image it covers
+type Swatch struct {
+	Color color.RGBA
+	Share float64
+}
+
+// FromFile decodes a PNG, JPEG or GIF image and derives a scheme from it
+func FromFile(path string) (Scheme, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Scheme{}, err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return Scheme{}, fmt.Errorf("failed to decode %s (PNG, JPEG and GIF are supported): %w", path, err)
+	}
+	return FromImage(img), nil
+}
+
+// FromImage derives a scheme from img's dominant colors: the background and
+// text follow its overall lightness and hue, the accents its most vivid
+// colors, and the semantic colors (success, warning, …) the nearest image
+// color of the expected hue, or that hue tinted to match when there is none.
+func FromImage(img image.Image) Scheme {
+	swatches := Dominant(img)
+	light := averageLightness(swatches) > 0.6
+
+	bgSwatch := swatches[0]
+	for _, s := range swatches {
+		if (light && lightness(s.Color) > lightness(bgSwatch.Color)) ||
+			(!light && lightness(s.Color) < lightness(bgSwatch.Color)) {
+			bgSwatch = s
+		}
+	}
+	bg := toHSL(bgSwatch.Color)
+	bg.s = math.Min(bg.s, 0.35)
+
+	// Surfaces step away from the background, text sits at the far end
+	shade := func(l float64) string {
+		if light {
+			l = 1 - l
+		}
+		return hsl{bg.h, bg.s, l}.hex()
+	}
+	textSat := math.Min(bg.s, 0.15)
+	text := func(l float64) string {
+		if light {
+			l = 1 - l
+		}
+		return hsl{bg.h, textSat, l}.hex()
+	}
+
+	accentL := 0.68
+	if light {
+		accentL = 0.42
+	}
+	vivid := func(h hsl) string {
+		return hsl{h.h, math.Max(h.s, 0.5), accentL}.hex()
+	}
+
+	byVividness := append([]Swatch(nil), swatches...)
+	sort.SliceStable(byVividness, func(i, j int) bool {
+		return vividness(byVividness[i]) > vividness(byVividness[j])
+	})
+	accent := toHSL(byVividness[0].Color)
+	accentAlt := hsl{math.Mod(accent.h+60, 360), accent.s, accent.l}
+	for _, s := range byVividness[1:] {
+		if h := toHSL(s.Color); h.s >= 0.25 && hueDistance(h.h, accent.h) >= 40 {
+			accentAlt = h
+			break
+		}
+	}
+
+	semantic := func(hue float64) string {
+		for _, s := range byVividness {
+			if h := toHSL(s.Color); h.s >= 0.25 && hueDistance(h.h, hue) <= 25 {
+				return vivid(h)
+			}
+		}
+		return vivid(hsl{hue, 0.6, accentL})
+	}
+
+	return Scheme{
+		Light: light,
+		Colors: map[string]string{
+			"accent":      vivid(accent),
+			"accent_alt":  vivid(accentAlt),
+			"info":        semantic(210),
+			"success":     semantic(120),
+			"warning":     semantic(45),
+			"error":       semantic(355),
+			"bg":          shade(0.10),
+			"surface":     shade(0.16),
+			"overlay":     shade(0.22),
+			"border":      shade(0.30),
+			"text":        text(0.88),
+			"text_muted":  text(0.62),
+			"text_bright": text(0.97),
+		},
+	}
+}
+
+// Dominant returns img's dominant colors, most common first. It samples a
+// grid of pixels and groups them with k-means, which is deterministic here
+// since the initial centers are spread evenly by lightness.
+func Dominant(img image.Image) []Swatch {
+	b := img.Bounds()
+	step := max(1, max(b.Dx(), b.Dy())/maxSamples)
+	var samples []color.RGBA
+	for y := b.Min.Y; y < b.Max.Y; y += step {
+		for x := b.Min.X; x < b.Max.X; x += step {
+			c := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+			if c.A < 128 {
+				continue // transparent pixels don't show
+			}
+			samples = append(samples, c)
+		}
+	}
+	if len(samples) == 0 {
+		return []Swatch{{Color: color.RGBA{0x1e, 0x1e, 0x2e, 0xff}, Share: 1}}
+	}
+
+	sorted := append([]color.RGBA(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return lightness(sorted[i]) < lightness(sorted[j]) })
+	k := min(clusters, len(samples))
+	centers := make([][3]float64, k)
+	for i := range centers {
+		c := sorted[(2*i+1)*len(sorted)/(2*k)]
+		centers[i] = [3]float64{float64(c.R), float64(c.G), float64(c.B)}
+	}
+
+	assign := make([]int, len(samples))
+	for range iterations {
+		for i, c := range samples {
+			best, bestDist := 0, math.MaxFloat64
+			for j, center := range centers {
+				dr, dg, db := float64(c.R)-center[0], float64(c.G)-center[1], float64(c.B)-center[2]
+				if d := dr*dr + dg*dg + db*db; d < bestDist {
+					best, bestDist = j, d
+				}
+			}
+			assign[i] = best
+		}
+		sums := make([][4]float64, k)
+		for i, c := range samples {
+			s := &sums[assign[i]]
+			s[0] += float64(c.R)
+			s[1] += float64(c.G)
+			s[2] += float64(c.B)
+			s[3]++
+		}
+		for j, s := range sums {
+			if s[3] > 0 {
+				centers[j] = [3]float64{s[0] / s[3], s[1] / s[3], s[2] / s[3]}
+			}
+		}
+	}
+
+	counts := make([]int, k)
+	for _, j := range assign {
+		counts[j]++
+	}
+	var swatches []Swatch
+	for j, center := range centers {
+		if counts[j] == 0 {
+			continue
+		}
+		swatches = append(swatches, Swatch{
+			Color: color.RGBA{uint8(math.Round(center[0])), uint8(math.Round(center[1])), uint8(math.Round(center[2])), 0xff},
+			Share: float64(counts[j]) / float64(len(samples)),
+		})
+	}
+	sort.SliceStable(swatches, func(i, j int) bool { return swatches[i].Share > swatches[j].Share })
+	return swatches
+}
+
+// vividness ranks a swatch as an accent: saturated, neither near black nor
+// near white, and not a speck
+func vividness(s Swatch) float64 {
+	h := toHSL(s.Color)
+	return h.s * (1 - math.Abs(2*h.l-1)) * math.Sqrt(s.Share)
+}
+
+// averageLightness is the image's lightness, weighted by swatch share
+func averageLightness(swatches []Swatch) float64 {
+	var l float64
+	for _, s := range swatches {
+		l += lightness(s.Color) * s.Share
+	}
+	return l
+}
+
+// hueDistance is the distance between two hues in degrees, around the wheel
+func hueDistance(a, b float64) float64 {
+	d := math.Abs(a - b)
+	return math.Min(d, 360-d)
+}
+
+// lightness returns c's HSL lightness (0-1)
+func lightness(c color.RGBA) float64 {
+	return toHSL(c).l
+}
+
+// hsl is a color as hue (degrees), saturation and lightness (0-1)
+type hsl struct{ h, s, l float64 }
+
+// toHSL converts c to HSL
+func toHSL(c color.RGBA) hsl {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	hi, lo := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	l := (hi + lo) / 2
+	if hi == lo {
+		return hsl{0, 0, l}
+	}
+	d := hi - lo
+	s := d / (1 - math.Abs(2*l-1))
+	var h float64
+	switch hi {
+	case r:
+		h = math.Mod((g-b)/d, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	return hsl{h, s, l}
+}
+
+// hex formats the color as #rrggbb
+func (c hsl) hex() string {
+	chroma := (1 - math.Abs(2*c.l-1)) * c.s
+	x := chroma * (1 - math.Abs(math.Mod(c.h/60, 2)-1))
+	m := c.l - chroma/2
+	var r, g, b float64
+	switch {
+	case c.h < 60:
+		r, g = chroma, x
+	case c.h < 120:
+		r, g = x, chroma
+	case c.h < 180:
+		g, b = chroma, x
+	case c.h < 240:
+		g, b = x, chroma
+	case c.h < 300:
+		r, b = x, chroma
+	default:
+		r, b = chroma, x
+	}
+	to8 := func(v float64) uint8 { return uint8(math.Round(math.Max(0, math.Min(1, v+m)) * 255)) }
+	return fmt.Sprintf("#%02x%02x%02x", to8(r), to8(g), to8(b))
+}
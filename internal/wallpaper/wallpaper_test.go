@@ -0,0 +1,108 @@
+package wallpaper
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+// stripes returns an image filled with colors in proportion to their weights
+func stripes(colors []color.RGBA, weights []int) *image.RGBA {
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	img := image.NewRGBA(image.Rect(0, 0, total, 40))
+	x := 0
+	for i, c := range colors {
+		for range weights[i] {
+			for y := range 40 {
+				img.Set(x, y, c)
+			}
+			x++
+		}
+	}
+	return img
+}
+
+func TestDominant(t *testing.T) {
+	navy := color.RGBA{0x10, 0x18, 0x30, 0xff}
+	orange := color.RGBA{0xf0, 0x80, 0x20, 0xff}
+	swatches := Dominant(stripes([]color.RGBA{navy, orange}, []int{90, 10}))
+
+	if len(swatches) == 0 || swatches[0].Color != navy {
+		t.Fatalf("swatches = %v, want navy most common", swatches)
+	}
+	var found bool
+	for _, s := range swatches {
+		if s.Color == orange {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("swatches = %v, want orange among them", swatches)
+	}
+}
+
+func TestFromImage(t *testing.T) {
+	navy := color.RGBA{0x10, 0x18, 0x30, 0xff}
+	orange := color.RGBA{0xf0, 0x80, 0x20, 0xff}
+	dark := FromImage(stripes([]color.RGBA{navy, orange}, []int{90, 10}))
+
+	if dark.Light {
+		t.Error("a mostly navy image should give a dark theme")
+	}
+	hex := regexp.MustCompile(`^#[0-9a-f]{6}$`)
+	for _, key := range []string{"accent", "accent_alt", "info", "success", "warning", "error", "bg", "surface", "overlay", "border", "text", "text_muted", "text_bright"} {
+		if !hex.MatchString(dark.Colors[key]) {
+			t.Errorf("%s = %q, want #rrggbb", key, dark.Colors[key])
+		}
+	}
+	if h := toHSL(parseHex(t, dark.Colors["accent"])).h; hueDistance(h, toHSL(orange).h) > 10 {
+		t.Errorf("accent %s should follow the orange (hue %.0f)", dark.Colors["accent"], h)
+	}
+	if toHSL(parseHex(t, dark.Colors["bg"])).l > 0.2 || toHSL(parseHex(t, dark.Colors["text"])).l < 0.8 {
+		t.Errorf("bg %s / text %s should be dark on light", dark.Colors["bg"], dark.Colors["text"])
+	}
+
+	cream := color.RGBA{0xf4, 0xee, 0xe0, 0xff}
+	light := FromImage(stripes([]color.RGBA{cream, orange}, []int{90, 10}))
+	if !light.Light || toHSL(parseHex(t, light.Colors["bg"])).l < 0.8 {
+		t.Errorf("a mostly cream image should give a light theme, bg %s", light.Colors["bg"])
+	}
+}
+
+func TestFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wall.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	png.Encode(f, stripes([]color.RGBA{{0x20, 0x40, 0x30, 0xff}}, []int{10}))
+	f.Close()
+
+	if _, err := FromFile(path); err != nil {
+		t.Errorf("FromFile() failed: %v", err)
+	}
+
+	os.WriteFile(path, []byte("not an image"), 0600)
+	if _, err := FromFile(path); err == nil {
+		t.Error("FromFile() should fail on a file that isn't an image")
+	}
+}
+
+// parseHex parses #rrggbb
+func parseHex(t *testing.T, s string) color.RGBA {
+	t.Helper()
+	var c color.RGBA
+	c.A = 0xff
+	if _, err := fmt.Sscanf(s, "#%02x%02x%02x", &c.R, &c.G, &c.B); err != nil {
+		t.Fatalf("bad hex %q: %v", s, err)
+	}
+	return c
+}